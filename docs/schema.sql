@@ -2,12 +2,15 @@
 -- Database: PostgreSQL
 -- Generated at: 2023-10-27T07:13:22.542Z
 
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
 CREATE TABLE "teams" (
   "id" BIGSERIAL PRIMARY KEY,
   "name" varchar(255) NOT NULL,
   "manager_id" bigint DEFAULT null,
   "created_at" timestamp NOT NULL DEFAULT (now()),
-  "updated_at" timestamp DEFAULT null
+  "updated_at" timestamp DEFAULT null,
+  "company_id" bigint DEFAULT null
 );
 
 CREATE TABLE "users" (
@@ -25,6 +28,7 @@ CREATE TABLE "users" (
   "updated_at" timestamp DEFAULT null,
   "language" varchar(2) NOT NULL DEFAULT 'en',
   "country" varchar(2) DEFAULT null,
+  "subdivision" varchar(3) DEFAULT null,
   "timezone" varchar(64) NOT NULL DEFAULT 'UTC',
   "manager_id" bigint DEFAULT null,
   "team_id" bigint DEFAULT null
@@ -39,7 +43,12 @@ CREATE TABLE "absences" (
   "paid" boolean NOT NULL DEFAULT true,
   "created_at" timestamp NOT NULL DEFAULT (now()),
   "updated_at" timestamp DEFAULT null,
-  "approved_by_id" bigint DEFAULT null
+  "approved_by_id" bigint DEFAULT null,
+  "status" varchar(32) NOT NULL DEFAULT 'pending',
+  "decided_at" timestamp DEFAULT null,
+  "decision_comment" varchar(255) DEFAULT null,
+  "absence_type_id" bigint DEFAULT null,
+  "kind" varchar(16) NOT NULL DEFAULT 'hours'
 );
 
 CREATE TABLE "entries" (
@@ -48,12 +57,271 @@ CREATE TABLE "entries" (
   "start_time" timestamp NOT NULL,
   "end_time" timestamp DEFAULT null,
   "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp DEFAULT null,
+  "project_id" bigint DEFAULT null,
+  "task_id" bigint DEFAULT null,
+  "description" text DEFAULT null,
+  "tags" varchar(64)[] NOT NULL DEFAULT '{}',
+  "billable" boolean NOT NULL DEFAULT false,
+  "invoice_id" bigint DEFAULT null,
+  "auto_closed_at" timestamp DEFAULT null,
+  "needs_review" boolean NOT NULL DEFAULT false
+);
+
+CREATE TABLE "entry_conflicts" (
+  "id" bigint PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "start_time" timestamp NOT NULL,
+  "end_time" timestamp DEFAULT null,
+  "created_at" timestamp NOT NULL,
+  "updated_at" timestamp DEFAULT null,
+  "reason" varchar(32) NOT NULL,
+  "moved_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "projects" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "company_id" bigint NOT NULL,
+  "name" varchar(255) NOT NULL,
+  "archived" boolean NOT NULL DEFAULT false,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp DEFAULT null,
+  "client_id" bigint DEFAULT null
+);
+
+CREATE TABLE "tasks" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "project_id" bigint NOT NULL,
+  "name" varchar(255) NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp DEFAULT null
+);
+
+CREATE TABLE "clients" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "company_id" bigint NOT NULL,
+  "name" varchar(255) NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp DEFAULT null
+);
+
+CREATE TABLE "hourly_rates" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "company_id" bigint NOT NULL,
+  "user_id" bigint DEFAULT null,
+  "project_id" bigint DEFAULT null,
+  "client_id" bigint DEFAULT null,
+  "amount_cents" bigint NOT NULL,
+  "currency" varchar(3) NOT NULL,
+  "effective_from" date NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "invoice_sequences" (
+  "company_id" bigint PRIMARY KEY,
+  "prefix" varchar(32) NOT NULL DEFAULT 'INV-',
+  "last_number" bigint NOT NULL DEFAULT 0,
+  "updated_at" timestamp DEFAULT null
+);
+
+CREATE TABLE "invoices" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "company_id" bigint NOT NULL,
+  "client_id" bigint NOT NULL,
+  "number" varchar(64) DEFAULT null,
+  "status" varchar(16) NOT NULL DEFAULT 'draft',
+  "currency" varchar(3) NOT NULL,
+  "period_from" timestamp NOT NULL,
+  "period_to" timestamp NOT NULL,
+  "total_cents" bigint NOT NULL DEFAULT 0,
+  "issued_at" timestamp DEFAULT null,
+  "paid_at" timestamp DEFAULT null,
+  "voided_at" timestamp DEFAULT null,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp DEFAULT null
+);
+
+CREATE TABLE "invoice_lines" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "invoice_id" bigint NOT NULL,
+  "project_id" bigint DEFAULT null,
+  "task_id" bigint DEFAULT null,
+  "description" varchar(512) NOT NULL,
+  "quantity_seconds" bigint NOT NULL,
+  "rate_cents" bigint NOT NULL,
+  "amount_cents" bigint NOT NULL
+);
+
+CREATE TABLE "timesheets" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "period" varchar(8) NOT NULL,
+  "period_start" date NOT NULL,
+  "period_end" date NOT NULL,
+  "status" varchar(16) NOT NULL DEFAULT 'open',
+  "submitted_at" timestamp DEFAULT null,
+  "decided_by_id" bigint DEFAULT null,
+  "decided_at" timestamp DEFAULT null,
+  "decision_comment" varchar(255) DEFAULT null,
+  "reopened_by_id" bigint DEFAULT null,
+  "reopened_at" timestamp DEFAULT null,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp DEFAULT null
+);
+
+CREATE TABLE "work_schedules" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "company_id" bigint NOT NULL,
+  "name" varchar(255) NOT NULL,
+  "monday_minutes" integer NOT NULL DEFAULT 0,
+  "tuesday_minutes" integer NOT NULL DEFAULT 0,
+  "wednesday_minutes" integer NOT NULL DEFAULT 0,
+  "thursday_minutes" integer NOT NULL DEFAULT 0,
+  "friday_minutes" integer NOT NULL DEFAULT 0,
+  "saturday_minutes" integer NOT NULL DEFAULT 0,
+  "sunday_minutes" integer NOT NULL DEFAULT 0,
+  "part_time_percent" integer NOT NULL DEFAULT 100,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
   "updated_at" timestamp DEFAULT null
 );
 
+CREATE TABLE "work_schedule_assignments" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "work_schedule_id" bigint NOT NULL,
+  "effective_from" date NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "absence_types" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "company_id" bigint NOT NULL,
+  "name" varchar(255) NOT NULL,
+  "paid" boolean NOT NULL DEFAULT true,
+  "tracks_balance" boolean NOT NULL DEFAULT false,
+  "accrual" varchar(16) NOT NULL DEFAULT 'yearly',
+  "carry_over_max_days" integer NOT NULL DEFAULT 0,
+  "carry_over_expiry_months" integer DEFAULT null,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp DEFAULT null
+);
+
+CREATE TABLE "leave_entitlements" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "absence_type_id" bigint NOT NULL,
+  "from_year" integer NOT NULL,
+  "days" integer NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "public_holidays" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "country" varchar(2) NOT NULL,
+  "subdivision" varchar(3) DEFAULT null,
+  "date" date NOT NULL,
+  "name" varchar(255) NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "company_holidays" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "company_id" bigint NOT NULL,
+  "date" date NOT NULL,
+  "name" varchar(255) NOT NULL,
+  "day_off" boolean NOT NULL DEFAULT true,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "timesheet_documents" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "timesheet_id" bigint NOT NULL,
+  "language" varchar(2) NOT NULL,
+  "content" bytea NOT NULL,
+  "sha256" varchar(64) NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "calendar_feeds" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "token_hash" varchar(64) NOT NULL,
+  "user_id" bigint DEFAULT null,
+  "team_id" bigint DEFAULT null,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "revoked_at" timestamp DEFAULT null
+);
+
+CREATE TABLE "webhook_subscriptions" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "company_id" bigint NOT NULL,
+  "url" varchar(2048) NOT NULL,
+  "secret" varchar(64) NOT NULL,
+  "events" varchar(64)[] NOT NULL,
+  "active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp DEFAULT null
+);
+
+CREATE TABLE "webhook_events" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "company_id" bigint NOT NULL,
+  "type" varchar(64) NOT NULL,
+  "payload" jsonb NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "webhook_deliveries" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "subscription_id" bigint NOT NULL,
+  "event_id" bigint NOT NULL,
+  "status" varchar(16) NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "next_attempt_at" timestamp NOT NULL DEFAULT (now()),
+  "last_attempt_at" timestamp DEFAULT null,
+  "response_status" int DEFAULT null,
+  "response_body" text DEFAULT null,
+  "error" text DEFAULT null,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "jobs" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "kind" varchar(64) NOT NULL,
+  "payload" jsonb NOT NULL DEFAULT '{}',
+  "status" varchar(16) NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "max_attempts" int NOT NULL DEFAULT 10,
+  "run_at" timestamp NOT NULL DEFAULT (now()),
+  "locked_until" timestamp DEFAULT null,
+  "last_error" text DEFAULT null,
+  "unique_key" varchar(255) DEFAULT null,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "finished_at" timestamp DEFAULT null
+);
+
+CREATE TABLE "clock_policies" (
+  "company_id" bigint PRIMARY KEY,
+  "auto_close_after_minutes" int DEFAULT null,
+  "auto_close_at_minute" int DEFAULT null,
+  "reminder_at_minute" int DEFAULT null,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp DEFAULT null
+);
+
+CREATE TABLE "notifications" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "kind" varchar(64) NOT NULL,
+  "data" jsonb NOT NULL DEFAULT '{}',
+  "dedupe_key" varchar(255) DEFAULT null,
+  "read_at" timestamp DEFAULT null,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
 CREATE TABLE "companies" (
   "id" BIGSERIAL PRIMARY KEY,
   "name" varchar(255) NOT NULL,
+  "default_work_schedule_id" bigint DEFAULT null,
   "created_at" timestamp NOT NULL DEFAULT (now()),
   "updated_at" timestamp DEFAULT null
 );
@@ -66,13 +334,18 @@ CREATE TABLE "sessions" (
   "client_ip" varchar NOT NULL,
   "is_blocked" boolean NOT NULL,
   "expires_at" timestamp NOT NULL,
-  "created_at" timestamp NOT NULL DEFAULT (now())
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "family_id" uuid NOT NULL,
+  "parent_id" uuid DEFAULT null,
+  "rotated_at" timestamp DEFAULT null
 );
 
 CREATE INDEX ON "teams" ("id");
 
 CREATE INDEX ON "teams" ("manager_id");
 
+CREATE INDEX ON "teams" ("company_id");
+
 CREATE INDEX ON "users" ("email");
 
 CREATE INDEX ON "users" ("username");
@@ -83,14 +356,226 @@ CREATE INDEX ON "users" ("team_id");
 
 CREATE INDEX ON "absences" ("user_id");
 
-CREATE INDEX ON "entries" ("user_id");
+CREATE INDEX ON "absences" ("status");
+
+CREATE INDEX ON "absences" ("absence_type_id");
+
+ALTER TABLE "absences" ADD CONSTRAINT "absences_kind" CHECK ("kind" IN ('full_day', 'morning', 'afternoon', 'hours'));
+
+ALTER TABLE "absences" ADD CONSTRAINT "absences_end_after_start" CHECK ("end_time" IS NULL OR "end_time" > "start_time");
+
+ALTER TABLE "absences" ADD CONSTRAINT "absences_no_overlap" EXCLUDE USING gist ("user_id" WITH =, tsrange("start_time", "end_time") WITH &&) WHERE ("status" IN ('pending', 'approved'));
+
+CREATE INDEX "entries_user_id_start_time" ON "entries" ("user_id", "start_time");
+
+CREATE UNIQUE INDEX "entries_running_user_id" ON "entries" ("user_id") WHERE "end_time" IS NULL;
+
+CREATE INDEX "entries_running" ON "entries" ("start_time") WHERE "end_time" IS NULL;
+
+ALTER TABLE "entries" ADD CONSTRAINT "entries_end_after_start" CHECK ("end_time" IS NULL OR "end_time" > "start_time");
+
+ALTER TABLE "entries" ADD CONSTRAINT "entries_no_overlap" EXCLUDE USING gist ("user_id" WITH =, tsrange("start_time", "end_time") WITH &&);
+
+CREATE INDEX ON "entries" ("project_id");
+
+CREATE INDEX ON "entries" ("task_id");
+
+CREATE INDEX ON "entries" USING GIN ("tags");
+
+ALTER TABLE "entries" ADD CONSTRAINT "entries_task_with_project" CHECK ("task_id" IS NULL OR "project_id" IS NOT NULL);
+
+CREATE INDEX ON "entries" ("invoice_id");
+
+CREATE INDEX ON "projects" ("company_id");
+
+CREATE INDEX ON "projects" ("client_id");
+
+CREATE INDEX ON "tasks" ("project_id");
+
+CREATE UNIQUE INDEX "tasks_id_project_id" ON "tasks" ("id", "project_id");
+
+CREATE INDEX ON "clients" ("company_id");
+
+CREATE INDEX ON "hourly_rates" ("company_id", "effective_from");
+
+CREATE UNIQUE INDEX "hourly_rates_scope_effective_from" ON "hourly_rates" ("company_id", "user_id", "project_id", "client_id", "effective_from") NULLS NOT DISTINCT;
+
+ALTER TABLE "hourly_rates" ADD CONSTRAINT "hourly_rates_single_scope" CHECK (num_nonnulls("user_id", "project_id", "client_id") <= 1);
+
+ALTER TABLE "hourly_rates" ADD CONSTRAINT "hourly_rates_amount_not_negative" CHECK ("amount_cents" >= 0);
+
+CREATE INDEX ON "invoices" ("company_id", "status");
+
+CREATE INDEX ON "invoices" ("client_id");
+
+CREATE UNIQUE INDEX "invoices_company_id_number" ON "invoices" ("company_id", "number");
+
+ALTER TABLE "invoices" ADD CONSTRAINT "invoices_number_when_issued" CHECK (("status" = 'draft') = ("number" IS NULL));
+
+CREATE INDEX ON "invoice_lines" ("invoice_id");
+
+CREATE INDEX ON "timesheets" ("status");
+
+ALTER TABLE "timesheets" ADD CONSTRAINT "timesheets_end_after_start" CHECK ("period_end" > "period_start");
+
+ALTER TABLE "timesheets" ADD CONSTRAINT "timesheets_no_overlap" EXCLUDE USING gist ("user_id" WITH =, daterange("period_start", "period_end") WITH &&);
+
+CREATE INDEX ON "work_schedules" ("company_id");
+
+ALTER TABLE "work_schedules" ADD CONSTRAINT "work_schedules_day_minutes" CHECK ("monday_minutes" BETWEEN 0 AND 1440 AND "tuesday_minutes" BETWEEN 0 AND 1440 AND "wednesday_minutes" BETWEEN 0 AND 1440 AND "thursday_minutes" BETWEEN 0 AND 1440 AND "friday_minutes" BETWEEN 0 AND 1440 AND "saturday_minutes" BETWEEN 0 AND 1440 AND "sunday_minutes" BETWEEN 0 AND 1440);
+
+ALTER TABLE "work_schedules" ADD CONSTRAINT "work_schedules_part_time_percent" CHECK ("part_time_percent" BETWEEN 1 AND 100);
+
+CREATE UNIQUE INDEX "work_schedule_assignments_user_id_effective_from" ON "work_schedule_assignments" ("user_id", "effective_from");
+
+CREATE INDEX ON "work_schedule_assignments" ("work_schedule_id");
+
+CREATE UNIQUE INDEX "absence_types_company_id_name" ON "absence_types" ("company_id", "name");
+
+ALTER TABLE "absence_types" ADD CONSTRAINT "absence_types_accrual" CHECK ("accrual" IN ('yearly', 'monthly'));
+
+ALTER TABLE "absence_types" ADD CONSTRAINT "absence_types_carry_over" CHECK ("carry_over_max_days" >= 0 AND ("carry_over_expiry_months" IS NULL OR "carry_over_expiry_months" BETWEEN 1 AND 12));
+
+CREATE UNIQUE INDEX "leave_entitlements_user_id_absence_type_id_from_year" ON "leave_entitlements" ("user_id", "absence_type_id", "from_year");
+
+CREATE INDEX ON "leave_entitlements" ("absence_type_id");
+
+ALTER TABLE "leave_entitlements" ADD CONSTRAINT "leave_entitlements_days_not_negative" CHECK ("days" >= 0);
+
+CREATE UNIQUE INDEX "public_holidays_country_subdivision_date" ON "public_holidays" ("country", "subdivision", "date") NULLS NOT DISTINCT;
+
+CREATE UNIQUE INDEX "company_holidays_company_id_date" ON "company_holidays" ("company_id", "date");
+
+CREATE INDEX ON "timesheet_documents" ("timesheet_id");
+
+CREATE UNIQUE INDEX "calendar_feeds_token_hash" ON "calendar_feeds" ("token_hash");
+
+CREATE INDEX ON "calendar_feeds" ("user_id");
+
+CREATE INDEX ON "calendar_feeds" ("team_id");
+
+ALTER TABLE "calendar_feeds" ADD CONSTRAINT "calendar_feeds_owner" CHECK (num_nonnulls("user_id", "team_id") = 1);
+
+CREATE INDEX ON "webhook_subscriptions" ("company_id");
+
+CREATE INDEX ON "webhook_events" ("company_id");
+
+CREATE INDEX ON "webhook_deliveries" ("subscription_id");
+
+CREATE INDEX ON "webhook_deliveries" ("event_id");
+
+CREATE INDEX "webhook_deliveries_pending" ON "webhook_deliveries" ("next_attempt_at") WHERE "status" = 'pending';
+
+ALTER TABLE "webhook_deliveries" ADD CONSTRAINT "webhook_deliveries_status" CHECK ("status" IN ('pending', 'succeeded', 'failed'));
+
+CREATE UNIQUE INDEX "jobs_unique_key" ON "jobs" ("unique_key");
+
+CREATE INDEX "jobs_pending" ON "jobs" ("run_at") WHERE "status" = 'pending';
+
+CREATE INDEX "jobs_running" ON "jobs" ("locked_until") WHERE "status" = 'running';
+
+ALTER TABLE "jobs" ADD CONSTRAINT "jobs_status" CHECK ("status" IN ('pending', 'running', 'succeeded', 'dead'));
+
+ALTER TABLE "clock_policies" ADD CONSTRAINT "clock_policies_auto_close_after" CHECK ("auto_close_after_minutes" > 0);
+
+ALTER TABLE "clock_policies" ADD CONSTRAINT "clock_policies_minutes_of_day" CHECK ("auto_close_at_minute" BETWEEN 0 AND 1439 AND "reminder_at_minute" BETWEEN 0 AND 1439);
+
+CREATE INDEX ON "notifications" ("user_id");
+
+CREATE UNIQUE INDEX "notifications_dedupe_key" ON "notifications" ("dedupe_key");
+
+CREATE INDEX ON "sessions" ("username");
+
+CREATE INDEX ON "sessions" ("family_id");
 
 COMMENT ON COLUMN "users"."language" IS 'ISO-2 language code';
 
 COMMENT ON COLUMN "users"."country" IS 'ISO-2 Country code';
 
+COMMENT ON COLUMN "users"."subdivision" IS 'ISO 3166-2 subdivision code within the country';
+
 COMMENT ON COLUMN "users"."timezone" IS 'Timezone name';
 
+COMMENT ON COLUMN "sessions"."family_id" IS 'First session of the refresh token rotation chain';
+
+COMMENT ON COLUMN "sessions"."parent_id" IS 'Session whose refresh token was exchanged for this one';
+
+COMMENT ON COLUMN "sessions"."rotated_at" IS 'Time the refresh token was exchanged for a new one';
+
+COMMENT ON COLUMN "absences"."approved_by_id" IS 'User who approved or rejected the absence';
+
+COMMENT ON COLUMN "absences"."kind" IS 'full_day, morning, afternoon or hours';
+
+COMMENT ON COLUMN "hourly_rates"."amount_cents" IS 'Hourly rate in minor units of the currency';
+
+COMMENT ON COLUMN "hourly_rates"."currency" IS 'ISO 4217 currency code';
+
+COMMENT ON COLUMN "invoices"."number" IS 'Assigned from the invoice sequence of the company when issued';
+
+COMMENT ON COLUMN "invoices"."status" IS 'draft, issued, paid or void';
+
+COMMENT ON COLUMN "invoices"."currency" IS 'ISO 4217 currency code';
+
+COMMENT ON COLUMN "invoice_lines"."rate_cents" IS 'Hourly rate in minor units of the currency';
+
+COMMENT ON COLUMN "timesheets"."period" IS 'week or month';
+
+COMMENT ON COLUMN "timesheets"."period_end" IS 'Exclusive';
+
+COMMENT ON COLUMN "timesheets"."status" IS 'open, submitted, approved or rejected';
+
+COMMENT ON COLUMN "timesheets"."decided_by_id" IS 'User who approved or rejected the timesheet';
+
+COMMENT ON COLUMN "absence_types"."tracks_balance" IS 'Absences of the type draw from the leave entitlements of their user';
+
+COMMENT ON COLUMN "absence_types"."accrual" IS 'yearly or monthly';
+
+COMMENT ON COLUMN "absence_types"."carry_over_expiry_months" IS 'Days carried over expire this many months into the year, never if null';
+
+COMMENT ON COLUMN "leave_entitlements"."days" IS 'Annual working days';
+
+COMMENT ON COLUMN "public_holidays"."subdivision" IS 'Holidays of the whole country if null';
+
+COMMENT ON COLUMN "company_holidays"."day_off" IS 'A working day replacing the public holidays of its date if false';
+
+COMMENT ON COLUMN "timesheet_documents"."content" IS 'PDF rendered on approval, printed with signature lines for the employee and the approver';
+
+COMMENT ON COLUMN "timesheet_documents"."sha256" IS 'Hex encoded SHA-256 hash of the content';
+
+COMMENT ON COLUMN "calendar_feeds"."token_hash" IS 'Hex encoded SHA-256 hash of the secret token';
+
+COMMENT ON COLUMN "calendar_feeds"."revoked_at" IS 'Time the token stopped being accepted';
+
+COMMENT ON COLUMN "webhook_subscriptions"."secret" IS 'Key of the HMAC-SHA256 signature of the deliveries';
+
+COMMENT ON COLUMN "webhook_deliveries"."status" IS 'pending, succeeded or failed';
+
+COMMENT ON COLUMN "webhook_deliveries"."next_attempt_at" IS 'Time a pending delivery is attempted, or the attempt in progress times out';
+
+COMMENT ON COLUMN "webhook_deliveries"."response_body" IS 'Beginning of the response body of the last attempt';
+
+COMMENT ON COLUMN "jobs"."status" IS 'pending, running, succeeded or dead';
+
+COMMENT ON COLUMN "jobs"."run_at" IS 'Time a pending job is run at';
+
+COMMENT ON COLUMN "jobs"."locked_until" IS 'Time a running job is given up on and run again';
+
+COMMENT ON COLUMN "jobs"."unique_key" IS 'Key of jobs which are enqueued at most once, such as the runs of a schedule';
+
+COMMENT ON COLUMN "entries"."auto_closed_at" IS 'Time the timer of the entry was stopped by the clock policy of the company';
+
+COMMENT ON COLUMN "entries"."needs_review" IS 'Set when the timer is stopped by the clock policy, cleared when the entry is updated';
+
+COMMENT ON COLUMN "entry_conflicts"."reason" IS 'end_not_after_start or overlap';
+
+COMMENT ON COLUMN "clock_policies"."auto_close_after_minutes" IS 'Running timers are stopped once they ran for this long';
+
+COMMENT ON COLUMN "clock_policies"."auto_close_at_minute" IS 'Running timers are stopped at this minute of the day';
+
+COMMENT ON COLUMN "clock_policies"."reminder_at_minute" IS 'Users without entries on a working day are reminded at this minute of the day';
+
+COMMENT ON COLUMN "notifications"."dedupe_key" IS 'Key of notifications which are sent at most once, such as the reminder of a day';
+
 ALTER TABLE "teams" ADD FOREIGN KEY ("manager_id") REFERENCES "users" ("id");
 
 ALTER TABLE "users" ADD FOREIGN KEY ("manager_id") REFERENCES "users" ("id");
@@ -101,8 +586,244 @@ ALTER TABLE "absences" ADD FOREIGN KEY ("approved_by_id") REFERENCES "users" ("i
 
 ALTER TABLE "users" ADD FOREIGN KEY ("company_id") REFERENCES "companies" ("id");
 
+ALTER TABLE "teams" ADD CONSTRAINT "company_teams" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
 ALTER TABLE "entries" ADD CONSTRAINT "user_entries" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
 
 ALTER TABLE "absences" ADD CONSTRAINT "user_absences" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
 
+ALTER TABLE "entry_conflicts" ADD CONSTRAINT "user_entry_conflicts" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
 ALTER TABLE "sessions" ADD CONSTRAINT "user_sessions" FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+ALTER TABLE "sessions" ADD CONSTRAINT "session_parent" FOREIGN KEY ("parent_id") REFERENCES "sessions" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "projects" ADD CONSTRAINT "company_projects" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "tasks" ADD CONSTRAINT "project_tasks" FOREIGN KEY ("project_id") REFERENCES "projects" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "entries" ADD CONSTRAINT "project_entries" FOREIGN KEY ("project_id") REFERENCES "projects" ("id");
+
+ALTER TABLE "entries" ADD CONSTRAINT "task_entries" FOREIGN KEY ("task_id", "project_id") REFERENCES "tasks" ("id", "project_id");
+
+ALTER TABLE "clients" ADD CONSTRAINT "company_clients" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "projects" ADD CONSTRAINT "client_projects" FOREIGN KEY ("client_id") REFERENCES "clients" ("id");
+
+ALTER TABLE "hourly_rates" ADD CONSTRAINT "company_hourly_rates" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "hourly_rates" ADD CONSTRAINT "user_hourly_rates" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "hourly_rates" ADD CONSTRAINT "project_hourly_rates" FOREIGN KEY ("project_id") REFERENCES "projects" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "hourly_rates" ADD CONSTRAINT "client_hourly_rates" FOREIGN KEY ("client_id") REFERENCES "clients" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "invoice_sequences" ADD CONSTRAINT "company_invoice_sequences" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "invoices" ADD CONSTRAINT "company_invoices" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "invoices" ADD CONSTRAINT "client_invoices" FOREIGN KEY ("client_id") REFERENCES "clients" ("id");
+
+ALTER TABLE "invoice_lines" ADD CONSTRAINT "invoice_invoice_lines" FOREIGN KEY ("invoice_id") REFERENCES "invoices" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "invoice_lines" ADD CONSTRAINT "project_invoice_lines" FOREIGN KEY ("project_id") REFERENCES "projects" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "invoice_lines" ADD CONSTRAINT "task_invoice_lines" FOREIGN KEY ("task_id") REFERENCES "tasks" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "entries" ADD CONSTRAINT "invoice_entries" FOREIGN KEY ("invoice_id") REFERENCES "invoices" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "timesheets" ADD CONSTRAINT "user_timesheets" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "timesheets" ADD FOREIGN KEY ("decided_by_id") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "timesheets" ADD FOREIGN KEY ("reopened_by_id") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "work_schedules" ADD CONSTRAINT "company_work_schedules" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "work_schedule_assignments" ADD CONSTRAINT "user_work_schedule_assignments" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "work_schedule_assignments" ADD CONSTRAINT "work_schedule_work_schedule_assignments" FOREIGN KEY ("work_schedule_id") REFERENCES "work_schedules" ("id");
+
+ALTER TABLE "companies" ADD CONSTRAINT "default_work_schedule_companies" FOREIGN KEY ("default_work_schedule_id") REFERENCES "work_schedules" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "absence_types" ADD CONSTRAINT "company_absence_types" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "leave_entitlements" ADD CONSTRAINT "user_leave_entitlements" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "leave_entitlements" ADD CONSTRAINT "absence_type_leave_entitlements" FOREIGN KEY ("absence_type_id") REFERENCES "absence_types" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "absences" ADD CONSTRAINT "absence_type_absences" FOREIGN KEY ("absence_type_id") REFERENCES "absence_types" ("id");
+
+ALTER TABLE "company_holidays" ADD CONSTRAINT "company_company_holidays" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "timesheet_documents" ADD CONSTRAINT "timesheet_timesheet_documents" FOREIGN KEY ("timesheet_id") REFERENCES "timesheets" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "calendar_feeds" ADD CONSTRAINT "user_calendar_feeds" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "calendar_feeds" ADD CONSTRAINT "team_calendar_feeds" FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "webhook_subscriptions" ADD CONSTRAINT "company_webhook_subscriptions" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "webhook_events" ADD CONSTRAINT "company_webhook_events" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "webhook_deliveries" ADD CONSTRAINT "webhook_subscription_webhook_deliveries" FOREIGN KEY ("subscription_id") REFERENCES "webhook_subscriptions" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "webhook_deliveries" ADD CONSTRAINT "webhook_event_webhook_deliveries" FOREIGN KEY ("event_id") REFERENCES "webhook_events" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "clock_policies" ADD CONSTRAINT "company_clock_policies" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "notifications" ADD CONSTRAINT "user_notifications" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+CREATE FUNCTION current_company_id() RETURNS bigint LANGUAGE sql STABLE AS $$ SELECT NULLIF(current_setting('tempus.company_id', true), '')::bigint $$;
+
+CREATE FUNCTION effective_hourly_rate(company_id bigint, user_id bigint, project_id bigint, client_id bigint, day date) RETURNS SETOF hourly_rates LANGUAGE sql STABLE AS $$ SELECT hr.* FROM hourly_rates hr WHERE hr.company_id = $1 AND hr.effective_from <= $5 AND (hr.user_id = $2 OR hr.project_id = $3 OR hr.client_id = $4 OR num_nonnulls(hr.user_id, hr.project_id, hr.client_id) = 0) ORDER BY CASE WHEN hr.user_id IS NOT NULL THEN 1 WHEN hr.project_id IS NOT NULL THEN 2 WHEN hr.client_id IS NOT NULL THEN 3 ELSE 4 END, hr.effective_from DESC LIMIT 1 $$;
+
+ALTER TABLE "companies" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "companies" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "companies_tenant_isolation" ON "companies" USING (current_company_id() IS NULL OR "id" = current_company_id());
+
+ALTER TABLE "users" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "users" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "users_tenant_isolation" ON "users" USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "teams" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "teams" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "teams_tenant_isolation" ON "teams" USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "entries" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "entries" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "entries_tenant_isolation" ON "entries" USING (current_company_id() IS NULL OR EXISTS (SELECT 1 FROM "users" WHERE "users"."id" = "entries"."user_id" AND "users"."company_id" = current_company_id()));
+
+ALTER TABLE "absences" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "absences" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "absences_tenant_isolation" ON "absences" USING (current_company_id() IS NULL OR EXISTS (SELECT 1 FROM "users" WHERE "users"."id" = "absences"."user_id" AND "users"."company_id" = current_company_id()));
+
+ALTER TABLE "projects" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "projects" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "projects_tenant_isolation" ON "projects" USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "tasks" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "tasks" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "tasks_tenant_isolation" ON "tasks" USING (current_company_id() IS NULL OR EXISTS (SELECT 1 FROM "projects" WHERE "projects"."id" = "tasks"."project_id" AND "projects"."company_id" = current_company_id()));
+
+ALTER TABLE "clients" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "clients" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "clients_tenant_isolation" ON "clients" USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "hourly_rates" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "hourly_rates" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "hourly_rates_tenant_isolation" ON "hourly_rates" USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "invoice_sequences" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "invoice_sequences" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "invoice_sequences_tenant_isolation" ON "invoice_sequences" USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "invoices" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "invoices" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "invoices_tenant_isolation" ON "invoices" USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "invoice_lines" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "invoice_lines" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "invoice_lines_tenant_isolation" ON "invoice_lines" USING (current_company_id() IS NULL OR EXISTS (SELECT 1 FROM "invoices" WHERE "invoices"."id" = "invoice_lines"."invoice_id" AND "invoices"."company_id" = current_company_id()));
+
+ALTER TABLE "timesheets" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "timesheets" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "timesheets_tenant_isolation" ON "timesheets" USING (current_company_id() IS NULL OR EXISTS (SELECT 1 FROM "users" WHERE "users"."id" = "timesheets"."user_id" AND "users"."company_id" = current_company_id()));
+
+ALTER TABLE "work_schedules" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "work_schedules" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "work_schedules_tenant_isolation" ON "work_schedules" USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "work_schedule_assignments" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "work_schedule_assignments" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "work_schedule_assignments_tenant_isolation" ON "work_schedule_assignments" USING (current_company_id() IS NULL OR EXISTS (SELECT 1 FROM "users" WHERE "users"."id" = "work_schedule_assignments"."user_id" AND "users"."company_id" = current_company_id()));
+
+ALTER TABLE "absence_types" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "absence_types" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "absence_types_tenant_isolation" ON "absence_types" USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "leave_entitlements" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "leave_entitlements" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "leave_entitlements_tenant_isolation" ON "leave_entitlements" USING (current_company_id() IS NULL OR EXISTS (SELECT 1 FROM "users" WHERE "users"."id" = "leave_entitlements"."user_id" AND "users"."company_id" = current_company_id()));
+
+ALTER TABLE "company_holidays" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "company_holidays" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "company_holidays_tenant_isolation" ON "company_holidays" USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "timesheet_documents" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "timesheet_documents" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "timesheet_documents_tenant_isolation" ON "timesheet_documents" USING (current_company_id() IS NULL OR EXISTS (SELECT 1 FROM "timesheets" JOIN "users" ON "users"."id" = "timesheets"."user_id" WHERE "timesheets"."id" = "timesheet_documents"."timesheet_id" AND "users"."company_id" = current_company_id()));
+
+ALTER TABLE "calendar_feeds" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "calendar_feeds" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "calendar_feeds_tenant_isolation" ON "calendar_feeds" USING (current_company_id() IS NULL OR EXISTS (SELECT 1 FROM "users" WHERE "users"."id" = "calendar_feeds"."user_id" AND "users"."company_id" = current_company_id()) OR EXISTS (SELECT 1 FROM "teams" WHERE "teams"."id" = "calendar_feeds"."team_id" AND "teams"."company_id" = current_company_id()));
+
+ALTER TABLE "webhook_subscriptions" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "webhook_subscriptions" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "webhook_subscriptions_tenant_isolation" ON "webhook_subscriptions" USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "webhook_events" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "webhook_events" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "webhook_events_tenant_isolation" ON "webhook_events" USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "webhook_deliveries" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "webhook_deliveries" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "webhook_deliveries_tenant_isolation" ON "webhook_deliveries" USING (current_company_id() IS NULL OR EXISTS (SELECT 1 FROM "webhook_subscriptions" WHERE "webhook_subscriptions"."id" = "webhook_deliveries"."subscription_id" AND "webhook_subscriptions"."company_id" = current_company_id()));
+
+ALTER TABLE "clock_policies" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "clock_policies" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "clock_policies_tenant_isolation" ON "clock_policies" USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "notifications" ENABLE ROW LEVEL SECURITY;
+
+ALTER TABLE "notifications" FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY "notifications_tenant_isolation" ON "notifications" USING (current_company_id() IS NULL OR EXISTS (SELECT 1 FROM "users" WHERE "users"."id" = "notifications"."user_id" AND "users"."company_id" = current_company_id()));
@@ -0,0 +1,543 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/jackc/pgx/v5"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/worktime"
+)
+
+type createAbsenceRequest struct {
+	UserID        int64  `json:"user_id" binding:"required,min=1"`
+	AbsenceTypeID *int64 `json:"absence_type_id" binding:"omitempty,min=1"`
+	AbsenceRequest
+}
+
+type AbsenceRequest struct {
+	// Kind defaults to hours on creation and to the stored kind on update. The times of other kinds are snapped to
+	// the days of the user.
+	Kind      string     `json:"kind" binding:"omitempty,absence_kind"`
+	StartTime time.Time  `json:"start_time" binding:"required"`
+	EndTime   *time.Time `json:"end_time" binding:"omitempty,gtfield=StartTime"`
+	Reason    string     `json:"reason" binding:"required,min=1,max=255"`
+	Paid      bool       `json:"paid"`
+}
+
+// absenceConflictResponse names the existing absence an absence would overlap with.
+type absenceConflictResponse struct {
+	Error              string     `json:"error"`
+	ConflictingAbsence db.Absence `json:"conflicting_absence"`
+}
+
+// absenceDurationResponse is the working time an absence takes of every working day of its user it covers.
+// Absences without an end are counted until now.
+type absenceDurationResponse struct {
+	AbsenceID   int64          `json:"absence_id"`
+	Kind        string         `json:"kind"`
+	StartTime   time.Time      `json:"start_time"`
+	EndTime     time.Time      `json:"end_time"`
+	Days        []worktime.Day `json:"days"`
+	WorkingDays float64        `json:"working_days"`
+	Seconds     int64          `json:"seconds"`
+}
+
+func (server *Server) createAbsence(ctx *gin.Context) {
+	var req createAbsenceRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.snapAbsence(ctx, req.UserID, &req.AbsenceRequest) {
+		return
+	}
+
+	paid := req.Paid
+	if req.AbsenceTypeID != nil {
+		absenceType, ok := server.validAbsenceOfType(ctx, req.UserID, *req.AbsenceTypeID, req.AbsenceRequest, 0)
+		if !ok {
+			return
+		}
+		paid = absenceType.Paid
+	}
+
+	arg := db.CreateAbsenceParams{
+		UserID:        req.UserID,
+		StartTime:     req.StartTime,
+		EndTime:       req.EndTime,
+		Reason:        req.Reason,
+		Paid:          paid,
+		AbsenceTypeID: req.AbsenceTypeID,
+		Kind:          req.Kind,
+	}
+	absence, err := server.store.CreateAbsence(ctx, arg)
+	if err != nil {
+		if server.absenceViolationResponse(ctx, err, db.GetOverlappingAbsenceParams{
+			UserID:    arg.UserID,
+			StartTime: arg.StartTime,
+			EndTime:   arg.EndTime,
+		}) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, absence)
+}
+
+func (server *Server) getAbsence(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	absence, err := server.store.GetAbsence(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, absence)
+}
+
+func (server *Server) getAbsenceDuration(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	absence, err := server.store.GetAbsence(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	user, err := server.store.GetUser(ctx, absence.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	location, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	end := time.Now().UTC()
+	if absence.EndTime != nil {
+		end = *absence.EndTime
+	}
+	response := absenceDurationResponse{
+		AbsenceID: absence.ID,
+		Kind:      absence.Kind,
+		StartTime: absence.StartTime,
+		EndTime:   end,
+		Days:      []worktime.Day{},
+	}
+	if end.After(absence.StartTime) {
+		calendar, err := worktime.LoadCalendar(ctx, server.store, user, location, worktime.Date(end.In(location)).AddDate(0, 0, 1))
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		for _, day := range calendar.WorkingDays(absence.Kind, absence.StartTime, end) {
+			response.Days = append(response.Days, day)
+			response.WorkingDays += day.Days
+			response.Seconds += day.Seconds
+		}
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (server *Server) updateAbsence(ctx *gin.Context) {
+	var reqID RequestWithID
+	var req AbsenceRequest
+	if err := ctx.ShouldBindUri(&reqID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	absence, err := server.store.GetAbsence(ctx, reqID.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if absence.Status != types.AbsencePending {
+		ctx.JSON(http.StatusConflict, errorResponse(errAbsenceNotPending))
+		return
+	}
+
+	// an absence keeps its kind unless another one is requested
+	if req.Kind == "" {
+		req.Kind = absence.Kind
+	}
+	if !server.snapAbsence(ctx, absence.UserID, &req) {
+		return
+	}
+
+	paid := req.Paid
+	if absence.AbsenceTypeID != nil {
+		absenceType, ok := server.validAbsenceOfType(ctx, absence.UserID, *absence.AbsenceTypeID, req, absence.ID)
+		if !ok {
+			return
+		}
+		paid = absenceType.Paid
+	}
+
+	arg := db.UpdateAbsenceParams{
+		ID:           absence.ID,
+		UserID:       absence.UserID,
+		Reason:       req.Reason,
+		Paid:         paid,
+		StartTime:    req.StartTime,
+		EndTime:      req.EndTime,
+		ApprovedByID: absence.ApprovedByID,
+		Kind:         req.Kind,
+	}
+	absence, err = server.store.UpdateAbsence(ctx, arg)
+	if err != nil {
+		if server.absenceViolationResponse(ctx, err, db.GetOverlappingAbsenceParams{
+			UserID:    arg.UserID,
+			ExcludeID: arg.ID,
+			StartTime: arg.StartTime,
+			EndTime:   arg.EndTime,
+		}) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, absence)
+}
+
+func (server *Server) listAbsences(ctx *gin.Context) {
+	var req PaginationRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	companyID, err := server.tenantScope(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	arg := db.ListAbsencesParams{
+		CompanyID: companyID,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+	}
+	absences, err := server.store.ListAbsences(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, absences)
+}
+
+func (server *Server) listUserAbsences(ctx *gin.Context) {
+	var idReq RequestWithID
+	if err := ctx.ShouldBindUri(&idReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	server.listAbsencesOfUser(ctx, idReq.ID)
+}
+
+// listAbsencesOfUser writes a page of the absences of the user with the given ID.
+func (server *Server) listAbsencesOfUser(ctx *gin.Context, userID int64) {
+	var queryReq PaginationRequest
+	if err := ctx.ShouldBindQuery(&queryReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListUserAbsencesParams{
+		UserID: userID,
+		Limit:  queryReq.Limit,
+		Offset: queryReq.Offset,
+	}
+	absences, err := server.store.ListUserAbsences(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, absences)
+}
+
+type decideAbsenceRequest struct {
+	Comment *string `json:"comment" binding:"omitempty,min=1,max=255"`
+}
+
+// decideAbsence returns a handler which approves or rejects a pending absence
+// and records the deciding user, the decision time and an optional comment.
+func (server *Server) decideAbsence(status string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var reqID RequestWithID
+		var req decideAbsenceRequest
+		if err := ctx.ShouldBindUri(&reqID); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		// the comment is optional, so an empty body is accepted
+		if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		absence, err := server.store.GetAbsence(ctx, reqID.ID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		if !types.CanTransitionAbsence(absence.Status, status) {
+			ctx.JSON(http.StatusConflict, errorResponse(absenceTransitionError(absence.Status, status)))
+			return
+		}
+
+		approver, err := server.authUser(ctx)
+		if err != nil {
+			ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+			return
+		}
+		// a manager of their own team or their own manager still cannot decide their own absences
+		if approver.ID == absence.UserID {
+			ctx.JSON(http.StatusForbidden, errorResponse(errOwnAbsenceDecision))
+			return
+		}
+
+		arg := db.DecideAbsenceParams{
+			ID:              absence.ID,
+			Status:          status,
+			ApprovedByID:    &approver.ID,
+			DecisionComment: req.Comment,
+			CurrentStatus:   absence.Status,
+		}
+		absence, err = server.store.DecideAbsenceTx(ctx, arg)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				ctx.JSON(http.StatusConflict, errorResponse(errAbsenceStatusChanged))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusOK, absence)
+	}
+}
+
+func (server *Server) cancelAbsence(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	absence, err := server.store.GetAbsence(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !types.CanTransitionAbsence(absence.Status, types.AbsenceCancelled) {
+		ctx.JSON(http.StatusConflict, errorResponse(absenceTransitionError(absence.Status, types.AbsenceCancelled)))
+		return
+	}
+
+	arg := db.CancelAbsenceParams{
+		ID:            absence.ID,
+		CurrentStatus: absence.Status,
+	}
+	absence, err = server.store.CancelAbsenceTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusConflict, errorResponse(errAbsenceStatusChanged))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, absence)
+}
+
+// validAbsenceOfType checks that the absence type belongs to the company of the user and, if it tracks a balance,
+// that the remaining leave of the user covers the absence, disregarding the absence with the ID exclude.
+// It writes the error response and returns false otherwise.
+func (server *Server) validAbsenceOfType(ctx *gin.Context, userID, absenceTypeID int64, req AbsenceRequest, exclude int64) (db.AbsenceType, bool) {
+	user, err := server.store.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return db.AbsenceType{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.AbsenceType{}, false
+	}
+
+	absenceType, ok := server.companyAbsenceType(ctx, absenceTypeID, user.CompanyID)
+	if !ok || !absenceType.TracksBalance {
+		return absenceType, ok
+	}
+	if req.EndTime == nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errLeaveEndRequired))
+		return absenceType, false
+	}
+	return absenceType, server.validLeave(ctx, user, absenceType, req.Kind, req.StartTime, *req.EndTime, exclude)
+}
+
+// snapAbsence defaults the kind of the absence in req to hours and snaps the times of the other kinds to the days of
+// the user in their time zone. It writes the error response and returns false if the user cannot be loaded.
+func (server *Server) snapAbsence(ctx *gin.Context, userID int64, req *AbsenceRequest) bool {
+	if req.Kind == "" {
+		req.Kind = types.AbsenceHours
+	}
+	if req.Kind == types.AbsenceHours {
+		return true
+	}
+
+	user, err := server.store.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+	location, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	req.StartTime, req.EndTime = absenceSpan(req.Kind, location, req.StartTime, req.EndTime)
+	return true
+}
+
+// absenceSpan returns the times of an absence of kind in location. Full days last from the midnight of the day they
+// start on until the midnight after the day they end on, or remain open without an end. Mornings and afternoons are
+// the halves of the day they start on, split at noon. Hourly absences keep their times.
+func absenceSpan(kind string, location *time.Location, start time.Time, end *time.Time) (time.Time, *time.Time) {
+	local := start.In(location)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+	noon := time.Date(local.Year(), local.Month(), local.Day(), 12, 0, 0, 0, location)
+	next := midnight.AddDate(0, 0, 1)
+
+	switch kind {
+	case types.AbsenceFullDay:
+		if end == nil {
+			return midnight.UTC(), nil
+		}
+		localEnd := end.In(location)
+		last := time.Date(localEnd.Year(), localEnd.Month(), localEnd.Day(), 0, 0, 0, 0, location)
+		// an absence ending at midnight does not cover the day it ends on
+		if last.Before(localEnd) {
+			last = last.AddDate(0, 0, 1)
+		}
+		last = last.UTC()
+		return midnight.UTC(), &last
+	case types.AbsenceMorning:
+		noon = noon.UTC()
+		return midnight.UTC(), &noon
+	case types.AbsenceAfternoon:
+		next = next.UTC()
+		return noon.UTC(), &next
+	}
+	return start, end
+}
+
+// absenceViolationResponse writes the response for an absence write rejected by one of the absences constraints
+// and reports whether err was such a violation. An overlap is reported together with the conflicting absence,
+// which is looked up with arg.
+func (server *Server) absenceViolationResponse(ctx *gin.Context, err error, arg db.GetOverlappingAbsenceParams) bool {
+	switch {
+	case db.ErrorCode(err) == db.ExclusionViolation && db.ConstraintName(err) == db.AbsenceOverlapConstraint:
+		conflicting, lookupErr := server.store.GetOverlappingAbsence(ctx, arg)
+		if lookupErr != nil {
+			// the conflicting absence may have been changed since the write failed
+			ctx.JSON(http.StatusConflict, errorResponse(errAbsenceOverlap))
+			return true
+		}
+		ctx.JSON(http.StatusConflict, absenceConflictResponse{
+			Error:              fmt.Sprintf("%s: absence %d", errAbsenceOverlap, conflicting.ID),
+			ConflictingAbsence: conflicting,
+		})
+	case db.ErrorCode(err) == db.CheckViolation && db.ConstraintName(err) == db.AbsenceTimeConstraint:
+		ctx.JSON(http.StatusBadRequest, errorResponse(errAbsenceTimes))
+	default:
+		return false
+	}
+	return true
+}
+
+// absenceTransitionError describes a status change which is not allowed.
+func absenceTransitionError(from, to string) error {
+	return fmt.Errorf("%w: %s absence cannot become %s", errInvalidAbsenceTransition, from, to)
+}
+
+// absenceOwnerFromURI resolves the subject of a request to the owner of the absence identified by the `:id` URI parameter.
+func (server *Server) absenceOwnerFromURI(ctx *gin.Context) (int64, error) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		return 0, &requestError{err}
+	}
+
+	absence, err := server.store.GetAbsence(ctx, req.ID)
+	if err != nil {
+		return 0, err
+	}
+	return absence.UserID, nil
+}
+
+// absenceUserFromBody resolves the subject of a request to the user the absence in the request body belongs to.
+func absenceUserFromBody(ctx *gin.Context) (int64, error) {
+	var req createAbsenceRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		return 0, &requestError{err}
+	}
+	return req.UserID, nil
+}
@@ -0,0 +1,1653 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/token"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/mateoradman/tempus/internal/worktime"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func requireBodyMatchAbsence(t *testing.T, body *bytes.Buffer, absence db.Absence) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotAbsence db.Absence
+	err = json.Unmarshal(data, &gotAbsence)
+	require.NoError(t, err)
+	require.Equal(t, absence, gotAbsence)
+}
+
+func requireBodyMatchAbsenceList(t *testing.T, body *bytes.Buffer, absences []db.Absence) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotAbsences []db.Absence
+	err = json.Unmarshal(data, &gotAbsences)
+	require.NoError(t, err)
+	require.Equal(t, absences, gotAbsences)
+}
+
+func requireBodyMatchAbsenceConflict(t *testing.T, body *bytes.Buffer, absence db.Absence) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var got absenceConflictResponse
+	err = json.Unmarshal(data, &got)
+	require.NoError(t, err)
+	require.Contains(t, got.Error, errAbsenceOverlap.Error())
+	require.Equal(t, absence, got.ConflictingAbsence)
+}
+
+func randomAbsence(userID int64) db.Absence {
+	startTime := time.Now().UTC().Truncate(time.Second)
+	endTime := startTime.Add(24 * time.Hour)
+	return db.Absence{
+		ID:        util.RandomInt(1, 1000),
+		UserID:    userID,
+		StartTime: startTime,
+		EndTime:   &endTime,
+		Reason:    util.RandomString(20),
+		Paid:      true,
+		Status:    types.AbsencePending,
+		Kind:      types.AbsenceHours,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+func TestCreateAbsenceAPI(t *testing.T) {
+	user := randomUser()
+	manager := randomUserWithRole(types.ManagerRole)
+	otherEmployee := randomUser()
+	user.ManagerID = &manager.ID
+	absence := randomAbsence(user.ID)
+	conflicting := randomAbsence(user.ID)
+	conflicting.Status = types.AbsenceApproved
+	zagrebUser := randomUser()
+	zagrebUser.Timezone = "Europe/Zagreb"
+
+	arg := db.CreateAbsenceParams{
+		UserID:    absence.UserID,
+		StartTime: absence.StartTime,
+		EndTime:   absence.EndTime,
+		Reason:    absence.Reason,
+		Paid:      absence.Paid,
+		Kind:      types.AbsenceHours,
+	}
+	body := gin.H{
+		"user_id":    absence.UserID,
+		"start_time": absence.StartTime,
+		"end_time":   absence.EndTime,
+		"reason":     absence.Reason,
+		"paid":       absence.Paid,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateAbsence(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(absence, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				requireBodyMatchAbsence(t, recorder.Body, absence)
+			},
+		},
+		{
+			name: "ManagerOK",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateAbsence(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(absence, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				requireBodyMatchAbsence(t, recorder.Body, absence)
+			},
+		},
+		{
+			name: "FullDays",
+			body: gin.H{
+				"user_id":    zagrebUser.ID,
+				"kind":       types.AbsenceFullDay,
+				"start_time": time.Date(2024, time.March, 7, 9, 30, 0, 0, time.UTC),
+				"end_time":   time.Date(2024, time.March, 8, 15, 0, 0, 0, time.UTC),
+				"reason":     absence.Reason,
+				"paid":       absence.Paid,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(zagrebUser.Username)).
+					Times(1).
+					Return(zagrebUser, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(zagrebUser.ID)).
+					Times(2).
+					Return(zagrebUser, nil)
+				// from the midnight of the first until the midnight after the last day in Zagreb
+				end := time.Date(2024, time.March, 8, 23, 0, 0, 0, time.UTC)
+				arg := db.CreateAbsenceParams{
+					UserID:    zagrebUser.ID,
+					StartTime: time.Date(2024, time.March, 6, 23, 0, 0, 0, time.UTC),
+					EndTime:   &end,
+					Reason:    absence.Reason,
+					Paid:      absence.Paid,
+					Kind:      types.AbsenceFullDay,
+				}
+				store.EXPECT().
+					CreateAbsence(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(absence, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, zagrebUser, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "Afternoon",
+			body: gin.H{
+				"user_id":    zagrebUser.ID,
+				"kind":       types.AbsenceAfternoon,
+				"start_time": time.Date(2024, time.March, 7, 14, 0, 0, 0, time.UTC),
+				"reason":     absence.Reason,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(zagrebUser.Username)).
+					Times(1).
+					Return(zagrebUser, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(zagrebUser.ID)).
+					Times(2).
+					Return(zagrebUser, nil)
+				end := time.Date(2024, time.March, 7, 23, 0, 0, 0, time.UTC)
+				arg := db.CreateAbsenceParams{
+					UserID:    zagrebUser.ID,
+					StartTime: time.Date(2024, time.March, 7, 11, 0, 0, 0, time.UTC),
+					EndTime:   &end,
+					Reason:    absence.Reason,
+					Kind:      types.AbsenceAfternoon,
+				}
+				store.EXPECT().
+					CreateAbsence(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(absence, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, zagrebUser, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidKind",
+			body: gin.H{
+				"user_id":    absence.UserID,
+				"kind":       "evening",
+				"start_time": absence.StartTime,
+				"reason":     absence.Reason,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAbsence(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Overlap",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateAbsence(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Absence{}, &pgconn.PgError{
+						Code:           db.ExclusionViolation,
+						ConstraintName: db.AbsenceOverlapConstraint,
+					})
+				store.EXPECT().
+					GetOverlappingAbsence(gomock.Any(), gomock.Eq(db.GetOverlappingAbsenceParams{
+						UserID:    arg.UserID,
+						StartTime: arg.StartTime,
+						EndTime:   arg.EndTime,
+					})).
+					Times(1).
+					Return(conflicting, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				requireBodyMatchAbsenceConflict(t, recorder.Body, conflicting)
+			},
+		},
+		{
+			name: "EndBeforeStart",
+			body: gin.H{
+				"user_id":    absence.UserID,
+				"start_time": absence.StartTime,
+				"end_time":   absence.StartTime.Add(-time.Hour),
+				"reason":     absence.Reason,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAbsence(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalServerError",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateAbsence(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Absence{}, pgx.ErrTxClosed)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(otherEmployee.Username)).
+					Times(1).
+					Return(otherEmployee, nil)
+				store.EXPECT().
+					CreateAbsence(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, otherEmployee, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Unauthorized",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAbsence(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/absences", bytes.NewBuffer(jsonData))
+			require.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			tc.setupAuth(t, request, server.tokenMaker)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetAbsenceAPI(t *testing.T) {
+	user := randomUser()
+	admin := randomUserWithRole(types.AdminRole)
+	outsider := randomUserWithRole(types.AdminRole)
+	outsider.CompanyID = util.Pointer(testCompanyID + 1)
+	otherEmployee := randomUser()
+	absence := randomAbsence(user.ID)
+
+	testCases := []struct {
+		name          string
+		absenceID     int64
+		buildStubs    func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			absenceID: absence.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(2).
+					Return(absence, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAbsence(t, recorder.Body, absence)
+			},
+		},
+		{
+			name:      "AdminOK",
+			absenceID: absence.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(2).
+					Return(absence, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAbsence(t, recorder.Body, absence)
+			},
+		},
+		{
+			name:      "NotFound",
+			absenceID: absence.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(1).
+					Return(db.Absence{}, pgx.ErrNoRows)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "InternalServerError",
+			absenceID: absence.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(1).
+					Return(db.Absence{}, pgx.ErrTxClosed)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidID",
+			absenceID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "Forbidden",
+			absenceID: absence.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(otherEmployee.Username)).
+					Times(1).
+					Return(otherEmployee, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(1).
+					Return(absence, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, otherEmployee, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "OtherCompanyNotFound",
+			absenceID: absence.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(outsider.Username)).
+					Times(1).
+					Return(outsider, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(1).
+					Return(absence, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, outsider, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "Unauthorized",
+			absenceID: absence.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/absences/%d", tc.absenceID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestUpdateAbsenceAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	user := randomUser()
+	absence := randomAbsence(user.ID)
+	approved := absence
+	approved.Status = types.AbsenceApproved
+	updatedAbsence := absence
+	updatedAbsence.Reason = util.RandomString(20)
+	updatedAbsence.Paid = false
+
+	body := gin.H{
+		"start_time": updatedAbsence.StartTime,
+		"end_time":   updatedAbsence.EndTime,
+		"reason":     updatedAbsence.Reason,
+		"paid":       updatedAbsence.Paid,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				arg := db.UpdateAbsenceParams{
+					ID:        absence.ID,
+					UserID:    absence.UserID,
+					Reason:    updatedAbsence.Reason,
+					Paid:      updatedAbsence.Paid,
+					StartTime: updatedAbsence.StartTime,
+					EndTime:   updatedAbsence.EndTime,
+					Kind:      types.AbsenceHours,
+				}
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(2).
+					Return(absence, nil)
+				store.EXPECT().
+					UpdateAbsence(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(updatedAbsence, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAbsence(t, recorder.Body, updatedAbsence)
+			},
+		},
+		{
+			name: "KeepsKind",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				fullDay := absence
+				fullDay.Kind = types.AbsenceFullDay
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(2).
+					Return(user, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(2).
+					Return(fullDay, nil)
+				store.EXPECT().
+					UpdateAbsence(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.UpdateAbsenceParams) (db.Absence, error) {
+						require.Equal(t, types.AbsenceFullDay, arg.Kind)
+						start, end := absenceSpan(types.AbsenceFullDay, time.UTC, updatedAbsence.StartTime, updatedAbsence.EndTime)
+						require.Equal(t, start, arg.StartTime)
+						require.Equal(t, end, arg.EndTime)
+						return fullDay, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotPending",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(2).
+					Return(approved, nil)
+				store.EXPECT().
+					UpdateAbsence(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "Overlap",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(2).
+					Return(absence, nil)
+				store.EXPECT().
+					UpdateAbsence(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Absence{}, &pgconn.PgError{
+						Code:           db.ExclusionViolation,
+						ConstraintName: db.AbsenceOverlapConstraint,
+					})
+				// the absence does not overlap itself
+				store.EXPECT().
+					GetOverlappingAbsence(gomock.Any(), gomock.Eq(db.GetOverlappingAbsenceParams{
+						UserID:    absence.UserID,
+						ExcludeID: absence.ID,
+						StartTime: updatedAbsence.StartTime,
+						EndTime:   updatedAbsence.EndTime,
+					})).
+					Times(1).
+					Return(db.Absence{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest",
+			body: gin.H{
+				"start_time": updatedAbsence.StartTime,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(1).
+					Return(absence, nil)
+				store.EXPECT().
+					UpdateAbsence(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalServerError",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(2).
+					Return(absence, nil)
+				store.EXPECT().
+					UpdateAbsence(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Absence{}, pgx.ErrTxClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+				AnyTimes().
+				Return(admin, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/absences/%d", absence.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(jsonData))
+			require.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListAbsencesAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	superuser := randomSuperuser()
+	manager := randomUserWithRole(types.ManagerRole)
+
+	n := 5
+	absences := make([]db.Absence, n)
+	for i := 0; i < n; i++ {
+		absences[i] = randomAbsence(util.RandomInt(1, 1000))
+	}
+
+	testCases := []struct {
+		name          string
+		actor         db.User
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			actor: admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListAbsencesParams{
+					CompanyID: admin.CompanyID,
+					Limit:     int32(n),
+					Offset:    0,
+				}
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					ListAbsences(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(absences, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAbsenceList(t, recorder.Body, absences)
+			},
+		},
+		{
+			name:  "SuperuserOK",
+			actor: superuser,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(superuser.Username)).
+					Times(1).
+					Return(superuser, nil)
+				arg := db.ListAbsencesParams{
+					Limit:  int32(n),
+					Offset: 0,
+				}
+				store.EXPECT().
+					ListAbsences(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(absences, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAbsenceList(t, recorder.Body, absences)
+			},
+		},
+		{
+			name:  "InternalServerError",
+			actor: admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					ListAbsences(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Absence{}, pgx.ErrTxClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:  "Forbidden",
+			actor: manager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					ListAbsences(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/absences?offset=0&limit=%d", n)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, tc.actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListUserAbsencesAPI(t *testing.T) {
+	user := randomUser()
+	otherEmployee := randomUser()
+
+	n := 5
+	absences := make([]db.Absence, n)
+	for i := 0; i < n; i++ {
+		absences[i] = randomAbsence(user.ID)
+	}
+
+	testCases := []struct {
+		name          string
+		actor         db.User
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			actor: user,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				arg := db.ListUserAbsencesParams{
+					UserID: user.ID,
+					Limit:  10,
+					Offset: 0,
+				}
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					ListUserAbsences(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(absences, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAbsenceList(t, recorder.Body, absences)
+			},
+		},
+		{
+			name:  "InternalServerError",
+			actor: user,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					ListUserAbsences(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Absence{}, pgx.ErrTxClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:  "Forbidden",
+			actor: otherEmployee,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(otherEmployee.Username)).
+					Times(1).
+					Return(otherEmployee, nil)
+				store.EXPECT().
+					ListUserAbsences(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/users/%d/absences", user.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, tc.actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDecideAbsenceAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	manager := randomUserWithRole(types.ManagerRole)
+	otherManager := randomUserWithRole(types.ManagerRole)
+	user := randomUser()
+	user.ManagerID = &manager.ID
+	absence := randomAbsence(user.ID)
+	comment := util.RandomString(20)
+
+	approved := absence
+	approved.Status = types.AbsenceApproved
+	approved.ApprovedByID = &manager.ID
+	decidedAt := time.Now().UTC().Truncate(time.Second)
+	approved.DecidedAt = &decidedAt
+
+	rejected := approved
+	rejected.Status = types.AbsenceRejected
+	rejected.DecisionComment = &comment
+
+	testCases := []struct {
+		name          string
+		action        string
+		actor         db.User
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "ApproveOK",
+			action: "approve",
+			actor:  manager,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.DecideAbsenceParams{
+					ID:            absence.ID,
+					Status:        types.AbsenceApproved,
+					ApprovedByID:  &manager.ID,
+					CurrentStatus: types.AbsencePending,
+				}
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(2).
+					Return(absence, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					DecideAbsenceTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(approved, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAbsence(t, recorder.Body, approved)
+			},
+		},
+		{
+			name:   "RejectWithCommentOK",
+			action: "reject",
+			actor:  manager,
+			body:   gin.H{"comment": comment},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.DecideAbsenceParams{
+					ID:              absence.ID,
+					Status:          types.AbsenceRejected,
+					ApprovedByID:    &manager.ID,
+					DecisionComment: &comment,
+					CurrentStatus:   types.AbsencePending,
+				}
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(2).
+					Return(absence, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					DecideAbsenceTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(rejected, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAbsence(t, recorder.Body, rejected)
+			},
+		},
+		{
+			name:   "OwnAbsenceForbidden",
+			action: "approve",
+			actor:  manager,
+			buildStubs: func(store *mockdb.MockStore) {
+				selfManaged := manager
+				selfManaged.ManagerID = &manager.ID
+				own := absence
+				own.UserID = manager.ID
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(selfManaged, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(2).
+					Return(own, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(manager.ID)).
+					Times(1).
+					Return(selfManaged, nil)
+				store.EXPECT().
+					DecideAbsenceTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "AdminOK",
+			action: "approve",
+			actor:  admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				arg := db.DecideAbsenceParams{
+					ID:            absence.ID,
+					Status:        types.AbsenceApproved,
+					ApprovedByID:  &admin.ID,
+					CurrentStatus: types.AbsencePending,
+				}
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(2).
+					Return(absence, nil)
+				store.EXPECT().
+					DecideAbsenceTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(approved, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "AlreadyDecided",
+			action: "reject",
+			actor:  manager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(2).
+					Return(approved, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					DecideAbsenceTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "ChangedConcurrently",
+			action: "approve",
+			actor:  admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(2).
+					Return(absence, nil)
+				store.EXPECT().
+					DecideAbsenceTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Absence{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "NotFound",
+			action: "approve",
+			actor:  admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(1).
+					Return(db.Absence{}, pgx.ErrNoRows)
+				store.EXPECT().
+					DecideAbsenceTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "InternalServerError",
+			action: "approve",
+			actor:  admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(2).
+					Return(absence, nil)
+				store.EXPECT().
+					DecideAbsenceTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Absence{}, pgx.ErrTxClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:   "OtherManagerForbidden",
+			action: "approve",
+			actor:  otherManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(otherManager.Username)).
+					Times(1).
+					Return(otherManager, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(1).
+					Return(absence, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					DecideAbsenceTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "SelfApprovalForbidden",
+			action: "approve",
+			actor:  user,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(1).
+					Return(absence, nil)
+				store.EXPECT().
+					DecideAbsenceTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			var body io.Reader = http.NoBody
+			if tc.body != nil {
+				jsonData, err := json.Marshal(tc.body)
+				require.NoError(t, err)
+				body = bytes.NewBuffer(jsonData)
+			}
+
+			url := fmt.Sprintf("/absences/%d/%s", absence.ID, tc.action)
+			request, err := http.NewRequest(http.MethodPost, url, body)
+			require.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, tc.actor, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCancelAbsenceAPI(t *testing.T) {
+	user := randomUser()
+	otherEmployee := randomUser()
+	absence := randomAbsence(user.ID)
+	approved := absence
+	approved.Status = types.AbsenceApproved
+	rejected := absence
+	rejected.Status = types.AbsenceRejected
+	cancelled := absence
+	cancelled.Status = types.AbsenceCancelled
+
+	testCases := []struct {
+		name          string
+		actor         db.User
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "PendingOK",
+			actor: user,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				arg := db.CancelAbsenceParams{
+					ID:            absence.ID,
+					CurrentStatus: types.AbsencePending,
+				}
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(2).
+					Return(absence, nil)
+				store.EXPECT().
+					CancelAbsenceTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(cancelled, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAbsence(t, recorder.Body, cancelled)
+			},
+		},
+		{
+			name:  "ApprovedOK",
+			actor: user,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				arg := db.CancelAbsenceParams{
+					ID:            absence.ID,
+					CurrentStatus: types.AbsenceApproved,
+				}
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(2).
+					Return(approved, nil)
+				store.EXPECT().
+					CancelAbsenceTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(cancelled, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "RejectedConflict",
+			actor: user,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(2).
+					Return(rejected, nil)
+				store.EXPECT().
+					CancelAbsenceTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalServerError",
+			actor: user,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(2).
+					Return(absence, nil)
+				store.EXPECT().
+					CancelAbsenceTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Absence{}, pgx.ErrTxClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:  "Forbidden",
+			actor: otherEmployee,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(otherEmployee.Username)).
+					Times(1).
+					Return(otherEmployee, nil)
+				store.EXPECT().
+					GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+					Times(1).
+					Return(absence, nil)
+				store.EXPECT().
+					CancelAbsenceTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/absences/%d/cancel", absence.ID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, tc.actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetAbsenceDurationAPI(t *testing.T) {
+	user := randomUser()
+	otherEmployee := randomUser()
+	// from Friday until Tuesday midnight, Monday being a company holiday
+	absence := randomAbsence(user.ID)
+	absence.Kind = types.AbsenceFullDay
+	absence.StartTime = time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.March, 12, 0, 0, 0, 0, time.UTC)
+	absence.EndTime = &end
+	companyHoliday := db.CompanyHoliday{
+		CompanyID: testCompanyID,
+		Date:      time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC),
+		Name:      util.RandomString(10),
+		DayOff:    true,
+	}
+
+	testCases := []struct {
+		name          string
+		actor         db.User
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			actor: user,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(2).
+					Return(user, nil)
+				store.EXPECT().
+					GetCompany(gomock.Any(), gomock.Eq(testCompanyID)).
+					Times(1).
+					Return(db.Company{ID: testCompanyID}, nil)
+				store.EXPECT().
+					ListUserWorkScheduleAssignments(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.WorkScheduleAssignment{}, nil)
+				store.EXPECT().
+					ListCompanyHolidays(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.CompanyHoliday{companyHoliday}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got absenceDurationResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, absence.ID, got.AbsenceID)
+				require.Equal(t, []worktime.Day{{Date: absence.StartTime, Days: 1, Seconds: 8 * 3600}}, got.Days)
+				require.Equal(t, float64(1), got.WorkingDays)
+				require.Equal(t, int64(8*3600), got.Seconds)
+			},
+		},
+		{
+			name:  "Forbidden",
+			actor: otherEmployee,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					ListUserWorkScheduleAssignments(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByUsername(gomock.Any(), gomock.Eq(tc.actor.Username)).
+				AnyTimes().
+				Return(tc.actor, nil)
+			store.EXPECT().
+				GetAbsence(gomock.Any(), gomock.Eq(absence.ID)).
+				AnyTimes().
+				Return(absence, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/absences/%d/duration", absence.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, tc.actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
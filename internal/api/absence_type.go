@@ -0,0 +1,213 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/jackc/pgx/v5"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+)
+
+// absenceTypeRequest configures an absence type. Absences of a type which tracks a balance draw from the leave
+// entitlements of their user, which accrue yearly or monthly. Days left at the end of a year are carried over
+// up to the carry-over maximum and expire after the carry-over expiry months, if set.
+type absenceTypeRequest struct {
+	Name                  string `json:"name" binding:"required,min=1,max=255"`
+	Paid                  bool   `json:"paid"`
+	TracksBalance         bool   `json:"tracks_balance"`
+	Accrual               string `json:"accrual" binding:"required,accrual"`
+	CarryOverMaxDays      int32  `json:"carry_over_max_days" binding:"min=0,max=366"`
+	CarryOverExpiryMonths *int32 `json:"carry_over_expiry_months" binding:"omitempty,min=1,max=12"`
+}
+
+type createAbsenceTypeRequest struct {
+	absenceTypeRequest
+	CompanyID int64 `json:"company_id" binding:"required,min=1"`
+}
+
+func (server *Server) createAbsenceType(ctx *gin.Context) {
+	var req createAbsenceTypeRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateAbsenceTypeParams{
+		CompanyID:             req.CompanyID,
+		Name:                  req.Name,
+		Paid:                  req.Paid,
+		TracksBalance:         req.TracksBalance,
+		Accrual:               req.Accrual,
+		CarryOverMaxDays:      req.CarryOverMaxDays,
+		CarryOverExpiryMonths: req.CarryOverExpiryMonths,
+	}
+	absenceType, err := server.store.CreateAbsenceType(ctx, arg)
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation && db.ConstraintName(err) == db.AbsenceTypeNameConstraint {
+			ctx.JSON(http.StatusConflict, errorResponse(errAbsenceTypeNameTaken))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, absenceType)
+}
+
+func (server *Server) getAbsenceType(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	absenceType, err := server.store.GetAbsenceType(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, absenceType)
+}
+
+// updateAbsenceType replaces the configuration of an absence type. Balances are recalculated with it,
+// existing absences are not revalidated.
+func (server *Server) updateAbsenceType(ctx *gin.Context) {
+	var reqID RequestWithID
+	var req absenceTypeRequest
+	if err := ctx.ShouldBindUri(&reqID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateAbsenceTypeParams{
+		ID:                    reqID.ID,
+		Name:                  req.Name,
+		Paid:                  req.Paid,
+		TracksBalance:         req.TracksBalance,
+		Accrual:               req.Accrual,
+		CarryOverMaxDays:      req.CarryOverMaxDays,
+		CarryOverExpiryMonths: req.CarryOverExpiryMonths,
+	}
+	absenceType, err := server.store.UpdateAbsenceType(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if db.ErrorCode(err) == db.UniqueViolation && db.ConstraintName(err) == db.AbsenceTypeNameConstraint {
+			ctx.JSON(http.StatusConflict, errorResponse(errAbsenceTypeNameTaken))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, absenceType)
+}
+
+// deleteAbsenceType deletes an absence type together with the leave entitlements of it.
+// Absence types which absences refer to cannot be deleted.
+func (server *Server) deleteAbsenceType(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	absenceType, err := server.store.DeleteAbsenceType(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if db.ErrorCode(err) == db.ForeignKeyViolation {
+			ctx.JSON(http.StatusConflict, errorResponse(errAbsenceTypeInUse))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, absenceType)
+}
+
+func (server *Server) listAbsenceTypes(ctx *gin.Context) {
+	var req PaginationRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	companyID, err := server.tenantScope(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	arg := db.ListAbsenceTypesParams{
+		CompanyID: companyID,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+	}
+	absenceTypes, err := server.store.ListAbsenceTypes(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, absenceTypes)
+}
+
+// companyAbsenceType returns the absence type if it exists and belongs to the company.
+// It writes the error response and returns false otherwise.
+func (server *Server) companyAbsenceType(ctx *gin.Context, absenceTypeID int64, companyID *int64) (db.AbsenceType, bool) {
+	absenceType, err := server.store.GetAbsenceType(ctx, absenceTypeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return absenceType, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return absenceType, false
+	}
+
+	if !sameCompany(&absenceType.CompanyID, companyID) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errAbsenceTypeOutsideCompany))
+		return absenceType, false
+	}
+	return absenceType, true
+}
+
+// absenceTypeCompanyFromURI resolves the company a request acts upon to the company of the absence type
+// identified by the `:id` URI parameter.
+func (server *Server) absenceTypeCompanyFromURI(ctx *gin.Context) (*int64, error) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		return nil, &requestError{err}
+	}
+	absenceType, err := server.store.GetAbsenceType(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &absenceType.CompanyID, nil
+}
+
+// absenceTypeCompanyFromBody resolves the company a request acts upon to the company of the absence type in the request body.
+func absenceTypeCompanyFromBody(ctx *gin.Context) (*int64, error) {
+	var req createAbsenceTypeRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		return nil, &requestError{err}
+	}
+	return &req.CompanyID, nil
+}
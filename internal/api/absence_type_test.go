@@ -0,0 +1,256 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func randomAbsenceType(tracksBalance bool) db.AbsenceType {
+	return db.AbsenceType{
+		ID:               util.RandomInt(1, 1000),
+		CompanyID:        testCompanyID,
+		Name:             util.RandomString(10),
+		Paid:             true,
+		TracksBalance:    tracksBalance,
+		Accrual:          types.AccrualYearly,
+		CarryOverMaxDays: 5,
+		CreatedAt:        time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func TestCreateAbsenceTypeAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	manager := randomUserWithRole(types.ManagerRole)
+	absenceType := randomAbsenceType(true)
+	months := int32(3)
+	absenceType.CarryOverExpiryMonths = &months
+
+	body := gin.H{
+		"company_id":               absenceType.CompanyID,
+		"name":                     absenceType.Name,
+		"paid":                     absenceType.Paid,
+		"tracks_balance":           absenceType.TracksBalance,
+		"accrual":                  absenceType.Accrual,
+		"carry_over_max_days":      absenceType.CarryOverMaxDays,
+		"carry_over_expiry_months": months,
+	}
+	arg := db.CreateAbsenceTypeParams{
+		CompanyID:             absenceType.CompanyID,
+		Name:                  absenceType.Name,
+		Paid:                  absenceType.Paid,
+		TracksBalance:         absenceType.TracksBalance,
+		Accrual:               absenceType.Accrual,
+		CarryOverMaxDays:      absenceType.CarryOverMaxDays,
+		CarryOverExpiryMonths: &months,
+	}
+
+	testCases := []struct {
+		name          string
+		actor         db.User
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			actor: admin,
+			body:  body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					CreateAbsenceType(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(absenceType, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var got db.AbsenceType
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, absenceType, got)
+			},
+		},
+		{
+			name:  "NameTaken",
+			actor: admin,
+			body:  body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					CreateAbsenceType(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.AbsenceType{}, &pgconn.PgError{
+						Code:           db.UniqueViolation,
+						ConstraintName: db.AbsenceTypeNameConstraint,
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidAccrual",
+			actor: admin,
+			body: gin.H{
+				"company_id": absenceType.CompanyID,
+				"name":       absenceType.Name,
+				"accrual":    "weekly",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					CreateAbsenceType(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidExpiry",
+			actor: admin,
+			body: gin.H{
+				"company_id":               absenceType.CompanyID,
+				"name":                     absenceType.Name,
+				"accrual":                  types.AccrualMonthly,
+				"carry_over_expiry_months": 13,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					CreateAbsenceType(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Forbidden",
+			actor: manager,
+			body:  body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					CreateAbsenceType(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/absence-types", bytes.NewBuffer(jsonData))
+			require.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, tc.actor, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeleteAbsenceTypeAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	absenceType := randomAbsenceType(false)
+
+	testCases := []struct {
+		name          string
+		deleteErr     error
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "InUse",
+			deleteErr: &pgconn.PgError{Code: db.ForeignKeyViolation},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetAbsenceType(gomock.Any(), gomock.Eq(absenceType.ID)).
+				Times(1).
+				Return(absenceType, nil)
+			store.EXPECT().
+				GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+				Times(1).
+				Return(admin, nil)
+			store.EXPECT().
+				DeleteAbsenceType(gomock.Any(), gomock.Eq(absenceType.ID)).
+				Times(1).
+				Return(absenceType, tc.deleteErr)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/absence-types/%d", absenceType.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
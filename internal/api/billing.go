@@ -0,0 +1,105 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/export"
+)
+
+type billingReportRequest struct {
+	// Format exports the lines as a spreadsheet instead of the JSON report
+	Format    string    `form:"format" binding:"omitempty,export_format"`
+	From      time.Time `form:"from" binding:"required"`
+	To        time.Time `form:"to" binding:"required,gtfield=From"`
+	ClientID  *int64    `form:"client_id" binding:"omitempty,min=1"`
+	ProjectID *int64    `form:"project_id" binding:"omitempty,min=1"`
+}
+
+// billingTotal is the amount billable in one currency, in its minor units.
+type billingTotal struct {
+	Currency        string `json:"currency"`
+	BillableSeconds int64  `json:"billable_seconds"`
+	AmountCents     int64  `json:"amount_cents"`
+}
+
+// billingReportResponse lists the billable time of every client and project. Lines without a currency
+// are billable time no rate applies to.
+type billingReportResponse struct {
+	From   time.Time                `json:"from"`
+	To     time.Time                `json:"to"`
+	Lines  []db.GetBillingReportRow `json:"lines"`
+	Totals []billingTotal           `json:"totals"`
+}
+
+// getBillingReport reports the billable entries of a company which started within [from, to), priced with
+// the rate of their user, project, client or company effective on the day they started, in that order.
+func (server *Server) getBillingReport(ctx *gin.Context) {
+	var idReq RequestWithID
+	var req billingReportRequest
+	if err := ctx.ShouldBindUri(&idReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.GetBillingReportParams{
+		CompanyID: idReq.ID,
+		From:      req.From,
+		To:        req.To,
+		ClientID:  req.ClientID,
+		ProjectID: req.ProjectID,
+	}
+	lines, err := server.store.GetBillingReport(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if req.Format != "" {
+		filename := fmt.Sprintf("billing-%s-%s", req.From.Format("20060102"), req.To.Format("20060102"))
+		writeExport(ctx, req.Format, filename, func(w export.Writer) error {
+			table := export.NewTable(w, billingColumns())
+			for _, line := range lines {
+				if err := table.Write(line); err != nil {
+					return err
+				}
+			}
+			return table.Close()
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, billingReportResponse{
+		From:   req.From,
+		To:     req.To,
+		Lines:  lines,
+		Totals: billingTotals(lines),
+	})
+}
+
+// billingTotals sums the priced lines of a billing report per currency, in the order the currencies appear.
+func billingTotals(lines []db.GetBillingReportRow) []billingTotal {
+	totals := []billingTotal{}
+	index := make(map[string]int)
+	for _, line := range lines {
+		if line.Currency == nil || line.AmountCents == nil {
+			continue
+		}
+		i, ok := index[*line.Currency]
+		if !ok {
+			i = len(totals)
+			index[*line.Currency] = i
+			totals = append(totals, billingTotal{Currency: *line.Currency})
+		}
+		totals[i].BillableSeconds += line.BillableSeconds
+		totals[i].AmountCents += *line.AmountCents
+	}
+	return totals
+}
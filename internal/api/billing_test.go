@@ -0,0 +1,332 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/token"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func requireBodyMatchBillingReport(t *testing.T, body *bytes.Buffer, report billingReportResponse) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotReport billingReportResponse
+	err = json.Unmarshal(data, &gotReport)
+	require.NoError(t, err)
+	require.Equal(t, report, gotReport)
+}
+
+func TestGetBillingReportAPI(t *testing.T) {
+	manager := randomUserWithRole(types.ManagerRole)
+	employee := randomUser()
+	outsider := randomUserWithRole(types.AdminRole)
+	outsider.CompanyID = util.Pointer(testCompanyID + 1)
+	client := randomClient()
+	project := randomProject()
+
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	lines := []db.GetBillingReportRow{
+		{
+			ClientID:        &client.ID,
+			ProjectID:       &project.ID,
+			Currency:        util.Pointer("EUR"),
+			Entries:         3,
+			BillableSeconds: 5400,
+			AmountCents:     util.Pointer(int64(12345)),
+		},
+		{
+			ClientID:        &client.ID,
+			ProjectID:       nil,
+			Currency:        util.Pointer("EUR"),
+			Entries:         1,
+			BillableSeconds: 3600,
+			AmountCents:     util.Pointer(int64(10001)),
+		},
+		{
+			ClientID:        nil,
+			ProjectID:       nil,
+			Currency:        util.Pointer("USD"),
+			Entries:         1,
+			BillableSeconds: 1800,
+			AmountCents:     util.Pointer(int64(5000)),
+		},
+		{
+			Entries:         2,
+			BillableSeconds: 7200,
+		},
+	}
+
+	query := func(from, to time.Time) url.Values {
+		return url.Values{
+			"from": {from.Format(time.RFC3339)},
+			"to":   {to.Format(time.RFC3339)},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		buildStubs    func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: query(from, to),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				arg := db.GetBillingReportParams{
+					CompanyID: testCompanyID,
+					From:      from,
+					To:        to,
+				}
+				store.EXPECT().
+					GetBillingReport(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(lines, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchBillingReport(t, recorder.Body, billingReportResponse{
+					From:  from,
+					To:    to,
+					Lines: lines,
+					Totals: []billingTotal{
+						{Currency: "EUR", BillableSeconds: 9000, AmountCents: 22346},
+						{Currency: "USD", BillableSeconds: 1800, AmountCents: 5000},
+					},
+				})
+			},
+		},
+		{
+			name: "ClientFilterOK",
+			query: func() url.Values {
+				q := query(from, to)
+				q.Set("client_id", fmt.Sprint(client.ID))
+				return q
+			}(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				arg := db.GetBillingReportParams{
+					CompanyID: testCompanyID,
+					From:      from,
+					To:        to,
+					ClientID:  &client.ID,
+				}
+				store.EXPECT().
+					GetBillingReport(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.GetBillingReportRow{}, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchBillingReport(t, recorder.Body, billingReportResponse{
+					From:   from,
+					To:     to,
+					Lines:  []db.GetBillingReportRow{},
+					Totals: []billingTotal{},
+				})
+			},
+		},
+		{
+			name: "CSV",
+			query: func() url.Values {
+				q := query(from, to)
+				q.Set("format", types.ExportCSV)
+				return q
+			}(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetBillingReport(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(lines, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, `attachment; filename="billing-20240301-20240401.csv"`, recorder.Header().Get("Content-Disposition"))
+				require.Equal(t, "Client ID,Project ID,Currency,Entries,Hours,Amount (minor units)\n"+
+					fmt.Sprintf("%d,%d,EUR,3,1.5,12345\n", client.ID, project.ID)+
+					fmt.Sprintf("%d,,EUR,1,1,10001\n", client.ID)+
+					",,USD,1,0.5,5000\n"+
+					",,,2,2,\n"+
+					"Total,,,7,5,\n", recorder.Body.String())
+			},
+		},
+		{
+			name: "InvalidFormat",
+			query: func() url.Values {
+				q := query(from, to)
+				q.Set("format", "ods")
+				return q
+			}(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetBillingReport(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidTimeRange",
+			query: query(to, from),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetBillingReport(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "MissingTimeRange",
+			query: url.Values{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetBillingReport(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "OtherCompanyNotFound",
+			query: query(from, to),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(outsider.Username)).
+					Times(1).
+					Return(outsider, nil)
+				store.EXPECT().
+					GetBillingReport(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, outsider, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "Forbidden",
+			query: query(from, to),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(employee.Username)).
+					Times(1).
+					Return(employee, nil)
+				store.EXPECT().
+					GetBillingReport(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, employee, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalServerError",
+			query: query(from, to),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetBillingReport(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, pgx.ErrTxClosed)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/companies/%d/reports/billing?%s", testCompanyID, tc.query.Encode())
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,396 @@
+package api
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/holiday"
+	"github.com/mateoradman/tempus/internal/ical"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/worktime"
+)
+
+const (
+	// feedTokenBytes is the number of random bytes of a calendar feed token
+	feedTokenBytes = 32
+	// feedPageSize is the number of team members and absences loaded at once while building a feed
+	feedPageSize = 100
+)
+
+// calendarFeedResponse describes a calendar feed without its token, which is only returned when the feed is created
+type calendarFeedResponse struct {
+	ID        int64      `json:"id"`
+	UserID    *int64     `json:"user_id"`
+	TeamID    *int64     `json:"team_id"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+}
+
+func newCalendarFeedResponse(feed db.CalendarFeed) calendarFeedResponse {
+	return calendarFeedResponse{
+		ID:        feed.ID,
+		UserID:    feed.UserID,
+		TeamID:    feed.TeamID,
+		CreatedAt: feed.CreatedAt,
+		RevokedAt: feed.RevokedAt,
+	}
+}
+
+// createCalendarFeedResponse holds the secret token of a new feed and the path the feed is subscribed to at
+type createCalendarFeedResponse struct {
+	calendarFeedResponse
+	Token string `json:"token"`
+	Path  string `json:"path"`
+}
+
+type calendarFeedURIRequest struct {
+	ID     int64 `uri:"id" binding:"required,min=1"`
+	FeedID int64 `uri:"feed_id" binding:"required,min=1"`
+}
+
+type calendarRequest struct {
+	Token string `uri:"token" binding:"required"`
+}
+
+// newFeedToken returns a random URL safe token together with the hash it is stored as
+func newFeedToken() (string, string, error) {
+	b := make([]byte, feedTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashFeedToken(token), nil
+}
+
+// hashFeedToken returns the hex encoded SHA-256 hash of a feed token
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (server *Server) createUserCalendarFeed(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	server.createCalendarFeed(ctx, db.CreateCalendarFeedParams{UserID: &req.ID})
+}
+
+func (server *Server) createTeamCalendarFeed(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	server.createCalendarFeed(ctx, db.CreateCalendarFeedParams{TeamID: &req.ID})
+}
+
+// createCalendarFeed creates a feed with a new secret token. Only the hash of the token is stored.
+func (server *Server) createCalendarFeed(ctx *gin.Context, arg db.CreateCalendarFeedParams) {
+	token, tokenHash, err := newFeedToken()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	arg.TokenHash = tokenHash
+
+	feed, err := server.store.CreateCalendarFeed(ctx, arg)
+	if err != nil {
+		if db.ErrorCode(err) == db.ForeignKeyViolation {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, createCalendarFeedResponse{
+		calendarFeedResponse: newCalendarFeedResponse(feed),
+		Token:                token,
+		Path:                 fmt.Sprintf("/calendars/%s.ics", token),
+	})
+}
+
+func (server *Server) listUserCalendarFeeds(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	server.listCalendarFeeds(ctx, db.ListCalendarFeedsParams{UserID: &req.ID})
+}
+
+func (server *Server) listTeamCalendarFeeds(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	server.listCalendarFeeds(ctx, db.ListCalendarFeedsParams{TeamID: &req.ID})
+}
+
+func (server *Server) listCalendarFeeds(ctx *gin.Context, arg db.ListCalendarFeedsParams) {
+	feeds, err := server.store.ListCalendarFeeds(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response := make([]calendarFeedResponse, 0, len(feeds))
+	for _, feed := range feeds {
+		response = append(response, newCalendarFeedResponse(feed))
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (server *Server) revokeUserCalendarFeed(ctx *gin.Context) {
+	var req calendarFeedURIRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	server.revokeCalendarFeed(ctx, db.RevokeCalendarFeedParams{ID: req.FeedID, UserID: &req.ID})
+}
+
+func (server *Server) revokeTeamCalendarFeed(ctx *gin.Context) {
+	var req calendarFeedURIRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	server.revokeCalendarFeed(ctx, db.RevokeCalendarFeedParams{ID: req.FeedID, TeamID: &req.ID})
+}
+
+// revokeCalendarFeed stops accepting the token of a feed. Revoked feeds are kept, they cannot be restored.
+func (server *Server) revokeCalendarFeed(ctx *gin.Context, arg db.RevokeCalendarFeedParams) {
+	feed, err := server.store.RevokeCalendarFeed(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newCalendarFeedResponse(feed))
+}
+
+// getCalendar writes the iCalendar file of the feed whose token is the `:token` URI parameter, optionally followed by
+// `.ics`. The file holds the approved absences of the user or of the current members of the team, the absences
+// cancelled after their approval and the holidays they observe from the start of the previous year until the end of
+// the next one.
+func (server *Server) getCalendar(ctx *gin.Context) {
+	var req calendarRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	feed, err := server.store.GetActiveCalendarFeed(ctx, hashFeedToken(strings.TrimSuffix(req.Token, ".ics")))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	var cal ical.Calendar
+	var members []db.User
+	var companyID *int64
+	if feed.UserID != nil {
+		var user db.User
+		user, err = server.store.GetUser(ctx, *feed.UserID)
+		members = []db.User{user}
+		companyID = user.CompanyID
+		cal.Name = fmt.Sprintf("%s %s", user.Name, user.Surname)
+	} else {
+		var team db.Team
+		team, err = server.store.GetTeam(ctx, *feed.TeamID)
+		companyID = team.CompanyID
+		cal.Name = team.Name
+	}
+	// the token stands in for an actor, restrict the rest of the request to the company of the owner of the feed
+	if companyID != nil {
+		ctx.Set(db.TenantKey, *companyID)
+	}
+	if err == nil && feed.TeamID != nil {
+		members, err = server.teamMembers(ctx, *feed.TeamID)
+	}
+	if err == nil {
+		cal.Events, err = server.feedEvents(ctx, members, feed.TeamID != nil)
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := ical.Write(&buf, cal); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.Header("Cache-Control", "private, max-age=900")
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", buf.Bytes())
+}
+
+// teamMembers returns every member of a team
+func (server *Server) teamMembers(ctx *gin.Context, teamID int64) ([]db.User, error) {
+	var members []db.User
+	for offset := int32(0); ; offset += feedPageSize {
+		page, err := server.store.ListTeamMembers(ctx, db.ListTeamMembersParams{
+			TeamID: &teamID,
+			Limit:  feedPageSize,
+			Offset: offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, page...)
+		if len(page) < feedPageSize {
+			return members, nil
+		}
+	}
+}
+
+// feedEvents returns the absence and holiday events of users. Absences of team feeds are summarized by the name of
+// their user, while the type of absence is only shared in the feed of the user.
+func (server *Server) feedEvents(ctx *gin.Context, users []db.User, team bool) ([]ical.Event, error) {
+	now := time.Now()
+	from := time.Date(now.Year()-1, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(now.Year()+2, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	events := []ical.Event{}
+	absenceTypes := make(map[int64]string)
+	holidays := make(map[string]bool)
+	for _, user := range users {
+		location, err := time.LoadLocation(user.Timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		for offset := int32(0); ; offset += feedPageSize {
+			page, err := server.store.ListUserAbsences(ctx, db.ListUserAbsencesParams{
+				UserID: user.ID,
+				Limit:  feedPageSize,
+				Offset: offset,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, absence := range page {
+				// pending, rejected and absences cancelled before a decision never took any time
+				if absence.Status != types.AbsenceApproved && (absence.Status != types.AbsenceCancelled || absence.DecidedAt == nil) {
+					continue
+				}
+
+				summary := fmt.Sprintf("%s %s", user.Name, user.Surname)
+				if !team {
+					summary = "Absence"
+					if absence.AbsenceTypeID != nil {
+						name, ok := absenceTypes[*absence.AbsenceTypeID]
+						if !ok {
+							absenceType, err := server.store.GetAbsenceType(ctx, *absence.AbsenceTypeID)
+							if err != nil {
+								return nil, err
+							}
+							name = absenceType.Name
+							absenceTypes[absenceType.ID] = name
+						}
+						summary = name
+					}
+				}
+				events = append(events, absenceEvent(absence, summary, location, now))
+			}
+			if len(page) < feedPageSize {
+				break
+			}
+		}
+
+		observed, err := worktime.LoadHolidays(ctx, server.store, user, from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range observed {
+			event := holidayEvent(h)
+			// members of a team mostly observe the same holidays
+			if !holidays[event.UID] {
+				holidays[event.UID] = true
+				events = append(events, event)
+			}
+		}
+	}
+	return events, nil
+}
+
+// absenceEvent returns the event of an absence of a user in location. Its UID is derived from the ID of the absence,
+// so that changes and cancellations replace the event, and its sequence grows with every update. Full day absences
+// are all day events. Absences which have not ended yet last until the end of the current day.
+func absenceEvent(absence db.Absence, summary string, location *time.Location, now time.Time) ical.Event {
+	modified := absence.CreatedAt
+	if absence.UpdatedAt != nil {
+		modified = *absence.UpdatedAt
+	}
+	end := now.In(location)
+	end = time.Date(end.Year(), end.Month(), end.Day()+1, 0, 0, 0, 0, location)
+	if absence.EndTime != nil {
+		end = *absence.EndTime
+	}
+	if end.Before(absence.StartTime) {
+		end = absence.StartTime
+	}
+
+	event := ical.Event{
+		UID:      fmt.Sprintf("absence-%d@tempus", absence.ID),
+		Summary:  summary,
+		Start:    absence.StartTime,
+		End:      end,
+		Status:   ical.StatusConfirmed,
+		Sequence: int64(modified.Sub(absence.CreatedAt) / time.Second),
+		Modified: modified,
+	}
+	if absence.Status == types.AbsenceCancelled {
+		event.Status = ical.StatusCancelled
+	}
+	if absence.Kind == types.AbsenceFullDay {
+		event.AllDay = true
+		event.Start = worktime.Date(absence.StartTime.In(location))
+		event.End = worktime.Date(end.In(location))
+		// an absence which does not end at midnight covers its last day
+		if !end.In(location).Equal(time.Date(event.End.Year(), event.End.Month(), event.End.Day(), 0, 0, 0, 0, location)) || !event.End.After(event.Start) {
+			event.End = event.End.AddDate(0, 0, 1)
+		}
+	}
+	return event
+}
+
+// holidayEvent returns the all day event of a holiday, whose UID is derived from its date and name
+func holidayEvent(h holiday.Holiday) ical.Event {
+	name := fnv.New32a()
+	name.Write([]byte(h.Name))
+	return ical.Event{
+		UID:         fmt.Sprintf("holiday-%s-%08x@tempus", h.Date.Format("20060102"), name.Sum32()),
+		Summary:     h.Name,
+		Start:       h.Date,
+		End:         h.Date.AddDate(0, 0, 1),
+		AllDay:      true,
+		Status:      ical.StatusConfirmed,
+		Modified:    h.Date,
+		Transparent: true,
+	}
+}
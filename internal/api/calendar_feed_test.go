@@ -0,0 +1,456 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/holiday"
+	"github.com/mateoradman/tempus/internal/ical"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestCreateCalendarFeedAPI(t *testing.T) {
+	user := randomUser()
+	other := randomUser()
+	manager := randomUserWithRole(types.ManagerRole)
+	team := randomTeam(&manager.ID)
+
+	testCases := []struct {
+		name          string
+		actor         db.User
+		url           string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "UserOK",
+			actor: user,
+			url:   fmt.Sprintf("/users/%d/calendar-feeds", user.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateCalendarFeed(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateCalendarFeedParams) (db.CalendarFeed, error) {
+						require.Equal(t, &user.ID, arg.UserID)
+						require.Nil(t, arg.TeamID)
+						return db.CalendarFeed{ID: 1, TokenHash: arg.TokenHash, UserID: arg.UserID, CreatedAt: time.Now()}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var got createCalendarFeedResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.NotEmpty(t, got.Token)
+				require.Equal(t, fmt.Sprintf("/calendars/%s.ics", got.Token), got.Path)
+				require.Equal(t, &user.ID, got.UserID)
+				// only the hash of the token is stored and returned
+				require.NotContains(t, recorder.Body.String(), hashFeedToken(got.Token))
+			},
+		},
+		{
+			name:  "UserForbidden",
+			actor: other,
+			url:   fmt.Sprintf("/users/%d/calendar-feeds", user.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(other.Username)).
+					Times(1).
+					Return(other, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateCalendarFeed(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "TeamOK",
+			actor: manager,
+			url:   fmt.Sprintf("/teams/%d/calendar-feeds", team.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetTeam(gomock.Any(), gomock.Eq(team.ID)).
+					Times(1).
+					Return(team, nil)
+				store.EXPECT().
+					CreateCalendarFeed(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateCalendarFeedParams) (db.CalendarFeed, error) {
+						require.Nil(t, arg.UserID)
+						require.Equal(t, &team.ID, arg.TeamID)
+						return db.CalendarFeed{ID: 2, TokenHash: arg.TokenHash, TeamID: arg.TeamID, CreatedAt: time.Now()}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name:  "TeamForbidden",
+			actor: user,
+			url:   fmt.Sprintf("/teams/%d/calendar-feeds", team.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetTeam(gomock.Any(), gomock.Eq(team.ID)).
+					Times(1).
+					Return(team, nil)
+				store.EXPECT().
+					CreateCalendarFeed(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, tc.url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, tc.actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestRevokeCalendarFeedAPI(t *testing.T) {
+	user := randomUser()
+	feed := db.CalendarFeed{
+		ID:        util.RandomInt(1, 1000),
+		TokenHash: hashFeedToken("token"),
+		UserID:    &user.ID,
+		CreatedAt: time.Now(),
+		RevokedAt: util.Pointer(time.Now()),
+	}
+	arg := db.RevokeCalendarFeedParams{ID: feed.ID, UserID: &user.ID}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RevokeCalendarFeed(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(feed, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.NotContains(t, recorder.Body.String(), feed.TokenHash)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RevokeCalendarFeed(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.CalendarFeed{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/users/%d/calendar-feeds/%d", user.ID, feed.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetCalendarAPI(t *testing.T) {
+	user := randomUser()
+	member := randomUser()
+	team := randomTeam(nil)
+	token := "secret"
+	absenceType := db.AbsenceType{ID: util.RandomInt(1, 1000), CompanyID: testCompanyID, Name: "Vacation"}
+	created := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	absences := []db.Absence{
+		{
+			ID:            1,
+			UserID:        user.ID,
+			StartTime:     time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC),
+			EndTime:       util.Pointer(time.Date(2024, time.March, 6, 0, 0, 0, 0, time.UTC)),
+			Reason:        "private",
+			Status:        types.AbsenceApproved,
+			Kind:          types.AbsenceFullDay,
+			AbsenceTypeID: &absenceType.ID,
+			CreatedAt:     created,
+			UpdatedAt:     util.Pointer(created.Add(time.Hour)),
+			DecidedAt:     util.Pointer(created.Add(time.Hour)),
+		},
+		{
+			ID:        2,
+			UserID:    user.ID,
+			StartTime: time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC),
+			EndTime:   util.Pointer(time.Date(2024, time.March, 12, 0, 0, 0, 0, time.UTC)),
+			Status:    types.AbsencePending,
+			Kind:      types.AbsenceFullDay,
+			CreatedAt: created,
+		},
+		{
+			ID:        3,
+			UserID:    user.ID,
+			StartTime: time.Date(2024, time.March, 13, 9, 0, 0, 0, time.UTC),
+			EndTime:   util.Pointer(time.Date(2024, time.March, 13, 11, 0, 0, 0, time.UTC)),
+			Status:    types.AbsenceCancelled,
+			Kind:      types.AbsenceHours,
+			CreatedAt: created,
+			UpdatedAt: util.Pointer(created.Add(2 * time.Hour)),
+			DecidedAt: util.Pointer(created.Add(time.Hour)),
+		},
+	}
+	companyHolidays := []db.CompanyHoliday{
+		{ID: 1, CompanyID: testCompanyID, Date: time.Date(2024, time.May, 31, 0, 0, 0, 0, time.UTC), Name: "Bridge day", DayOff: true},
+	}
+
+	testCases := []struct {
+		name          string
+		path          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "UserOK",
+			path: "/calendars/" + token + ".ics",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetActiveCalendarFeed(gomock.Any(), gomock.Eq(hashFeedToken(token))).
+					Times(1).
+					Return(db.CalendarFeed{ID: 1, UserID: &user.ID}, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					ListUserAbsences(gomock.Any(), gomock.Eq(db.ListUserAbsencesParams{UserID: user.ID, Limit: feedPageSize})).
+					Times(1).
+					Return(absences, nil)
+				store.EXPECT().
+					GetAbsenceType(gomock.Any(), gomock.Eq(absenceType.ID)).
+					Times(1).
+					Return(absenceType, nil)
+				store.EXPECT().
+					ListCompanyHolidays(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(companyHolidays, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "text/calendar; charset=utf-8", recorder.Header().Get("Content-Type"))
+
+				data := recorder.Body.String()
+				require.Equal(t, 3, strings.Count(data, "BEGIN:VEVENT"))
+				require.Contains(t, data, "UID:absence-1@tempus\r\n")
+				require.Contains(t, data, "DTSTART;VALUE=DATE:20240304\r\nDTEND;VALUE=DATE:20240306\r\nSUMMARY:Vacation\r\n")
+				require.Contains(t, data, "STATUS:CONFIRMED\r\nSEQUENCE:3600\r\n")
+				// pending absences are left out, absences cancelled after their approval are cancelled
+				require.NotContains(t, data, "UID:absence-2@tempus")
+				require.Contains(t, data, "UID:absence-3@tempus\r\n")
+				require.Contains(t, data, "STATUS:CANCELLED\r\nSEQUENCE:7200\r\n")
+				require.Contains(t, data, "SUMMARY:Bridge day\r\n")
+				// reasons are private
+				require.NotContains(t, data, "private")
+			},
+		},
+		{
+			name: "TeamOK",
+			path: "/calendars/" + token,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetActiveCalendarFeed(gomock.Any(), gomock.Eq(hashFeedToken(token))).
+					Times(1).
+					Return(db.CalendarFeed{ID: 2, TeamID: &team.ID}, nil)
+				store.EXPECT().
+					GetTeam(gomock.Any(), gomock.Eq(team.ID)).
+					Times(1).
+					Return(team, nil)
+				store.EXPECT().
+					ListTeamMembers(gomock.Any(), gomock.Eq(db.ListTeamMembersParams{TeamID: &team.ID, Limit: feedPageSize})).
+					Times(1).
+					Return([]db.User{user, member}, nil)
+				store.EXPECT().
+					ListUserAbsences(gomock.Any(), gomock.Eq(db.ListUserAbsencesParams{UserID: user.ID, Limit: feedPageSize})).
+					Times(1).
+					Return(absences, nil)
+				store.EXPECT().
+					ListUserAbsences(gomock.Any(), gomock.Eq(db.ListUserAbsencesParams{UserID: member.ID, Limit: feedPageSize})).
+					Times(1).
+					Return([]db.Absence{}, nil)
+				store.EXPECT().
+					GetAbsenceType(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					ListCompanyHolidays(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(companyHolidays, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data := recorder.Body.String()
+				// the holiday of both members is listed once
+				require.Equal(t, 3, strings.Count(data, "BEGIN:VEVENT"))
+				require.Contains(t, data, fmt.Sprintf("SUMMARY:%s %s\r\n", user.Name, user.Surname))
+				require.NotContains(t, data, "Vacation")
+			},
+		},
+		{
+			name: "Revoked",
+			path: "/calendars/" + token + ".ics",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetActiveCalendarFeed(gomock.Any(), gomock.Eq(hashFeedToken(token))).
+					Times(1).
+					Return(db.CalendarFeed{}, pgx.ErrNoRows)
+				store.EXPECT().
+					ListUserAbsences(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestAbsenceEvent(t *testing.T) {
+	zagreb, err := time.LoadLocation("Europe/Zagreb")
+	require.NoError(t, err)
+	now := time.Date(2024, time.March, 20, 15, 0, 0, 0, zagreb)
+	created := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	// a full day absence ending within its last day covers that day
+	event := absenceEvent(db.Absence{
+		ID:        7,
+		StartTime: time.Date(2024, time.March, 4, 0, 0, 0, 0, zagreb),
+		EndTime:   util.Pointer(time.Date(2024, time.March, 5, 23, 59, 0, 0, zagreb)),
+		Status:    types.AbsenceApproved,
+		Kind:      types.AbsenceFullDay,
+		CreatedAt: created,
+	}, "Vacation", zagreb, now)
+	require.Equal(t, "absence-7@tempus", event.UID)
+	require.True(t, event.AllDay)
+	require.Equal(t, time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC), event.Start)
+	require.Equal(t, time.Date(2024, time.March, 6, 0, 0, 0, 0, time.UTC), event.End)
+	require.Equal(t, int64(0), event.Sequence)
+	require.Equal(t, created, event.Modified)
+
+	// absences which have not ended last until the end of the current day
+	event = absenceEvent(db.Absence{
+		ID:        8,
+		StartTime: time.Date(2024, time.March, 20, 9, 0, 0, 0, zagreb),
+		Status:    types.AbsenceApproved,
+		Kind:      types.AbsenceHours,
+		CreatedAt: created,
+	}, "Sick leave", zagreb, now)
+	require.False(t, event.AllDay)
+	require.Equal(t, ical.StatusConfirmed, event.Status)
+	require.True(t, event.End.Equal(time.Date(2024, time.March, 21, 0, 0, 0, 0, zagreb)))
+}
+
+func TestHolidayEvent(t *testing.T) {
+	date := time.Date(2024, time.May, 30, 0, 0, 0, 0, time.UTC)
+	event := holidayEvent(holiday.Holiday{Date: date, Name: "Statehood Day"})
+	require.Equal(t, event, holidayEvent(holiday.Holiday{Date: date, Name: "Statehood Day"}))
+	require.NotEqual(t, event.UID, holidayEvent(holiday.Holiday{Date: date, Name: "Bridge day"}).UID)
+	require.True(t, strings.HasPrefix(event.UID, "holiday-20240530-"))
+	require.True(t, event.AllDay)
+	require.True(t, event.Transparent)
+	require.Equal(t, date.AddDate(0, 0, 1), event.End)
+}
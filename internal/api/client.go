@@ -0,0 +1,183 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/jackc/pgx/v5"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+)
+
+type createClientRequest struct {
+	Name      string `json:"name" binding:"required,min=1,max=255"`
+	CompanyID int64  `json:"company_id" binding:"required,min=1"`
+}
+
+func (server *Server) createClient(ctx *gin.Context) {
+	var req createClientRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateClientParams{
+		CompanyID: req.CompanyID,
+		Name:      req.Name,
+	}
+	client, err := server.store.CreateClient(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, client)
+}
+
+func (server *Server) getClient(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	client, err := server.store.GetClient(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, client)
+}
+
+type updateClientRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=255"`
+}
+
+func (server *Server) updateClient(ctx *gin.Context) {
+	var reqID RequestWithID
+	var req updateClientRequest
+	if err := ctx.ShouldBindUri(&reqID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateClientParams{
+		ID:   reqID.ID,
+		Name: req.Name,
+	}
+	client, err := server.store.UpdateClient(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, client)
+}
+
+func (server *Server) deleteClient(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	client, err := server.store.DeleteClient(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if db.ErrorCode(err) == db.ForeignKeyViolation {
+			ctx.JSON(http.StatusConflict, errorResponse(errClientHasProjects))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, client)
+}
+
+func (server *Server) listClients(ctx *gin.Context) {
+	var req PaginationRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	companyID, err := server.tenantScope(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	arg := db.ListClientsParams{
+		CompanyID: companyID,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+	}
+	clients, err := server.store.ListClients(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, clients)
+}
+
+// validCompanyClient checks that the client exists and belongs to the company.
+// It writes the error response and returns false otherwise.
+func (server *Server) validCompanyClient(ctx *gin.Context, clientID int64, companyID int64) bool {
+	client, err := server.store.GetClient(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if client.CompanyID != companyID {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errClientOutsideCompany))
+		return false
+	}
+	return true
+}
+
+// clientCompanyFromURI resolves the company a request acts upon to the company of the client
+// identified by the `:id` URI parameter.
+func (server *Server) clientCompanyFromURI(ctx *gin.Context) (*int64, error) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		return nil, &requestError{err}
+	}
+	client, err := server.store.GetClient(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &client.CompanyID, nil
+}
+
+// clientCompanyFromBody resolves the company a request acts upon to the company of the client in the request body.
+func clientCompanyFromBody(ctx *gin.Context) (*int64, error) {
+	var req createClientRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		return nil, &requestError{err}
+	}
+	return &req.CompanyID, nil
+}
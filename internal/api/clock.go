@@ -0,0 +1,99 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+)
+
+type clockResponse struct {
+	Running bool      `json:"running"`
+	Entry   *db.Entry `json:"entry"`
+}
+
+// getClock returns the running entry of the authenticated user, if any.
+func (server *Server) getClock(ctx *gin.Context) {
+	user, err := server.authUser(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	entry, err := server.store.GetRunningEntry(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusOK, clockResponse{Running: false})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, clockResponse{Running: true, Entry: &entry})
+}
+
+// clockIn starts a new entry for the authenticated user at the current server time.
+func (server *Server) clockIn(ctx *gin.Context) {
+	user, err := server.authUser(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	arg := db.CreateEntryParams{
+		UserID:    user.ID,
+		StartTime: time.Now().UTC(),
+	}
+	entry, err := server.store.CreateEntryTx(ctx, arg)
+	if err != nil {
+		if server.entryViolationResponse(ctx, err, db.GetOverlappingEntryParams{
+			UserID:    arg.UserID,
+			StartTime: arg.StartTime,
+		}) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, entry)
+}
+
+// clockOut stops the running entry of the authenticated user at the current server time.
+func (server *Server) clockOut(ctx *gin.Context) {
+	user, err := server.authUser(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	endTime := time.Now().UTC()
+	arg := db.StopRunningEntryParams{
+		UserID:  user.ID,
+		EndTime: &endTime,
+	}
+	entry, err := server.store.StopRunningEntryTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusConflict, errorResponse(errTimerNotRunning))
+			return
+		}
+		if lockErr := entryLockError(err); lockErr != nil {
+			ctx.JSON(http.StatusConflict, errorResponse(lockErr))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entry)
+}
+
+// isRunningEntryViolation reports whether err was caused by a second running entry of the same user.
+func isRunningEntryViolation(err error) bool {
+	return db.ErrorCode(err) == db.UniqueViolation && db.ConstraintName(err) == db.RunningEntryConstraint
+}
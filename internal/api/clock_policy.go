@@ -0,0 +1,119 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+)
+
+// clockTimeLayout is the layout of the times of day of a clock policy, in the time zone of each user
+const clockTimeLayout = "15:04"
+
+type clockPolicyRequest struct {
+	// AutoCloseAfterMinutes stops running timers once they ran for this long, at most a week
+	AutoCloseAfterMinutes *int32 `json:"auto_close_after_minutes" binding:"omitempty,min=1,max=10080"`
+	// AutoCloseAt stops running timers at this time of day
+	AutoCloseAt *string `json:"auto_close_at" binding:"omitempty,datetime=15:04"`
+	// ReminderAt reminds users without entries on a working day at this time of day
+	ReminderAt *string `json:"reminder_at" binding:"omitempty,datetime=15:04"`
+}
+
+// clockPolicyResponse describes the clock policy of a company, unset fields are disabled
+type clockPolicyResponse struct {
+	CompanyID             int64      `json:"company_id"`
+	AutoCloseAfterMinutes *int32     `json:"auto_close_after_minutes"`
+	AutoCloseAt           *string    `json:"auto_close_at"`
+	ReminderAt            *string    `json:"reminder_at"`
+	CreatedAt             *time.Time `json:"created_at"`
+	UpdatedAt             *time.Time `json:"updated_at"`
+}
+
+func newClockPolicyResponse(policy db.ClockPolicy) clockPolicyResponse {
+	response := clockPolicyResponse{
+		CompanyID:             policy.CompanyID,
+		AutoCloseAfterMinutes: policy.AutoCloseAfterMinutes,
+		AutoCloseAt:           formatMinuteOfDay(policy.AutoCloseAtMinute),
+		ReminderAt:            formatMinuteOfDay(policy.ReminderAtMinute),
+		UpdatedAt:             policy.UpdatedAt,
+	}
+	if !policy.CreatedAt.IsZero() {
+		response.CreatedAt = &policy.CreatedAt
+	}
+	return response
+}
+
+// formatMinuteOfDay returns a minute of the day as a time of day, or nil if minute is nil
+func formatMinuteOfDay(minute *int32) *string {
+	if minute == nil {
+		return nil
+	}
+	s := fmt.Sprintf("%02d:%02d", *minute/60, *minute%60)
+	return &s
+}
+
+// parseMinuteOfDay returns the minute of the day of a validated time of day, or nil if s is nil
+func parseMinuteOfDay(s *string) *int32 {
+	if s == nil {
+		return nil
+	}
+	t, _ := time.Parse(clockTimeLayout, *s)
+	minute := int32(t.Hour()*60 + t.Minute())
+	return &minute
+}
+
+// getClockPolicy returns the clock policy of a company, with every field unset if the company has none
+func (server *Server) getClockPolicy(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	policy, err := server.store.GetClockPolicy(ctx, req.ID)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		policy = db.ClockPolicy{CompanyID: req.ID}
+	}
+
+	ctx.JSON(http.StatusOK, newClockPolicyResponse(policy))
+}
+
+// updateClockPolicy replaces the clock policy of a company. Timers are stopped at the earliest time of the policy,
+// which is the end time of their entries.
+func (server *Server) updateClockPolicy(ctx *gin.Context) {
+	var reqID RequestWithID
+	var req clockPolicyRequest
+	if err := ctx.ShouldBindUri(&reqID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	policy, err := server.store.UpsertClockPolicy(ctx, db.UpsertClockPolicyParams{
+		CompanyID:             reqID.ID,
+		AutoCloseAfterMinutes: req.AutoCloseAfterMinutes,
+		AutoCloseAtMinute:     parseMinuteOfDay(req.AutoCloseAt),
+		ReminderAtMinute:      parseMinuteOfDay(req.ReminderAt),
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.ForeignKeyViolation {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newClockPolicyResponse(policy))
+}
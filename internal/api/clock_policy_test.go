@@ -0,0 +1,171 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestGetClockPolicyAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+		Times(1).
+		Return(admin, nil)
+	store.EXPECT().
+		GetClockPolicy(gomock.Any(), gomock.Eq(testCompanyID)).
+		Times(1).
+		Return(db.ClockPolicy{}, pgx.ErrNoRows)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/companies/%d/clock-policy", testCompanyID)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	// companies without a policy have every rule disabled
+	var got clockPolicyResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Equal(t, testCompanyID, got.CompanyID)
+	require.Nil(t, got.AutoCloseAfterMinutes)
+	require.Nil(t, got.AutoCloseAt)
+	require.Nil(t, got.ReminderAt)
+}
+
+func TestUpdateClockPolicyAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	manager := randomUserWithRole(types.ManagerRole)
+
+	testCases := []struct {
+		name          string
+		actor         db.User
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			actor: admin,
+			body:  gin.H{"auto_close_after_minutes": 720, "auto_close_at": "22:30", "reminder_at": "17:00"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpsertClockPolicyParams{
+					CompanyID:             testCompanyID,
+					AutoCloseAfterMinutes: util.Pointer(int32(720)),
+					AutoCloseAtMinute:     util.Pointer(int32(22*60 + 30)),
+					ReminderAtMinute:      util.Pointer(int32(17 * 60)),
+				}
+				store.EXPECT().
+					UpsertClockPolicy(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ClockPolicy{
+						CompanyID:             arg.CompanyID,
+						AutoCloseAfterMinutes: arg.AutoCloseAfterMinutes,
+						AutoCloseAtMinute:     arg.AutoCloseAtMinute,
+						ReminderAtMinute:      arg.ReminderAtMinute,
+						CreatedAt:             time.Now().UTC(),
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got clockPolicyResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, "22:30", *got.AutoCloseAt)
+				require.Equal(t, "17:00", *got.ReminderAt)
+			},
+		},
+		{
+			name:  "InvalidTime",
+			actor: admin,
+			body:  gin.H{"auto_close_at": "25:00"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertClockPolicy(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidDuration",
+			actor: admin,
+			body:  gin.H{"auto_close_after_minutes": 0},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertClockPolicy(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Forbidden",
+			actor: manager,
+			body:  gin.H{"reminder_at": "17:00"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertClockPolicy(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByUsername(gomock.Any(), gomock.Eq(tc.actor.Username)).
+				Times(1).
+				Return(tc.actor, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/companies/%d/clock-policy", testCompanyID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(jsonData))
+			require.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, tc.actor, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestGetClockAPI(t *testing.T) {
+	user := randomUser()
+	entry := randomEntry(user.ID)
+	entry.EndTime = nil
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Running",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRunningEntry(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(entry, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+				var got clockResponse
+				require.NoError(t, json.Unmarshal(data, &got))
+				require.True(t, got.Running)
+				require.Equal(t, entry, *got.Entry)
+			},
+		},
+		{
+			name: "NotRunning",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRunningEntry(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.Entry{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+				var got clockResponse
+				require.NoError(t, json.Unmarshal(data, &got))
+				require.False(t, got.Running)
+				require.Nil(t, got.Entry)
+			},
+		},
+		{
+			name: "InternalServerError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRunningEntry(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.Entry{}, pgx.ErrTxClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/me/clock", nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestClockInAPI(t *testing.T) {
+	user := randomUser()
+	entry := randomEntry(user.ID)
+	entry.EndTime = nil
+
+	startsNow := gomock.Cond(func(x any) bool {
+		arg, ok := x.(db.CreateEntryParams)
+		return ok &&
+			arg.UserID == user.ID &&
+			arg.EndTime == nil &&
+			time.Since(arg.StartTime) < time.Minute
+	})
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateEntryTx(gomock.Any(), startsNow).
+					Times(1).
+					Return(entry, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				requireBodyMatchEntry(t, recorder.Body, entry)
+			},
+		},
+		{
+			name: "AlreadyRunning",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateEntryTx(gomock.Any(), startsNow).
+					Times(1).
+					Return(db.Entry{}, &pgconn.PgError{
+						Code:           db.UniqueViolation,
+						ConstraintName: db.RunningEntryConstraint,
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errTimerRunning.Error())
+			},
+		},
+		{
+			name: "Overlap",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateEntryTx(gomock.Any(), startsNow).
+					Times(1).
+					Return(db.Entry{}, overlapViolation())
+				store.EXPECT().
+					GetOverlappingEntry(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(entry, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				requireBodyMatchEntryConflict(t, recorder.Body, entry)
+			},
+		},
+		{
+			name: "InternalServerError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateEntryTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Entry{}, pgx.ErrTxClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, "/me/clock-in", nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestClockOutAPI(t *testing.T) {
+	user := randomUser()
+	entry := randomEntry(user.ID)
+
+	endsNow := gomock.Cond(func(x any) bool {
+		arg, ok := x.(db.StopRunningEntryParams)
+		return ok &&
+			arg.UserID == user.ID &&
+			arg.EndTime != nil &&
+			time.Since(*arg.EndTime) < time.Minute
+	})
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StopRunningEntryTx(gomock.Any(), endsNow).
+					Times(1).
+					Return(entry, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchEntry(t, recorder.Body, entry)
+			},
+		},
+		{
+			name: "NotRunning",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StopRunningEntryTx(gomock.Any(), endsNow).
+					Times(1).
+					Return(db.Entry{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errTimerNotRunning.Error())
+			},
+		},
+		{
+			name: "InternalServerError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StopRunningEntryTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Entry{}, pgx.ErrTxClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, "/me/clock-out", nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -146,5 +146,14 @@ func (server *Server) listCompanyEmployees(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, employees)
+	ctx.JSON(http.StatusOK, newUserListResponse(employees))
+}
+
+// companyFromURI resolves the company a request acts upon to the one identified by the `:id` URI parameter.
+func companyFromURI(ctx *gin.Context) (*int64, error) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		return nil, &requestError{err}
+	}
+	return &req.ID, nil
 }
@@ -14,6 +14,7 @@ import (
 	mockdb "github.com/mateoradman/tempus/internal/db/mock"
 	db "github.com/mateoradman/tempus/internal/db/sqlc"
 	"github.com/mateoradman/tempus/internal/token"
+	"github.com/mateoradman/tempus/internal/types"
 	"github.com/mateoradman/tempus/internal/util"
 	"github.com/stretchr/testify/require"
 	gomock "go.uber.org/mock/gomock"
@@ -48,7 +49,8 @@ func randomCompany() db.Company {
 }
 
 func TestCreateCompanyAPI(t *testing.T) {
-	user := randomUser()
+	user := randomSuperuser()
+	admin := randomUserWithRole(types.AdminRole)
 	company := randomCompany()
 
 	testCases := []struct {
@@ -62,6 +64,10 @@ func TestCreateCompanyAPI(t *testing.T) {
 			name:        "OK",
 			companyName: company.Name,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					CreateCompany(gomock.Any(), gomock.Eq(company.Name)).
 					Times(1).
@@ -79,6 +85,10 @@ func TestCreateCompanyAPI(t *testing.T) {
 			name:        "InternalServerError",
 			companyName: company.Name,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					CreateCompany(gomock.Any(), gomock.Eq(company.Name)).
 					Times(1).
@@ -95,6 +105,10 @@ func TestCreateCompanyAPI(t *testing.T) {
 			name:        "BadRequest",
 			companyName: "",
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					CreateCompany(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -106,6 +120,25 @@ func TestCreateCompanyAPI(t *testing.T) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
+		{
+			name:        "Forbidden",
+			companyName: company.Name,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					CreateCompany(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
 		{
 			name:        "Unauthorized",
 			companyName: company.Name,
@@ -150,7 +183,10 @@ func TestCreateCompanyAPI(t *testing.T) {
 
 func TestGetCompanyAPI(t *testing.T) {
 	user := randomUser()
+	outsider := randomUser()
+	outsider.CompanyID = util.Pointer(testCompanyID + 1)
 	company := randomCompany()
+	company.ID = testCompanyID
 
 	testCases := []struct {
 		name          string
@@ -163,6 +199,10 @@ func TestGetCompanyAPI(t *testing.T) {
 			name:      "OK",
 			companyID: company.ID,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					GetCompany(gomock.Any(), gomock.Eq(company.ID)).
 					Times(1).
@@ -180,6 +220,10 @@ func TestGetCompanyAPI(t *testing.T) {
 			name:      "NotFound",
 			companyID: company.ID,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					GetCompany(gomock.Any(), gomock.Eq(company.ID)).
 					Times(1).
@@ -196,6 +240,10 @@ func TestGetCompanyAPI(t *testing.T) {
 			name:      "InternalServerError",
 			companyID: company.ID,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					GetCompany(gomock.Any(), gomock.Eq(company.ID)).
 					Times(1).
@@ -208,10 +256,33 @@ func TestGetCompanyAPI(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name:      "OtherCompanyNotFound",
+			companyID: company.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(outsider.Username)).
+					Times(1).
+					Return(outsider, nil)
+				store.EXPECT().
+					GetCompany(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, outsider, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
 		{
 			name:      "InvalidID",
 			companyID: 0,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					GetCompany(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -263,7 +334,8 @@ func TestGetCompanyAPI(t *testing.T) {
 }
 
 func TestDeleteCompanyAPI(t *testing.T) {
-	user := randomUser()
+	user := randomSuperuser()
+	admin := randomUserWithRole(types.AdminRole)
 	company := randomCompany()
 
 	testCases := []struct {
@@ -277,6 +349,10 @@ func TestDeleteCompanyAPI(t *testing.T) {
 			name:      "OK",
 			companyID: company.ID,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					DeleteCompany(gomock.Any(), gomock.Eq(company.ID)).
 					Times(1).
@@ -294,6 +370,10 @@ func TestDeleteCompanyAPI(t *testing.T) {
 			name:      "NotFound",
 			companyID: company.ID,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					DeleteCompany(gomock.Any(), gomock.Eq(company.ID)).
 					Times(1).
@@ -310,6 +390,10 @@ func TestDeleteCompanyAPI(t *testing.T) {
 			name:      "InternalServerError",
 			companyID: company.ID,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					DeleteCompany(gomock.Any(), gomock.Eq(company.ID)).
 					Times(1).
@@ -326,6 +410,10 @@ func TestDeleteCompanyAPI(t *testing.T) {
 			name:      "InvalidID",
 			companyID: 0,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					DeleteCompany(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -337,6 +425,25 @@ func TestDeleteCompanyAPI(t *testing.T) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
+		{
+			name:      "Forbidden",
+			companyID: company.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					DeleteCompany(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
 		{
 			name:      "Unauthorized",
 			companyID: company.ID,
@@ -377,8 +484,10 @@ func TestDeleteCompanyAPI(t *testing.T) {
 }
 
 func TestUpdateCompanyAPI(t *testing.T) {
-	user := randomUser()
+	user := randomUserWithRole(types.AdminRole)
+	employee := randomUser()
 	company := randomCompany()
+	company.ID = testCompanyID
 	arg := db.UpdateCompanyParams{
 		Name: company.Name,
 		ID:   company.ID,
@@ -397,6 +506,10 @@ func TestUpdateCompanyAPI(t *testing.T) {
 			companyID:   arg.ID,
 			companyName: arg.Name,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					UpdateCompany(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
@@ -415,6 +528,10 @@ func TestUpdateCompanyAPI(t *testing.T) {
 			companyID:   arg.ID,
 			companyName: arg.Name,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					UpdateCompany(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
@@ -432,6 +549,10 @@ func TestUpdateCompanyAPI(t *testing.T) {
 			companyID:   arg.ID,
 			companyName: arg.Name,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					UpdateCompany(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
@@ -449,6 +570,10 @@ func TestUpdateCompanyAPI(t *testing.T) {
 			companyID:   0,
 			companyName: arg.Name,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					UpdateCompany(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -465,6 +590,10 @@ func TestUpdateCompanyAPI(t *testing.T) {
 			companyID:   arg.ID,
 			companyName: "",
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					UpdateCompany(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -476,6 +605,26 @@ func TestUpdateCompanyAPI(t *testing.T) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
+		{
+			name:        "Forbidden",
+			companyID:   arg.ID,
+			companyName: arg.Name,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(employee.Username)).
+					Times(1).
+					Return(employee, nil)
+				store.EXPECT().
+					UpdateCompany(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, employee, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
 		{
 			name:        "Unauthorized",
 			companyID:   arg.ID,
@@ -520,7 +669,8 @@ func TestUpdateCompanyAPI(t *testing.T) {
 }
 
 func TestListCompaniesAPI(t *testing.T) {
-	user := randomUser()
+	user := randomSuperuser()
+	admin := randomUserWithRole(types.AdminRole)
 	company := randomCompany()
 	arg := db.ListCompaniesParams{
 		Offset: int32(util.RandomInt(0, 100000)),
@@ -541,6 +691,10 @@ func TestListCompaniesAPI(t *testing.T) {
 			offset: arg.Offset,
 			limit:  arg.Limit,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					ListCompanies(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
@@ -559,6 +713,10 @@ func TestListCompaniesAPI(t *testing.T) {
 			offset: arg.Offset,
 			limit:  arg.Limit,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					ListCompanies(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
@@ -576,6 +734,10 @@ func TestListCompaniesAPI(t *testing.T) {
 			limit:  arg.Limit,
 			offset: -1,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					ListCompanies(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -592,6 +754,10 @@ func TestListCompaniesAPI(t *testing.T) {
 			offset: arg.Offset,
 			limit:  1000,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					ListCompanies(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -603,6 +769,26 @@ func TestListCompaniesAPI(t *testing.T) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
+		{
+			name:   "Forbidden",
+			offset: arg.Offset,
+			limit:  arg.Limit,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					ListCompanies(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
 		{
 			name:   "Unauthorized",
 			offset: arg.Offset,
@@ -651,12 +837,15 @@ func TestListCompaniesAPI(t *testing.T) {
 
 func TestListCompanyEmployeesAPI(t *testing.T) {
 	company := randomCompany()
+	company.ID = testCompanyID
 	arg := db.ListCompanyEmployeesParams{
 		ID:     company.ID,
 		Offset: int32(util.RandomInt(0, 100000)),
 		Limit:  int32(util.RandomInt(1, 100)),
 	}
-	user := randomUser()
+	user := randomUserWithRole(types.AdminRole)
+	manager := randomUserWithRole(types.ManagerRole)
+	employee := randomUser()
 	returnVal := []db.User{user}
 
 	testCases := []struct {
@@ -674,6 +863,10 @@ func TestListCompanyEmployeesAPI(t *testing.T) {
 			offset:    arg.Offset,
 			limit:     arg.Limit,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					ListCompanyEmployees(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
@@ -687,12 +880,39 @@ func TestListCompanyEmployeesAPI(t *testing.T) {
 				requireBodyMatchUserList(t, recorder.Body, returnVal)
 			},
 		},
+		{
+			name:      "ManagerOK",
+			companyID: company.ID,
+			offset:    arg.Offset,
+			limit:     arg.Limit,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					ListCompanyEmployees(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(returnVal, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchUserList(t, recorder.Body, returnVal)
+			},
+		},
 		{
 			name:      "InternalServerError",
 			companyID: company.ID,
 			offset:    arg.Offset,
 			limit:     arg.Limit,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					ListCompanyEmployees(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
@@ -711,6 +931,10 @@ func TestListCompanyEmployeesAPI(t *testing.T) {
 			limit:     arg.Limit,
 			offset:    -1,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					ListCompanyEmployees(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -728,6 +952,10 @@ func TestListCompanyEmployeesAPI(t *testing.T) {
 			offset:    arg.Offset,
 			limit:     1000,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					ListCompanyEmployees(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -745,6 +973,10 @@ func TestListCompanyEmployeesAPI(t *testing.T) {
 			offset:    arg.Offset,
 			limit:     arg.Limit,
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
 				store.EXPECT().
 					ListCompanyEmployees(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -756,6 +988,27 @@ func TestListCompanyEmployeesAPI(t *testing.T) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
+		{
+			name:      "Forbidden",
+			companyID: arg.ID,
+			offset:    arg.Offset,
+			limit:     arg.Limit,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(employee.Username)).
+					Times(1).
+					Return(employee, nil)
+				store.EXPECT().
+					ListCompanyEmployees(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, employee, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
 		{
 			name:      "Unauthorized",
 			companyID: arg.ID,
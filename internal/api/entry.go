@@ -2,36 +2,64 @@ package api
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/jackc/pgx/v5"
 	db "github.com/mateoradman/tempus/internal/db/sqlc"
 )
 
 type EntryRequest struct {
-	UserID    int64      `json:"user_id" binding:"required,min=1"`
-	StartTime time.Time  `json:"start_time" binding:"required,timestamp"`
-	EndTime   *time.Time `json:"end_time" binding:"omitempty,timestamp"`
-	Date      time.Time  `json:"date" binding:"required,timestamp"`
+	UserID      int64      `json:"user_id" binding:"required,min=1"`
+	StartTime   time.Time  `json:"start_time" binding:"required"`
+	EndTime     *time.Time `json:"end_time" binding:"omitempty,gtfield=StartTime"`
+	ProjectID   *int64     `json:"project_id" binding:"omitempty,min=1"`
+	TaskID      *int64     `json:"task_id" binding:"omitempty,min=1"`
+	Description *string    `json:"description"`
+	Tags        []string   `json:"tags" binding:"omitempty,max=20,dive,min=1,max=64"`
+	Billable    bool       `json:"billable"`
+}
+
+// entryConflictResponse names the existing entry an entry would overlap with.
+type entryConflictResponse struct {
+	Error            string   `json:"error"`
+	ConflictingEntry db.Entry `json:"conflicting_entry"`
 }
 
 func (server *Server) createEntry(ctx *gin.Context) {
 	var req EntryRequest
-	err := ctx.ShouldBindJSON(&req)
+	err := ctx.ShouldBindBodyWith(&req, binding.JSON)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
+	if !server.validEntryProject(ctx, req, nil) {
+		return
+	}
+
 	arg := db.CreateEntryParams{
-		UserID:    req.UserID,
-		StartTime: req.StartTime,
-		EndTime:   req.EndTime,
+		UserID:      req.UserID,
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+		ProjectID:   req.ProjectID,
+		TaskID:      req.TaskID,
+		Description: req.Description,
+		Tags:        req.Tags,
+		Billable:    req.Billable,
 	}
-	entry, err := server.store.CreateEntry(ctx, arg)
+	entry, err := server.store.CreateEntryTx(ctx, arg)
 	if err != nil {
+		if server.entryViolationResponse(ctx, err, db.GetOverlappingEntryParams{
+			UserID:    arg.UserID,
+			StartTime: arg.StartTime,
+			EndTime:   arg.EndTime,
+		}) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -68,12 +96,16 @@ func (server *Server) deleteEntry(ctx *gin.Context) {
 		return
 	}
 
-	entry, err := server.store.DeleteEntry(ctx, req.ID)
+	entry, err := server.store.DeleteEntryTx(ctx, req.ID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
+		if lockErr := entryLockError(err); lockErr != nil {
+			ctx.JSON(http.StatusConflict, errorResponse(lockErr))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -88,23 +120,53 @@ func (server *Server) updateEntry(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
-	if err := ctx.ShouldBindJSON(&req); err != nil {
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
+	var currentProjectID *int64
+	if req.ProjectID != nil {
+		current, err := server.store.GetEntry(ctx, reqID.ID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		currentProjectID = current.ProjectID
+	}
+	if !server.validEntryProject(ctx, req, currentProjectID) {
+		return
+	}
+
 	arg := db.UpdateEntryParams{
-		ID:        reqID.ID,
-		UserID:    req.UserID,
-		StartTime: req.StartTime,
-		EndTime:   req.EndTime,
+		ID:          reqID.ID,
+		UserID:      req.UserID,
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+		ProjectID:   req.ProjectID,
+		TaskID:      req.TaskID,
+		Description: req.Description,
+		Tags:        req.Tags,
+		Billable:    req.Billable,
 	}
-	entry, err := server.store.UpdateEntry(ctx, arg)
+	entry, err := server.store.UpdateEntryTx(ctx, arg)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
+		if server.entryViolationResponse(ctx, err, db.GetOverlappingEntryParams{
+			UserID:    arg.UserID,
+			ExcludeID: arg.ID,
+			StartTime: arg.StartTime,
+			EndTime:   arg.EndTime,
+		}) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -112,23 +174,229 @@ func (server *Server) updateEntry(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, entry)
 }
 
+// entryFilterRequest restricts an entry listing to a project, a task or a tag.
+type entryFilterRequest struct {
+	ProjectID *int64  `form:"project_id" binding:"omitempty,min=1"`
+	TaskID    *int64  `form:"task_id" binding:"omitempty,min=1"`
+	Tag       *string `form:"tag" binding:"omitempty,min=1,max=64"`
+	// NeedsReview filters entries whose timer was stopped by the clock policy and which were not updated since
+	NeedsReview *bool `form:"needs_review"`
+}
+
+type listEntriesRequest struct {
+	PaginationRequest
+	TimeRangeRequest
+	entryFilterRequest
+	UserID    *int64 `form:"user_id" binding:"omitempty,min=1"`
+	TeamID    *int64 `form:"team_id" binding:"omitempty,min=1"`
+	CompanyID *int64 `form:"company_id" binding:"omitempty,min=1"`
+}
+
 func (server *Server) listEntries(ctx *gin.Context) {
-	var req PaginationRequest
+	var req listEntriesRequest
 	err := ctx.ShouldBindQuery(&req)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+	if err := req.TimeRangeRequest.validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	companyID, err := server.tenantScope(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+	if companyID != nil {
+		// everybody but the superuser can only filter by their own company
+		if req.CompanyID != nil && *req.CompanyID != *companyID {
+			ctx.JSON(http.StatusForbidden, errorResponse(errForbidden))
+			return
+		}
+		req.CompanyID = companyID
+	}
 
 	arg := db.ListEntriesParams{
-		Limit:  req.Limit,
-		Offset: req.Offset,
+		UserID:      req.UserID,
+		TeamID:      req.TeamID,
+		CompanyID:   req.CompanyID,
+		From:        req.From,
+		To:          req.To,
+		ProjectID:   req.ProjectID,
+		TaskID:      req.TaskID,
+		Tag:         req.Tag,
+		NeedsReview: req.NeedsReview,
+		Limit:       req.Limit,
+		Offset:      req.Offset,
 	}
-	companies, err := server.store.ListEntries(ctx, arg)
+	entries, err := server.store.ListEntries(ctx, arg)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, companies)
+	ctx.JSON(http.StatusOK, entries)
+}
+
+type listUserEntriesRequest struct {
+	PaginationRequest
+	TimeRangeRequest
+	entryFilterRequest
+}
+
+func (server *Server) listUserEntries(ctx *gin.Context) {
+	var idReq RequestWithID
+	if err := ctx.ShouldBindUri(&idReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	server.listEntriesOfUser(ctx, idReq.ID)
+}
+
+// listEntriesOfUser writes the entries of the user with the given ID, filtered by the query parameters.
+func (server *Server) listEntriesOfUser(ctx *gin.Context, userID int64) {
+	var queryReq listUserEntriesRequest
+	if err := ctx.ShouldBindQuery(&queryReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := queryReq.TimeRangeRequest.validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListUserEntriesParams{
+		UserID:      userID,
+		From:        queryReq.From,
+		To:          queryReq.To,
+		ProjectID:   queryReq.ProjectID,
+		TaskID:      queryReq.TaskID,
+		Tag:         queryReq.Tag,
+		NeedsReview: queryReq.NeedsReview,
+		Limit:       queryReq.Limit,
+		Offset:      queryReq.Offset,
+	}
+	entries, err := server.store.ListUserEntries(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entries)
+}
+
+// entryViolationResponse writes the response for an entry write rejected by one of the entries constraints
+// and reports whether err was such a violation. An overlap is reported together with the conflicting entry,
+// which is looked up with arg.
+func (server *Server) entryViolationResponse(ctx *gin.Context, err error, arg db.GetOverlappingEntryParams) bool {
+	switch {
+	case isRunningEntryViolation(err):
+		ctx.JSON(http.StatusConflict, errorResponse(errTimerRunning))
+	case db.ErrorCode(err) == db.ExclusionViolation && db.ConstraintName(err) == db.EntryOverlapConstraint:
+		conflicting, lookupErr := server.store.GetOverlappingEntry(ctx, arg)
+		if lookupErr != nil {
+			// the conflicting entry may have been changed since the write failed
+			ctx.JSON(http.StatusConflict, errorResponse(errEntryOverlap))
+			return true
+		}
+		ctx.JSON(http.StatusConflict, entryConflictResponse{
+			Error:            fmt.Sprintf("%s: entry %d", errEntryOverlap, conflicting.ID),
+			ConflictingEntry: conflicting,
+		})
+	case db.ErrorCode(err) == db.CheckViolation && db.ConstraintName(err) == db.EntryTimeConstraint:
+		ctx.JSON(http.StatusBadRequest, errorResponse(errEntryTimes))
+	case entryLockError(err) != nil:
+		ctx.JSON(http.StatusConflict, errorResponse(entryLockError(err)))
+	default:
+		return false
+	}
+	return true
+}
+
+// entryLockError returns the error reported for an entry write rejected because the entry is invoiced
+// or in an approved timesheet, or nil if err was not caused by such a lock.
+func entryLockError(err error) error {
+	if db.ErrorCode(err) != db.CheckViolation {
+		return nil
+	}
+	switch db.ConstraintName(err) {
+	case db.EntryInvoicedConstraint:
+		return errEntryInvoiced
+	case db.EntryPeriodLockedConstraint:
+		return errEntryPeriodLocked
+	}
+	return nil
+}
+
+// validEntryProject checks that the project and task of an entry exist and belong to the company of the
+// user of the entry. Archived projects only keep the entries they already had, currentProjectID being the
+// project of the entry before the write. It writes the error response and returns false otherwise.
+func (server *Server) validEntryProject(ctx *gin.Context, req EntryRequest, currentProjectID *int64) bool {
+	if req.ProjectID == nil {
+		if req.TaskID != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errTaskWithoutProject))
+			return false
+		}
+		return true
+	}
+
+	project, err := server.store.GetProject(ctx, *req.ProjectID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	user, err := server.store.GetUser(ctx, req.UserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+	if !belongsToCompany(user, project.CompanyID) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errProjectOutsideCompany))
+		return false
+	}
+
+	if project.Archived && (currentProjectID == nil || *currentProjectID != project.ID) {
+		ctx.JSON(http.StatusConflict, errorResponse(errProjectArchived))
+		return false
+	}
+
+	if req.TaskID != nil {
+		return server.validProjectTask(ctx, projectTaskRequest{ID: project.ID, TaskID: *req.TaskID})
+	}
+	return true
+}
+
+// entryOwnerFromURI resolves the subject of a request to the owner of the entry identified by the `:id` URI parameter.
+func (server *Server) entryOwnerFromURI(ctx *gin.Context) (int64, error) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		return 0, &requestError{err}
+	}
+
+	entry, err := server.store.GetEntry(ctx, req.ID)
+	if err != nil {
+		return 0, err
+	}
+	return entry.UserID, nil
+}
+
+// entryUserFromBody resolves the subject of a request to the user the entry in the request body belongs to.
+func entryUserFromBody(ctx *gin.Context) (int64, error) {
+	var req EntryRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		return 0, &requestError{err}
+	}
+	return req.UserID, nil
 }
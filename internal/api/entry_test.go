@@ -1 +1,2052 @@
-package api
\ No newline at end of file
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/token"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func requireBodyMatchEntry(t *testing.T, body *bytes.Buffer, entry db.Entry) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotEntry db.Entry
+	err = json.Unmarshal(data, &gotEntry)
+	require.NoError(t, err)
+	require.Equal(t, entry, gotEntry)
+}
+
+func requireBodyMatchEntryList(t *testing.T, body *bytes.Buffer, entries []db.Entry) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotEntries []db.Entry
+	err = json.Unmarshal(data, &gotEntries)
+	require.NoError(t, err)
+	require.Equal(t, entries, gotEntries)
+}
+
+func requireBodyMatchEntryConflict(t *testing.T, body *bytes.Buffer, entry db.Entry) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var got entryConflictResponse
+	err = json.Unmarshal(data, &got)
+	require.NoError(t, err)
+	require.Contains(t, got.Error, errEntryOverlap.Error())
+	require.Equal(t, entry, got.ConflictingEntry)
+}
+
+func overlapViolation() error {
+	return &pgconn.PgError{
+		Code:           db.ExclusionViolation,
+		ConstraintName: db.EntryOverlapConstraint,
+	}
+}
+
+func randomEntry(userID int64) db.Entry {
+	startTime := time.Now().UTC().Truncate(time.Second)
+	endTime := startTime.Add(time.Hour)
+	return db.Entry{
+		ID:        util.RandomInt(1, 1000),
+		UserID:    userID,
+		StartTime: startTime,
+		EndTime:   &endTime,
+		CreatedAt: startTime,
+	}
+}
+
+func TestCreateEntryAPI(t *testing.T) {
+	user := randomUser()
+	admin := randomUserWithRole(types.AdminRole)
+	manager := randomUserWithRole(types.ManagerRole)
+	otherEmployee := randomUser()
+	user.ManagerID = &manager.ID
+	entry := randomEntry(user.ID)
+	conflicting := randomEntry(user.ID)
+
+	arg := db.CreateEntryParams{
+		UserID:    entry.UserID,
+		StartTime: entry.StartTime,
+		EndTime:   entry.EndTime,
+	}
+
+	project := randomProject()
+	task := randomTask(project.ID)
+	archived := randomProject()
+	archived.Archived = true
+	otherCompanyProject := randomProject()
+	otherCompanyProject.CompanyID = testCompanyID + 1
+	otherTask := randomTask(project.ID + 1)
+	description := util.RandomString(20)
+	tags := []string{util.RandomString(5), util.RandomString(5)}
+	categorized := entry
+	categorized.ProjectID = &project.ID
+	categorized.TaskID = &task.ID
+	categorized.Description = &description
+	categorized.Tags = tags
+	categorized.Billable = true
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateEntryTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(entry, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				requireBodyMatchEntry(t, recorder.Body, entry)
+			},
+		},
+		{
+			name: "WithProjectOK",
+			body: gin.H{
+				"user_id":     entry.UserID,
+				"start_time":  entry.StartTime,
+				"end_time":    entry.EndTime,
+				"project_id":  project.ID,
+				"task_id":     task.ID,
+				"description": description,
+				"tags":        tags,
+				"billable":    true,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(2).
+					Return(user, nil)
+				store.EXPECT().
+					GetProject(gomock.Any(), gomock.Eq(project.ID)).
+					Times(1).
+					Return(project, nil)
+				store.EXPECT().
+					GetTask(gomock.Any(), gomock.Eq(task.ID)).
+					Times(1).
+					Return(task, nil)
+				categorizedArg := db.CreateEntryParams{
+					UserID:      entry.UserID,
+					StartTime:   entry.StartTime,
+					EndTime:     entry.EndTime,
+					ProjectID:   &project.ID,
+					TaskID:      &task.ID,
+					Description: &description,
+					Tags:        tags,
+					Billable:    true,
+				}
+				store.EXPECT().
+					CreateEntryTx(gomock.Any(), gomock.Eq(categorizedArg)).
+					Times(1).
+					Return(categorized, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				requireBodyMatchEntry(t, recorder.Body, categorized)
+			},
+		},
+		{
+			name: "ArchivedProject",
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+				"project_id": archived.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(2).
+					Return(user, nil)
+				store.EXPECT().
+					GetProject(gomock.Any(), gomock.Eq(archived.ID)).
+					Times(1).
+					Return(archived, nil)
+				store.EXPECT().
+					CreateEntryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "ProjectOutsideCompany",
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+				"project_id": otherCompanyProject.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(2).
+					Return(user, nil)
+				store.EXPECT().
+					GetProject(gomock.Any(), gomock.Eq(otherCompanyProject.ID)).
+					Times(1).
+					Return(otherCompanyProject, nil)
+				store.EXPECT().
+					CreateEntryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "TaskOutsideProject",
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+				"project_id": project.ID,
+				"task_id":    otherTask.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(2).
+					Return(user, nil)
+				store.EXPECT().
+					GetProject(gomock.Any(), gomock.Eq(project.ID)).
+					Times(1).
+					Return(project, nil)
+				store.EXPECT().
+					GetTask(gomock.Any(), gomock.Eq(otherTask.ID)).
+					Times(1).
+					Return(otherTask, nil)
+				store.EXPECT().
+					CreateEntryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "TaskWithoutProject",
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+				"task_id":    task.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateEntryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "AdminOK",
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateEntryTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(entry, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				requireBodyMatchEntry(t, recorder.Body, entry)
+			},
+		},
+		{
+			name: "ManagerOK",
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateEntryTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(entry, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				requireBodyMatchEntry(t, recorder.Body, entry)
+			},
+		},
+		{
+			name: "Forbidden",
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(otherEmployee.Username)).
+					Times(1).
+					Return(otherEmployee, nil)
+				store.EXPECT().
+					CreateEntryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, otherEmployee, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "TimerRunning",
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateEntryTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Entry{}, &pgconn.PgError{
+						Code:           db.UniqueViolation,
+						ConstraintName: db.RunningEntryConstraint,
+					})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "Overlap",
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateEntryTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Entry{}, overlapViolation())
+				store.EXPECT().
+					GetOverlappingEntry(gomock.Any(), gomock.Eq(db.GetOverlappingEntryParams{
+						UserID:    entry.UserID,
+						StartTime: entry.StartTime,
+						EndTime:   entry.EndTime,
+					})).
+					Times(1).
+					Return(conflicting, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				requireBodyMatchEntryConflict(t, recorder.Body, conflicting)
+			},
+		},
+		{
+			name: "OverlapChanged",
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateEntryTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Entry{}, overlapViolation())
+				store.EXPECT().
+					GetOverlappingEntry(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Entry{}, pgx.ErrNoRows)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errEntryOverlap.Error())
+			},
+		},
+		{
+			name: "EndBeforeStart",
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.StartTime.Add(-time.Hour),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateEntryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalServerError",
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateEntryTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Entry{}, pgx.ErrTxClosed)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest",
+			body: gin.H{
+				"start_time": entry.StartTime,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateEntryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Unauthorized",
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateEntryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/entries", bytes.NewBuffer(jsonData))
+			require.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			tc.setupAuth(t, request, server.tokenMaker)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetEntryAPI(t *testing.T) {
+	user := randomUser()
+	admin := randomUserWithRole(types.AdminRole)
+	manager := randomUserWithRole(types.ManagerRole)
+	otherManager := randomUserWithRole(types.ManagerRole)
+	otherEmployee := randomUser()
+	outsider := randomUserWithRole(types.AdminRole)
+	outsider.CompanyID = util.Pointer(testCompanyID + 1)
+	user.ManagerID = &manager.ID
+	entry := randomEntry(user.ID)
+
+	testCases := []struct {
+		name          string
+		entryID       int64
+		buildStubs    func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			entryID: entry.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(2).
+					Return(entry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchEntry(t, recorder.Body, entry)
+			},
+		},
+		{
+			name:    "AdminOK",
+			entryID: entry.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(2).
+					Return(entry, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchEntry(t, recorder.Body, entry)
+			},
+		},
+		{
+			name:    "ManagerOK",
+			entryID: entry.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(2).
+					Return(entry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchEntry(t, recorder.Body, entry)
+			},
+		},
+		{
+			name:    "ManagerForbidden",
+			entryID: entry.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(entry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(otherManager.Username)).
+					Times(1).
+					Return(otherManager, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, otherManager, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:    "Forbidden",
+			entryID: entry.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(entry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(otherEmployee.Username)).
+					Times(1).
+					Return(otherEmployee, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, otherEmployee, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:    "OtherCompanyNotFound",
+			entryID: entry.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(outsider.Username)).
+					Times(1).
+					Return(outsider, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(entry, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, outsider, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:    "NotFound",
+			entryID: entry.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(db.Entry{}, pgx.ErrNoRows)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:    "InternalServerError",
+			entryID: entry.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(db.Entry{}, pgx.ErrTxClosed)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:    "InvalidID",
+			entryID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:    "Unauthorized",
+			entryID: entry.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/entries/%d", tc.entryID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeleteEntryAPI(t *testing.T) {
+	user := randomUser()
+	admin := randomUserWithRole(types.AdminRole)
+	otherEmployee := randomUser()
+	entry := randomEntry(user.ID)
+
+	testCases := []struct {
+		name          string
+		entryID       int64
+		buildStubs    func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			entryID: entry.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(entry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					DeleteEntryTx(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(entry, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchEntry(t, recorder.Body, entry)
+			},
+		},
+		{
+			name:    "AdminOK",
+			entryID: entry.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(entry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					DeleteEntryTx(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(entry, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchEntry(t, recorder.Body, entry)
+			},
+		},
+		{
+			name:    "Forbidden",
+			entryID: entry.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(entry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(otherEmployee.Username)).
+					Times(1).
+					Return(otherEmployee, nil)
+				store.EXPECT().
+					DeleteEntryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, otherEmployee, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:    "NotFound",
+			entryID: entry.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(entry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					DeleteEntryTx(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(db.Entry{}, pgx.ErrNoRows)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:    "Invoiced",
+			entryID: entry.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(entry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					DeleteEntryTx(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(db.Entry{}, &pgconn.PgError{Code: db.CheckViolation, ConstraintName: db.EntryInvoicedConstraint})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:    "PeriodLocked",
+			entryID: entry.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(entry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					DeleteEntryTx(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(db.Entry{}, &pgconn.PgError{Code: db.CheckViolation, ConstraintName: db.EntryPeriodLockedConstraint})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:    "InternalServerError",
+			entryID: entry.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(entry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					DeleteEntryTx(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(db.Entry{}, pgx.ErrTxClosed)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:    "InvalidID",
+			entryID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteEntryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:    "Unauthorized",
+			entryID: entry.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteEntryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/entries/%d", tc.entryID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestUpdateEntryAPI(t *testing.T) {
+	user := randomUser()
+	admin := randomUserWithRole(types.AdminRole)
+	otherEmployee := randomUser()
+	entry := randomEntry(user.ID)
+	conflicting := randomEntry(user.ID)
+	archived := randomProject()
+	archived.Archived = true
+	archivedEntry := entry
+	archivedEntry.ProjectID = &archived.ID
+
+	arg := db.UpdateEntryParams{
+		ID:        entry.ID,
+		UserID:    entry.UserID,
+		StartTime: entry.StartTime,
+		EndTime:   entry.EndTime,
+	}
+
+	testCases := []struct {
+		name          string
+		entryID       int64
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			entryID: entry.ID,
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(2).
+					Return(user, nil)
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(entry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					UpdateEntryTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(entry, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchEntry(t, recorder.Body, entry)
+			},
+		},
+		{
+			name:    "ArchivedProjectKeepsEntry",
+			entryID: entry.ID,
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+				"project_id": archived.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(2).
+					Return(archivedEntry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(3).
+					Return(user, nil)
+				store.EXPECT().
+					GetProject(gomock.Any(), gomock.Eq(archived.ID)).
+					Times(1).
+					Return(archived, nil)
+				archivedArg := arg
+				archivedArg.ProjectID = &archived.ID
+				store.EXPECT().
+					UpdateEntryTx(gomock.Any(), gomock.Eq(archivedArg)).
+					Times(1).
+					Return(archivedEntry, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:    "MoveToArchivedProject",
+			entryID: entry.ID,
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+				"project_id": archived.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(2).
+					Return(entry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(3).
+					Return(user, nil)
+				store.EXPECT().
+					GetProject(gomock.Any(), gomock.Eq(archived.ID)).
+					Times(1).
+					Return(archived, nil)
+				store.EXPECT().
+					UpdateEntryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:    "AdminOK",
+			entryID: entry.ID,
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(entry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(2).
+					Return(user, nil)
+				store.EXPECT().
+					UpdateEntryTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(entry, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchEntry(t, recorder.Body, entry)
+			},
+		},
+		{
+			name:    "Forbidden",
+			entryID: entry.ID,
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(entry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(otherEmployee.Username)).
+					Times(1).
+					Return(otherEmployee, nil)
+				store.EXPECT().
+					UpdateEntryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, otherEmployee, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:    "ReassignForbidden",
+			entryID: entry.ID,
+			body: gin.H{
+				"user_id":    otherEmployee.ID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(otherEmployee.ID)).
+					Times(1).
+					Return(otherEmployee, nil)
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(entry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					UpdateEntryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:    "NotFound",
+			entryID: entry.ID,
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(entry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(2).
+					Return(user, nil)
+				store.EXPECT().
+					UpdateEntryTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Entry{}, pgx.ErrNoRows)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:    "Overlap",
+			entryID: entry.ID,
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(entry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(2).
+					Return(user, nil)
+				store.EXPECT().
+					UpdateEntryTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Entry{}, overlapViolation())
+				store.EXPECT().
+					GetOverlappingEntry(gomock.Any(), gomock.Eq(db.GetOverlappingEntryParams{
+						UserID:    entry.UserID,
+						ExcludeID: entry.ID,
+						StartTime: entry.StartTime,
+						EndTime:   entry.EndTime,
+					})).
+					Times(1).
+					Return(conflicting, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				requireBodyMatchEntryConflict(t, recorder.Body, conflicting)
+			},
+		},
+		{
+			name:    "InvalidTimes",
+			entryID: entry.ID,
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(entry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(2).
+					Return(user, nil)
+				store.EXPECT().
+					UpdateEntryTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Entry{}, &pgconn.PgError{
+						Code:           db.CheckViolation,
+						ConstraintName: db.EntryTimeConstraint,
+					})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errEntryTimes.Error())
+			},
+		},
+		{
+			name:    "BadRequest",
+			entryID: entry.ID,
+			body: gin.H{
+				"user_id": entry.UserID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetEntry(gomock.Any(), gomock.Eq(entry.ID)).
+					Times(1).
+					Return(entry, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					UpdateEntryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:    "Unauthorized",
+			entryID: entry.ID,
+			body: gin.H{
+				"user_id":    entry.UserID,
+				"start_time": entry.StartTime,
+				"end_time":   entry.EndTime,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateEntryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/entries/%d", tc.entryID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(jsonData))
+			require.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			tc.setupAuth(t, request, server.tokenMaker)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListEntriesAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	manager := randomUserWithRole(types.ManagerRole)
+	employee := randomUser()
+	superuser := randomSuperuser()
+	arg := db.ListEntriesParams{
+		CompanyID: admin.CompanyID,
+		Offset:    int32(util.RandomInt(0, 100000)),
+		Limit:     int32(util.RandomInt(1, 100)),
+	}
+	returnVal := []db.Entry{randomEntry(employee.ID)}
+
+	from := time.Now().UTC().Truncate(time.Second).Add(-7 * 24 * time.Hour)
+	to := from.Add(7 * 24 * time.Hour)
+	teamID := util.RandomInt(1, 1000)
+	companyID := testCompanyID
+	projectID := util.RandomInt(1, 1000)
+	taskID := util.RandomInt(1, 1000)
+	tag := util.RandomString(6)
+
+	testCases := []struct {
+		name          string
+		offset        int32
+		limit         int32
+		filters       map[string]string
+		buildStubs    func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			offset: arg.Offset,
+			limit:  arg.Limit,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					ListEntries(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(returnVal, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchEntryList(t, recorder.Body, returnVal)
+			},
+		},
+		{
+			name:   "FilterOK",
+			offset: arg.Offset,
+			limit:  arg.Limit,
+			filters: map[string]string{
+				"user_id":    fmt.Sprintf("%d", employee.ID),
+				"team_id":    fmt.Sprintf("%d", teamID),
+				"company_id": fmt.Sprintf("%d", companyID),
+				"from":       from.Format(time.RFC3339),
+				"to":         to.Format(time.RFC3339),
+				"project_id": fmt.Sprintf("%d", projectID),
+				"task_id":    fmt.Sprintf("%d", taskID),
+				"tag":        tag,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				filterArg := db.ListEntriesParams{
+					UserID:    &employee.ID,
+					TeamID:    &teamID,
+					CompanyID: &companyID,
+					From:      &from,
+					To:        &to,
+					ProjectID: &projectID,
+					TaskID:    &taskID,
+					Tag:       &tag,
+					Offset:    arg.Offset,
+					Limit:     arg.Limit,
+				}
+				store.EXPECT().
+					ListEntries(gomock.Any(), gomock.Eq(filterArg)).
+					Times(1).
+					Return(returnVal, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchEntryList(t, recorder.Body, returnVal)
+			},
+		},
+		{
+			name:   "OtherCompanyForbidden",
+			offset: arg.Offset,
+			limit:  arg.Limit,
+			filters: map[string]string{
+				"company_id": fmt.Sprintf("%d", companyID+1),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					ListEntries(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "SuperuserOK",
+			offset: arg.Offset,
+			limit:  arg.Limit,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(superuser.Username)).
+					Times(1).
+					Return(superuser, nil)
+				superuserArg := db.ListEntriesParams{
+					Offset: arg.Offset,
+					Limit:  arg.Limit,
+				}
+				store.EXPECT().
+					ListEntries(gomock.Any(), gomock.Eq(superuserArg)).
+					Times(1).
+					Return(returnVal, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, superuser, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchEntryList(t, recorder.Body, returnVal)
+			},
+		},
+		{
+			name:   "InvalidTimeRange",
+			offset: arg.Offset,
+			limit:  arg.Limit,
+			filters: map[string]string{
+				"from": to.Format(time.RFC3339),
+				"to":   from.Format(time.RFC3339),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					ListEntries(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidUserID",
+			offset: arg.Offset,
+			limit:  arg.Limit,
+			filters: map[string]string{
+				"user_id": "0",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					ListEntries(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "InternalServerError",
+			offset: arg.Offset,
+			limit:  arg.Limit,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					ListEntries(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(returnVal, pgx.ErrTxClosed)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidLimit",
+			offset: arg.Offset,
+			limit:  1000,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					ListEntries(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "ManagerForbidden",
+			offset: arg.Offset,
+			limit:  arg.Limit,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					ListEntries(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Forbidden",
+			offset: arg.Offset,
+			limit:  arg.Limit,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(employee.Username)).
+					Times(1).
+					Return(employee, nil)
+				store.EXPECT().
+					ListEntries(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, employee, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Unauthorized",
+			offset: arg.Offset,
+			limit:  arg.Limit,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListEntries(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/entries", nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+
+			q := request.URL.Query()
+			q.Set("offset", fmt.Sprintf("%d", tc.offset))
+			q.Set("limit", fmt.Sprintf("%d", tc.limit))
+			for key, value := range tc.filters {
+				q.Set(key, value)
+			}
+			request.URL.RawQuery = q.Encode()
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListUserEntriesAPI(t *testing.T) {
+	user := randomUser()
+	manager := randomUserWithRole(types.ManagerRole)
+	otherEmployee := randomUser()
+	user.ManagerID = &manager.ID
+
+	n := 5
+	entries := make([]db.Entry, n)
+	for i := 0; i < n; i++ {
+		entries[i] = randomEntry(user.ID)
+	}
+
+	from := time.Now().UTC().Truncate(time.Second).Add(-24 * time.Hour)
+	to := from.Add(24 * time.Hour)
+
+	testCases := []struct {
+		name          string
+		actor         db.User
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			actor: user,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				arg := db.ListUserEntriesParams{
+					UserID: user.ID,
+					Limit:  10,
+					Offset: 0,
+				}
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					ListUserEntries(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(entries, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchEntryList(t, recorder.Body, entries)
+			},
+		},
+		{
+			name:  "ManagerTimeRangeOK",
+			actor: manager,
+			query: fmt.Sprintf("?from=%s&to=%s", from.Format(time.RFC3339), to.Format(time.RFC3339)),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListUserEntriesParams{
+					UserID: user.ID,
+					From:   &from,
+					To:     &to,
+					Limit:  10,
+					Offset: 0,
+				}
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					ListUserEntries(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(entries, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchEntryList(t, recorder.Body, entries)
+			},
+		},
+		{
+			name:  "InvalidTimeRange",
+			actor: user,
+			query: fmt.Sprintf("?from=%s&to=%s", to.Format(time.RFC3339), from.Format(time.RFC3339)),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					ListUserEntries(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalServerError",
+			actor: user,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					ListUserEntries(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Entry{}, pgx.ErrTxClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:  "Forbidden",
+			actor: otherEmployee,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(otherEmployee.Username)).
+					Times(1).
+					Return(otherEmployee, nil)
+				store.EXPECT().
+					ListUserEntries(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/users/%d/entries%s", user.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, tc.actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
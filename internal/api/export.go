@@ -0,0 +1,306 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/export"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/worktime"
+)
+
+// exportTimeLayout is the layout of the times of an export, in the time zone of the user of the row
+const exportTimeLayout = "2006-01-02 15:04"
+
+type exportRequest struct {
+	Format string    `form:"format" binding:"omitempty,export_format"`
+	From   time.Time `form:"from" binding:"required"`
+	To     time.Time `form:"to" binding:"required,gtfield=From"`
+	UserID *int64    `form:"user_id" binding:"omitempty,min=1"`
+	TeamID *int64    `form:"team_id" binding:"omitempty,min=1"`
+	// Columns is a comma separated list of the columns to export, all columns if empty
+	Columns string `form:"columns"`
+}
+
+type exportAbsencesRequest struct {
+	exportRequest
+	Status *string `form:"status" binding:"omitempty,absence_status"`
+}
+
+// columnNames splits the comma separated columns of an export request
+func (req exportRequest) columnNames() []string {
+	var names []string
+	for _, name := range strings.Split(req.Columns, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// filename returns the name of the file of an export of kind
+func (req exportRequest) filename(kind string) string {
+	return fmt.Sprintf("%s-%s-%s", kind, req.From.Format("20060102"), req.To.Format("20060102"))
+}
+
+// exportEntries streams the entries of a company which started within [from, to) as a spreadsheet,
+// optionally only those of a user or team, ordered by user and start time.
+func (server *Server) exportEntries(ctx *gin.Context) {
+	var idReq RequestWithID
+	var req exportRequest
+	if err := ctx.ShouldBindUri(&idReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	columns, err := export.Select(entryColumns(locationCache{}), req.columnNames())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ExportEntriesParams{
+		CompanyID: idReq.ID,
+		UserID:    req.UserID,
+		TeamID:    req.TeamID,
+		From:      req.From,
+		To:        req.To,
+	}
+	writeExport(ctx, req.Format, req.filename("entries"), func(w export.Writer) error {
+		table := export.NewTable(w, columns)
+		if err := server.store.ExportEntriesTx(ctx, arg, table.Write); err != nil {
+			return err
+		}
+		return table.Close()
+	})
+}
+
+// exportAbsences streams the absences of a company which overlap [from, to) as a spreadsheet, optionally only
+// those of a user or team or with a status, ordered by user and start time. Durations are counted within the range.
+func (server *Server) exportAbsences(ctx *gin.Context) {
+	var idReq RequestWithID
+	var req exportAbsencesRequest
+	if err := ctx.ShouldBindUri(&idReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	columns, err := export.Select(absenceColumns(locationCache{}, req.From, req.To), req.columnNames())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ExportAbsencesParams{
+		CompanyID: idReq.ID,
+		UserID:    req.UserID,
+		TeamID:    req.TeamID,
+		Status:    req.Status,
+		From:      req.From,
+		To:        req.To,
+	}
+	writeExport(ctx, req.Format, req.filename("absences"), func(w export.Writer) error {
+		table := export.NewTable(w, columns)
+		if err := server.store.ExportAbsencesTx(ctx, arg, table.Write); err != nil {
+			return err
+		}
+		return table.Close()
+	})
+}
+
+// writeExport responds with the spreadsheet write writes as an attachment. Errors are reported as usual until
+// the first bytes of the spreadsheet are sent, later ones abort the response and the client receives a truncated file.
+func writeExport(ctx *gin.Context, format, filename string, write func(export.Writer) error) {
+	if format == "" {
+		format = types.ExportCSV
+	}
+	ctx.Header("Content-Type", export.ContentType(format))
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, filename, format))
+	ctx.Status(http.StatusOK)
+
+	if err := write(export.NewWriter(ctx.Writer, format)); err != nil {
+		if !ctx.Writer.Written() {
+			ctx.Writer.Header().Del("Content-Type")
+			ctx.Writer.Header().Del("Content-Disposition")
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		_ = ctx.Error(err)
+		ctx.Abort()
+	}
+}
+
+// locationCache loads the time zones of the users of an export once
+type locationCache map[string]*time.Location
+
+// load returns the time zone name, or UTC if it is unknown
+func (c locationCache) load(name string) *time.Location {
+	location, ok := c[name]
+	if !ok {
+		var err error
+		if location, err = time.LoadLocation(name); err != nil {
+			location = time.UTC
+		}
+		c[name] = location
+	}
+	return location
+}
+
+// localTime formats t in the time zone name, or returns nil for an empty cell without t
+func (c locationCache) localTime(t *time.Time, name string) any {
+	if t == nil {
+		return nil
+	}
+	return t.In(c.load(name)).Format(exportTimeLayout)
+}
+
+// hours converts seconds to decimal hours rounded to the cent
+func hours(seconds int64) float64 {
+	return math.Round(float64(seconds)/36) / 100
+}
+
+func optionalText(text *string) any {
+	if text == nil {
+		return nil
+	}
+	return *text
+}
+
+func entryColumns(locations locationCache) []export.Column[db.ExportEntriesRow] {
+	// seconds returns the duration of an entry, running entries do not have one yet
+	seconds := func(row db.ExportEntriesRow) *int64 {
+		if row.EndTime == nil {
+			return nil
+		}
+		seconds := int64(row.EndTime.Sub(row.StartTime) / time.Second)
+		return &seconds
+	}
+
+	return []export.Column[db.ExportEntriesRow]{
+		{Name: "id", Header: "Entry", Value: func(row db.ExportEntriesRow) any { return row.ID }},
+		{Name: "user_id", Header: "User ID", Value: func(row db.ExportEntriesRow) any { return row.UserID }},
+		{Name: "username", Header: "Username", Value: func(row db.ExportEntriesRow) any { return row.Username }},
+		{Name: "name", Header: "Name", Value: func(row db.ExportEntriesRow) any { return row.Name + " " + row.Surname }},
+		{Name: "date", Header: "Date", Value: func(row db.ExportEntriesRow) any {
+			return row.StartTime.In(locations.load(row.Timezone)).Format(time.DateOnly)
+		}},
+		{Name: "start", Header: "Start", Value: func(row db.ExportEntriesRow) any { return locations.localTime(&row.StartTime, row.Timezone) }},
+		{Name: "end", Header: "End", Value: func(row db.ExportEntriesRow) any { return locations.localTime(row.EndTime, row.Timezone) }},
+		{Name: "timezone", Header: "Time zone", Value: func(row db.ExportEntriesRow) any { return locations.load(row.Timezone).String() }},
+		{Name: "hours", Header: "Hours", Total: true, Value: func(row db.ExportEntriesRow) any {
+			if s := seconds(row); s != nil {
+				return hours(*s)
+			}
+			return nil
+		}},
+		{Name: "seconds", Header: "Seconds", Total: true, Value: func(row db.ExportEntriesRow) any {
+			if s := seconds(row); s != nil {
+				return *s
+			}
+			return nil
+		}},
+		{Name: "project", Header: "Project", Value: func(row db.ExportEntriesRow) any { return optionalText(row.ProjectName) }},
+		{Name: "task", Header: "Task", Value: func(row db.ExportEntriesRow) any { return optionalText(row.TaskName) }},
+		{Name: "description", Header: "Description", Value: func(row db.ExportEntriesRow) any { return optionalText(row.Description) }},
+		{Name: "tags", Header: "Tags", Value: func(row db.ExportEntriesRow) any { return strings.Join(row.Tags, ", ") }},
+		{Name: "billable", Header: "Billable", Value: func(row db.ExportEntriesRow) any { return yesNo(row.Billable) }},
+	}
+}
+
+// absenceColumns returns the columns of absences exported for [from, to). Full and half days count in days, hourly
+// absences in hours, both within the range and, for absences which have not ended yet, until now.
+func absenceColumns(locations locationCache, from, to time.Time) []export.Column[db.ExportAbsencesRow] {
+	// span returns the part of an absence within the range
+	span := func(row db.ExportAbsencesRow) (time.Time, time.Time) {
+		start, end := row.StartTime, to
+		if row.EndTime != nil && row.EndTime.Before(end) {
+			end = *row.EndTime
+		} else if now := time.Now().UTC(); row.EndTime == nil && now.Before(end) {
+			end = now
+		}
+		if start.Before(from) {
+			start = from
+		}
+		return start, end
+	}
+
+	return []export.Column[db.ExportAbsencesRow]{
+		{Name: "id", Header: "Absence", Value: func(row db.ExportAbsencesRow) any { return row.ID }},
+		{Name: "user_id", Header: "User ID", Value: func(row db.ExportAbsencesRow) any { return row.UserID }},
+		{Name: "username", Header: "Username", Value: func(row db.ExportAbsencesRow) any { return row.Username }},
+		{Name: "name", Header: "Name", Value: func(row db.ExportAbsencesRow) any { return row.Name + " " + row.Surname }},
+		{Name: "type", Header: "Type", Value: func(row db.ExportAbsencesRow) any { return optionalText(row.AbsenceTypeName) }},
+		{Name: "kind", Header: "Kind", Value: func(row db.ExportAbsencesRow) any { return row.Kind }},
+		{Name: "status", Header: "Status", Value: func(row db.ExportAbsencesRow) any { return row.Status }},
+		{Name: "start", Header: "Start", Value: func(row db.ExportAbsencesRow) any { return locations.localTime(&row.StartTime, row.Timezone) }},
+		{Name: "end", Header: "End", Value: func(row db.ExportAbsencesRow) any { return locations.localTime(row.EndTime, row.Timezone) }},
+		{Name: "timezone", Header: "Time zone", Value: func(row db.ExportAbsencesRow) any { return locations.load(row.Timezone).String() }},
+		{Name: "days", Header: "Days", Total: true, Value: func(row db.ExportAbsencesRow) any {
+			if row.Kind == types.AbsenceHours {
+				return nil
+			}
+			start, end := span(row)
+			if !end.After(start) {
+				return 0.0
+			}
+			if row.Kind != types.AbsenceFullDay {
+				return 0.5
+			}
+			location := locations.load(row.Timezone)
+			first := worktime.Date(start.In(location))
+			// the last day is the one before the end, which is exclusive
+			last := worktime.Date(end.Add(-time.Nanosecond).In(location))
+			return float64(last.Sub(first)/(24*time.Hour) + 1)
+		}},
+		{Name: "hours", Header: "Hours", Total: true, Value: func(row db.ExportAbsencesRow) any {
+			if row.Kind != types.AbsenceHours {
+				return nil
+			}
+			start, end := span(row)
+			if !end.After(start) {
+				return 0.0
+			}
+			return hours(int64(end.Sub(start) / time.Second))
+		}},
+		{Name: "paid", Header: "Paid", Value: func(row db.ExportAbsencesRow) any { return yesNo(row.Paid) }},
+		{Name: "reason", Header: "Reason", Value: func(row db.ExportAbsencesRow) any { return row.Reason }},
+	}
+}
+
+// billingColumns are the columns of a billing report exported as a spreadsheet. Amounts are in minor units
+// of their currency and are not summed, as the lines may be billed in different currencies.
+func billingColumns() []export.Column[db.GetBillingReportRow] {
+	optionalNumber := func(id *int64) any {
+		if id == nil {
+			return nil
+		}
+		return *id
+	}
+
+	return []export.Column[db.GetBillingReportRow]{
+		{Name: "client_id", Header: "Client ID", Value: func(row db.GetBillingReportRow) any { return optionalNumber(row.ClientID) }},
+		{Name: "project_id", Header: "Project ID", Value: func(row db.GetBillingReportRow) any { return optionalNumber(row.ProjectID) }},
+		{Name: "currency", Header: "Currency", Value: func(row db.GetBillingReportRow) any { return optionalText(row.Currency) }},
+		{Name: "entries", Header: "Entries", Total: true, Value: func(row db.GetBillingReportRow) any { return row.Entries }},
+		{Name: "hours", Header: "Hours", Total: true, Value: func(row db.GetBillingReportRow) any { return hours(row.BillableSeconds) }},
+		{Name: "amount_cents", Header: "Amount (minor units)", Value: func(row db.GetBillingReportRow) any { return optionalNumber(row.AmountCents) }},
+	}
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,330 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// streamEntries returns a stub of ExportEntriesTx which passes rows to the callback
+func streamEntries(rows []db.ExportEntriesRow) func(context.Context, db.ExportEntriesParams, func(db.ExportEntriesRow) error) error {
+	return func(_ context.Context, _ db.ExportEntriesParams, fn func(db.ExportEntriesRow) error) error {
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func readCSV(t *testing.T, body *bytes.Buffer) [][]string {
+	records, err := csv.NewReader(body).ReadAll()
+	require.NoError(t, err)
+	return records
+}
+
+func TestExportEntriesAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	employee := randomUser()
+	teamID := util.RandomInt(1, 1000)
+
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	start := time.Date(2024, time.March, 4, 8, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Minute)
+	rows := []db.ExportEntriesRow{
+		{
+			ID:          1,
+			UserID:      employee.ID,
+			Username:    employee.Username,
+			Name:        "Ana",
+			Surname:     "Horvat",
+			Timezone:    "Europe/Zagreb",
+			StartTime:   start,
+			EndTime:     &end,
+			ProjectName: util.Pointer("Website"),
+			Description: util.Pointer("=cmd"),
+			Tags:        []string{"design", "review"},
+			Billable:    true,
+		},
+		{
+			ID:        2,
+			UserID:    employee.ID,
+			Username:  employee.Username,
+			Name:      "Ana",
+			Surname:   "Horvat",
+			Timezone:  "Europe/Zagreb",
+			StartTime: start.Add(3 * time.Hour),
+		},
+	}
+
+	query := func(params ...string) url.Values {
+		q := url.Values{
+			"from": {from.Format(time.RFC3339)},
+			"to":   {to.Format(time.RFC3339)},
+		}
+		for i := 0; i < len(params); i += 2 {
+			q.Set(params[i], params[i+1])
+		}
+		return q
+	}
+
+	testCases := []struct {
+		name          string
+		actor         db.User
+		query         url.Values
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "CSV",
+			actor: admin,
+			query: query("team_id", fmt.Sprint(teamID)),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ExportEntriesParams{
+					CompanyID: testCompanyID,
+					TeamID:    &teamID,
+					From:      from,
+					To:        to,
+				}
+				store.EXPECT().
+					ExportEntriesTx(gomock.Any(), gomock.Eq(arg), gomock.Any()).
+					Times(1).
+					DoAndReturn(streamEntries(rows))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+				require.Equal(t, `attachment; filename="entries-20240301-20240401.csv"`, recorder.Header().Get("Content-Disposition"))
+
+				records := readCSV(t, recorder.Body)
+				require.Len(t, records, 4)
+				require.Equal(t, "Entry", records[0][0])
+				// times are in the time zone of the user and formulas are not evaluated
+				require.Equal(t, []string{
+					"1", fmt.Sprint(employee.ID), employee.Username, "Ana Horvat", "2024-03-04",
+					"2024-03-04 09:00", "2024-03-04 10:30", "Europe/Zagreb", "1.5", "5400",
+					"Website", "", "'=cmd", "design, review", "yes",
+				}, records[1])
+				// running entries have no duration yet
+				require.Equal(t, "", records[2][6])
+				require.Equal(t, "", records[2][8])
+				require.Equal(t, []string{"Total", "", "", "", "", "", "", "", "1.5", "5400", "", "", "", "", ""}, records[3])
+			},
+		},
+		{
+			name:  "Columns",
+			actor: admin,
+			query: query("columns", "username, hours", "user_id", fmt.Sprint(employee.ID)),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ExportEntriesParams{
+					CompanyID: testCompanyID,
+					UserID:    &employee.ID,
+					From:      from,
+					To:        to,
+				}
+				store.EXPECT().
+					ExportEntriesTx(gomock.Any(), gomock.Eq(arg), gomock.Any()).
+					Times(1).
+					DoAndReturn(streamEntries(rows))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, [][]string{
+					{"Username", "Hours"},
+					{employee.Username, "1.5"},
+					{employee.Username, ""},
+					{"Total", "1.5"},
+				}, readCSV(t, recorder.Body))
+			},
+		},
+		{
+			name:  "XLSX",
+			actor: admin,
+			query: query("format", types.ExportXLSX),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ExportEntriesTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(streamEntries(rows))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, `attachment; filename="entries-20240301-20240401.xlsx"`, recorder.Header().Get("Content-Disposition"))
+				archive, err := zip.NewReader(bytes.NewReader(recorder.Body.Bytes()), int64(recorder.Body.Len()))
+				require.NoError(t, err)
+				require.Len(t, archive.File, 5)
+			},
+		},
+		{
+			name:  "UnknownColumn",
+			actor: admin,
+			query: query("columns", "username,salary"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ExportEntriesTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "MissingTimeRange",
+			actor: admin,
+			query: url.Values{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ExportEntriesTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Forbidden",
+			actor: employee,
+			query: query(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ExportEntriesTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalServerError",
+			actor: admin,
+			query: query(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ExportEntriesTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(pgx.ErrTxClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Empty(t, recorder.Header().Get("Content-Disposition"))
+				require.Contains(t, recorder.Header().Get("Content-Type"), "application/json")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByUsername(gomock.Any(), gomock.Eq(tc.actor.Username)).
+				AnyTimes().
+				Return(tc.actor, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/companies/%d/exports/entries?%s", testCompanyID, tc.query.Encode())
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, tc.actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestExportAbsencesAPI(t *testing.T) {
+	manager := randomUserWithRole(types.ManagerRole)
+	employee := randomUser()
+
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	// full days in Zagreb from February 28 until March 6, of which March 1 to 5 are within the range
+	fullDayStart := time.Date(2024, time.February, 27, 23, 0, 0, 0, time.UTC)
+	fullDayEnd := time.Date(2024, time.March, 5, 23, 0, 0, 0, time.UTC)
+	morningEnd := time.Date(2024, time.March, 11, 11, 0, 0, 0, time.UTC)
+	hoursStart := time.Date(2024, time.March, 12, 13, 0, 0, 0, time.UTC)
+	hoursEnd := hoursStart.Add(150 * time.Minute)
+	row := db.ExportAbsencesRow{UserID: employee.ID, Username: employee.Username, Timezone: "Europe/Zagreb", Status: types.AbsenceApproved, Paid: true}
+	rows := []db.ExportAbsencesRow{row, row, row}
+	rows[0].ID, rows[0].Kind, rows[0].StartTime, rows[0].EndTime = 1, types.AbsenceFullDay, fullDayStart, &fullDayEnd
+	rows[1].ID, rows[1].Kind, rows[1].StartTime, rows[1].EndTime = 2, types.AbsenceMorning, morningEnd.Add(-12*time.Hour), &morningEnd
+	rows[2].ID, rows[2].Kind, rows[2].StartTime, rows[2].EndTime = 3, types.AbsenceHours, hoursStart, &hoursEnd
+	rows[2].AbsenceTypeName = util.Pointer("Doctor")
+
+	status := types.AbsenceApproved
+	q := url.Values{
+		"from":    {from.Format(time.RFC3339)},
+		"to":      {to.Format(time.RFC3339)},
+		"status":  {status},
+		"columns": {"id,type,kind,start,days,hours"},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+		Times(1).
+		Return(manager, nil)
+	arg := db.ExportAbsencesParams{
+		CompanyID: testCompanyID,
+		Status:    &status,
+		From:      from,
+		To:        to,
+	}
+	store.EXPECT().
+		ExportAbsencesTx(gomock.Any(), gomock.Eq(arg), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, _ db.ExportAbsencesParams, fn func(db.ExportAbsencesRow) error) error {
+			for _, row := range rows {
+				if err := fn(row); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/companies/%d/exports/absences?%s", testCompanyID, q.Encode())
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, [][]string{
+		{"Absence", "Type", "Kind", "Start", "Days", "Hours"},
+		{"1", "", types.AbsenceFullDay, "2024-02-28 00:00", "5", ""},
+		{"2", "", types.AbsenceMorning, "2024-03-11 00:00", "0.5", ""},
+		{"3", "Doctor", types.AbsenceHours, "2024-03-12 14:00", "", "2.5"},
+		{"Total", "", "", "", "5.5", "2.5"},
+	}, readCSV(t, recorder.Body))
+}
@@ -0,0 +1,333 @@
+package api
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/holiday"
+	"github.com/mateoradman/tempus/internal/worktime"
+)
+
+// maxICalBytes limits the size of uploaded iCalendar files
+const maxICalBytes = 1 << 20
+
+// maxHolidayNameLength is the length of the name column of holidays
+const maxHolidayNameLength = 255
+
+type holidayRangeRequest struct {
+	From time.Time `form:"from" binding:"required"`
+	To   time.Time `form:"to" binding:"required,gtfield=From"`
+}
+
+type listPublicHolidaysRequest struct {
+	Country     string  `form:"country" binding:"required,len=2,alpha"`
+	Subdivision *string `form:"subdivision" binding:"omitempty,min=1,max=3,alphanum"`
+	holidayRangeRequest
+}
+
+// listPublicHolidays lists the public holidays within [from, to) observed in a country,
+// or in a subdivision of it, which observes the holidays of the whole country as well.
+func (server *Server) listPublicHolidays(ctx *gin.Context) {
+	var req listPublicHolidaysRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListPublicHolidaysParams{
+		Country:     strings.ToUpper(req.Country),
+		Subdivision: upperCase(req.Subdivision),
+		From:        worktime.Date(req.From),
+		To:          worktime.Date(req.To),
+	}
+	holidays, err := server.store.ListPublicHolidays(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, holidays)
+}
+
+type importPublicHolidaysRequest struct {
+	Country     string  `json:"country" binding:"required,len=2,alpha"`
+	Subdivision *string `json:"subdivision" binding:"omitempty,min=1,max=3,alphanum"`
+	Year        int     `json:"year" binding:"required,min=1900,max=2200"`
+}
+
+// importPublicHolidays imports the public holidays of a year from the bundled dataset. Without a subdivision they
+// are the holidays of the whole country, otherwise only those the subdivision observes in addition.
+func (server *Server) importPublicHolidays(ctx *gin.Context) {
+	var req importPublicHolidaysRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var subdivision string
+	if req.Subdivision != nil {
+		subdivision = *req.Subdivision
+	}
+	holidays, err := holiday.Generate(req.Country, subdivision, req.Year)
+	if err != nil {
+		if errors.Is(err, holiday.ErrUnknownCountry) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.savePublicHolidays(ctx, req.Country, req.Subdivision, holidays)
+}
+
+type importPublicHolidaysICalRequest struct {
+	Country     string                `form:"country" binding:"required,len=2,alpha"`
+	Subdivision *string               `form:"subdivision" binding:"omitempty,min=1,max=3,alphanum"`
+	File        *multipart.FileHeader `form:"file" binding:"required"`
+}
+
+// importPublicHolidaysICal imports the public holidays of the events of an iCalendar file uploaded as a multipart form.
+func (server *Server) importPublicHolidaysICal(ctx *gin.Context) {
+	var req importPublicHolidaysICalRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.File.Size > maxICalBytes {
+		ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(errICalTooLarge))
+		return
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	defer file.Close()
+
+	holidays, err := holiday.ParseICal(file)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	server.savePublicHolidays(ctx, req.Country, req.Subdivision, holidays)
+}
+
+// savePublicHolidays creates the public holidays of a country or one of its subdivisions, renaming existing ones,
+// and writes them to the response.
+func (server *Server) savePublicHolidays(ctx *gin.Context, country string, subdivision *string, holidays []holiday.Holiday) {
+	arg := make([]db.UpsertPublicHolidayParams, len(holidays))
+	for i, h := range holidays {
+		if utf8.RuneCountInString(h.Name) > maxHolidayNameLength {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errHolidayNameTooLong))
+			return
+		}
+		arg[i] = db.UpsertPublicHolidayParams{
+			Country:     strings.ToUpper(country),
+			Subdivision: upperCase(subdivision),
+			Date:        h.Date,
+			Name:        h.Name,
+		}
+	}
+
+	saved, err := server.store.ImportPublicHolidaysTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, saved)
+}
+
+func (server *Server) deletePublicHoliday(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	publicHoliday, err := server.store.DeletePublicHoliday(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, publicHoliday)
+}
+
+type createCompanyHolidayRequest struct {
+	Date time.Time `json:"date" binding:"required"`
+	Name string    `json:"name" binding:"required,min=1,max=255"`
+	// DayOff is false for a working day replacing a public holiday, true by default
+	DayOff *bool `json:"day_off"`
+}
+
+// createCompanyHoliday overrides the public holidays a company observes on a date,
+// either with a day off or with a working day.
+func (server *Server) createCompanyHoliday(ctx *gin.Context) {
+	var reqID RequestWithID
+	var req createCompanyHolidayRequest
+	if err := ctx.ShouldBindUri(&reqID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateCompanyHolidayParams{
+		CompanyID: reqID.ID,
+		Date:      worktime.Date(req.Date),
+		Name:      req.Name,
+		DayOff:    req.DayOff == nil || *req.DayOff,
+	}
+	companyHoliday, err := server.store.CreateCompanyHoliday(ctx, arg)
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation && db.ConstraintName(err) == db.CompanyHolidayDateConstraint {
+			ctx.JSON(http.StatusConflict, errorResponse(errCompanyHolidayTaken))
+			return
+		}
+		if db.ErrorCode(err) == db.ForeignKeyViolation {
+			ctx.JSON(http.StatusNotFound, errorResponse(pgx.ErrNoRows))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, companyHoliday)
+}
+
+func (server *Server) listCompanyHolidays(ctx *gin.Context) {
+	var reqID RequestWithID
+	var req holidayRangeRequest
+	if err := ctx.ShouldBindUri(&reqID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListCompanyHolidaysParams{
+		CompanyID: reqID.ID,
+		From:      worktime.Date(req.From),
+		To:        worktime.Date(req.To),
+	}
+	holidays, err := server.store.ListCompanyHolidays(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, holidays)
+}
+
+type companyHolidayRequest struct {
+	ID        int64 `uri:"id" binding:"required,min=1"`
+	HolidayID int64 `uri:"holiday_id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteCompanyHoliday(ctx *gin.Context) {
+	var req companyHolidayRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	companyHoliday, err := server.store.GetCompanyHoliday(ctx, req.HolidayID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if companyHoliday.CompanyID != req.ID {
+		ctx.JSON(http.StatusNotFound, errorResponse(errHolidayOutsideCompany))
+		return
+	}
+
+	companyHoliday, err = server.store.DeleteCompanyHoliday(ctx, companyHoliday.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, companyHoliday)
+}
+
+// userHolidaysResponse holds the days off a user observes
+type userHolidaysResponse struct {
+	UserID      int64             `json:"user_id"`
+	Country     *string           `json:"country"`
+	Subdivision *string           `json:"subdivision"`
+	Holidays    []holiday.Holiday `json:"holidays"`
+}
+
+func (server *Server) getUserHolidays(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.GetUser(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.holidaysOfUser(ctx, user)
+}
+
+// holidaysOfUser writes the days off the user observes within [from, to).
+func (server *Server) holidaysOfUser(ctx *gin.Context, user db.User) {
+	var req holidayRangeRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	holidays, err := worktime.LoadHolidays(ctx, server.store, user, worktime.Date(req.From), worktime.Date(req.To))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, userHolidaysResponse{
+		UserID:      user.ID,
+		Country:     user.Country,
+		Subdivision: user.Subdivision,
+		Holidays:    holidays,
+	})
+}
+
+// upperCase returns the upper case of s, or nil if s is nil
+func upperCase(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	upper := strings.ToUpper(*s)
+	return &upper
+}
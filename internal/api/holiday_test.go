@@ -0,0 +1,442 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/holiday"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// savedHolidays returns the public holidays the import transaction creates of arg
+func savedHolidays(arg []db.UpsertPublicHolidayParams) []db.PublicHoliday {
+	holidays := make([]db.PublicHoliday, len(arg))
+	for i, params := range arg {
+		holidays[i] = db.PublicHoliday{
+			ID:          int64(i + 1),
+			Country:     params.Country,
+			Subdivision: params.Subdivision,
+			Date:        params.Date,
+			Name:        params.Name,
+		}
+	}
+	return holidays
+}
+
+func TestImportPublicHolidaysAPI(t *testing.T) {
+	superuser := randomSuperuser()
+	admin := randomUserWithRole(types.AdminRole)
+
+	testCases := []struct {
+		name          string
+		actor         db.User
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			actor: superuser,
+			body:  gin.H{"country": "de", "subdivision": "by", "year": 2024},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(superuser.Username)).
+					Times(1).
+					Return(superuser, nil)
+				store.EXPECT().
+					ImportPublicHolidaysTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg []db.UpsertPublicHolidayParams) ([]db.PublicHoliday, error) {
+						return savedHolidays(arg), nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var got []db.PublicHoliday
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got, 3)
+				for _, h := range got {
+					require.Equal(t, "DE", h.Country)
+					require.Equal(t, "BY", *h.Subdivision)
+				}
+				require.Equal(t, "Epiphany", got[0].Name)
+				require.Equal(t, time.Date(2024, time.January, 6, 0, 0, 0, 0, time.UTC), got[0].Date)
+			},
+		},
+		{
+			name:  "UnknownCountry",
+			actor: superuser,
+			body:  gin.H{"country": "ZZ", "year": 2024},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(superuser.Username)).
+					Times(1).
+					Return(superuser, nil)
+				store.EXPECT().
+					ImportPublicHolidaysTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidYear",
+			actor: superuser,
+			body:  gin.H{"country": "HR", "year": 24},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(superuser.Username)).
+					Times(1).
+					Return(superuser, nil)
+				store.EXPECT().
+					ImportPublicHolidaysTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Forbidden",
+			actor: admin,
+			body:  gin.H{"country": "HR", "year": 2024},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					ImportPublicHolidaysTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/holidays/import", bytes.NewBuffer(jsonData))
+			require.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, tc.actor, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestImportPublicHolidaysICalAPI(t *testing.T) {
+	superuser := randomSuperuser()
+	calendar := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n" +
+		"BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20240530\r\nSUMMARY:Statehood Day\r\nEND:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	testCases := []struct {
+		name          string
+		file          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			file: calendar,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(superuser.Username)).
+					Times(1).
+					Return(superuser, nil)
+				arg := []db.UpsertPublicHolidayParams{{
+					Country: "HR",
+					Date:    time.Date(2024, time.May, 30, 0, 0, 0, 0, time.UTC),
+					Name:    "Statehood Day",
+				}}
+				store.EXPECT().
+					ImportPublicHolidaysTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(savedHolidays(arg), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidFile",
+			file: "not a calendar",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(superuser.Username)).
+					Times(1).
+					Return(superuser, nil)
+				store.EXPECT().
+					ImportPublicHolidaysTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			writer := multipart.NewWriter(&body)
+			require.NoError(t, writer.WriteField("country", "hr"))
+			part, err := writer.CreateFormFile("file", "holidays.ics")
+			require.NoError(t, err)
+			_, err = part.Write([]byte(tc.file))
+			require.NoError(t, err)
+			require.NoError(t, writer.Close())
+
+			request, err := http.NewRequest(http.MethodPost, "/holidays/import/ical", &body)
+			require.NoError(t, err)
+
+			request.Header.Set("Content-Type", writer.FormDataContentType())
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, superuser, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCreateCompanyHolidayAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	employee := randomUser()
+	date := time.Date(2024, time.May, 31, 0, 0, 0, 0, time.UTC)
+	companyHoliday := db.CompanyHoliday{
+		ID:        util.RandomInt(1, 1000),
+		CompanyID: testCompanyID,
+		Date:      date,
+		Name:      "Bridge day",
+		DayOff:    true,
+	}
+	arg := db.CreateCompanyHolidayParams{
+		CompanyID: testCompanyID,
+		Date:      date,
+		Name:      companyHoliday.Name,
+		DayOff:    true,
+	}
+
+	testCases := []struct {
+		name          string
+		actor         db.User
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			actor: admin,
+			body:  gin.H{"date": date, "name": companyHoliday.Name},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					CreateCompanyHoliday(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(companyHoliday, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name:  "WorkingDay",
+			actor: admin,
+			body:  gin.H{"date": date, "name": companyHoliday.Name, "day_off": false},
+			buildStubs: func(store *mockdb.MockStore) {
+				working := arg
+				working.DayOff = false
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					CreateCompanyHoliday(gomock.Any(), gomock.Eq(working)).
+					Times(1).
+					Return(companyHoliday, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name:  "DateTaken",
+			actor: admin,
+			body:  gin.H{"date": date, "name": companyHoliday.Name},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					CreateCompanyHoliday(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.CompanyHoliday{}, &pgconn.PgError{
+						Code:           db.UniqueViolation,
+						ConstraintName: db.CompanyHolidayDateConstraint,
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:  "Forbidden",
+			actor: employee,
+			body:  gin.H{"date": date, "name": companyHoliday.Name},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(employee.Username)).
+					Times(1).
+					Return(employee, nil)
+				store.EXPECT().
+					CreateCompanyHoliday(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/companies/%d/holidays", testCompanyID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
+			require.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, tc.actor, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetUserHolidaysAPI(t *testing.T) {
+	user := randomUser()
+	user.Country = util.Pointer("hr")
+	from := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(user, nil)
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.ID)).
+		Times(2).
+		Return(user, nil)
+	store.EXPECT().
+		ListPublicHolidays(gomock.Any(), gomock.Eq(db.ListPublicHolidaysParams{
+			Country: "HR",
+			From:    from,
+			To:      to,
+		})).
+		Times(1).
+		Return([]db.PublicHoliday{
+			{ID: 1, Country: "HR", Date: time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC), Name: "Labour Day"},
+			{ID: 2, Country: "HR", Date: time.Date(2024, time.May, 30, 0, 0, 0, 0, time.UTC), Name: "Statehood Day"},
+			{ID: 3, Country: "HR", Date: time.Date(2024, time.June, 22, 0, 0, 0, 0, time.UTC), Name: "Anti-Fascist Struggle Day"},
+		}, nil)
+	store.EXPECT().
+		ListCompanyHolidays(gomock.Any(), gomock.Eq(db.ListCompanyHolidaysParams{
+			CompanyID: testCompanyID,
+			From:      from,
+			To:        to,
+		})).
+		Times(1).
+		Return([]db.CompanyHoliday{
+			{ID: 1, CompanyID: testCompanyID, Date: time.Date(2024, time.May, 31, 0, 0, 0, 0, time.UTC), Name: "Bridge day", DayOff: true},
+			{ID: 2, CompanyID: testCompanyID, Date: time.Date(2024, time.June, 22, 0, 0, 0, 0, time.UTC), Name: "Working day"},
+		}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	query := url.Values{
+		"from": {from.Format(time.RFC3339)},
+		"to":   {to.Format(time.RFC3339)},
+	}
+	url := fmt.Sprintf("/users/%d/holidays?%s", user.ID, query.Encode())
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, user, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got userHolidaysResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Equal(t, user.ID, got.UserID)
+	require.Equal(t, []holiday.Holiday{
+		{Date: time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC), Name: "Labour Day"},
+		{Date: time.Date(2024, time.May, 30, 0, 0, 0, 0, time.UTC), Name: "Statehood Day"},
+		{Date: time.Date(2024, time.May, 31, 0, 0, 0, 0, time.UTC), Name: "Bridge day"},
+	}, got.Holidays)
+}
@@ -0,0 +1,378 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/jackc/pgx/v5"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/invoicing"
+	"github.com/mateoradman/tempus/internal/types"
+)
+
+type createInvoiceRequest struct {
+	ClientID int64     `json:"client_id" binding:"required,min=1"`
+	From     time.Time `json:"from" binding:"required"`
+	To       time.Time `json:"to" binding:"required,gtfield=From"`
+}
+
+// invoiceResponse is an invoice together with its line items.
+type invoiceResponse struct {
+	Invoice db.Invoice       `json:"invoice"`
+	Lines   []db.InvoiceLine `json:"lines"`
+}
+
+func (server *Server) createInvoice(ctx *gin.Context) {
+	var req createInvoiceRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	client, err := server.store.GetClient(ctx, req.ClientID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.DraftInvoiceTxParams{
+		CompanyID: client.CompanyID,
+		ClientID:  client.ID,
+		From:      req.From,
+		To:        req.To,
+	}
+	result, err := server.store.DraftInvoiceTx(ctx, arg)
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrNoInvoiceableEntries),
+			errors.Is(err, db.ErrUnpricedEntries),
+			errors.Is(err, db.ErrMixedCurrencies):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, invoiceResponse(result))
+}
+
+func (server *Server) getInvoice(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	invoice, err := server.store.GetInvoice(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	lines, err := server.store.ListInvoiceLines(ctx, invoice.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, invoiceResponse{Invoice: invoice, Lines: lines})
+}
+
+type listInvoicesRequest struct {
+	PaginationRequest
+	ClientID *int64  `form:"client_id" binding:"omitempty,min=1"`
+	Status   *string `form:"status" binding:"omitempty,invoice_status"`
+}
+
+func (server *Server) listInvoices(ctx *gin.Context) {
+	var req listInvoicesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	companyID, err := server.tenantScope(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	arg := db.ListInvoicesParams{
+		CompanyID: companyID,
+		ClientID:  req.ClientID,
+		Status:    req.Status,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+	}
+	invoices, err := server.store.ListInvoices(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, invoices)
+}
+
+// transitionInvoice returns a handler moving the invoice identified by the `:id` URI parameter to status.
+// Issuing assigns the next number of the company, voiding releases the entries of the invoice.
+func (server *Server) transitionInvoice(status string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req RequestWithID
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		invoice, err := server.store.GetInvoice(ctx, req.ID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		if !types.CanTransitionInvoice(invoice.Status, status) {
+			ctx.JSON(http.StatusConflict, errorResponse(invoiceTransitionError(invoice.Status, status)))
+			return
+		}
+
+		switch status {
+		case types.InvoiceIssued:
+			invoice, err = server.store.IssueInvoiceTx(ctx, invoice.ID)
+		case types.InvoicePaid:
+			invoice, err = server.store.PayInvoice(ctx, invoice.ID)
+		case types.InvoiceVoid:
+			invoice, err = server.store.VoidInvoiceTx(ctx, invoice.ID)
+		}
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				ctx.JSON(http.StatusConflict, errorResponse(errInvoiceStatusChanged))
+				return
+			}
+			if db.ErrorCode(err) == db.UniqueViolation && db.ConstraintName(err) == db.InvoiceNumberConstraint {
+				ctx.JSON(http.StatusConflict, errorResponse(errInvoiceNumberTaken))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusOK, invoice)
+	}
+}
+
+func invoiceTransitionError(from, to string) error {
+	return fmt.Errorf("%w: %s invoice cannot become %s", errInvalidInvoiceTransition, from, to)
+}
+
+func (server *Server) deleteInvoice(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	invoice, err := server.store.GetInvoice(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if invoice.Status != types.InvoiceDraft {
+		ctx.JSON(http.StatusConflict, errorResponse(errInvoiceNotDraft))
+		return
+	}
+
+	// the entries of the draft are released by the foreign key
+	invoice, err = server.store.DeleteInvoice(ctx, invoice.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusConflict, errorResponse(errInvoiceStatusChanged))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, invoice)
+}
+
+func (server *Server) getInvoicePDF(ctx *gin.Context) {
+	doc, ok := server.invoiceDocument(ctx)
+	if !ok {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := invoicing.RenderPDF(&buf, doc); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.pdf"`, invoiceFilename(doc.Invoice)))
+	ctx.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
+func (server *Server) getInvoiceUBL(ctx *gin.Context) {
+	doc, ok := server.invoiceDocument(ctx)
+	if !ok {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := invoicing.RenderUBL(&buf, doc); err != nil {
+		if errors.Is(err, invoicing.ErrDraft) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.xml"`, invoiceFilename(doc.Invoice)))
+	ctx.Data(http.StatusOK, "application/xml", buf.Bytes())
+}
+
+// invoiceDocument loads the invoice identified by the `:id` URI parameter together with its lines, company and client.
+// It writes the error response and returns false if any of them cannot be loaded.
+func (server *Server) invoiceDocument(ctx *gin.Context) (invoicing.Document, bool) {
+	var doc invoicing.Document
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return doc, false
+	}
+
+	var err error
+	doc.Invoice, err = server.store.GetInvoice(ctx, req.ID)
+	if err == nil {
+		doc.Lines, err = server.store.ListInvoiceLines(ctx, doc.Invoice.ID)
+	}
+	if err == nil {
+		doc.Company, err = server.store.GetCompany(ctx, doc.Invoice.CompanyID)
+	}
+	if err == nil {
+		doc.Client, err = server.store.GetClient(ctx, doc.Invoice.ClientID)
+	}
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return doc, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return doc, false
+	}
+	return doc, true
+}
+
+// invoiceFilename names the files an invoice is downloaded as, drafts are named after their ID.
+func invoiceFilename(invoice db.Invoice) string {
+	if invoice.Number == nil {
+		return fmt.Sprintf("invoice-draft-%d", invoice.ID)
+	}
+	return fmt.Sprintf("invoice-%s", *invoice.Number)
+}
+
+func (server *Server) getInvoiceSequence(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	sequence, err := server.store.GetInvoiceSequence(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sequence)
+}
+
+type updateInvoiceSequenceRequest struct {
+	Prefix     string `json:"prefix" binding:"max=32"`
+	LastNumber *int64 `json:"last_number" binding:"required,min=0"`
+}
+
+// updateInvoiceSequence sets the prefix and the last number of the invoices of a company.
+// The next issued invoice is numbered LastNumber + 1.
+func (server *Server) updateInvoiceSequence(ctx *gin.Context) {
+	var reqID RequestWithID
+	var req updateInvoiceSequenceRequest
+	if err := ctx.ShouldBindUri(&reqID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpsertInvoiceSequenceParams{
+		CompanyID:  reqID.ID,
+		Prefix:     req.Prefix,
+		LastNumber: *req.LastNumber,
+	}
+	sequence, err := server.store.UpsertInvoiceSequence(ctx, arg)
+	if err != nil {
+		if db.ErrorCode(err) == db.ForeignKeyViolation {
+			ctx.JSON(http.StatusNotFound, errorResponse(pgx.ErrNoRows))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sequence)
+}
+
+// invoiceCompanyFromURI resolves the company a request acts upon to the company of the invoice
+// identified by the `:id` URI parameter.
+func (server *Server) invoiceCompanyFromURI(ctx *gin.Context) (*int64, error) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		return nil, &requestError{err}
+	}
+	invoice, err := server.store.GetInvoice(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &invoice.CompanyID, nil
+}
+
+// invoiceCompanyFromBody resolves the company a request acts upon to the company of the client
+// the invoice in the request body is drafted for.
+func (server *Server) invoiceCompanyFromBody(ctx *gin.Context) (*int64, error) {
+	var req createInvoiceRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		return nil, &requestError{err}
+	}
+	client, err := server.store.GetClient(ctx, req.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	return &client.CompanyID, nil
+}
@@ -0,0 +1,957 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/token"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func requireBodyMatchInvoice(t *testing.T, body *bytes.Buffer, invoice db.Invoice) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotInvoice db.Invoice
+	err = json.Unmarshal(data, &gotInvoice)
+	require.NoError(t, err)
+	require.Equal(t, invoice, gotInvoice)
+}
+
+func requireBodyMatchInvoiceResponse(t *testing.T, body *bytes.Buffer, response invoiceResponse) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotResponse invoiceResponse
+	err = json.Unmarshal(data, &gotResponse)
+	require.NoError(t, err)
+	require.Equal(t, response, gotResponse)
+}
+
+func randomInvoice(client db.Client) db.Invoice {
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	return db.Invoice{
+		ID:         util.RandomInt(1, 1000),
+		CompanyID:  client.CompanyID,
+		ClientID:   client.ID,
+		Status:     types.InvoiceDraft,
+		Currency:   "EUR",
+		PeriodFrom: from,
+		PeriodTo:   from.AddDate(0, 1, 0),
+		TotalCents: 15000,
+		CreatedAt:  time.Now().UTC(),
+	}
+}
+
+func randomIssuedInvoice(client db.Client) db.Invoice {
+	invoice := randomInvoice(client)
+	issuedAt := time.Now().UTC()
+	invoice.Status = types.InvoiceIssued
+	invoice.Number = util.Pointer(fmt.Sprintf("INV-%05d", util.RandomInt(1, 1000)))
+	invoice.IssuedAt = &issuedAt
+	return invoice
+}
+
+func randomInvoiceLine(invoice db.Invoice) db.InvoiceLine {
+	return db.InvoiceLine{
+		ID:              util.RandomInt(1, 1000),
+		InvoiceID:       invoice.ID,
+		Description:     util.RandomString(10),
+		QuantitySeconds: 5400,
+		RateCents:       10000,
+		AmountCents:     15000,
+	}
+}
+
+func TestCreateInvoiceAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	manager := randomUserWithRole(types.ManagerRole)
+	client := randomClient()
+	otherClient := randomClient()
+	otherClient.CompanyID = testCompanyID + 1
+	invoice := randomInvoice(client)
+	lines := []db.InvoiceLine{randomInvoiceLine(invoice)}
+
+	body := func(clientID int64, from, to time.Time) gin.H {
+		return gin.H{
+			"client_id": clientID,
+			"from":      from,
+			"to":        to,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body(client.ID, invoice.PeriodFrom, invoice.PeriodTo),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetClient(gomock.Any(), gomock.Eq(client.ID)).
+					Times(2).
+					Return(client, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				arg := db.DraftInvoiceTxParams{
+					CompanyID: client.CompanyID,
+					ClientID:  client.ID,
+					From:      invoice.PeriodFrom,
+					To:        invoice.PeriodTo,
+				}
+				store.EXPECT().
+					DraftInvoiceTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.DraftInvoiceTxResult{Invoice: invoice, Lines: lines}, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				requireBodyMatchInvoiceResponse(t, recorder.Body, invoiceResponse{Invoice: invoice, Lines: lines})
+			},
+		},
+		{
+			name: "NoInvoiceableEntries",
+			body: body(client.ID, invoice.PeriodFrom, invoice.PeriodTo),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetClient(gomock.Any(), gomock.Eq(client.ID)).
+					Times(2).
+					Return(client, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					DraftInvoiceTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.DraftInvoiceTxResult{}, db.ErrNoInvoiceableEntries)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "MixedCurrencies",
+			body: body(client.ID, invoice.PeriodFrom, invoice.PeriodTo),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetClient(gomock.Any(), gomock.Eq(client.ID)).
+					Times(2).
+					Return(client, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					DraftInvoiceTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.DraftInvoiceTxResult{}, db.ErrMixedCurrencies)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidPeriod",
+			body: body(client.ID, invoice.PeriodTo, invoice.PeriodFrom),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					DraftInvoiceTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ClientNotFound",
+			body: body(client.ID, invoice.PeriodFrom, invoice.PeriodTo),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetClient(gomock.Any(), gomock.Eq(client.ID)).
+					Times(1).
+					Return(db.Client{}, pgx.ErrNoRows)
+				store.EXPECT().
+					DraftInvoiceTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "OtherCompanyNotFound",
+			body: body(otherClient.ID, invoice.PeriodFrom, invoice.PeriodTo),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetClient(gomock.Any(), gomock.Eq(otherClient.ID)).
+					Times(1).
+					Return(otherClient, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					DraftInvoiceTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden",
+			body: body(client.ID, invoice.PeriodFrom, invoice.PeriodTo),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetClient(gomock.Any(), gomock.Eq(client.ID)).
+					Times(1).
+					Return(client, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					DraftInvoiceTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/invoices", bytes.NewBuffer(jsonData))
+			require.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			tc.setupAuth(t, request, server.tokenMaker)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetInvoiceAPI(t *testing.T) {
+	manager := randomUserWithRole(types.ManagerRole)
+	employee := randomUser()
+	client := randomClient()
+	invoice := randomInvoice(client)
+	lines := []db.InvoiceLine{randomInvoiceLine(invoice), randomInvoiceLine(invoice)}
+
+	testCases := []struct {
+		name          string
+		invoiceID     int64
+		buildStubs    func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			invoiceID: invoice.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetInvoice(gomock.Any(), gomock.Eq(invoice.ID)).
+					Times(2).
+					Return(invoice, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					ListInvoiceLines(gomock.Any(), gomock.Eq(invoice.ID)).
+					Times(1).
+					Return(lines, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchInvoiceResponse(t, recorder.Body, invoiceResponse{Invoice: invoice, Lines: lines})
+			},
+		},
+		{
+			name:      "NotFound",
+			invoiceID: invoice.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetInvoice(gomock.Any(), gomock.Eq(invoice.ID)).
+					Times(1).
+					Return(db.Invoice{}, pgx.ErrNoRows)
+				store.EXPECT().
+					ListInvoiceLines(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "Forbidden",
+			invoiceID: invoice.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetInvoice(gomock.Any(), gomock.Eq(invoice.ID)).
+					Times(1).
+					Return(invoice, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(employee.Username)).
+					Times(1).
+					Return(employee, nil)
+				store.EXPECT().
+					ListInvoiceLines(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, employee, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidID",
+			invoiceID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetInvoice(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/invoices/%d", tc.invoiceID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListInvoicesAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	client := randomClient()
+	invoices := []db.Invoice{randomIssuedInvoice(client), randomIssuedInvoice(client)}
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			query: url.Values{
+				"client_id": {fmt.Sprint(client.ID)},
+				"status":    {types.InvoiceIssued},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				arg := db.ListInvoicesParams{
+					CompanyID: util.Pointer(testCompanyID),
+					ClientID:  &client.ID,
+					Status:    util.Pointer(types.InvoiceIssued),
+					Limit:     10,
+					Offset:    0,
+				}
+				store.EXPECT().
+					ListInvoices(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(invoices, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var gotInvoices []db.Invoice
+				err = json.Unmarshal(data, &gotInvoices)
+				require.NoError(t, err)
+				require.Equal(t, invoices, gotInvoices)
+			},
+		},
+		{
+			name:  "InvalidStatus",
+			query: url.Values{"status": {"overdue"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					ListInvoices(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/invoices?%s", tc.query.Encode())
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestTransitionInvoiceAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	client := randomClient()
+	draft := randomInvoice(client)
+	issued := randomIssuedInvoice(client)
+	issued.ID = draft.ID
+
+	paid := issued
+	paidAt := time.Now().UTC()
+	paid.Status = types.InvoicePaid
+	paid.PaidAt = &paidAt
+
+	voided := issued
+	voidedAt := time.Now().UTC()
+	voided.Status = types.InvoiceVoid
+	voided.VoidedAt = &voidedAt
+
+	testCases := []struct {
+		name          string
+		action        string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "IssueOK",
+			action: "issue",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetInvoice(gomock.Any(), gomock.Eq(draft.ID)).
+					Times(2).
+					Return(draft, nil)
+				store.EXPECT().
+					IssueInvoiceTx(gomock.Any(), gomock.Eq(draft.ID)).
+					Times(1).
+					Return(issued, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchInvoice(t, recorder.Body, issued)
+			},
+		},
+		{
+			name:   "IssueNumberTaken",
+			action: "issue",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetInvoice(gomock.Any(), gomock.Eq(draft.ID)).
+					Times(2).
+					Return(draft, nil)
+				store.EXPECT().
+					IssueInvoiceTx(gomock.Any(), gomock.Eq(draft.ID)).
+					Times(1).
+					Return(db.Invoice{}, &pgconn.PgError{Code: db.UniqueViolation, ConstraintName: db.InvoiceNumberConstraint})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "IssueStatusChanged",
+			action: "issue",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetInvoice(gomock.Any(), gomock.Eq(draft.ID)).
+					Times(2).
+					Return(draft, nil)
+				store.EXPECT().
+					IssueInvoiceTx(gomock.Any(), gomock.Eq(draft.ID)).
+					Times(1).
+					Return(db.Invoice{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "PayOK",
+			action: "pay",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetInvoice(gomock.Any(), gomock.Eq(issued.ID)).
+					Times(2).
+					Return(issued, nil)
+				store.EXPECT().
+					PayInvoice(gomock.Any(), gomock.Eq(issued.ID)).
+					Times(1).
+					Return(paid, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchInvoice(t, recorder.Body, paid)
+			},
+		},
+		{
+			name:   "VoidOK",
+			action: "void",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetInvoice(gomock.Any(), gomock.Eq(issued.ID)).
+					Times(2).
+					Return(issued, nil)
+				store.EXPECT().
+					VoidInvoiceTx(gomock.Any(), gomock.Eq(issued.ID)).
+					Times(1).
+					Return(voided, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchInvoice(t, recorder.Body, voided)
+			},
+		},
+		{
+			name:   "PayDraft",
+			action: "pay",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetInvoice(gomock.Any(), gomock.Eq(draft.ID)).
+					Times(2).
+					Return(draft, nil)
+				store.EXPECT().
+					PayInvoice(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "VoidPaid",
+			action: "void",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetInvoice(gomock.Any(), gomock.Eq(paid.ID)).
+					Times(2).
+					Return(paid, nil)
+				store.EXPECT().
+					VoidInvoiceTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+				Times(1).
+				Return(admin, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/invoices/%d/%s", draft.ID, tc.action)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeleteInvoiceAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	client := randomClient()
+	draft := randomInvoice(client)
+	issued := randomIssuedInvoice(client)
+	issued.ID = draft.ID
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetInvoice(gomock.Any(), gomock.Eq(draft.ID)).
+					Times(2).
+					Return(draft, nil)
+				store.EXPECT().
+					DeleteInvoice(gomock.Any(), gomock.Eq(draft.ID)).
+					Times(1).
+					Return(draft, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchInvoice(t, recorder.Body, draft)
+			},
+		},
+		{
+			name: "Issued",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetInvoice(gomock.Any(), gomock.Eq(issued.ID)).
+					Times(2).
+					Return(issued, nil)
+				store.EXPECT().
+					DeleteInvoice(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+				Times(1).
+				Return(admin, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/invoices/%d", draft.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetInvoiceDocumentAPI(t *testing.T) {
+	manager := randomUserWithRole(types.ManagerRole)
+	client := randomClient()
+	company := db.Company{ID: testCompanyID, Name: util.RandomString(10)}
+	draft := randomInvoice(client)
+	issued := randomIssuedInvoice(client)
+	issued.ID = draft.ID
+	lines := []db.InvoiceLine{randomInvoiceLine(issued)}
+
+	documentStubs := func(store *mockdb.MockStore, invoice db.Invoice) {
+		store.EXPECT().
+			GetInvoice(gomock.Any(), gomock.Eq(invoice.ID)).
+			Times(2).
+			Return(invoice, nil)
+		store.EXPECT().
+			ListInvoiceLines(gomock.Any(), gomock.Eq(invoice.ID)).
+			Times(1).
+			Return(lines, nil)
+		store.EXPECT().
+			GetCompany(gomock.Any(), gomock.Eq(invoice.CompanyID)).
+			Times(1).
+			Return(company, nil)
+		store.EXPECT().
+			GetClient(gomock.Any(), gomock.Eq(invoice.ClientID)).
+			Times(1).
+			Return(client, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		format        string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "PDFOK",
+			format: "pdf",
+			buildStubs: func(store *mockdb.MockStore) {
+				documentStubs(store, issued)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/pdf", recorder.Header().Get("Content-Type"))
+				require.Contains(t, recorder.Header().Get("Content-Disposition"), *issued.Number)
+				require.True(t, bytes.HasPrefix(recorder.Body.Bytes(), []byte("%PDF-")))
+			},
+		},
+		{
+			name:   "DraftPDFOK",
+			format: "pdf",
+			buildStubs: func(store *mockdb.MockStore) {
+				documentStubs(store, draft)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/pdf", recorder.Header().Get("Content-Type"))
+			},
+		},
+		{
+			name:   "UBLOK",
+			format: "ubl",
+			buildStubs: func(store *mockdb.MockStore) {
+				documentStubs(store, issued)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/xml", recorder.Header().Get("Content-Type"))
+				require.Contains(t, recorder.Body.String(), fmt.Sprintf("<cbc:ID>%s</cbc:ID>", *issued.Number))
+			},
+		},
+		{
+			name:   "DraftUBL",
+			format: "ubl",
+			buildStubs: func(store *mockdb.MockStore) {
+				documentStubs(store, draft)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+				Times(1).
+				Return(manager, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/invoices/%d/%s", draft.ID, tc.format)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestUpdateInvoiceSequenceAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	sequence := db.InvoiceSequence{
+		CompanyID:  testCompanyID,
+		Prefix:     "ACME-",
+		LastNumber: 41,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"prefix":      sequence.Prefix,
+				"last_number": sequence.LastNumber,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpsertInvoiceSequenceParams{
+					CompanyID:  sequence.CompanyID,
+					Prefix:     sequence.Prefix,
+					LastNumber: sequence.LastNumber,
+				}
+				store.EXPECT().
+					UpsertInvoiceSequence(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(sequence, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var gotSequence db.InvoiceSequence
+				err = json.Unmarshal(data, &gotSequence)
+				require.NoError(t, err)
+				require.Equal(t, sequence, gotSequence)
+			},
+		},
+		{
+			name: "MissingLastNumber",
+			body: gin.H{
+				"prefix": sequence.Prefix,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertInvoiceSequence(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+				Times(1).
+				Return(admin, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/companies/%d/invoice-sequence", testCompanyID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(jsonData))
+			require.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
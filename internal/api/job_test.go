@@ -0,0 +1,228 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func randomJob(status string) db.Job {
+	return db.Job{
+		ID:          util.RandomInt(1, 1000),
+		Kind:        "webhooks.dispatch",
+		Payload:     []byte(`{"id":42}`),
+		Status:      status,
+		Attempts:    10,
+		MaxAttempts: 10,
+		RunAt:       time.Now().UTC(),
+		LastError:   util.Pointer("failure"),
+		CreatedAt:   time.Now().UTC(),
+	}
+}
+
+func TestListJobsAPI(t *testing.T) {
+	superuser := randomSuperuser()
+	admin := randomUserWithRole(types.AdminRole)
+	job := randomJob(types.JobDead)
+
+	testCases := []struct {
+		name          string
+		actor         db.User
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			actor: superuser,
+			query: "status=dead&kind=webhooks.dispatch",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(superuser.Username)).
+					Times(1).
+					Return(superuser, nil)
+				store.EXPECT().
+					ListJobs(gomock.Any(), gomock.Eq(db.ListJobsParams{
+						Status: util.Pointer(types.JobDead),
+						Kind:   util.Pointer("webhooks.dispatch"),
+						Limit:  10,
+					})).
+					Times(1).
+					Return([]db.Job{job}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []jobResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got, 1)
+				require.JSONEq(t, `{"id":42}`, string(got[0].Payload))
+			},
+		},
+		{
+			name:  "InvalidStatus",
+			actor: superuser,
+			query: "status=exploded",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(superuser.Username)).
+					Times(1).
+					Return(superuser, nil)
+				store.EXPECT().
+					ListJobs(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Forbidden",
+			actor: admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					ListJobs(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/jobs?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, tc.actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestRetryJobAPI(t *testing.T) {
+	superuser := randomSuperuser()
+	dead := randomJob(types.JobDead)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(superuser.Username)).
+					Times(1).
+					Return(superuser, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(dead.ID)).
+					Times(1).
+					Return(dead, nil)
+				requeued := dead
+				requeued.Status = types.JobPending
+				requeued.Attempts = 0
+				store.EXPECT().
+					RequeueDeadJob(gomock.Any(), gomock.Eq(dead.ID)).
+					Times(1).
+					Return(requeued, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got jobResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, types.JobPending, got.Status)
+				require.Zero(t, got.Attempts)
+			},
+		},
+		{
+			name: "NotDead",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(superuser.Username)).
+					Times(1).
+					Return(superuser, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(dead.ID)).
+					Times(1).
+					Return(randomJob(types.JobSucceeded), nil)
+				store.EXPECT().
+					RequeueDeadJob(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(superuser.Username)).
+					Times(1).
+					Return(superuser, nil)
+				store.EXPECT().
+					GetJob(gomock.Any(), gomock.Eq(dead.ID)).
+					Times(1).
+					Return(db.Job{}, pgx.ErrNoRows)
+				store.EXPECT().
+					RequeueDeadJob(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/jobs/%d/retry", dead.ID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, superuser, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/leave"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/worktime"
+)
+
+type createLeaveEntitlementRequest struct {
+	AbsenceTypeID int64 `json:"absence_type_id" binding:"required,min=1"`
+	FromYear      int32 `json:"from_year" binding:"required,min=1900,max=9999"`
+	Days          int32 `json:"days" binding:"min=0,max=366"`
+}
+
+// createLeaveEntitlement grants a user annual days of an absence type from a year on.
+func (server *Server) createLeaveEntitlement(ctx *gin.Context) {
+	var reqID RequestWithID
+	var req createLeaveEntitlementRequest
+	if err := ctx.ShouldBindUri(&reqID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.GetUser(ctx, reqID.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if _, ok := server.companyAbsenceType(ctx, req.AbsenceTypeID, user.CompanyID); !ok {
+		return
+	}
+
+	arg := db.CreateLeaveEntitlementParams{
+		UserID:        user.ID,
+		AbsenceTypeID: req.AbsenceTypeID,
+		FromYear:      req.FromYear,
+		Days:          req.Days,
+	}
+	entitlement, err := server.store.CreateLeaveEntitlement(ctx, arg)
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation && db.ConstraintName(err) == db.LeaveEntitlementYearConstraint {
+			ctx.JSON(http.StatusConflict, errorResponse(errEntitlementYearTaken))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, entitlement)
+}
+
+func (server *Server) listUserLeaveEntitlements(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	entitlements, err := server.store.ListUserLeaveEntitlements(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entitlements)
+}
+
+type userLeaveEntitlementRequest struct {
+	ID            int64 `uri:"id" binding:"required,min=1"`
+	EntitlementID int64 `uri:"entitlement_id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteLeaveEntitlement(ctx *gin.Context) {
+	var req userLeaveEntitlementRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	entitlement, err := server.store.GetLeaveEntitlement(ctx, req.EntitlementID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if entitlement.UserID != req.ID {
+		ctx.JSON(http.StatusNotFound, errorResponse(errEntitlementOutsideUser))
+		return
+	}
+
+	entitlement, err = server.store.DeleteLeaveEntitlement(ctx, entitlement.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entitlement)
+}
+
+type leaveBalanceRequest struct {
+	Date *time.Time `form:"date"`
+}
+
+// leaveBalanceResponse holds the balances of the absence types of the company of a user which track a balance
+type leaveBalanceResponse struct {
+	UserID   int64           `json:"user_id"`
+	Date     time.Time       `json:"date"`
+	Balances []leave.Balance `json:"balances"`
+}
+
+func (server *Server) getUserLeaveBalance(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.GetUser(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.leaveBalanceOfUser(ctx, user)
+}
+
+// leaveBalanceOfUser writes the leave balances of the user as of the date in the query, today by default.
+func (server *Server) leaveBalanceOfUser(ctx *gin.Context, user db.User) {
+	var req leaveBalanceRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	location, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	day := worktime.Date(time.Now().In(location))
+	if req.Date != nil {
+		day = worktime.Date(*req.Date)
+	}
+	calendar, entitlements, err := server.leaveCalendar(ctx, user, day)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response := leaveBalanceResponse{
+		UserID:   user.ID,
+		Date:     day,
+		Balances: []leave.Balance{},
+	}
+	if user.CompanyID == nil {
+		ctx.JSON(http.StatusOK, response)
+		return
+	}
+
+	absenceTypes, err := server.store.ListTrackedAbsenceTypes(ctx, *user.CompanyID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	for _, absenceType := range absenceTypes {
+		usage, err := server.leaveUsage(ctx, calendar, user.ID, absenceType.ID, 0)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		policy := leavePolicy(user, calendar, absenceType, entitlements)
+		response.Balances = append(response.Balances, policy.Balance(day, usage))
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// leaveCalendar loads the work calendar the leave of the user is counted in working days of, with the holidays until
+// the end of the year of until, and their entitlements.
+func (server *Server) leaveCalendar(ctx *gin.Context, user db.User, until time.Time) (worktime.Calendar, []db.LeaveEntitlement, error) {
+	location, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		return worktime.Calendar{}, nil, err
+	}
+	calendar, err := worktime.LoadCalendar(ctx, server.store, user, location, time.Date(until.Year()+1, time.January, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return calendar, nil, err
+	}
+	entitlements, err := server.store.ListUserLeaveEntitlements(ctx, user.ID)
+	if err != nil {
+		return calendar, nil, err
+	}
+	return calendar, entitlements, nil
+}
+
+// leaveUsage returns the working days taken by the approved and pending absences of the user of an absence type,
+// except the absence with the ID exclude.
+func (server *Server) leaveUsage(ctx *gin.Context, calendar worktime.Calendar, userID, absenceTypeID, exclude int64) ([]leave.Usage, error) {
+	absences, err := server.store.ListUserLeave(ctx, db.ListUserLeaveParams{
+		UserID:        userID,
+		AbsenceTypeID: absenceTypeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var usage []leave.Usage
+	for _, absence := range absences {
+		if absence.ID == exclude || absence.EndTime == nil {
+			continue
+		}
+		for _, day := range calendar.WorkingDays(absence.Kind, absence.StartTime, *absence.EndTime) {
+			usage = append(usage, leave.Usage{Day: day, Pending: absence.Status == types.AbsencePending})
+		}
+	}
+	return usage, nil
+}
+
+func leavePolicy(user db.User, calendar worktime.Calendar, absenceType db.AbsenceType, entitlements []db.LeaveEntitlement) leave.Policy {
+	return leave.Policy{
+		AbsenceType:  absenceType,
+		Joined:       worktime.Date(user.CreatedAt.In(calendar.Location)),
+		Entitlements: entitlements,
+	}
+}
+
+// validLeave checks that the remaining leave of the user covers an absence of the absence type and kind within
+// [start, end), disregarding the absence with the ID exclude. It writes the error response and returns false otherwise.
+func (server *Server) validLeave(ctx *gin.Context, user db.User, absenceType db.AbsenceType, kind string, start, end time.Time, exclude int64) bool {
+	calendar, entitlements, err := server.leaveCalendar(ctx, user, end)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+	usage, err := server.leaveUsage(ctx, calendar, user.ID, absenceType.ID, exclude)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	policy := leavePolicy(user, calendar, absenceType, entitlements)
+	if err := policy.Check(calendar.WorkingDays(kind, start, end), usage); err != nil {
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+		return false
+	}
+	return true
+}
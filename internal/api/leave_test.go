@@ -0,0 +1,404 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// buildLeaveCalendarStubs expects the work calendar of a user without work schedules and holidays to be loaded
+func buildLeaveCalendarStubs(store *mockdb.MockStore, user db.User, entitlements []db.LeaveEntitlement) {
+	store.EXPECT().
+		GetCompany(gomock.Any(), gomock.Eq(*user.CompanyID)).
+		Times(1).
+		Return(db.Company{ID: *user.CompanyID}, nil)
+	store.EXPECT().
+		ListUserWorkScheduleAssignments(gomock.Any(), gomock.Eq(user.ID)).
+		Times(1).
+		Return([]db.WorkScheduleAssignment{}, nil)
+	store.EXPECT().
+		ListCompanyHolidays(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.CompanyHoliday{}, nil)
+	store.EXPECT().
+		ListUserLeaveEntitlements(gomock.Any(), gomock.Eq(user.ID)).
+		Times(1).
+		Return(entitlements, nil)
+}
+
+func TestCreateAbsenceOfTypeAPI(t *testing.T) {
+	user := randomUser()
+	user.CreatedAt = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	vacation := randomAbsenceType(true)
+	sickLeave := randomAbsenceType(false)
+	otherVacation := randomAbsenceType(true)
+	otherVacation.CompanyID = testCompanyID + 1
+
+	// Monday until Wednesday
+	start := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.March, 6, 0, 0, 0, 0, time.UTC)
+	taken := db.Absence{
+		ID:            util.RandomInt(1, 1000),
+		UserID:        user.ID,
+		StartTime:     time.Date(2024, time.January, 8, 0, 0, 0, 0, time.UTC),
+		EndTime:       util.Pointer(time.Date(2024, time.January, 13, 0, 0, 0, 0, time.UTC)),
+		Status:        types.AbsenceApproved,
+		AbsenceTypeID: &vacation.ID,
+	}
+
+	bodyOfType := func(absenceTypeID int64, end *time.Time) gin.H {
+		return gin.H{
+			"user_id":         user.ID,
+			"absence_type_id": absenceTypeID,
+			"start_time":      start,
+			"end_time":        end,
+			"reason":          util.RandomString(10),
+			"paid":            false,
+		}
+	}
+	entitlement := func(days int32) []db.LeaveEntitlement {
+		return []db.LeaveEntitlement{{UserID: user.ID, AbsenceTypeID: vacation.ID, FromYear: 2024, Days: days}}
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: bodyOfType(vacation.ID, &end),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAbsenceType(gomock.Any(), gomock.Eq(vacation.ID)).
+					Times(1).
+					Return(vacation, nil)
+				buildLeaveCalendarStubs(store, user, entitlement(7))
+				store.EXPECT().
+					ListUserLeave(gomock.Any(), gomock.Eq(db.ListUserLeaveParams{UserID: user.ID, AbsenceTypeID: vacation.ID})).
+					Times(1).
+					Return([]db.Absence{taken}, nil)
+				store.EXPECT().
+					CreateAbsence(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateAbsenceParams) (db.Absence, error) {
+						require.True(t, arg.Paid)
+						require.Equal(t, &vacation.ID, arg.AbsenceTypeID)
+						return db.Absence{UserID: user.ID, AbsenceTypeID: arg.AbsenceTypeID}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "InsufficientBalance",
+			body: bodyOfType(vacation.ID, &end),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAbsenceType(gomock.Any(), gomock.Eq(vacation.ID)).
+					Times(1).
+					Return(vacation, nil)
+				buildLeaveCalendarStubs(store, user, entitlement(6))
+				store.EXPECT().
+					ListUserLeave(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Absence{taken}, nil)
+				store.EXPECT().
+					CreateAbsence(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "EndRequired",
+			body: bodyOfType(vacation.ID, nil),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAbsenceType(gomock.Any(), gomock.Eq(vacation.ID)).
+					Times(1).
+					Return(vacation, nil)
+				store.EXPECT().
+					CreateAbsence(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UntrackedType",
+			body: bodyOfType(sickLeave.ID, nil),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAbsenceType(gomock.Any(), gomock.Eq(sickLeave.ID)).
+					Times(1).
+					Return(sickLeave, nil)
+				store.EXPECT().
+					ListUserLeave(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					CreateAbsence(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateAbsenceParams) (db.Absence, error) {
+						require.True(t, arg.Paid)
+						return db.Absence{UserID: user.ID, AbsenceTypeID: arg.AbsenceTypeID}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "TypeOutsideCompany",
+			body: bodyOfType(otherVacation.ID, &end),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAbsenceType(gomock.Any(), gomock.Eq(otherVacation.ID)).
+					Times(1).
+					Return(otherVacation, nil)
+				store.EXPECT().
+					CreateAbsence(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(2).
+				Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/absences", bytes.NewBuffer(jsonData))
+			require.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, user, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetUserLeaveBalanceAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	user := randomUser()
+	user.CreatedAt = time.Date(2024, time.July, 15, 9, 0, 0, 0, time.UTC)
+	vacation := randomAbsenceType(true)
+	vacation.Accrual = types.AccrualMonthly
+	entitlements := []db.LeaveEntitlement{{UserID: user.ID, AbsenceTypeID: vacation.ID, FromYear: 2024, Days: 24}}
+	pending := db.Absence{
+		ID:            util.RandomInt(1, 1000),
+		UserID:        user.ID,
+		StartTime:     time.Date(2024, time.August, 5, 8, 0, 0, 0, time.UTC),
+		EndTime:       util.Pointer(time.Date(2024, time.August, 5, 12, 0, 0, 0, time.UTC)),
+		Status:        types.AbsencePending,
+		AbsenceTypeID: &vacation.ID,
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+		Times(1).
+		Return(admin, nil)
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.ID)).
+		Times(2).
+		Return(user, nil)
+	buildLeaveCalendarStubs(store, user, entitlements)
+	store.EXPECT().
+		ListTrackedAbsenceTypes(gomock.Any(), gomock.Eq(testCompanyID)).
+		Times(1).
+		Return([]db.AbsenceType{vacation}, nil)
+	store.EXPECT().
+		ListUserLeave(gomock.Any(), gomock.Eq(db.ListUserLeaveParams{UserID: user.ID, AbsenceTypeID: vacation.ID})).
+		Times(1).
+		Return([]db.Absence{pending}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/users/%d/leave-balance?date=2024-09-10T00:00:00Z", user.ID)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got leaveBalanceResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Equal(t, user.ID, got.UserID)
+	require.Len(t, got.Balances, 1)
+
+	balance := got.Balances[0]
+	require.Equal(t, vacation.ID, balance.AbsenceTypeID)
+	require.Equal(t, 2024, balance.Year)
+	// the user joined in July, so half of the entitlement applies and three months have accrued
+	require.Equal(t, 12.0, balance.EntitlementDays)
+	require.Equal(t, 6.0, balance.AccruedDays)
+	require.Equal(t, 0.5, balance.PendingDays)
+	require.Equal(t, 5.5, balance.RemainingDays)
+}
+
+func TestCreateLeaveEntitlementAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	user := randomUser()
+	vacation := randomAbsenceType(true)
+	otherVacation := randomAbsenceType(true)
+	otherVacation.CompanyID = testCompanyID + 1
+	entitlement := db.LeaveEntitlement{
+		ID:            util.RandomInt(1, 1000),
+		UserID:        user.ID,
+		AbsenceTypeID: vacation.ID,
+		FromYear:      2024,
+		Days:          25,
+	}
+	arg := db.CreateLeaveEntitlementParams{
+		UserID:        user.ID,
+		AbsenceTypeID: vacation.ID,
+		FromYear:      2024,
+		Days:          25,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"absence_type_id": vacation.ID, "from_year": 2024, "days": 25},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAbsenceType(gomock.Any(), gomock.Eq(vacation.ID)).
+					Times(1).
+					Return(vacation, nil)
+				store.EXPECT().
+					CreateLeaveEntitlement(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(entitlement, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var got db.LeaveEntitlement
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, entitlement, got)
+			},
+		},
+		{
+			name: "YearTaken",
+			body: gin.H{"absence_type_id": vacation.ID, "from_year": 2024, "days": 25},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAbsenceType(gomock.Any(), gomock.Eq(vacation.ID)).
+					Times(1).
+					Return(vacation, nil)
+				store.EXPECT().
+					CreateLeaveEntitlement(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.LeaveEntitlement{}, &pgconn.PgError{
+						Code:           db.UniqueViolation,
+						ConstraintName: db.LeaveEntitlementYearConstraint,
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "TypeOutsideCompany",
+			body: gin.H{"absence_type_id": otherVacation.ID, "from_year": 2024, "days": 25},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAbsenceType(gomock.Any(), gomock.Eq(otherVacation.ID)).
+					Times(1).
+					Return(otherVacation, nil)
+				store.EXPECT().
+					CreateLeaveEntitlement(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+				Times(1).
+				Return(admin, nil)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(2).
+				Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/users/%d/leave-entitlements", user.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
+			require.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/util"
+)
+
+// getMe returns the authenticated user.
+func (server *Server) getMe(ctx *gin.Context) {
+	user, err := server.authUser(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newUserResponse(user))
+}
+
+// updateMe updates the profile of the authenticated user.
+func (server *Server) updateMe(ctx *gin.Context) {
+	var req updateUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := server.authUser(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	server.updateUserProfile(ctx, user.ID, req)
+}
+
+// listMyEntries lists the entries of the authenticated user.
+func (server *Server) listMyEntries(ctx *gin.Context) {
+	user, err := server.authUser(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	server.listEntriesOfUser(ctx, user.ID)
+}
+
+// listMyAbsences lists the absences of the authenticated user.
+func (server *Server) listMyAbsences(ctx *gin.Context) {
+	user, err := server.authUser(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	server.listAbsencesOfUser(ctx, user.ID)
+}
+
+// listMyTimesheets lists the timesheets of the authenticated user.
+func (server *Server) listMyTimesheets(ctx *gin.Context) {
+	user, err := server.authUser(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	server.listTimesheetsOfUser(ctx, user.ID)
+}
+
+type changePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6"`
+}
+
+// changeMyPassword replaces the password of the authenticated user after verifying the current one.
+// Every existing session of the user is revoked and the caller receives the tokens of a new session,
+// so only the client which changed the password stays logged in.
+func (server *Server) changeMyPassword(ctx *gin.Context) {
+	var req changePasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := server.authUser(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	if err := util.CheckPassword(req.CurrentPassword, user.Password); err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errWrongPassword))
+		return
+	}
+
+	hashedPassword, err := util.HashPassword(req.NewPassword)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	resp, session, err := server.newUserSession(ctx, user)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.ChangePasswordTx(ctx, db.ChangePasswordTxParams{
+		UserID:              user.ID,
+		Password:            hashedPassword,
+		CreateSessionParams: session,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	resp.SessionID = result.Session.ID
+	resp.User = newUserResponse(result.User)
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// getMyWorkBalance returns the work balance of the authenticated user.
+func (server *Server) getMyWorkBalance(ctx *gin.Context) {
+	user, err := server.authUser(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	server.workBalanceOfUser(ctx, user)
+}
+
+func (server *Server) getMyLeaveBalance(ctx *gin.Context) {
+	user, err := server.authUser(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	server.leaveBalanceOfUser(ctx, user)
+}
+
+func (server *Server) getMyHolidays(ctx *gin.Context) {
+	user, err := server.authUser(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	server.holidaysOfUser(ctx, user)
+}
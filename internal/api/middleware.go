@@ -6,7 +6,10 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
 	"github.com/mateoradman/tempus/internal/token"
+	"github.com/mateoradman/tempus/internal/types"
 	"github.com/mateoradman/tempus/internal/util"
 )
 
@@ -44,5 +47,299 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 		ctx.Set(util.AuthPayloadKey, payload)
 		ctx.Next()
 	}
+}
+
+// subjectResolver returns the ID of the user a request acts upon.
+type subjectResolver func(ctx *gin.Context) (int64, error)
+
+// policy reports whether the authenticated user is allowed to access a route.
+type policy func(ctx *gin.Context, access *accessRequest) (bool, error)
+
+// authActorKey is the context key under which the authenticated user is cached.
+const authActorKey = "authorization_actor"
+
+// accessRequest lazily loads the users needed to evaluate the policies of a route,
+// so that every user is fetched at most once per request.
+type accessRequest struct {
+	store     db.Store
+	payload   *token.Payload
+	resolve   subjectResolver
+	subjectID *int64
+	subject   *db.User
+}
+
+// getActor returns the authenticated user.
+func (access *accessRequest) getActor(ctx *gin.Context) (db.User, error) {
+	if actor, ok := ctx.Get(authActorKey); ok {
+		return actor.(db.User), nil
+	}
+
+	actor, err := access.store.GetUserByUsername(ctx, access.payload.Username)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.User{}, errUnknownActor
+		}
+		return db.User{}, err
+	}
+	ctx.Set(authActorKey, actor)
+	// restrict the queries of the rest of the request to the company of the actor
+	if !isSuperuser(actor) && actor.CompanyID != nil {
+		ctx.Set(db.TenantKey, *actor.CompanyID)
+	}
+	return actor, nil
+}
+
+// getSubjectID returns the ID of the user the request acts upon.
+func (access *accessRequest) getSubjectID(ctx *gin.Context) (int64, error) {
+	if access.subjectID == nil {
+		if access.resolve == nil {
+			return 0, errors.New("route has no subject resolver")
+		}
+		subjectID, err := access.resolve(ctx)
+		if err != nil {
+			return 0, err
+		}
+		access.subjectID = &subjectID
+	}
+	return *access.subjectID, nil
+}
+
+// getSubject returns the user the request acts upon.
+func (access *accessRequest) getSubject(ctx *gin.Context) (db.User, error) {
+	if access.subject == nil {
+		subjectID, err := access.getSubjectID(ctx)
+		if err != nil {
+			return db.User{}, err
+		}
+		subject, err := access.store.GetUser(ctx, subjectID)
+		if err != nil {
+			return db.User{}, err
+		}
+		access.subject = &subject
+	}
+	return *access.subject, nil
+}
+
+// hasRole allows users with one of the provided roles.
+func hasRole(roles ...string) policy {
+	return func(ctx *gin.Context, access *accessRequest) (bool, error) {
+		actor, err := access.getActor(ctx)
+		if err != nil {
+			return false, err
+		}
+		if isSuperuser(actor) {
+			return true, nil
+		}
+		for _, role := range roles {
+			if actor.Role == role {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+// adminOnly allows administrators.
+var adminOnly = hasRole(types.AdminRole)
+
+// superuserOnly allows the platform superuser.
+var superuserOnly policy = func(ctx *gin.Context, access *accessRequest) (bool, error) {
+	actor, err := access.getActor(ctx)
+	if err != nil {
+		return false, err
+	}
+	return isSuperuser(actor), nil
+}
+
+// selfOnly allows users acting upon themselves.
+var selfOnly policy = func(ctx *gin.Context, access *accessRequest) (bool, error) {
+	subjectID, err := access.getSubjectID(ctx)
+	if err != nil {
+		return false, err
+	}
+	actor, err := access.getActor(ctx)
+	if err != nil {
+		return false, err
+	}
+	return actor.ID == subjectID, nil
+}
+
+// managerOfSubject allows managers acting upon users they manage directly or through a team.
+var managerOfSubject policy = func(ctx *gin.Context, access *accessRequest) (bool, error) {
+	actor, err := access.getActor(ctx)
+	if err != nil {
+		return false, err
+	}
+	if actor.Role != types.ManagerRole {
+		return false, nil
+	}
+	subject, err := access.getSubject(ctx)
+	if err != nil {
+		return false, err
+	}
+	return manages(ctx, access.store, actor, subject)
+}
+
+// manages reports whether the manager is the direct manager of the user or the manager of the user's team.
+func manages(ctx *gin.Context, store db.Store, manager db.User, user db.User) (bool, error) {
+	if user.ManagerID != nil && *user.ManagerID == manager.ID {
+		return true, nil
+	}
+	if user.TeamID == nil {
+		return false, nil
+	}
+	team, err := store.GetTeam(ctx, *user.TeamID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return team.ManagerID != nil && *team.ManagerID == manager.ID, nil
+}
+
+// authorize returns a middleware which allows the request if any of the policies grants access
+// and aborts with 403 Forbidden otherwise. Subject policies use the resolver to find the affected user.
+// Subjects outside of the company of the authenticated user are reported as missing.
+func (server *Server) authorize(resolve subjectResolver, policies ...policy) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		access := &accessRequest{
+			store:   server.store,
+			payload: authPayload(ctx),
+			resolve: resolve,
+		}
+
+		if resolve != nil {
+			inTenant, err := access.subjectInTenant(ctx)
+			if err != nil {
+				ctx.AbortWithStatusJSON(authorizationErrorStatus(err), errorResponse(err))
+				return
+			}
+			if !inTenant {
+				ctx.AbortWithStatusJSON(http.StatusNotFound, errorResponse(pgx.ErrNoRows))
+				return
+			}
+		}
+
+		for _, allows := range policies {
+			allowed, err := allows(ctx, access)
+			if err != nil {
+				ctx.AbortWithStatusJSON(authorizationErrorStatus(err), errorResponse(err))
+				return
+			}
+			if allowed {
+				ctx.Next()
+				return
+			}
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(errForbidden))
+	}
+}
+
+// subjectInTenant reports whether the subject of the request belongs to the company of the actor.
+// The superuser acts upon users of every company and users without a company only upon themselves.
+func (access *accessRequest) subjectInTenant(ctx *gin.Context) (bool, error) {
+	// invalid requests are rejected before loading any user
+	if _, err := access.getSubjectID(ctx); err != nil {
+		return false, err
+	}
+	actor, err := access.getActor(ctx)
+	if err != nil {
+		return false, err
+	}
+	if isSuperuser(actor) {
+		return true, nil
+	}
+	subject, err := access.getSubject(ctx)
+	if err != nil {
+		return false, err
+	}
+	return actor.ID == subject.ID || sameCompany(actor.CompanyID, subject.CompanyID), nil
+}
+
+// companyResolver returns the ID of the company owning the resource a request acts upon.
+type companyResolver func(ctx *gin.Context) (*int64, error)
+
+// inTenant returns a middleware which aborts with 404 Not Found when the resource of the request
+// does not belong to the company of the authenticated user. The superuser is not restricted.
+func (server *Server) inTenant(resolve companyResolver) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		actor, err := server.authUser(ctx)
+		if err != nil {
+			ctx.AbortWithStatusJSON(authorizationErrorStatus(err), errorResponse(err))
+			return
+		}
+		if isSuperuser(actor) {
+			ctx.Next()
+			return
+		}
 
+		companyID, err := resolve(ctx)
+		if err != nil {
+			ctx.AbortWithStatusJSON(authorizationErrorStatus(err), errorResponse(err))
+			return
+		}
+		if !sameCompany(actor.CompanyID, companyID) {
+			ctx.AbortWithStatusJSON(http.StatusNotFound, errorResponse(pgx.ErrNoRows))
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// tenantScope returns the company the listings of the authenticated user are restricted to,
+// or nil for the superuser who lists resources of every company.
+func (server *Server) tenantScope(ctx *gin.Context) (*int64, error) {
+	actor, err := server.authUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if isSuperuser(actor) {
+		return nil, nil
+	}
+	if actor.CompanyID == nil {
+		return nil, errNoCompany
+	}
+	return actor.CompanyID, nil
+}
+
+// isSuperuser reports whether the user is the platform superuser.
+func isSuperuser(user db.User) bool {
+	return user.Role == types.SuperuserRole
+}
+
+// sameCompany reports whether both company IDs are set and equal.
+func sameCompany(a, b *int64) bool {
+	return a != nil && b != nil && *a == *b
+}
+
+// authorizationErrorStatus maps errors raised while evaluating policies to HTTP status codes.
+func authorizationErrorStatus(err error) int {
+	var reqErr *requestError
+	switch {
+	case errors.As(err, &reqErr):
+		return http.StatusBadRequest
+	case errors.Is(err, errUnknownActor):
+		return http.StatusUnauthorized
+	case errors.Is(err, errNoCompany):
+		return http.StatusForbidden
+	case errors.Is(err, pgx.ErrNoRows):
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// authPayload returns the token payload set by the auth middleware.
+func authPayload(ctx *gin.Context) *token.Payload {
+	return ctx.MustGet(util.AuthPayloadKey).(*token.Payload)
+}
+
+// authUser returns the authenticated user, reusing the one loaded while authorizing the request.
+func (server *Server) authUser(ctx *gin.Context) (db.User, error) {
+	access := &accessRequest{
+		store:   server.store,
+		payload: authPayload(ctx),
+	}
+	return access.getActor(ctx)
 }
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+)
+
+type listNotificationsRequest struct {
+	PaginationRequest
+	Unread *bool `form:"unread"`
+}
+
+type notificationURIRequest struct {
+	ID             int64 `uri:"id" binding:"required,min=1"`
+	NotificationID int64 `uri:"notification_id" binding:"required,min=1"`
+}
+
+// notificationResponse describes a notification with its data as JSON
+type notificationResponse struct {
+	ID        int64           `json:"id"`
+	UserID    int64           `json:"user_id"`
+	Kind      string          `json:"kind"`
+	Data      json.RawMessage `json:"data"`
+	ReadAt    *time.Time      `json:"read_at"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func newNotificationResponse(notification db.Notification) notificationResponse {
+	return notificationResponse{
+		ID:        notification.ID,
+		UserID:    notification.UserID,
+		Kind:      notification.Kind,
+		Data:      notification.Data,
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+}
+
+// listUserNotifications returns the notifications of a user, latest notifications first
+func (server *Server) listUserNotifications(ctx *gin.Context) {
+	var reqID RequestWithID
+	var req listNotificationsRequest
+	if err := ctx.ShouldBindUri(&reqID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	notifications, err := server.store.ListUserNotifications(ctx, db.ListUserNotificationsParams{
+		UserID: reqID.ID,
+		Unread: req.Unread,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response := make([]notificationResponse, len(notifications))
+	for i, notification := range notifications {
+		response[i] = newNotificationResponse(notification)
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// readUserNotification marks a notification of a user as read
+func (server *Server) readUserNotification(ctx *gin.Context) {
+	var req notificationURIRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	notification, err := server.store.ReadNotification(ctx, db.ReadNotificationParams{
+		ID:     req.NotificationID,
+		UserID: req.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newNotificationResponse(notification))
+}
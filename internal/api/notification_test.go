@@ -0,0 +1,174 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestListUserNotificationsAPI(t *testing.T) {
+	user := randomUser()
+	admin := randomUserWithRole(types.AdminRole)
+	notification := db.Notification{
+		ID:        util.RandomInt(1, 1000),
+		UserID:    user.ID,
+		Kind:      types.NotificationEntryAutoClosed,
+		Data:      []byte(`{"entry_id":7}`),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	testCases := []struct {
+		name          string
+		actor         db.User
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			actor: user,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListUserNotifications(gomock.Any(), gomock.Eq(db.ListUserNotificationsParams{
+						UserID: user.ID,
+						Unread: util.Pointer(true),
+						Limit:  10,
+					})).
+					Times(1).
+					Return([]db.Notification{notification}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []notificationResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got, 1)
+				require.JSONEq(t, `{"entry_id":7}`, string(got[0].Data))
+			},
+		},
+		{
+			// notifications are personal, admins cannot read them
+			name:  "Forbidden",
+			actor: admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListUserNotifications(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByUsername(gomock.Any(), gomock.Eq(tc.actor.Username)).
+				Times(1).
+				Return(tc.actor, nil)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.ID)).
+				AnyTimes().
+				Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/users/%d/notifications?unread=true", user.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, tc.actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestReadUserNotificationAPI(t *testing.T) {
+	user := randomUser()
+	notificationID := util.RandomInt(1, 1000)
+	arg := db.ReadNotificationParams{ID: notificationID, UserID: user.ID}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReadNotification(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Notification{ID: notificationID, UserID: user.ID, ReadAt: util.Pointer(time.Now().UTC())}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got notificationResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.NotNil(t, got.ReadAt)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReadNotification(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Notification{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/users/%d/notifications/%d/read", user.ID, notificationID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
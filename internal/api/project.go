@@ -0,0 +1,343 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/jackc/pgx/v5"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+)
+
+type createProjectRequest struct {
+	Name      string `json:"name" binding:"required,min=1,max=255"`
+	CompanyID int64  `json:"company_id" binding:"required,min=1"`
+	ClientID  *int64 `json:"client_id" binding:"omitempty,min=1"`
+}
+
+func (server *Server) createProject(ctx *gin.Context) {
+	var req createProjectRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.ClientID != nil && !server.validCompanyClient(ctx, *req.ClientID, req.CompanyID) {
+		return
+	}
+
+	arg := db.CreateProjectParams{
+		CompanyID: req.CompanyID,
+		Name:      req.Name,
+		ClientID:  req.ClientID,
+	}
+	project, err := server.store.CreateProject(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, project)
+}
+
+func (server *Server) getProject(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	project, err := server.store.GetProject(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, project)
+}
+
+type updateProjectRequest struct {
+	Name     string `json:"name" binding:"required,min=1,max=255"`
+	Archived bool   `json:"archived"`
+	ClientID *int64 `json:"client_id" binding:"omitempty,min=1"`
+}
+
+func (server *Server) updateProject(ctx *gin.Context) {
+	var reqID RequestWithID
+	var req updateProjectRequest
+	if err := ctx.ShouldBindUri(&reqID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.ClientID != nil {
+		project, err := server.store.GetProject(ctx, reqID.ID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if !server.validCompanyClient(ctx, *req.ClientID, project.CompanyID) {
+			return
+		}
+	}
+
+	arg := db.UpdateProjectParams{
+		ID:       reqID.ID,
+		Name:     req.Name,
+		Archived: req.Archived,
+		ClientID: req.ClientID,
+	}
+	project, err := server.store.UpdateProject(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, project)
+}
+
+func (server *Server) deleteProject(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	project, err := server.store.DeleteProject(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if db.ErrorCode(err) == db.ForeignKeyViolation {
+			ctx.JSON(http.StatusConflict, errorResponse(errProjectHasEntries))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, project)
+}
+
+type listProjectsRequest struct {
+	PaginationRequest
+	Archived *bool  `form:"archived"`
+	ClientID *int64 `form:"client_id" binding:"omitempty,min=1"`
+}
+
+func (server *Server) listProjects(ctx *gin.Context) {
+	var req listProjectsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	companyID, err := server.tenantScope(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	arg := db.ListProjectsParams{
+		CompanyID: companyID,
+		Archived:  req.Archived,
+		ClientID:  req.ClientID,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+	}
+	projects, err := server.store.ListProjects(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, projects)
+}
+
+type taskRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=255"`
+}
+
+func (server *Server) createTask(ctx *gin.Context) {
+	var reqID RequestWithID
+	var req taskRequest
+	if err := ctx.ShouldBindUri(&reqID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	project, err := server.store.GetProject(ctx, reqID.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if project.Archived {
+		ctx.JSON(http.StatusConflict, errorResponse(errProjectArchived))
+		return
+	}
+
+	arg := db.CreateTaskParams{
+		ProjectID: project.ID,
+		Name:      req.Name,
+	}
+	task, err := server.store.CreateTask(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, task)
+}
+
+func (server *Server) listProjectTasks(ctx *gin.Context) {
+	var idReq RequestWithID
+	var queryReq PaginationRequest
+	if err := ctx.ShouldBindUri(&idReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&queryReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListProjectTasksParams{
+		ProjectID: idReq.ID,
+		Limit:     queryReq.Limit,
+		Offset:    queryReq.Offset,
+	}
+	tasks, err := server.store.ListProjectTasks(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tasks)
+}
+
+type projectTaskRequest struct {
+	ID     int64 `uri:"id" binding:"required,min=1"`
+	TaskID int64 `uri:"task_id" binding:"required,min=1"`
+}
+
+func (server *Server) updateTask(ctx *gin.Context) {
+	var uriReq projectTaskRequest
+	var req taskRequest
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.validProjectTask(ctx, uriReq) {
+		return
+	}
+
+	arg := db.UpdateTaskParams{
+		ID:   uriReq.TaskID,
+		Name: req.Name,
+	}
+	task, err := server.store.UpdateTask(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, task)
+}
+
+func (server *Server) deleteTask(ctx *gin.Context) {
+	var req projectTaskRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.validProjectTask(ctx, req) {
+		return
+	}
+
+	task, err := server.store.DeleteTask(ctx, req.TaskID)
+	if err != nil {
+		if db.ErrorCode(err) == db.ForeignKeyViolation {
+			ctx.JSON(http.StatusConflict, errorResponse(errTaskHasEntries))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, task)
+}
+
+// validProjectTask checks that the task of the request exists and belongs to the project of the request.
+// It writes the error response and returns false otherwise.
+func (server *Server) validProjectTask(ctx *gin.Context, req projectTaskRequest) bool {
+	task, err := server.store.GetTask(ctx, req.TaskID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if task.ProjectID != req.ID {
+		ctx.JSON(http.StatusNotFound, errorResponse(errTaskOutsideProject))
+		return false
+	}
+	return true
+}
+
+// projectCompanyFromURI resolves the company a request acts upon to the company of the project
+// identified by the `:id` URI parameter.
+func (server *Server) projectCompanyFromURI(ctx *gin.Context) (*int64, error) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		return nil, &requestError{err}
+	}
+	project, err := server.store.GetProject(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &project.CompanyID, nil
+}
+
+// projectCompanyFromBody resolves the company a request acts upon to the company of the project in the request body.
+func projectCompanyFromBody(ctx *gin.Context) (*int64, error) {
+	var req createProjectRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		return nil, &requestError{err}
+	}
+	return &req.CompanyID, nil
+}
@@ -0,0 +1,210 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/jackc/pgx/v5"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+)
+
+// rateScopeRequest names what an hourly rate applies to. A rate without any of them is the company default.
+type rateScopeRequest struct {
+	UserID    *int64 `json:"user_id" form:"user_id" binding:"omitempty,min=1"`
+	ProjectID *int64 `json:"project_id" form:"project_id" binding:"omitempty,min=1"`
+	ClientID  *int64 `json:"client_id" form:"client_id" binding:"omitempty,min=1"`
+}
+
+type createHourlyRateRequest struct {
+	rateScopeRequest
+	CompanyID     int64     `json:"company_id" binding:"required,min=1"`
+	AmountCents   *int64    `json:"amount_cents" binding:"required,min=0"`
+	Currency      string    `json:"currency" binding:"required,iso4217"`
+	EffectiveFrom time.Time `json:"effective_from" binding:"required"`
+}
+
+// createHourlyRate adds a rate effective from the given day. Rates are never changed in place so that
+// entries keep being billed with the rate that was effective when they were tracked.
+func (server *Server) createHourlyRate(ctx *gin.Context) {
+	var req createHourlyRateRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.validRateScope(ctx, req.rateScopeRequest, req.CompanyID) {
+		return
+	}
+
+	arg := db.CreateHourlyRateParams{
+		CompanyID:     req.CompanyID,
+		UserID:        req.UserID,
+		ProjectID:     req.ProjectID,
+		ClientID:      req.ClientID,
+		AmountCents:   *req.AmountCents,
+		Currency:      req.Currency,
+		EffectiveFrom: req.EffectiveFrom,
+	}
+	rate, err := server.store.CreateHourlyRate(ctx, arg)
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation && db.ConstraintName(err) == db.HourlyRateEffectiveFromConstraint {
+			ctx.JSON(http.StatusConflict, errorResponse(errRateEffectiveFromTaken))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, rate)
+}
+
+func (server *Server) getHourlyRate(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rate, err := server.store.GetHourlyRate(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rate)
+}
+
+func (server *Server) deleteHourlyRate(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rate, err := server.store.DeleteHourlyRate(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rate)
+}
+
+type listHourlyRatesRequest struct {
+	PaginationRequest
+	rateScopeRequest
+}
+
+func (server *Server) listHourlyRates(ctx *gin.Context) {
+	var req listHourlyRatesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	companyID, err := server.tenantScope(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	arg := db.ListHourlyRatesParams{
+		CompanyID: companyID,
+		UserID:    req.UserID,
+		ProjectID: req.ProjectID,
+		ClientID:  req.ClientID,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+	}
+	rates, err := server.store.ListHourlyRates(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rates)
+}
+
+// validRateScope checks that a rate applies to at most one user, project or client and that it belongs
+// to the company of the rate. It writes the error response and returns false otherwise.
+func (server *Server) validRateScope(ctx *gin.Context, req rateScopeRequest, companyID int64) bool {
+	scopes := 0
+	for _, id := range []*int64{req.UserID, req.ProjectID, req.ClientID} {
+		if id != nil {
+			scopes++
+		}
+	}
+	if scopes > 1 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errRateScope))
+		return false
+	}
+
+	var scopeCompanyID int64
+	var err error
+	switch {
+	case req.UserID != nil:
+		var user db.User
+		user, err = server.store.GetUser(ctx, *req.UserID)
+		if user.CompanyID != nil {
+			scopeCompanyID = *user.CompanyID
+		}
+	case req.ProjectID != nil:
+		var project db.Project
+		project, err = server.store.GetProject(ctx, *req.ProjectID)
+		scopeCompanyID = project.CompanyID
+	case req.ClientID != nil:
+		var client db.Client
+		client, err = server.store.GetClient(ctx, *req.ClientID)
+		scopeCompanyID = client.CompanyID
+	default:
+		return true
+	}
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+	if scopeCompanyID != companyID {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errRateOutsideCompany))
+		return false
+	}
+	return true
+}
+
+// rateCompanyFromURI resolves the company a request acts upon to the company of the hourly rate
+// identified by the `:id` URI parameter.
+func (server *Server) rateCompanyFromURI(ctx *gin.Context) (*int64, error) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		return nil, &requestError{err}
+	}
+	rate, err := server.store.GetHourlyRate(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &rate.CompanyID, nil
+}
+
+// rateCompanyFromBody resolves the company a request acts upon to the company of the hourly rate in the request body.
+func rateCompanyFromBody(ctx *gin.Context) (*int64, error) {
+	var req createHourlyRateRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		return nil, &requestError{err}
+	}
+	return &req.CompanyID, nil
+}
@@ -0,0 +1,399 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/token"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func requireBodyMatchHourlyRate(t *testing.T, body *bytes.Buffer, rate db.HourlyRate) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotRate db.HourlyRate
+	err = json.Unmarshal(data, &gotRate)
+	require.NoError(t, err)
+	require.Equal(t, rate, gotRate)
+}
+
+func randomHourlyRate() db.HourlyRate {
+	return db.HourlyRate{
+		ID:            util.RandomInt(1, 1000),
+		CompanyID:     testCompanyID,
+		AmountCents:   util.RandomInt(1000, 20000),
+		Currency:      "EUR",
+		EffectiveFrom: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt:     time.Now().UTC(),
+	}
+}
+
+func TestCreateHourlyRateAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	manager := randomUserWithRole(types.ManagerRole)
+	user := randomUser()
+	outsider := randomUser()
+	outsider.CompanyID = util.Pointer(testCompanyID + 1)
+	project := randomProject()
+
+	rate := randomHourlyRate()
+	userRate := rate
+	userRate.UserID = &user.ID
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "CompanyDefaultOK",
+			body: gin.H{
+				"company_id":     rate.CompanyID,
+				"amount_cents":   rate.AmountCents,
+				"currency":       rate.Currency,
+				"effective_from": rate.EffectiveFrom,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				arg := db.CreateHourlyRateParams{
+					CompanyID:     rate.CompanyID,
+					AmountCents:   rate.AmountCents,
+					Currency:      rate.Currency,
+					EffectiveFrom: rate.EffectiveFrom,
+				}
+				store.EXPECT().
+					CreateHourlyRate(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(rate, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				requireBodyMatchHourlyRate(t, recorder.Body, rate)
+			},
+		},
+		{
+			name: "UserRateOK",
+			body: gin.H{
+				"company_id":     rate.CompanyID,
+				"user_id":        user.ID,
+				"amount_cents":   rate.AmountCents,
+				"currency":       rate.Currency,
+				"effective_from": rate.EffectiveFrom,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				arg := db.CreateHourlyRateParams{
+					CompanyID:     rate.CompanyID,
+					UserID:        &user.ID,
+					AmountCents:   rate.AmountCents,
+					Currency:      rate.Currency,
+					EffectiveFrom: rate.EffectiveFrom,
+				}
+				store.EXPECT().
+					CreateHourlyRate(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(userRate, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				requireBodyMatchHourlyRate(t, recorder.Body, userRate)
+			},
+		},
+		{
+			name: "UserOutsideCompany",
+			body: gin.H{
+				"company_id":     rate.CompanyID,
+				"user_id":        outsider.ID,
+				"amount_cents":   rate.AmountCents,
+				"currency":       rate.Currency,
+				"effective_from": rate.EffectiveFrom,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(outsider.ID)).
+					Times(1).
+					Return(outsider, nil)
+				store.EXPECT().
+					CreateHourlyRate(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "MultipleScopes",
+			body: gin.H{
+				"company_id":     rate.CompanyID,
+				"user_id":        user.ID,
+				"project_id":     project.ID,
+				"amount_cents":   rate.AmountCents,
+				"currency":       rate.Currency,
+				"effective_from": rate.EffectiveFrom,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					CreateHourlyRate(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "EffectiveFromTaken",
+			body: gin.H{
+				"company_id":     rate.CompanyID,
+				"amount_cents":   rate.AmountCents,
+				"currency":       rate.Currency,
+				"effective_from": rate.EffectiveFrom,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					CreateHourlyRate(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.HourlyRate{}, &pgconn.PgError{
+						Code:           db.UniqueViolation,
+						ConstraintName: db.HourlyRateEffectiveFromConstraint,
+					})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidCurrency",
+			body: gin.H{
+				"company_id":     rate.CompanyID,
+				"amount_cents":   rate.AmountCents,
+				"currency":       "EURO",
+				"effective_from": rate.EffectiveFrom,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					CreateHourlyRate(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NegativeAmount",
+			body: gin.H{
+				"company_id":     rate.CompanyID,
+				"amount_cents":   -1,
+				"currency":       rate.Currency,
+				"effective_from": rate.EffectiveFrom,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					CreateHourlyRate(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden",
+			body: gin.H{
+				"company_id":     rate.CompanyID,
+				"amount_cents":   rate.AmountCents,
+				"currency":       rate.Currency,
+				"effective_from": rate.EffectiveFrom,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					CreateHourlyRate(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/rates", bytes.NewBuffer(jsonData))
+			require.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			tc.setupAuth(t, request, server.tokenMaker)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeleteHourlyRateAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	outsider := randomUserWithRole(types.AdminRole)
+	outsider.CompanyID = util.Pointer(testCompanyID + 1)
+	rate := randomHourlyRate()
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetHourlyRate(gomock.Any(), gomock.Eq(rate.ID)).
+					Times(1).
+					Return(rate, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					DeleteHourlyRate(gomock.Any(), gomock.Eq(rate.ID)).
+					Times(1).
+					Return(rate, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchHourlyRate(t, recorder.Body, rate)
+			},
+		},
+		{
+			name: "OtherCompanyNotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetHourlyRate(gomock.Any(), gomock.Eq(rate.ID)).
+					Times(1).
+					Return(rate, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(outsider.Username)).
+					Times(1).
+					Return(outsider, nil)
+				store.EXPECT().
+					DeleteHourlyRate(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, outsider, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/rates/%d", rate.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
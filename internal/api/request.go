@@ -1,5 +1,7 @@
 package api
 
+import "time"
+
 // Pagination contains pagination request
 type PaginationRequest struct {
 	Offset int32 `form:"offset" binding:"min=0"`
@@ -10,3 +12,16 @@ type PaginationRequest struct {
 type RequestWithID struct {
 	ID int64 `uri:"id" binding:"required,min=1,max=9223372036854775807"`
 }
+
+// TimeRangeRequest restricts a listing to resources starting within [From, To)
+type TimeRangeRequest struct {
+	From *time.Time `form:"from"`
+	To   *time.Time `form:"to"`
+}
+
+func (r TimeRangeRequest) validate() error {
+	if r.From != nil && r.To != nil && !r.To.After(*r.From) {
+		return errInvalidTimeRange
+	}
+	return nil
+}
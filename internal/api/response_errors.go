@@ -1,6 +1,101 @@
 package api
 
-import "github.com/gin-gonic/gin"
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errInvalidTimeRange = errors.New("to must be after from")
+
+	errWrongPassword      = errors.New("current password is incorrect")
+	errRefreshTokenReused = errors.New("refresh token was already used, all sessions of its chain are revoked")
+
+	errForbidden    = errors.New("you do not have permission to access this resource")
+	errUnknownActor = errors.New("authenticated user does not exist")
+	errNoCompany    = errors.New("authenticated user does not belong to a company")
+
+	errManagerOutsideCompany = errors.New("team manager must belong to the same company as the team")
+	errMemberOutsideCompany  = errors.New("user must belong to the same company as the team")
+	errNotTeamMember         = errors.New("user is not a member of the team")
+
+	errUserManagerOutsideCompany = errors.New("manager must belong to the same company as the user")
+	errUserTeamOutsideCompany    = errors.New("team must belong to the same company as the user")
+	errSuperuserRole             = errors.New("only the superuser can grant the superuser role")
+
+	errAbsenceNotPending        = errors.New("only pending absences can be changed")
+	errAbsenceStatusChanged     = errors.New("absence status was changed by another request")
+	errInvalidAbsenceTransition = errors.New("invalid absence status transition")
+	errAbsenceOverlap           = errors.New("absence overlaps another pending or approved absence of the user")
+	errAbsenceTimes             = errors.New("absence end time must be after its start time")
+	errOwnAbsenceDecision       = errors.New("absences cannot be approved or rejected by their own user")
+
+	errTimerRunning    = errors.New("a timer is already running for this user")
+	errTimerNotRunning = errors.New("no timer is running for this user")
+	errEntryOverlap    = errors.New("entry overlaps another entry of the user")
+	errEntryTimes      = errors.New("entry end time must be after its start time")
+	errEntryInvoiced   = errors.New("entry is invoiced and cannot be changed")
+
+	errEntryPeriodLocked = errors.New("entry is in an approved timesheet, an admin has to reopen it first")
+
+	errProjectArchived       = errors.New("project is archived")
+	errProjectOutsideCompany = errors.New("project must belong to the same company as the user of the entry")
+	errProjectHasEntries     = errors.New("project has time entries, archive it instead")
+	errTaskWithoutProject    = errors.New("task requires a project")
+	errTaskOutsideProject    = errors.New("task does not belong to the project")
+	errTaskHasEntries        = errors.New("task has time entries")
+
+	errClientOutsideCompany   = errors.New("client must belong to the same company as the project")
+	errClientHasProjects      = errors.New("client has projects")
+	errRateScope              = errors.New("a rate applies to at most one of user_id, project_id and client_id")
+	errRateOutsideCompany     = errors.New("user, project or client of the rate must belong to the company of the rate")
+	errRateEffectiveFromTaken = errors.New("a rate of the same scope is already effective from this date")
+
+	errInvoiceNotDraft          = errors.New("only draft invoices can be deleted, void issued invoices instead")
+	errInvoiceStatusChanged     = errors.New("invoice status was changed by another request")
+	errInvalidInvoiceTransition = errors.New("invalid invoice status transition")
+	errInvoiceNumberTaken       = errors.New("the next invoice number is already taken, update the invoice sequence")
+
+	errTimesheetOverlap           = errors.New("timesheet overlaps another timesheet of the user")
+	errTimesheetNotOpen           = errors.New("only open timesheets can be deleted")
+	errTimesheetStatusChanged     = errors.New("timesheet status was changed by another request")
+	errInvalidTimesheetTransition = errors.New("invalid timesheet status transition")
+	errOwnTimesheetDecision       = errors.New("timesheets cannot be approved or rejected by their own user")
+
+	errWorkScheduleOutsideCompany     = errors.New("work schedule must belong to the same company")
+	errWorkScheduleAssigned           = errors.New("work schedule is assigned to users")
+	errWorkScheduleEffectiveFromTaken = errors.New("a work schedule of the user is already effective from this date")
+	errAssignmentOutsideUser          = errors.New("work schedule assignment does not belong to the user")
+	errBalanceRangeTooLong            = errors.New("work balance range must not exceed a year")
+
+	errAbsenceTypeOutsideCompany = errors.New("absence type must belong to the same company as the user")
+	errAbsenceTypeInUse          = errors.New("absence type has absences")
+	errAbsenceTypeNameTaken      = errors.New("an absence type with this name already exists")
+	errEntitlementYearTaken      = errors.New("an entitlement of this absence type already applies from this year")
+	errEntitlementOutsideUser    = errors.New("leave entitlement does not belong to the user")
+	errLeaveEndRequired          = errors.New("absences of a type which tracks a balance must have an end time")
+
+	errICalTooLarge          = errors.New("iCalendar file must not exceed 1 MiB")
+	errHolidayNameTooLong    = errors.New("holiday names must not exceed 255 characters")
+	errCompanyHolidayTaken   = errors.New("the company already has a holiday on this date")
+	errHolidayOutsideCompany = errors.New("holiday does not belong to the company")
+
+	errJobNotDead = errors.New("only dead jobs can be retried")
+)
+
+// requestError wraps errors caused by an invalid request.
+type requestError struct {
+	err error
+}
+
+func (e *requestError) Error() string {
+	return e.err.Error()
+}
+
+func (e *requestError) Unwrap() error {
+	return e.err
+}
 
 func errorResponse(err error) gin.H {
 	return gin.H{"error": err.Error()}
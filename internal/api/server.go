@@ -1,7 +1,10 @@
 package api
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -9,8 +12,12 @@ import (
 	"github.com/mateoradman/tempus/internal/config"
 	db "github.com/mateoradman/tempus/internal/db/sqlc"
 	"github.com/mateoradman/tempus/internal/token"
+	"github.com/mateoradman/tempus/internal/types"
 )
 
+// shutdownTimeout is the time the requests being served have to finish when the server stops
+const shutdownTimeout = 30 * time.Second
+
 // Server stores information about a server.
 type Server struct {
 	config     config.Config
@@ -35,6 +42,19 @@ func NewServer(config config.Config, store db.Store) (*Server, error) {
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		_ = v.RegisterValidation("gender", validGender)
 		_ = v.RegisterValidation("language", validLanguage)
+		_ = v.RegisterValidation("role", validRole)
+		_ = v.RegisterValidation("invoice_status", validInvoiceStatus)
+		_ = v.RegisterValidation("timesheet_status", validTimesheetStatus)
+		_ = v.RegisterValidation("timesheet_period", validTimesheetPeriod)
+		_ = v.RegisterValidation("balance_period", validBalancePeriod)
+		_ = v.RegisterValidation("accrual", validAccrual)
+		_ = v.RegisterValidation("absence_kind", validAbsenceKind)
+		_ = v.RegisterValidation("absence_status", validAbsenceStatus)
+		_ = v.RegisterValidation("export_format", validExportFormat)
+		_ = v.RegisterValidation("webhook_event", validWebhookEvent)
+		_ = v.RegisterValidation("job_status", validJobStatus)
+		_ = v.RegisterValidation("timezone", validTimezone)
+		_ = v.RegisterValidation("public_url", validPublicURL)
 	}
 
 	server.setupRouter()
@@ -45,33 +65,345 @@ func (server *Server) setupRouter() {
 	router := gin.Default()
 
 	// routes not protected by auth middleware
-	router.POST("/users", server.createUser)
 	router.POST("/users/login", server.loginUser)
+	router.POST("/users/logout", server.logoutUser)
 	router.POST("/tokens/refresh", server.refreshToken)
+	// calendar feeds are protected by the secret token in their path, as calendar applications cannot log in
+	router.GET("/calendars/:token", server.getCalendar)
 
 	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker))
 
-	authRoutes.POST("/companies", server.createCompany)
-	authRoutes.GET("/companies/:id", server.getCompany)
-	authRoutes.DELETE("/companies/:id", server.deleteCompany)
-	authRoutes.PUT("/companies/:id", server.updateCompany)
-	authRoutes.GET("/companies", server.listCompany)
-	authRoutes.GET("/companies/:id/employees", server.listCompanyEmployees)
-
-	authRoutes.GET("/users/:id", server.getUser)
-	authRoutes.DELETE("/users/:id", server.deleteUser)
-	authRoutes.PATCH("/users/:id", server.updateUser)
-	authRoutes.GET("/users", server.listUsers)
-
-	authRoutes.POST("/entries", server.createEntry)
-	authRoutes.GET("/entries/:id", server.getEntry)
-	authRoutes.DELETE("/entries/:id", server.deleteEntry)
-	authRoutes.PUT("/entries/:id", server.updateEntry)
-	authRoutes.GET("/entries", server.listEntries)
+	authRoutes.GET("/me", server.getMe)
+	authRoutes.PATCH("/me", server.updateMe)
+	authRoutes.PUT("/me/password", server.changeMyPassword)
+	authRoutes.GET("/me/entries", server.listMyEntries)
+	authRoutes.GET("/me/absences", server.listMyAbsences)
+	authRoutes.GET("/me/timesheets", server.listMyTimesheets)
+	authRoutes.GET("/me/work-balance", server.getMyWorkBalance)
+	authRoutes.GET("/me/leave-balance", server.getMyLeaveBalance)
+	authRoutes.GET("/me/holidays", server.getMyHolidays)
+	authRoutes.GET("/me/clock", server.getClock)
+	authRoutes.POST("/me/clock-in", server.clockIn)
+	authRoutes.POST("/me/clock-out", server.clockOut)
+	authRoutes.GET("/me/sessions", server.listMySessions)
+	authRoutes.DELETE("/me/sessions", server.revokeMySessions)
+	authRoutes.DELETE("/me/sessions/:id", server.revokeMySession)
+
+	authRoutes.POST("/companies", server.authorize(nil, superuserOnly), server.createCompany)
+	authRoutes.GET("/companies/:id", server.inTenant(companyFromURI), server.getCompany)
+	authRoutes.DELETE("/companies/:id", server.authorize(nil, superuserOnly), server.deleteCompany)
+	authRoutes.PUT("/companies/:id", server.inTenant(companyFromURI), server.authorize(nil, adminOnly), server.updateCompany)
+	authRoutes.GET("/companies", server.authorize(nil, superuserOnly), server.listCompany)
+	authRoutes.GET("/companies/:id/employees",
+		server.inTenant(companyFromURI),
+		server.authorize(nil, hasRole(types.AdminRole, types.ManagerRole)),
+		server.listCompanyEmployees,
+	)
+	authRoutes.GET("/companies/:id/reports/billing",
+		server.inTenant(companyFromURI),
+		server.authorize(nil, hasRole(types.AdminRole, types.ManagerRole)),
+		server.getBillingReport,
+	)
+	authRoutes.GET("/companies/:id/exports/entries",
+		server.inTenant(companyFromURI),
+		server.authorize(nil, hasRole(types.AdminRole, types.ManagerRole)),
+		server.exportEntries,
+	)
+	authRoutes.GET("/companies/:id/exports/absences",
+		server.inTenant(companyFromURI),
+		server.authorize(nil, hasRole(types.AdminRole, types.ManagerRole)),
+		server.exportAbsences,
+	)
+	authRoutes.GET("/companies/:id/invoice-sequence",
+		server.inTenant(companyFromURI),
+		server.authorize(nil, adminOnly),
+		server.getInvoiceSequence,
+	)
+	authRoutes.PUT("/companies/:id/invoice-sequence",
+		server.inTenant(companyFromURI),
+		server.authorize(nil, adminOnly),
+		server.updateInvoiceSequence,
+	)
+	authRoutes.PUT("/companies/:id/work-schedule",
+		server.inTenant(companyFromURI),
+		server.authorize(nil, adminOnly),
+		server.updateCompanyWorkSchedule,
+	)
+	authRoutes.GET("/companies/:id/holidays", server.inTenant(companyFromURI), server.listCompanyHolidays)
+	authRoutes.POST("/companies/:id/holidays", server.inTenant(companyFromURI), server.authorize(nil, adminOnly), server.createCompanyHoliday)
+	authRoutes.DELETE("/companies/:id/holidays/:holiday_id",
+		server.inTenant(companyFromURI),
+		server.authorize(nil, adminOnly),
+		server.deleteCompanyHoliday,
+	)
+	authRoutes.GET("/companies/:id/webhooks", server.inTenant(companyFromURI), server.authorize(nil, adminOnly), server.listWebhooks)
+	authRoutes.POST("/companies/:id/webhooks", server.inTenant(companyFromURI), server.authorize(nil, adminOnly), server.createWebhook)
+	authRoutes.PUT("/companies/:id/webhooks/:webhook_id",
+		server.inTenant(companyFromURI),
+		server.authorize(nil, adminOnly),
+		server.updateWebhook,
+	)
+	authRoutes.DELETE("/companies/:id/webhooks/:webhook_id",
+		server.inTenant(companyFromURI),
+		server.authorize(nil, adminOnly),
+		server.deleteWebhook,
+	)
+	authRoutes.GET("/companies/:id/webhooks/:webhook_id/deliveries",
+		server.inTenant(companyFromURI),
+		server.authorize(nil, adminOnly),
+		server.listWebhookDeliveries,
+	)
+	authRoutes.POST("/companies/:id/webhooks/:webhook_id/deliveries/:delivery_id/redeliver",
+		server.inTenant(companyFromURI),
+		server.authorize(nil, adminOnly),
+		server.redeliverWebhook,
+	)
+	authRoutes.GET("/companies/:id/clock-policy", server.inTenant(companyFromURI), server.authorize(nil, adminOnly), server.getClockPolicy)
+	authRoutes.PUT("/companies/:id/clock-policy", server.inTenant(companyFromURI), server.authorize(nil, adminOnly), server.updateClockPolicy)
+
+	authRoutes.POST("/users", server.authorize(nil, adminOnly), server.createUser)
+	authRoutes.GET("/users/:id", server.authorize(userFromURI, adminOnly, selfOnly, managerOfSubject), server.getUser)
+	authRoutes.DELETE("/users/:id", server.authorize(userFromURI, adminOnly), server.deleteUser)
+	authRoutes.PATCH("/users/:id", server.authorize(userFromURI, adminOnly, selfOnly), server.updateUser)
+	authRoutes.PUT("/users/:id/role", server.authorize(userFromURI, adminOnly), server.updateUserRole)
+	authRoutes.GET("/users", server.authorize(nil, hasRole(types.AdminRole, types.ManagerRole)), server.listUsers)
+	authRoutes.GET("/users/:id/absences", server.authorize(userFromURI, adminOnly, selfOnly, managerOfSubject), server.listUserAbsences)
+	authRoutes.GET("/users/:id/entries", server.authorize(userFromURI, adminOnly, selfOnly, managerOfSubject), server.listUserEntries)
+	authRoutes.GET("/users/:id/timesheets", server.authorize(userFromURI, adminOnly, selfOnly, managerOfSubject), server.listUserTimesheets)
+	authRoutes.DELETE("/users/:id/sessions", server.authorize(userFromURI, adminOnly), server.revokeUserSessions)
+	authRoutes.GET("/users/:id/work-schedules",
+		server.authorize(userFromURI, adminOnly, selfOnly, managerOfSubject),
+		server.listUserWorkScheduleAssignments,
+	)
+	authRoutes.POST("/users/:id/work-schedules", server.authorize(userFromURI, adminOnly), server.createWorkScheduleAssignment)
+	authRoutes.DELETE("/users/:id/work-schedules/:assignment_id",
+		server.authorize(userFromURI, adminOnly),
+		server.deleteWorkScheduleAssignment,
+	)
+	authRoutes.GET("/users/:id/work-balance", server.authorize(userFromURI, adminOnly, selfOnly, managerOfSubject), server.getUserWorkBalance)
+	authRoutes.GET("/users/:id/leave-entitlements",
+		server.authorize(userFromURI, adminOnly, selfOnly, managerOfSubject),
+		server.listUserLeaveEntitlements,
+	)
+	authRoutes.POST("/users/:id/leave-entitlements", server.authorize(userFromURI, adminOnly), server.createLeaveEntitlement)
+	authRoutes.DELETE("/users/:id/leave-entitlements/:entitlement_id",
+		server.authorize(userFromURI, adminOnly),
+		server.deleteLeaveEntitlement,
+	)
+	authRoutes.GET("/users/:id/leave-balance", server.authorize(userFromURI, adminOnly, selfOnly, managerOfSubject), server.getUserLeaveBalance)
+	authRoutes.GET("/users/:id/holidays", server.authorize(userFromURI, adminOnly, selfOnly, managerOfSubject), server.getUserHolidays)
+	authRoutes.GET("/users/:id/calendar-feeds",
+		server.authorize(userFromURI, adminOnly, selfOnly, managerOfSubject),
+		server.listUserCalendarFeeds,
+	)
+	authRoutes.POST("/users/:id/calendar-feeds",
+		server.authorize(userFromURI, adminOnly, selfOnly, managerOfSubject),
+		server.createUserCalendarFeed,
+	)
+	authRoutes.DELETE("/users/:id/calendar-feeds/:feed_id",
+		server.authorize(userFromURI, adminOnly, selfOnly, managerOfSubject),
+		server.revokeUserCalendarFeed,
+	)
+	authRoutes.GET("/users/:id/notifications", server.authorize(userFromURI, selfOnly), server.listUserNotifications)
+	authRoutes.POST("/users/:id/notifications/:notification_id/read",
+		server.authorize(userFromURI, selfOnly),
+		server.readUserNotification,
+	)
+
+	authRoutes.POST("/entries", server.authorize(entryUserFromBody, adminOnly, selfOnly, managerOfSubject), server.createEntry)
+	authRoutes.GET("/entries/:id", server.authorize(server.entryOwnerFromURI, adminOnly, selfOnly, managerOfSubject), server.getEntry)
+	authRoutes.DELETE("/entries/:id", server.authorize(server.entryOwnerFromURI, adminOnly, selfOnly, managerOfSubject), server.deleteEntry)
+	authRoutes.PUT("/entries/:id",
+		server.authorize(server.entryOwnerFromURI, adminOnly, selfOnly, managerOfSubject),
+		server.authorize(entryUserFromBody, adminOnly, selfOnly, managerOfSubject),
+		server.updateEntry,
+	)
+	authRoutes.GET("/entries", server.authorize(nil, adminOnly), server.listEntries)
+
+	authRoutes.POST("/absences", server.authorize(absenceUserFromBody, adminOnly, selfOnly, managerOfSubject), server.createAbsence)
+	authRoutes.GET("/absences/:id", server.authorize(server.absenceOwnerFromURI, adminOnly, selfOnly, managerOfSubject), server.getAbsence)
+	authRoutes.GET("/absences/:id/duration", server.authorize(server.absenceOwnerFromURI, adminOnly, selfOnly, managerOfSubject), server.getAbsenceDuration)
+	authRoutes.PUT("/absences/:id", server.authorize(server.absenceOwnerFromURI, adminOnly, selfOnly, managerOfSubject), server.updateAbsence)
+	authRoutes.GET("/absences", server.authorize(nil, adminOnly), server.listAbsences)
+	authRoutes.POST("/absences/:id/approve", server.authorize(server.absenceOwnerFromURI, adminOnly, managerOfSubject), server.decideAbsence(types.AbsenceApproved))
+	authRoutes.POST("/absences/:id/reject", server.authorize(server.absenceOwnerFromURI, adminOnly, managerOfSubject), server.decideAbsence(types.AbsenceRejected))
+	authRoutes.POST("/absences/:id/cancel", server.authorize(server.absenceOwnerFromURI, adminOnly, selfOnly), server.cancelAbsence)
+
+	authRoutes.POST("/timesheets", server.authorize(timesheetUserFromBody, adminOnly, selfOnly, managerOfSubject), server.createTimesheet)
+	authRoutes.GET("/timesheets/:id", server.authorize(server.timesheetOwnerFromURI, adminOnly, selfOnly, managerOfSubject), server.getTimesheet)
+	authRoutes.GET("/timesheets/:id/pdf",
+		server.authorize(server.timesheetOwnerFromURI, adminOnly, selfOnly, managerOfSubject),
+		server.getTimesheetPDF,
+	)
+	authRoutes.DELETE("/timesheets/:id", server.authorize(server.timesheetOwnerFromURI, adminOnly, selfOnly), server.deleteTimesheet)
+	authRoutes.GET("/timesheets", server.authorize(nil, hasRole(types.AdminRole, types.ManagerRole)), server.listTimesheets)
+	authRoutes.POST("/timesheets/:id/submit", server.authorize(server.timesheetOwnerFromURI, adminOnly, selfOnly), server.submitTimesheet)
+	authRoutes.POST("/timesheets/:id/approve",
+		server.authorize(server.timesheetOwnerFromURI, adminOnly, managerOfSubject),
+		server.decideTimesheet(types.TimesheetApproved),
+	)
+	authRoutes.POST("/timesheets/:id/reject",
+		server.authorize(server.timesheetOwnerFromURI, adminOnly, managerOfSubject),
+		server.decideTimesheet(types.TimesheetRejected),
+	)
+	authRoutes.POST("/timesheets/:id/reopen", server.authorize(server.timesheetOwnerFromURI, adminOnly), server.reopenTimesheet)
+
+	authRoutes.POST("/teams", server.inTenant(teamCompanyFromBody), server.authorize(nil, adminOnly), server.createTeam)
+	authRoutes.GET("/teams/:id", server.inTenant(server.teamCompanyFromURI), server.getTeam)
+	authRoutes.DELETE("/teams/:id", server.inTenant(server.teamCompanyFromURI), server.authorize(nil, adminOnly), server.deleteTeam)
+	authRoutes.PUT("/teams/:id", server.inTenant(server.teamCompanyFromURI), server.authorize(nil, adminOnly), server.updateTeam)
+	authRoutes.GET("/teams", server.authorize(nil, hasRole(types.AdminRole, types.ManagerRole)), server.listTeams)
+	authRoutes.GET("/teams/:id/members",
+		server.inTenant(server.teamCompanyFromURI),
+		server.authorize(nil, hasRole(types.AdminRole, types.ManagerRole)),
+		server.listTeamMembers,
+	)
+	authRoutes.POST("/teams/:id/members",
+		server.inTenant(server.teamCompanyFromURI),
+		server.authorize(nil, adminOnly),
+		server.addTeamMember,
+	)
+	authRoutes.DELETE("/teams/:id/members/:user_id",
+		server.inTenant(server.teamCompanyFromURI),
+		server.authorize(nil, adminOnly),
+		server.removeTeamMember,
+	)
+	authRoutes.GET("/teams/:id/calendar-feeds",
+		server.inTenant(server.teamCompanyFromURI),
+		server.authorize(nil, hasRole(types.AdminRole, types.ManagerRole)),
+		server.listTeamCalendarFeeds,
+	)
+	authRoutes.POST("/teams/:id/calendar-feeds",
+		server.inTenant(server.teamCompanyFromURI),
+		server.authorize(nil, hasRole(types.AdminRole, types.ManagerRole)),
+		server.createTeamCalendarFeed,
+	)
+	authRoutes.DELETE("/teams/:id/calendar-feeds/:feed_id",
+		server.inTenant(server.teamCompanyFromURI),
+		server.authorize(nil, hasRole(types.AdminRole, types.ManagerRole)),
+		server.revokeTeamCalendarFeed,
+	)
+	authRoutes.POST("/projects", server.inTenant(projectCompanyFromBody), server.authorize(nil, adminOnly), server.createProject)
+	authRoutes.GET("/projects/:id", server.inTenant(server.projectCompanyFromURI), server.getProject)
+	authRoutes.DELETE("/projects/:id", server.inTenant(server.projectCompanyFromURI), server.authorize(nil, adminOnly), server.deleteProject)
+	authRoutes.PUT("/projects/:id", server.inTenant(server.projectCompanyFromURI), server.authorize(nil, adminOnly), server.updateProject)
+	authRoutes.GET("/projects", server.listProjects)
+	authRoutes.GET("/projects/:id/tasks", server.inTenant(server.projectCompanyFromURI), server.listProjectTasks)
+	authRoutes.POST("/projects/:id/tasks",
+		server.inTenant(server.projectCompanyFromURI),
+		server.authorize(nil, hasRole(types.AdminRole, types.ManagerRole)),
+		server.createTask,
+	)
+	authRoutes.PUT("/projects/:id/tasks/:task_id",
+		server.inTenant(server.projectCompanyFromURI),
+		server.authorize(nil, hasRole(types.AdminRole, types.ManagerRole)),
+		server.updateTask,
+	)
+	authRoutes.DELETE("/projects/:id/tasks/:task_id",
+		server.inTenant(server.projectCompanyFromURI),
+		server.authorize(nil, hasRole(types.AdminRole, types.ManagerRole)),
+		server.deleteTask,
+	)
+
+	authRoutes.POST("/clients", server.inTenant(clientCompanyFromBody), server.authorize(nil, adminOnly), server.createClient)
+	authRoutes.GET("/clients/:id", server.inTenant(server.clientCompanyFromURI), server.getClient)
+	authRoutes.DELETE("/clients/:id", server.inTenant(server.clientCompanyFromURI), server.authorize(nil, adminOnly), server.deleteClient)
+	authRoutes.PUT("/clients/:id", server.inTenant(server.clientCompanyFromURI), server.authorize(nil, adminOnly), server.updateClient)
+	authRoutes.GET("/clients", server.listClients)
+
+	authRoutes.POST("/rates", server.inTenant(rateCompanyFromBody), server.authorize(nil, adminOnly), server.createHourlyRate)
+	authRoutes.GET("/rates/:id", server.inTenant(server.rateCompanyFromURI), server.authorize(nil, adminOnly), server.getHourlyRate)
+	authRoutes.DELETE("/rates/:id", server.inTenant(server.rateCompanyFromURI), server.authorize(nil, adminOnly), server.deleteHourlyRate)
+	authRoutes.GET("/rates", server.authorize(nil, adminOnly), server.listHourlyRates)
+
+	authRoutes.POST("/work-schedules", server.inTenant(workScheduleCompanyFromBody), server.authorize(nil, adminOnly), server.createWorkSchedule)
+	authRoutes.GET("/work-schedules/:id", server.inTenant(server.workScheduleCompanyFromURI), server.getWorkSchedule)
+	authRoutes.DELETE("/work-schedules/:id",
+		server.inTenant(server.workScheduleCompanyFromURI),
+		server.authorize(nil, adminOnly),
+		server.deleteWorkSchedule,
+	)
+	authRoutes.PUT("/work-schedules/:id",
+		server.inTenant(server.workScheduleCompanyFromURI),
+		server.authorize(nil, adminOnly),
+		server.updateWorkSchedule,
+	)
+	authRoutes.GET("/work-schedules", server.listWorkSchedules)
+
+	authRoutes.POST("/absence-types", server.inTenant(absenceTypeCompanyFromBody), server.authorize(nil, adminOnly), server.createAbsenceType)
+	authRoutes.GET("/absence-types/:id", server.inTenant(server.absenceTypeCompanyFromURI), server.getAbsenceType)
+	authRoutes.DELETE("/absence-types/:id",
+		server.inTenant(server.absenceTypeCompanyFromURI),
+		server.authorize(nil, adminOnly),
+		server.deleteAbsenceType,
+	)
+	authRoutes.PUT("/absence-types/:id",
+		server.inTenant(server.absenceTypeCompanyFromURI),
+		server.authorize(nil, adminOnly),
+		server.updateAbsenceType,
+	)
+	authRoutes.GET("/absence-types", server.listAbsenceTypes)
+
+	authRoutes.GET("/holidays", server.listPublicHolidays)
+	authRoutes.POST("/holidays/import", server.authorize(nil, superuserOnly), server.importPublicHolidays)
+	authRoutes.POST("/holidays/import/ical", server.authorize(nil, superuserOnly), server.importPublicHolidaysICal)
+	authRoutes.DELETE("/holidays/:id", server.authorize(nil, superuserOnly), server.deletePublicHoliday)
+
+	authRoutes.GET("/jobs", server.authorize(nil, superuserOnly), server.listJobs)
+	authRoutes.GET("/jobs/:id", server.authorize(nil, superuserOnly), server.getJob)
+	authRoutes.POST("/jobs/:id/retry", server.authorize(nil, superuserOnly), server.retryJob)
+
+	authRoutes.POST("/invoices", server.inTenant(server.invoiceCompanyFromBody), server.authorize(nil, adminOnly), server.createInvoice)
+	authRoutes.GET("/invoices/:id",
+		server.inTenant(server.invoiceCompanyFromURI),
+		server.authorize(nil, hasRole(types.AdminRole, types.ManagerRole)),
+		server.getInvoice,
+	)
+	authRoutes.DELETE("/invoices/:id", server.inTenant(server.invoiceCompanyFromURI), server.authorize(nil, adminOnly), server.deleteInvoice)
+	authRoutes.GET("/invoices", server.authorize(nil, hasRole(types.AdminRole, types.ManagerRole)), server.listInvoices)
+	authRoutes.POST("/invoices/:id/issue",
+		server.inTenant(server.invoiceCompanyFromURI),
+		server.authorize(nil, adminOnly),
+		server.transitionInvoice(types.InvoiceIssued),
+	)
+	authRoutes.POST("/invoices/:id/pay",
+		server.inTenant(server.invoiceCompanyFromURI),
+		server.authorize(nil, adminOnly),
+		server.transitionInvoice(types.InvoicePaid),
+	)
+	authRoutes.POST("/invoices/:id/void",
+		server.inTenant(server.invoiceCompanyFromURI),
+		server.authorize(nil, adminOnly),
+		server.transitionInvoice(types.InvoiceVoid),
+	)
+	authRoutes.GET("/invoices/:id/pdf",
+		server.inTenant(server.invoiceCompanyFromURI),
+		server.authorize(nil, hasRole(types.AdminRole, types.ManagerRole)),
+		server.getInvoicePDF,
+	)
+	authRoutes.GET("/invoices/:id/ubl",
+		server.inTenant(server.invoiceCompanyFromURI),
+		server.authorize(nil, hasRole(types.AdminRole, types.ManagerRole)),
+		server.getInvoiceUBL,
+	)
 	server.router = router
 }
 
-// Start runs a server on a given address.
-func (server *Server) Start(address string) error {
-	return server.router.Run(address)
+// Start runs a server on a given address until ctx is done, then waits for the requests being served to finish.
+func (server *Server) Start(ctx context.Context, address string) error {
+	srv := &http.Server{Addr: address, Handler: server.router}
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
 }
@@ -0,0 +1,328 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/jackc/pgx/v5"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+)
+
+type createTeamRequest struct {
+	Name      string `json:"name" binding:"required,min=1"`
+	CompanyID int64  `json:"company_id" binding:"required,min=1"`
+	ManagerID *int64 `json:"manager_id" binding:"omitempty,min=1"`
+}
+
+func (server *Server) createTeam(ctx *gin.Context) {
+	var req createTeamRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.ManagerID != nil && !server.validTeamManager(ctx, *req.ManagerID, req.CompanyID) {
+		return
+	}
+
+	arg := db.CreateTeamParams{
+		Name:      req.Name,
+		ManagerID: req.ManagerID,
+		CompanyID: &req.CompanyID,
+	}
+	team, err := server.store.CreateTeam(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, team)
+}
+
+func (server *Server) getTeam(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	team, err := server.store.GetTeam(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, team)
+}
+
+func (server *Server) deleteTeam(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	team, err := server.store.DeleteTeam(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, team)
+}
+
+type updateTeamRequest struct {
+	Name      string `json:"name" binding:"required,min=1"`
+	ManagerID *int64 `json:"manager_id" binding:"omitempty,min=1"`
+}
+
+func (server *Server) updateTeam(ctx *gin.Context) {
+	var reqID RequestWithID
+	var req updateTeamRequest
+	if err := ctx.ShouldBindUri(&reqID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	team, err := server.store.GetTeam(ctx, reqID.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if req.ManagerID != nil {
+		if team.CompanyID == nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errManagerOutsideCompany))
+			return
+		}
+		if !server.validTeamManager(ctx, *req.ManagerID, *team.CompanyID) {
+			return
+		}
+	}
+
+	arg := db.UpdateTeamParams{
+		ID:        team.ID,
+		Name:      req.Name,
+		ManagerID: req.ManagerID,
+	}
+	team, err = server.store.UpdateTeam(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, team)
+}
+
+func (server *Server) listTeams(ctx *gin.Context) {
+	var req PaginationRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	companyID, err := server.tenantScope(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	arg := db.ListTeamsParams{
+		CompanyID: companyID,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+	}
+	teams, err := server.store.ListTeams(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, teams)
+}
+
+func (server *Server) listTeamMembers(ctx *gin.Context) {
+	var idReq RequestWithID
+	var queryReq PaginationRequest
+	if err := ctx.ShouldBindUri(&idReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&queryReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListTeamMembersParams{
+		TeamID: &idReq.ID,
+		Limit:  queryReq.Limit,
+		Offset: queryReq.Offset,
+	}
+	members, err := server.store.ListTeamMembers(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newUserListResponse(members))
+}
+
+type addTeamMemberRequest struct {
+	UserID int64 `json:"user_id" binding:"required,min=1"`
+}
+
+func (server *Server) addTeamMember(ctx *gin.Context) {
+	var reqID RequestWithID
+	var req addTeamMemberRequest
+	if err := ctx.ShouldBindUri(&reqID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	team, err := server.store.GetTeam(ctx, reqID.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.GetUser(ctx, req.UserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if team.CompanyID == nil || !belongsToCompany(user, *team.CompanyID) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errMemberOutsideCompany))
+		return
+	}
+
+	arg := db.UpdateUserTeamParams{
+		ID:     user.ID,
+		TeamID: &team.ID,
+	}
+	user, err = server.store.UpdateUserTeam(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newUserResponse(user))
+}
+
+type teamMemberRequest struct {
+	ID     int64 `uri:"id" binding:"required,min=1"`
+	UserID int64 `uri:"user_id" binding:"required,min=1"`
+}
+
+func (server *Server) removeTeamMember(ctx *gin.Context) {
+	var req teamMemberRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.GetUser(ctx, req.UserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if user.TeamID == nil || *user.TeamID != req.ID {
+		ctx.JSON(http.StatusNotFound, errorResponse(errNotTeamMember))
+		return
+	}
+
+	arg := db.UpdateUserTeamParams{
+		ID:     user.ID,
+		TeamID: nil,
+	}
+	user, err = server.store.UpdateUserTeam(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newUserResponse(user))
+}
+
+// validTeamManager checks that the manager exists and belongs to the company of the team.
+// It writes the error response and returns false otherwise.
+func (server *Server) validTeamManager(ctx *gin.Context, managerID int64, companyID int64) bool {
+	manager, err := server.store.GetUser(ctx, managerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if !belongsToCompany(manager, companyID) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errManagerOutsideCompany))
+		return false
+	}
+	return true
+}
+
+// belongsToCompany reports whether the user is an employee of the company.
+func belongsToCompany(user db.User, companyID int64) bool {
+	return user.CompanyID != nil && *user.CompanyID == companyID
+}
+
+// teamCompanyFromURI resolves the company a request acts upon to the company of the team
+// identified by the `:id` URI parameter.
+func (server *Server) teamCompanyFromURI(ctx *gin.Context) (*int64, error) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		return nil, &requestError{err}
+	}
+	team, err := server.store.GetTeam(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	return team.CompanyID, nil
+}
+
+// teamCompanyFromBody resolves the company a request acts upon to the company of the team in the request body.
+func teamCompanyFromBody(ctx *gin.Context) (*int64, error) {
+	var req createTeamRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		return nil, &requestError{err}
+	}
+	return &req.CompanyID, nil
+}
@@ -0,0 +1,518 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/jackc/pgx/v5"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/timesheetpdf"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/worktime"
+)
+
+type createTimesheetRequest struct {
+	UserID int64     `json:"user_id" binding:"required,min=1"`
+	Period string    `json:"period" binding:"required,timesheet_period"`
+	Date   time.Time `json:"date" binding:"required"`
+}
+
+// timesheetBounds returns the first day and the day after the last day of the period containing the calendar day of date.
+// Weeks start on Monday.
+func timesheetBounds(period string, date time.Time) (time.Time, time.Time) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	if period == types.TimesheetMonth {
+		start := day.AddDate(0, 0, 1-day.Day())
+		return start, start.AddDate(0, 1, 0)
+	}
+	start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	return start, start.AddDate(0, 0, 7)
+}
+
+func (server *Server) createTimesheet(ctx *gin.Context) {
+	var req createTimesheetRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	start, end := timesheetBounds(req.Period, req.Date)
+	arg := db.CreateTimesheetParams{
+		UserID:      req.UserID,
+		Period:      req.Period,
+		PeriodStart: start,
+		PeriodEnd:   end,
+	}
+	timesheet, err := server.store.CreateTimesheet(ctx, arg)
+	if err != nil {
+		if db.ErrorCode(err) == db.ExclusionViolation && db.ConstraintName(err) == db.TimesheetOverlapConstraint {
+			ctx.JSON(http.StatusConflict, errorResponse(errTimesheetOverlap))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, timesheet)
+}
+
+func (server *Server) getTimesheet(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	timesheet, err := server.store.GetTimesheet(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, timesheet)
+}
+
+func (server *Server) deleteTimesheet(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	timesheet, err := server.store.GetTimesheet(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if timesheet.Status != types.TimesheetOpen {
+		ctx.JSON(http.StatusConflict, errorResponse(errTimesheetNotOpen))
+		return
+	}
+
+	arg := db.DeleteTimesheetParams{
+		ID:            timesheet.ID,
+		CurrentStatus: timesheet.Status,
+	}
+	timesheet, err = server.store.DeleteTimesheet(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusConflict, errorResponse(errTimesheetStatusChanged))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, timesheet)
+}
+
+type listTimesheetsRequest struct {
+	PaginationRequest
+	Status *string `form:"status" binding:"omitempty,timesheet_status"`
+}
+
+// listTimesheets lists the timesheets of the company. Managers only list the timesheets of the users they manage,
+// directly or through a team, like the users whose timesheets they may approve.
+func (server *Server) listTimesheets(ctx *gin.Context) {
+	var req listTimesheetsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	companyID, err := server.tenantScope(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	arg := db.ListTimesheetsParams{
+		CompanyID: companyID,
+		Status:    req.Status,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+	}
+	actor, err := server.authUser(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+	if actor.Role == types.ManagerRole {
+		arg.ManagerID = &actor.ID
+	}
+	timesheets, err := server.store.ListTimesheets(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, timesheets)
+}
+
+func (server *Server) listUserTimesheets(ctx *gin.Context) {
+	var idReq RequestWithID
+	if err := ctx.ShouldBindUri(&idReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	server.listTimesheetsOfUser(ctx, idReq.ID)
+}
+
+// listTimesheetsOfUser writes a page of the timesheets of the user with the given ID, latest period first.
+func (server *Server) listTimesheetsOfUser(ctx *gin.Context, userID int64) {
+	var queryReq PaginationRequest
+	if err := ctx.ShouldBindQuery(&queryReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListUserTimesheetsParams{
+		UserID: userID,
+		Limit:  queryReq.Limit,
+		Offset: queryReq.Offset,
+	}
+	timesheets, err := server.store.ListUserTimesheets(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, timesheets)
+}
+
+// timesheetToTransition loads the timesheet identified by the `:id` URI parameter and checks that it may become status.
+// It writes the error response and returns false otherwise.
+func (server *Server) timesheetToTransition(ctx *gin.Context, status string) (db.Timesheet, bool) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.Timesheet{}, false
+	}
+
+	timesheet, err := server.store.GetTimesheet(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return db.Timesheet{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.Timesheet{}, false
+	}
+
+	if !types.CanTransitionTimesheet(timesheet.Status, status) {
+		ctx.JSON(http.StatusConflict, errorResponse(timesheetTransitionError(timesheet.Status, status)))
+		return db.Timesheet{}, false
+	}
+	return timesheet, true
+}
+
+// submitTimesheet submits an open or rejected timesheet for approval.
+func (server *Server) submitTimesheet(ctx *gin.Context) {
+	timesheet, ok := server.timesheetToTransition(ctx, types.TimesheetSubmitted)
+	if !ok {
+		return
+	}
+
+	arg := db.SubmitTimesheetParams{
+		ID:            timesheet.ID,
+		CurrentStatus: timesheet.Status,
+	}
+	timesheet, err := server.store.SubmitTimesheet(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusConflict, errorResponse(errTimesheetStatusChanged))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, timesheet)
+}
+
+type decideTimesheetRequest struct {
+	Comment *string `json:"comment" binding:"omitempty,min=1,max=255"`
+}
+
+// decideTimesheet returns a handler which approves or rejects a submitted timesheet.
+// Entries starting within an approved timesheet are locked until it is reopened.
+// Approving a timesheet stores its document, see approveTimesheet.
+func (server *Server) decideTimesheet(status string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req decideTimesheetRequest
+		// the comment is optional, so an empty body is accepted
+		if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		timesheet, ok := server.timesheetToTransition(ctx, status)
+		if !ok {
+			return
+		}
+
+		approver, err := server.authUser(ctx)
+		if err != nil {
+			ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+			return
+		}
+		// a manager of their own team or their own manager still cannot decide, and thereby lock, their own timesheets
+		if approver.ID == timesheet.UserID {
+			ctx.JSON(http.StatusForbidden, errorResponse(errOwnTimesheetDecision))
+			return
+		}
+
+		arg := db.DecideTimesheetParams{
+			ID:              timesheet.ID,
+			Status:          status,
+			DecidedByID:     &approver.ID,
+			DecisionComment: req.Comment,
+			CurrentStatus:   timesheet.Status,
+		}
+		if status == types.TimesheetApproved {
+			server.approveTimesheet(ctx, timesheet, approver, arg)
+			return
+		}
+		timesheet, err = server.store.DecideTimesheet(ctx, arg)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				ctx.JSON(http.StatusConflict, errorResponse(errTimesheetStatusChanged))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusOK, timesheet)
+	}
+}
+
+// timesheetDocumentResponse describes a stored document of a timesheet without its content
+type timesheetDocumentResponse struct {
+	ID        int64     `json:"id"`
+	Language  string    `json:"language"`
+	Sha256    string    `json:"sha256"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// approvedTimesheetResponse is an approved timesheet together with the document stored on approval
+type approvedTimesheetResponse struct {
+	db.Timesheet
+	Document timesheetDocumentResponse `json:"document"`
+}
+
+// approveTimesheet approves a submitted timesheet. The document of the approved timesheet is rendered in the language
+// of its user and stored along with its SHA-256 hash, so that a printed copy can be verified later on.
+func (server *Server) approveTimesheet(ctx *gin.Context, timesheet db.Timesheet, approver db.User, arg db.DecideTimesheetParams) {
+	result, err := server.store.ApproveTimesheetTx(ctx, db.ApproveTimesheetTxParams{
+		DecideTimesheetParams: arg,
+		// the document is loaded after the approval locked the entries, so that it matches them
+		Render: func(q db.Querier, approved db.Timesheet) ([]byte, string, error) {
+			doc, err := timesheetDocument(ctx, q, approved)
+			if err != nil {
+				return nil, "", err
+			}
+			doc.Approver = &approver
+			var buf bytes.Buffer
+			err = timesheetpdf.RenderPDF(&buf, doc)
+			return buf.Bytes(), doc.User.Language, err
+		},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusConflict, errorResponse(errTimesheetStatusChanged))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, approvedTimesheetResponse{
+		Timesheet: result.Timesheet,
+		Document: timesheetDocumentResponse{
+			ID:        result.Document.ID,
+			Language:  result.Document.Language,
+			Sha256:    result.Document.Sha256,
+			CreatedAt: result.Document.CreatedAt,
+		},
+	})
+}
+
+// getTimesheetPDF writes the document of the timesheet identified by the `:id` URI parameter. Approved timesheets are
+// served as stored on approval, with the SHA-256 hash of the document as entity tag. Other timesheets are rendered as
+// a preview.
+func (server *Server) getTimesheetPDF(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	timesheet, err := server.store.GetTimesheet(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	filename := fmt.Sprintf("timesheet-%d-%s", timesheet.UserID, timesheet.PeriodStart.Format("20060102"))
+	if timesheet.Status == types.TimesheetApproved {
+		document, err := server.store.GetLatestTimesheetDocument(ctx, timesheet.ID)
+		if err == nil {
+			ctx.Header("ETag", fmt.Sprintf(`"%s"`, document.Sha256))
+			ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.pdf"`, filename))
+			ctx.Data(http.StatusOK, "application/pdf", document.Content)
+			return
+		}
+		// timesheets approved before documents were stored are rendered on request
+		if !errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	doc, err := timesheetDocument(ctx, server.store, timesheet)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if timesheet.Status == types.TimesheetApproved && timesheet.DecidedByID != nil {
+		approver, err := server.store.GetUser(ctx, *timesheet.DecidedByID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		doc.Approver = &approver
+	}
+
+	var buf bytes.Buffer
+	if err := timesheetpdf.RenderPDF(&buf, doc); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.pdf"`, filename))
+	ctx.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
+// timesheetDocument loads the user of a timesheet together with their entries, absences, holidays and work balance
+// within the period of the timesheet.
+func timesheetDocument(ctx context.Context, q db.Querier, timesheet db.Timesheet) (timesheetpdf.Document, error) {
+	doc := timesheetpdf.Document{Timesheet: timesheet}
+
+	var err error
+	doc.User, err = q.GetUser(ctx, timesheet.UserID)
+	if err != nil {
+		return doc, err
+	}
+	doc.Location, err = time.LoadLocation(doc.User.Timezone)
+	if err != nil {
+		return doc, err
+	}
+
+	start, end := worktime.Date(timesheet.PeriodStart), worktime.Date(timesheet.PeriodEnd)
+	doc.Balance, err = workBalance(ctx, q, doc.User, doc.Location, start, end, types.BalanceDay)
+	if err != nil {
+		return doc, err
+	}
+	doc.Holidays, err = worktime.LoadHolidays(ctx, q, doc.User, start, end)
+	if err != nil {
+		return doc, err
+	}
+
+	// the days of the user begin at midnight of their time zone
+	from := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, doc.Location).UTC()
+	to := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, doc.Location).UTC()
+	doc.Entries, err = q.ListTimesheetEntries(ctx, db.ListTimesheetEntriesParams{
+		UserID: doc.User.ID,
+		From:   from,
+		To:     to,
+	})
+	if err != nil {
+		return doc, err
+	}
+	doc.Absences, err = q.ListTimesheetAbsences(ctx, db.ListTimesheetAbsencesParams{
+		UserID: doc.User.ID,
+		From:   from,
+		To:     to,
+	})
+	return doc, err
+}
+
+// reopenTimesheet reopens an approved timesheet, which unlocks its entries.
+func (server *Server) reopenTimesheet(ctx *gin.Context) {
+	timesheet, ok := server.timesheetToTransition(ctx, types.TimesheetOpen)
+	if !ok {
+		return
+	}
+
+	admin, err := server.authUser(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	arg := db.ReopenTimesheetParams{
+		ID:            timesheet.ID,
+		ReopenedByID:  &admin.ID,
+		CurrentStatus: timesheet.Status,
+	}
+	timesheet, err = server.store.ReopenTimesheet(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusConflict, errorResponse(errTimesheetStatusChanged))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, timesheet)
+}
+
+// timesheetTransitionError describes a status change which is not allowed.
+func timesheetTransitionError(from, to string) error {
+	return fmt.Errorf("%w: %s timesheet cannot become %s", errInvalidTimesheetTransition, from, to)
+}
+
+// timesheetOwnerFromURI resolves the subject of a request to the owner of the timesheet identified by the `:id` URI parameter.
+func (server *Server) timesheetOwnerFromURI(ctx *gin.Context) (int64, error) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		return 0, &requestError{err}
+	}
+
+	timesheet, err := server.store.GetTimesheet(ctx, req.ID)
+	if err != nil {
+		return 0, err
+	}
+	return timesheet.UserID, nil
+}
+
+// timesheetUserFromBody resolves the subject of a request to the user the timesheet in the request body belongs to.
+func timesheetUserFromBody(ctx *gin.Context) (int64, error) {
+	var req createTimesheetRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		return 0, &requestError{err}
+	}
+	return req.UserID, nil
+}
@@ -0,0 +1,1201 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/token"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func requireBodyMatchTimesheet(t *testing.T, body *bytes.Buffer, timesheet db.Timesheet) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotTimesheet db.Timesheet
+	err = json.Unmarshal(data, &gotTimesheet)
+	require.NoError(t, err)
+	require.Equal(t, timesheet, gotTimesheet)
+}
+
+func requireBodyMatchTimesheetList(t *testing.T, body *bytes.Buffer, timesheets []db.Timesheet) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotTimesheets []db.Timesheet
+	err = json.Unmarshal(data, &gotTimesheets)
+	require.NoError(t, err)
+	require.Equal(t, timesheets, gotTimesheets)
+}
+
+// buildTimesheetDocumentStubs expects the document of timesheet to be loaded once. The user has no work schedule,
+// holidays, entries or absences.
+func buildTimesheetDocumentStubs(store *mockdb.MockStore, user db.User, timesheet db.Timesheet) {
+	store.EXPECT().
+		GetCompany(gomock.Any(), gomock.Eq(*user.CompanyID)).
+		Times(1).
+		Return(db.Company{ID: *user.CompanyID}, nil)
+	store.EXPECT().
+		ListUserWorkScheduleAssignments(gomock.Any(), gomock.Eq(user.ID)).
+		Times(1).
+		Return([]db.WorkScheduleAssignment{}, nil)
+	// once for the work calendar and once for the document
+	store.EXPECT().
+		ListCompanyHolidays(gomock.Any(), gomock.Any()).
+		Times(2).
+		Return([]db.CompanyHoliday{}, nil)
+	store.EXPECT().
+		ListDailyWorkedSeconds(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.ListDailyWorkedSecondsRow{}, nil)
+	store.EXPECT().
+		ListUserPaidAbsences(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.Absence{}, nil)
+	// the user is in UTC
+	store.EXPECT().
+		ListTimesheetEntries(gomock.Any(), gomock.Eq(db.ListTimesheetEntriesParams{
+			UserID: user.ID,
+			From:   timesheet.PeriodStart,
+			To:     timesheet.PeriodEnd,
+		})).
+		Times(1).
+		Return([]db.ListTimesheetEntriesRow{}, nil)
+	store.EXPECT().
+		ListTimesheetAbsences(gomock.Any(), gomock.Eq(db.ListTimesheetAbsencesParams{
+			UserID: user.ID,
+			From:   timesheet.PeriodStart,
+			To:     timesheet.PeriodEnd,
+		})).
+		Times(1).
+		Return([]db.ListTimesheetAbsencesRow{}, nil)
+}
+
+// sha256Hex returns the hex encoded SHA-256 hash of content
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func randomTimesheet(userID int64) db.Timesheet {
+	start := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	return db.Timesheet{
+		ID:          util.RandomInt(1, 1000),
+		UserID:      userID,
+		Period:      types.TimesheetWeek,
+		PeriodStart: start,
+		PeriodEnd:   start.AddDate(0, 0, 7),
+		Status:      types.TimesheetOpen,
+		CreatedAt:   time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func TestTimesheetBounds(t *testing.T) {
+	testCases := []struct {
+		name   string
+		period string
+		date   time.Time
+		start  time.Time
+		end    time.Time
+	}{
+		{
+			name:   "WeekFromMonday",
+			period: types.TimesheetWeek,
+			date:   time.Date(2024, time.March, 4, 13, 0, 0, 0, time.UTC),
+			start:  time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC),
+			end:    time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "WeekFromSunday",
+			period: types.TimesheetWeek,
+			date:   time.Date(2024, time.March, 10, 23, 0, 0, 0, time.UTC),
+			start:  time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC),
+			end:    time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "WeekAcrossMonths",
+			period: types.TimesheetWeek,
+			date:   time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			start:  time.Date(2024, time.February, 26, 0, 0, 0, 0, time.UTC),
+			end:    time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "Month",
+			period: types.TimesheetMonth,
+			date:   time.Date(2024, time.February, 29, 12, 0, 0, 0, time.UTC),
+			start:  time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+			end:    time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "MonthAcrossYears",
+			period: types.TimesheetMonth,
+			date:   time.Date(2023, time.December, 31, 0, 0, 0, 0, time.UTC),
+			start:  time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC),
+			end:    time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start, end := timesheetBounds(tc.period, tc.date)
+			require.Equal(t, tc.start, start)
+			require.Equal(t, tc.end, end)
+		})
+	}
+}
+
+func TestCreateTimesheetAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	manager := randomUserWithRole(types.ManagerRole)
+	user := randomUser()
+	user.ManagerID = &manager.ID
+	otherUser := randomUser()
+	timesheet := randomTimesheet(user.ID)
+
+	body := gin.H{
+		"user_id": user.ID,
+		"period":  types.TimesheetWeek,
+		"date":    timesheet.PeriodStart.AddDate(0, 0, 2),
+	}
+	arg := db.CreateTimesheetParams{
+		UserID:      user.ID,
+		Period:      types.TimesheetWeek,
+		PeriodStart: timesheet.PeriodStart,
+		PeriodEnd:   timesheet.PeriodEnd,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateTimesheet(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(timesheet, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				requireBodyMatchTimesheet(t, recorder.Body, timesheet)
+			},
+		},
+		{
+			name: "ManagerOK",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateTimesheet(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(timesheet, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "Overlap",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateTimesheet(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Timesheet{}, &pgconn.PgError{Code: db.ExclusionViolation, ConstraintName: db.TimesheetOverlapConstraint})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotManager",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(otherUser.Username)).
+					Times(1).
+					Return(otherUser, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateTimesheet(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, otherUser, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidPeriod",
+			body: gin.H{
+				"user_id": user.ID,
+				"period":  "fortnight",
+				"date":    timesheet.PeriodStart,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateTimesheet(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateTimesheet(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Timesheet{}, pgx.ErrTxClosed)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/timesheets", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeleteTimesheetAPI(t *testing.T) {
+	user := randomUser()
+	timesheet := randomTimesheet(user.ID)
+	submitted := timesheet
+	submitted.Status = types.TimesheetSubmitted
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimesheet(gomock.Any(), gomock.Eq(timesheet.ID)).
+					Times(2).
+					Return(timesheet, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				arg := db.DeleteTimesheetParams{
+					ID:            timesheet.ID,
+					CurrentStatus: types.TimesheetOpen,
+				}
+				store.EXPECT().
+					DeleteTimesheet(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(timesheet, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchTimesheet(t, recorder.Body, timesheet)
+			},
+		},
+		{
+			name: "NotOpen",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimesheet(gomock.Any(), gomock.Eq(timesheet.ID)).
+					Times(2).
+					Return(submitted, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					DeleteTimesheet(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "StatusChanged",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimesheet(gomock.Any(), gomock.Eq(timesheet.ID)).
+					Times(2).
+					Return(timesheet, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					DeleteTimesheet(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Timesheet{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimesheet(gomock.Any(), gomock.Eq(timesheet.ID)).
+					Times(1).
+					Return(db.Timesheet{}, pgx.ErrNoRows)
+				store.EXPECT().
+					DeleteTimesheet(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/timesheets/%d", timesheet.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListTimesheetsAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	manager := randomUserWithRole(types.ManagerRole)
+	user := randomUser()
+	timesheets := []db.Timesheet{randomTimesheet(user.ID), randomTimesheet(user.ID)}
+
+	testCases := []struct {
+		name          string
+		query         string
+		actor         db.User
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "AdminOK",
+			query: "limit=5&offset=0&status=submitted",
+			actor: admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				arg := db.ListTimesheetsParams{
+					CompanyID: admin.CompanyID,
+					Status:    util.Pointer(types.TimesheetSubmitted),
+					Limit:     5,
+					Offset:    0,
+				}
+				store.EXPECT().
+					ListTimesheets(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(timesheets, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchTimesheetList(t, recorder.Body, timesheets)
+			},
+		},
+		{
+			name:  "ManagerListsDirectReports",
+			query: "limit=5&offset=0",
+			actor: manager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				arg := db.ListTimesheetsParams{
+					CompanyID: manager.CompanyID,
+					ManagerID: &manager.ID,
+					Limit:     5,
+					Offset:    0,
+				}
+				store.EXPECT().
+					ListTimesheets(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(timesheets, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "Employee",
+			query: "limit=5&offset=0",
+			actor: user,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					ListTimesheets(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidStatus",
+			query: "limit=5&offset=0&status=done",
+			actor: admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					ListTimesheets(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/timesheets?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, tc.actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestTransitionTimesheetAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	manager := randomUserWithRole(types.ManagerRole)
+	user := randomUser()
+	user.ManagerID = &manager.ID
+	comment := util.RandomString(20)
+
+	open := randomTimesheet(user.ID)
+	submitted := open
+	submitted.Status = types.TimesheetSubmitted
+	decidedAt := time.Now().UTC().Truncate(time.Second)
+	approved := submitted
+	approved.Status = types.TimesheetApproved
+	approved.DecidedByID = &manager.ID
+	approved.DecidedAt = &decidedAt
+	rejected := approved
+	rejected.Status = types.TimesheetRejected
+	rejected.DecisionComment = &comment
+	reopened := approved
+	reopened.Status = types.TimesheetOpen
+	reopened.ReopenedByID = &admin.ID
+	reopened.ReopenedAt = &decidedAt
+
+	testCases := []struct {
+		name          string
+		action        string
+		actor         db.User
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "SubmitOK",
+			action: "submit",
+			actor:  user,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimesheet(gomock.Any(), gomock.Eq(open.ID)).
+					Times(2).
+					Return(open, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				arg := db.SubmitTimesheetParams{
+					ID:            open.ID,
+					CurrentStatus: types.TimesheetOpen,
+				}
+				store.EXPECT().
+					SubmitTimesheet(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(submitted, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchTimesheet(t, recorder.Body, submitted)
+			},
+		},
+		{
+			name:   "SubmitApproved",
+			action: "submit",
+			actor:  user,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimesheet(gomock.Any(), gomock.Eq(open.ID)).
+					Times(2).
+					Return(approved, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					SubmitTimesheet(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "ApproveOK",
+			action: "approve",
+			actor:  manager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimesheet(gomock.Any(), gomock.Eq(open.ID)).
+					Times(2).
+					Return(submitted, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(2).
+					Return(user, nil)
+				buildTimesheetDocumentStubs(store, user, submitted)
+				arg := db.DecideTimesheetParams{
+					ID:            open.ID,
+					Status:        types.TimesheetApproved,
+					DecidedByID:   &manager.ID,
+					CurrentStatus: types.TimesheetSubmitted,
+				}
+				store.EXPECT().
+					DecideTimesheet(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					ApproveTimesheetTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, params db.ApproveTimesheetTxParams) (db.ApproveTimesheetTxResult, error) {
+						require.Equal(t, arg, params.DecideTimesheetParams)
+						// the document is loaded within the transaction
+						content, language, err := params.Render(store, approved)
+						require.NoError(t, err)
+						require.Equal(t, user.Language, language)
+						require.True(t, bytes.HasPrefix(content, []byte("%PDF-")))
+						return db.ApproveTimesheetTxResult{
+							Timesheet: approved,
+							Document: db.TimesheetDocument{
+								ID:          1,
+								TimesheetID: approved.ID,
+								Language:    language,
+								Content:     content,
+								Sha256:      sha256Hex(content),
+								CreatedAt:   decidedAt,
+							},
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got approvedTimesheetResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, approved, got.Timesheet)
+				require.Equal(t, int64(1), got.Document.ID)
+				require.Equal(t, user.Language, got.Document.Language)
+				require.Len(t, got.Document.Sha256, 64)
+				require.NotContains(t, recorder.Body.String(), "content")
+			},
+		},
+		{
+			name:   "ApproveStatusChanged",
+			action: "approve",
+			actor:  manager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimesheet(gomock.Any(), gomock.Eq(open.ID)).
+					Times(2).
+					Return(submitted, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				// the document is not loaded as the approval fails
+				store.EXPECT().
+					ApproveTimesheetTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ApproveTimesheetTxResult{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "RejectWithCommentOK",
+			action: "reject",
+			actor:  manager,
+			body:   gin.H{"comment": comment},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimesheet(gomock.Any(), gomock.Eq(open.ID)).
+					Times(2).
+					Return(submitted, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				arg := db.DecideTimesheetParams{
+					ID:              open.ID,
+					Status:          types.TimesheetRejected,
+					DecidedByID:     &manager.ID,
+					DecisionComment: &comment,
+					CurrentStatus:   types.TimesheetSubmitted,
+				}
+				store.EXPECT().
+					DecideTimesheet(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(rejected, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchTimesheet(t, recorder.Body, rejected)
+			},
+		},
+		{
+			name:   "ApproveOpen",
+			action: "approve",
+			actor:  manager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimesheet(gomock.Any(), gomock.Eq(open.ID)).
+					Times(2).
+					Return(open, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					ApproveTimesheetTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "ApproveOwnTimesheet",
+			action: "approve",
+			actor:  user,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimesheet(gomock.Any(), gomock.Eq(open.ID)).
+					Times(1).
+					Return(submitted, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					ApproveTimesheetTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "ManagerApprovesOwnTimesheet",
+			action: "approve",
+			actor:  manager,
+			buildStubs: func(store *mockdb.MockStore) {
+				selfManaged := manager
+				selfManaged.ManagerID = &manager.ID
+				own := submitted
+				own.UserID = manager.ID
+				store.EXPECT().
+					GetTimesheet(gomock.Any(), gomock.Eq(open.ID)).
+					Times(2).
+					Return(own, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(selfManaged, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(manager.ID)).
+					Times(1).
+					Return(selfManaged, nil)
+				store.EXPECT().
+					ApproveTimesheetTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "ReopenOK",
+			action: "reopen",
+			actor:  admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimesheet(gomock.Any(), gomock.Eq(open.ID)).
+					Times(2).
+					Return(approved, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				arg := db.ReopenTimesheetParams{
+					ID:            open.ID,
+					ReopenedByID:  &admin.ID,
+					CurrentStatus: types.TimesheetApproved,
+				}
+				store.EXPECT().
+					ReopenTimesheet(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(reopened, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchTimesheet(t, recorder.Body, reopened)
+			},
+		},
+		{
+			name:   "ReopenByManager",
+			action: "reopen",
+			actor:  manager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimesheet(gomock.Any(), gomock.Eq(open.ID)).
+					Times(1).
+					Return(approved, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					AnyTimes().
+					Return(user, nil)
+				store.EXPECT().
+					ReopenTimesheet(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "StatusChanged",
+			action: "reopen",
+			actor:  admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimesheet(gomock.Any(), gomock.Eq(open.ID)).
+					Times(2).
+					Return(approved, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					ReopenTimesheet(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Timesheet{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			var body io.Reader = http.NoBody
+			if tc.body != nil {
+				jsonData, err := json.Marshal(tc.body)
+				require.NoError(t, err)
+				body = bytes.NewBuffer(jsonData)
+			}
+
+			url := fmt.Sprintf("/timesheets/%d/%s", open.ID, tc.action)
+			request, err := http.NewRequest(http.MethodPost, url, body)
+			require.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, tc.actor, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetTimesheetPDFAPI(t *testing.T) {
+	manager := randomUserWithRole(types.ManagerRole)
+	user := randomUser()
+	user.ManagerID = &manager.ID
+	other := randomUser()
+
+	submitted := randomTimesheet(user.ID)
+	submitted.Status = types.TimesheetSubmitted
+	decidedAt := time.Now().UTC().Truncate(time.Second)
+	approved := submitted
+	approved.Status = types.TimesheetApproved
+	approved.DecidedByID = &manager.ID
+	approved.DecidedAt = &decidedAt
+
+	content := []byte("%PDF-" + util.RandomString(20))
+	document := db.TimesheetDocument{
+		ID:          1,
+		TimesheetID: approved.ID,
+		Language:    user.Language,
+		Content:     content,
+		Sha256:      sha256Hex(content),
+		CreatedAt:   decidedAt,
+	}
+	filename := fmt.Sprintf(`attachment; filename="timesheet-%d-20240304.pdf"`, user.ID)
+
+	testCases := []struct {
+		name          string
+		actor         db.User
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "StoredDocument",
+			actor: manager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimesheet(gomock.Any(), gomock.Eq(approved.ID)).
+					Times(2).
+					Return(approved, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetLatestTimesheetDocument(gomock.Any(), gomock.Eq(approved.ID)).
+					Times(1).
+					Return(document, nil)
+				store.EXPECT().
+					ListTimesheetEntries(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/pdf", recorder.Header().Get("Content-Type"))
+				require.Equal(t, filename, recorder.Header().Get("Content-Disposition"))
+				require.Equal(t, fmt.Sprintf(`"%s"`, document.Sha256), recorder.Header().Get("ETag"))
+				require.Equal(t, content, recorder.Body.Bytes())
+			},
+		},
+		{
+			name:  "ApprovedWithoutDocument",
+			actor: user,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimesheet(gomock.Any(), gomock.Eq(approved.ID)).
+					Times(2).
+					Return(approved, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(2).
+					Return(user, nil)
+				store.EXPECT().
+					GetLatestTimesheetDocument(gomock.Any(), gomock.Eq(approved.ID)).
+					Times(1).
+					Return(db.TimesheetDocument{}, pgx.ErrNoRows)
+				buildTimesheetDocumentStubs(store, user, approved)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(manager.ID)).
+					Times(1).
+					Return(manager, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/pdf", recorder.Header().Get("Content-Type"))
+				require.Empty(t, recorder.Header().Get("ETag"))
+				require.True(t, bytes.HasPrefix(recorder.Body.Bytes(), []byte("%PDF-")))
+			},
+		},
+		{
+			name:  "Preview",
+			actor: user,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimesheet(gomock.Any(), gomock.Eq(submitted.ID)).
+					Times(2).
+					Return(submitted, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(2).
+					Return(user, nil)
+				store.EXPECT().
+					GetLatestTimesheetDocument(gomock.Any(), gomock.Any()).
+					Times(0)
+				buildTimesheetDocumentStubs(store, user, submitted)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, filename, recorder.Header().Get("Content-Disposition"))
+				require.True(t, bytes.HasPrefix(recorder.Body.Bytes(), []byte("%PDF-")))
+			},
+		},
+		{
+			name:  "OtherEmployee",
+			actor: other,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimesheet(gomock.Any(), gomock.Eq(approved.ID)).
+					Times(1).
+					Return(approved, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(other.Username)).
+					Times(1).
+					Return(other, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetLatestTimesheetDocument(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "NotFound",
+			actor: manager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimesheet(gomock.Any(), gomock.Eq(approved.ID)).
+					Times(1).
+					Return(db.Timesheet{}, pgx.ErrNoRows)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					AnyTimes().
+					Return(manager, nil)
+				store.EXPECT().
+					GetLatestTimesheetDocument(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/timesheets/%d/pdf", approved.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, tc.actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
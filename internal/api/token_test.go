@@ -14,6 +14,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	mockdb "github.com/mateoradman/tempus/internal/db/mock"
 	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
 	"github.com/mateoradman/tempus/internal/util"
 	"github.com/stretchr/testify/require"
 	gomock "go.uber.org/mock/gomock"
@@ -34,6 +35,16 @@ func requireBodyMatchRefreshToken(t *testing.T, body *bytes.Buffer, server *Serv
 		gotResponse.AccessTokenExpiresAt,
 		2*time.Second,
 	)
+
+	refreshPayload, err := server.tokenMaker.VerifyToken(gotResponse.RefreshToken)
+	require.NoError(t, err)
+	require.Equal(t, gotResponse.SessionID, refreshPayload.ID)
+	require.WithinDuration(
+		t,
+		time.Now().Add(server.config.RefreshTokenDuration),
+		gotResponse.RefreshTokenExpiresAt,
+		2*time.Second,
+	)
 }
 
 func TestRefreshTokenAPI(t *testing.T) {
@@ -51,6 +62,16 @@ func TestRefreshTokenAPI(t *testing.T) {
 		IsBlocked: false,
 		CreatedAt: time.Now(),
 	}
+	session.FamilyID = session.ID
+
+	// rotatesSession matches the rotation of session into a new session of the same chain
+	rotatesSession := gomock.Cond(func(x any) bool {
+		arg, ok := x.(db.RotateSessionTxParams)
+		return ok &&
+			arg.ID == session.ID &&
+			arg.CreateSessionParams.ID != session.ID &&
+			arg.RefreshToken != session.RefreshToken
+	})
 
 	testCases := []struct {
 		name          string
@@ -63,12 +84,172 @@ func TestRefreshTokenAPI(t *testing.T) {
 				store.EXPECT().
 					GetSession(gomock.Any(), gomock.Any()).
 					Times(1).Return(session, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					RotateSessionTx(gomock.Any(), rotatesSession).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.RotateSessionTxParams) (db.RotateSessionTxResult, error) {
+						rotatedAt := time.Now()
+						rotated := session
+						rotated.RotatedAt = &rotatedAt
+						next := db.Session{
+							ID:           arg.CreateSessionParams.ID,
+							Username:     session.Username,
+							RefreshToken: arg.RefreshToken,
+							ExpiresAt:    arg.ExpiresAt,
+							FamilyID:     session.FamilyID,
+							ParentID:     &session.ID,
+						}
+						return db.RotateSessionTxResult{Rotated: rotated, Session: next}, nil
+					})
+				store.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, server *Server) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 				requireBodyMatchRefreshToken(t, recorder.Body, server)
 			},
 		},
+		{
+			name: "DemotedUser",
+			buildStubs: func(store *mockdb.MockStore) {
+				demoted := user
+				demoted.Role = types.EmployeeRole
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(session, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(demoted, nil)
+				store.EXPECT().
+					RotateSessionTx(gomock.Any(), rotatesSession).
+					Times(1).
+					Return(db.RotateSessionTxResult{Session: session}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, server *Server) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got refreshTokenResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				for _, token := range []string{got.AccessToken, got.RefreshToken} {
+					payload, err := server.tokenMaker.VerifyToken(token)
+					require.NoError(t, err)
+					require.Equal(t, types.EmployeeRole, payload.Role)
+				}
+			},
+		},
+		{
+			name: "UserDoesNotExist",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(session, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(db.User{}, pgx.ErrNoRows)
+				store.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, server *Server) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "ReusedToken",
+			buildStubs: func(store *mockdb.MockStore) {
+				rotatedAt := time.Now().Add(-time.Minute)
+				rotated := session
+				rotated.RotatedAt = &rotatedAt
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(rotated, nil)
+				store.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Eq(session.FamilyID)).
+					Times(1).
+					Return(int64(3), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, server *Server) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errRefreshTokenReused.Error())
+			},
+		},
+		{
+			name: "ConcurrentRotation",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(session, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					RotateSessionTx(gomock.Any(), rotatesSession).
+					Times(1).
+					Return(db.RotateSessionTxResult{}, pgx.ErrNoRows)
+				store.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Eq(session.FamilyID)).
+					Times(1).
+					Return(int64(1), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, server *Server) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errRefreshTokenReused.Error())
+			},
+		},
+		{
+			name: "RevokeFamilyError",
+			buildStubs: func(store *mockdb.MockStore) {
+				rotatedAt := time.Now().Add(-time.Minute)
+				rotated := session
+				rotated.RotatedAt = &rotatedAt
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(rotated, nil)
+				store.EXPECT().
+					BlockSessionFamily(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), pgx.ErrTxClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, server *Server) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "RotateInternalServerError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(session, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RotateSessionTxResult{}, pgx.ErrTxClosed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, server *Server) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
 		{
 			name: "MissmatchedSessionToken",
 			buildStubs: func(store *mockdb.MockStore) {
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
 	"github.com/mateoradman/tempus/internal/util"
 )
 
@@ -21,20 +22,74 @@ type UserRequest struct {
 	BirthDate time.Time `json:"birth_date" binding:"required,lt"`
 	Gender    string    `json:"gender" binding:"omitempty,gender"`
 	Language  string    `json:"language" binding:"omitempty,language"`
-	Timezone  string    `json:"timezone" binding:"omitempty"`
+	Timezone  string    `json:"timezone" binding:"omitempty,timezone"`
 	// Optional user information
-	Country *string `json:"country,omitempty" binding:"omitempty,len=2,ascii"`
+	Country     *string `json:"country,omitempty" binding:"omitempty,len=2,ascii"`
+	Subdivision *string `json:"subdivision,omitempty" binding:"omitempty,min=1,max=3,alphanum"`
 	// Foreign keys
 	CompanyID *int64 `json:"company_id"`
 	ManagerID *int64 `json:"manager_id"`
 	TeamID    *int64 `json:"team_id"`
 }
 
+// userResponse is the public representation of a user. It never includes the password hash.
+type userResponse struct {
+	ID          int64      `json:"id"`
+	Username    string     `json:"username"`
+	Email       string     `json:"email"`
+	Name        string     `json:"name"`
+	Surname     string     `json:"surname"`
+	CompanyID   *int64     `json:"company_id"`
+	Gender      string     `json:"gender"`
+	BirthDate   time.Time  `json:"birth_date"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+	Language    string     `json:"language"`
+	Country     *string    `json:"country"`
+	Subdivision *string    `json:"subdivision"`
+	Timezone    string     `json:"timezone"`
+	ManagerID   *int64     `json:"manager_id"`
+	TeamID      *int64     `json:"team_id"`
+	Role        string     `json:"role"`
+}
+
+func newUserResponse(user db.User) userResponse {
+	return userResponse{
+		ID:          user.ID,
+		Username:    user.Username,
+		Email:       user.Email,
+		Name:        user.Name,
+		Surname:     user.Surname,
+		CompanyID:   user.CompanyID,
+		Gender:      user.Gender,
+		BirthDate:   user.BirthDate,
+		CreatedAt:   user.CreatedAt,
+		UpdatedAt:   user.UpdatedAt,
+		Language:    user.Language,
+		Country:     user.Country,
+		Subdivision: user.Subdivision,
+		Timezone:    user.Timezone,
+		ManagerID:   user.ManagerID,
+		TeamID:      user.TeamID,
+		Role:        user.Role,
+	}
+}
+
+func newUserListResponse(users []db.User) []userResponse {
+	resp := make([]userResponse, len(users))
+	for i, user := range users {
+		resp[i] = newUserResponse(user)
+	}
+	return resp
+}
+
 type createUserRequest struct {
 	Password string `json:"password" binding:"required,min=6"`
 	UserRequest
 }
 
+// createUser creates a user. Administrators create users of their own company,
+// only the superuser chooses the company of the user.
 func (server *Server) createUser(ctx *gin.Context) {
 	var req createUserRequest
 	err := ctx.ShouldBindJSON(&req)
@@ -42,6 +97,27 @@ func (server *Server) createUser(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+
+	companyID, err := server.tenantScope(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+	if companyID != nil {
+		if req.CompanyID != nil && *req.CompanyID != *companyID {
+			ctx.JSON(http.StatusNotFound, errorResponse(pgx.ErrNoRows))
+			return
+		}
+		req.CompanyID = companyID
+	}
+	if !server.validUserRelations(ctx, req.UserRequest) {
+		return
+	}
+
+	if req.Timezone == "" {
+		req.Timezone = types.DefaultTimezone
+	}
+
 	hashedPassword, err := util.HashPassword(req.Password)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -49,19 +125,20 @@ func (server *Server) createUser(ctx *gin.Context) {
 	}
 
 	arg := db.CreateUserParams{
-		Username:  req.Username,
-		Password:  hashedPassword,
-		Email:     req.Email,
-		Name:      req.Name,
-		Surname:   req.Surname,
-		CompanyID: req.CompanyID,
-		Gender:    req.Gender,
-		BirthDate: req.BirthDate,
-		Language:  req.Language,
-		Country:   req.Country,
-		Timezone:  req.Timezone,
-		ManagerID: req.ManagerID,
-		TeamID:    req.TeamID,
+		Username:    req.Username,
+		Password:    hashedPassword,
+		Email:       req.Email,
+		Name:        req.Name,
+		Surname:     req.Surname,
+		CompanyID:   req.CompanyID,
+		Gender:      req.Gender,
+		BirthDate:   req.BirthDate,
+		Language:    req.Language,
+		Country:     req.Country,
+		Subdivision: req.Subdivision,
+		Timezone:    req.Timezone,
+		ManagerID:   req.ManagerID,
+		TeamID:      req.TeamID,
 	}
 	user, err := server.store.CreateUser(ctx, arg)
 	if err != nil {
@@ -69,7 +146,7 @@ func (server *Server) createUser(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, user)
+	ctx.JSON(http.StatusCreated, newUserResponse(user))
 }
 
 func (server *Server) getUser(ctx *gin.Context) {
@@ -90,7 +167,7 @@ func (server *Server) getUser(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, user)
+	ctx.JSON(http.StatusOK, newUserResponse(user))
 }
 
 func (server *Server) deleteUser(ctx *gin.Context) {
@@ -101,7 +178,7 @@ func (server *Server) deleteUser(ctx *gin.Context) {
 		return
 	}
 
-	user, err := server.store.DeleteUser(ctx, req.ID)
+	user, err := server.store.DeleteUserTx(ctx, req.ID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
@@ -111,16 +188,17 @@ func (server *Server) deleteUser(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, user)
+	ctx.JSON(http.StatusOK, newUserResponse(user))
 }
 
 type updateUserRequest struct {
-	Name      *string    `json:"name,omitempty" binding:"omitempty,min=1"`
-	Surname   *string    `json:"surname,omitempty" binding:"omitempty,min=1"`
-	Gender    *string    `json:"gender,omitempty" binding:"omitempty,gender"`
-	BirthDate *time.Time `json:"birth_date,omitempty" binding:"omitempty,lt"`
-	Language  *string    `json:"language,omitempty" binding:"omitempty,len=2,ascii"`
-	Country   *string    `json:"country,omitempty" binding:"omitempty,len=2,ascii"`
+	Name        *string    `json:"name,omitempty" binding:"omitempty,min=1"`
+	Surname     *string    `json:"surname,omitempty" binding:"omitempty,min=1"`
+	Gender      *string    `json:"gender,omitempty" binding:"omitempty,gender"`
+	BirthDate   *time.Time `json:"birth_date,omitempty" binding:"omitempty,lt"`
+	Language    *string    `json:"language,omitempty" binding:"omitempty,len=2,ascii"`
+	Country     *string    `json:"country,omitempty" binding:"omitempty,len=2,ascii"`
+	Subdivision *string    `json:"subdivision,omitempty" binding:"omitempty,min=1,max=3,alphanum"`
 }
 
 func (server *Server) updateUser(ctx *gin.Context) {
@@ -135,7 +213,12 @@ func (server *Server) updateUser(ctx *gin.Context) {
 		return
 	}
 
-	user, err := server.store.GetUser(ctx, reqID.ID)
+	server.updateUserProfile(ctx, reqID.ID, req)
+}
+
+// updateUserProfile applies req to the user with the given ID and writes the updated user to the response.
+func (server *Server) updateUserProfile(ctx *gin.Context, userID int64, req updateUserRequest) {
+	user, err := server.store.GetUser(ctx, userID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
@@ -146,13 +229,14 @@ func (server *Server) updateUser(ctx *gin.Context) {
 	}
 
 	arg := db.UpdateUserParams{
-		ID:        user.ID,
-		Name:      req.Name,
-		Surname:   req.Surname,
-		Gender:    req.Gender,
-		BirthDate: req.BirthDate,
-		Language:  req.Language,
-		Country:   req.Country,
+		ID:          user.ID,
+		Name:        req.Name,
+		Surname:     req.Surname,
+		Gender:      req.Gender,
+		BirthDate:   req.BirthDate,
+		Language:    req.Language,
+		Country:     req.Country,
+		Subdivision: req.Subdivision,
 	}
 	user, err = server.store.UpdateUser(ctx, arg)
 	if err != nil {
@@ -160,7 +244,7 @@ func (server *Server) updateUser(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, user)
+	ctx.JSON(http.StatusOK, newUserResponse(user))
 }
 
 type listUserRequest struct {
@@ -176,9 +260,16 @@ func (server *Server) listUsers(ctx *gin.Context) {
 		return
 	}
 
+	companyID, err := server.tenantScope(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
 	arg := db.ListUsersParams{
-		Limit:  req.Limit,
-		Offset: req.Offset,
+		CompanyID: companyID,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
 	}
 	users, err := server.store.ListUsers(ctx, arg)
 	if err != nil {
@@ -186,7 +277,97 @@ func (server *Server) listUsers(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, users)
+	ctx.JSON(http.StatusOK, newUserListResponse(users))
+}
+
+type updateUserRoleRequest struct {
+	Role string `json:"role" binding:"required,role"`
+}
+
+func (server *Server) updateUserRole(ctx *gin.Context) {
+	var reqID RequestWithID
+	var req updateUserRoleRequest
+	if err := ctx.ShouldBindUri(&reqID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Role == types.SuperuserRole {
+		actor, err := server.authUser(ctx)
+		if err != nil {
+			ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+			return
+		}
+		if !isSuperuser(actor) {
+			ctx.JSON(http.StatusForbidden, errorResponse(errSuperuserRole))
+			return
+		}
+	}
+
+	arg := db.UpdateUserRoleParams{
+		ID:   reqID.ID,
+		Role: req.Role,
+	}
+	user, err := server.store.UpdateUserRole(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newUserResponse(user))
+}
+
+// validUserRelations checks that the manager and the team of a new user belong to the company of the user.
+// It writes the error response and returns false otherwise.
+func (server *Server) validUserRelations(ctx *gin.Context, req UserRequest) bool {
+	if req.ManagerID != nil {
+		manager, err := server.store.GetUser(ctx, *req.ManagerID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return false
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return false
+		}
+		if !sameCompany(manager.CompanyID, req.CompanyID) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errUserManagerOutsideCompany))
+			return false
+		}
+	}
+
+	if req.TeamID != nil {
+		team, err := server.store.GetTeam(ctx, *req.TeamID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return false
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return false
+		}
+		if !sameCompany(team.CompanyID, req.CompanyID) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errUserTeamOutsideCompany))
+			return false
+		}
+	}
+	return true
+}
+
+// userFromURI resolves the subject of a request to the user identified by the `:id` URI parameter.
+func userFromURI(ctx *gin.Context) (int64, error) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		return 0, &requestError{err}
+	}
+	return req.ID, nil
 }
 
 type loginUserRequest struct {
@@ -195,12 +376,12 @@ type loginUserRequest struct {
 }
 
 type loginUserResponse struct {
-	SessionID             uuid.UUID `json:"session_id"`
-	AccessToken           string    `json:"access_token"`
-	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
-	RefreshToken          string    `json:"refresh_token"`
-	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
-	User                  db.User   `json:"user"`
+	SessionID             uuid.UUID    `json:"session_id"`
+	AccessToken           string       `json:"access_token"`
+	AccessTokenExpiresAt  time.Time    `json:"access_token_expires_at"`
+	RefreshToken          string       `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time    `json:"refresh_token_expires_at"`
+	User                  userResponse `json:"user"`
 }
 
 func (server *Server) loginUser(ctx *gin.Context) {
@@ -225,19 +406,45 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 
-	accessToken, accessPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, server.config.AccessTokenDuration)
+	resp, err := server.createUserSession(ctx, user)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// createUserSession issues an access and a refresh token for user and stores the session of the refresh token.
+func (server *Server) createUserSession(ctx *gin.Context, user db.User) (loginUserResponse, error) {
+	resp, arg, err := server.newUserSession(ctx, user)
+	if err != nil {
+		return loginUserResponse{}, err
+	}
+
+	session, err := server.store.CreateSession(ctx, arg)
+	if err != nil {
+		return loginUserResponse{}, err
+	}
+
+	resp.SessionID = session.ID
+	return resp, nil
+}
+
+// newUserSession issues an access and a refresh token for user and returns them along with the parameters of the
+// session of the refresh token, which is left to the caller to store.
+func (server *Server) newUserSession(ctx *gin.Context, user db.User) (loginUserResponse, db.CreateSessionParams, error) {
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, server.config.AccessTokenDuration)
+	if err != nil {
+		return loginUserResponse{}, db.CreateSessionParams{}, err
+	}
+
 	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, server.config.RefreshTokenDuration)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
+		return loginUserResponse{}, db.CreateSessionParams{}, err
 	}
 
-	session, err := server.store.CreateSession(ctx, db.CreateSessionParams{
+	arg := db.CreateSessionParams{
 		ID:           refreshPayload.ID,
 		Username:     user.Username,
 		RefreshToken: refreshToken,
@@ -245,20 +452,15 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		ClientIp:     ctx.ClientIP(),
 		IsBlocked:    false,
 		ExpiresAt:    refreshPayload.ExpiredAt,
-	})
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
+		// the first session of a login starts its own rotation chain
+		FamilyID: refreshPayload.ID,
 	}
-
-	resp := loginUserResponse{
-		SessionID:             session.ID,
+	return loginUserResponse{
+		SessionID:             refreshPayload.ID,
 		AccessToken:           accessToken,
 		AccessTokenExpiresAt:  accessPayload.ExpiredAt,
 		RefreshToken:          refreshToken,
 		RefreshTokenExpiresAt: refreshPayload.ExpiredAt,
-		User:                  user,
-	}
-
-	ctx.JSON(http.StatusOK, resp)
+		User:                  newUserResponse(user),
+	}, arg, nil
 }
@@ -3,6 +3,7 @@ package api
 import (
 	validator "github.com/go-playground/validator/v10"
 	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/webhook"
 )
 
 // validGender is a custom gender validator
@@ -20,3 +21,107 @@ var validLanguage validator.Func = func(fl validator.FieldLevel) bool {
 	}
 	return false
 }
+
+// validRole is a custom role validator
+var validRole validator.Func = func(fl validator.FieldLevel) bool {
+	if role, ok := fl.Field().Interface().(string); ok {
+		return types.IsValidRole(role)
+	}
+	return false
+}
+
+// validTimesheetStatus is a custom timesheet status validator
+var validTimesheetStatus validator.Func = func(fl validator.FieldLevel) bool {
+	if status, ok := fl.Field().Interface().(string); ok {
+		return types.IsValidTimesheetStatus(status)
+	}
+	return false
+}
+
+// validTimesheetPeriod is a custom timesheet period validator
+var validTimesheetPeriod validator.Func = func(fl validator.FieldLevel) bool {
+	if period, ok := fl.Field().Interface().(string); ok {
+		return types.IsValidTimesheetPeriod(period)
+	}
+	return false
+}
+
+// validInvoiceStatus is a custom invoice status validator
+var validInvoiceStatus validator.Func = func(fl validator.FieldLevel) bool {
+	if status, ok := fl.Field().Interface().(string); ok {
+		return types.IsValidInvoiceStatus(status)
+	}
+	return false
+}
+
+// validBalancePeriod is a custom work balance period validator
+var validBalancePeriod validator.Func = func(fl validator.FieldLevel) bool {
+	if period, ok := fl.Field().Interface().(string); ok {
+		return types.IsValidBalancePeriod(period)
+	}
+	return false
+}
+
+// validAccrual is a custom leave accrual validator
+var validAccrual validator.Func = func(fl validator.FieldLevel) bool {
+	if accrual, ok := fl.Field().Interface().(string); ok {
+		return types.IsValidAccrual(accrual)
+	}
+	return false
+}
+
+// validAbsenceKind is a custom absence kind validator
+var validAbsenceKind validator.Func = func(fl validator.FieldLevel) bool {
+	if kind, ok := fl.Field().Interface().(string); ok {
+		return types.IsValidAbsenceKind(kind)
+	}
+	return false
+}
+
+// validAbsenceStatus is a custom absence status validator
+var validAbsenceStatus validator.Func = func(fl validator.FieldLevel) bool {
+	if status, ok := fl.Field().Interface().(string); ok {
+		return types.IsValidAbsenceStatus(status)
+	}
+	return false
+}
+
+// validExportFormat is a custom export format validator
+var validExportFormat validator.Func = func(fl validator.FieldLevel) bool {
+	if format, ok := fl.Field().Interface().(string); ok {
+		return types.IsValidExportFormat(format)
+	}
+	return false
+}
+
+// validWebhookEvent is a custom webhook event type validator
+var validWebhookEvent validator.Func = func(fl validator.FieldLevel) bool {
+	if event, ok := fl.Field().Interface().(string); ok {
+		return types.IsValidWebhookEvent(event)
+	}
+	return false
+}
+
+// validJobStatus is a custom job status validator
+var validJobStatus validator.Func = func(fl validator.FieldLevel) bool {
+	if status, ok := fl.Field().Interface().(string); ok {
+		return types.IsValidJobStatus(status)
+	}
+	return false
+}
+
+// validTimezone is a custom time zone validator
+var validTimezone validator.Func = func(fl validator.FieldLevel) bool {
+	if name, ok := fl.Field().Interface().(string); ok {
+		return types.IsValidTimezone(name)
+	}
+	return false
+}
+
+// validPublicURL is a custom validator of URLs which do not point to localhost or a private network
+var validPublicURL validator.Func = func(fl validator.FieldLevel) bool {
+	if rawURL, ok := fl.Field().Interface().(string); ok {
+		return webhook.IsPublicURL(rawURL)
+	}
+	return false
+}
@@ -0,0 +1,271 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+)
+
+// webhookSecretBytes is the number of random bytes of the signing secret of a webhook subscription
+const webhookSecretBytes = 32
+
+type webhookRequest struct {
+	URL    string   `json:"url" binding:"required,http_url,public_url,max=2048"`
+	Events []string `json:"events" binding:"required,min=1,dive,webhook_event"`
+	Active *bool    `json:"active"`
+}
+
+type webhookURIRequest struct {
+	ID        int64 `uri:"id" binding:"required,min=1"`
+	WebhookID int64 `uri:"webhook_id" binding:"required,min=1"`
+}
+
+type webhookDeliveryURIRequest struct {
+	ID         int64 `uri:"id" binding:"required,min=1"`
+	WebhookID  int64 `uri:"webhook_id" binding:"required,min=1"`
+	DeliveryID int64 `uri:"delivery_id" binding:"required,min=1"`
+}
+
+// webhookResponse describes a webhook subscription without its secret, which is only returned when the subscription
+// is created
+type webhookResponse struct {
+	ID        int64      `json:"id"`
+	CompanyID int64      `json:"company_id"`
+	URL       string     `json:"url"`
+	Events    []string   `json:"events"`
+	Active    bool       `json:"active"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func newWebhookResponse(subscription db.WebhookSubscription) webhookResponse {
+	return webhookResponse{
+		ID:        subscription.ID,
+		CompanyID: subscription.CompanyID,
+		URL:       subscription.Url,
+		Events:    subscription.Events,
+		Active:    subscription.Active,
+		CreatedAt: subscription.CreatedAt,
+		UpdatedAt: subscription.UpdatedAt,
+	}
+}
+
+// createWebhookResponse holds the secret deliveries of a new subscription are signed with
+type createWebhookResponse struct {
+	webhookResponse
+	Secret string `json:"secret"`
+}
+
+// createWebhook subscribes a URL to events of the company. The response holds the secret the HMAC-SHA256 signature
+// of every delivery is computed with.
+func (server *Server) createWebhook(ctx *gin.Context) {
+	var reqID RequestWithID
+	var req webhookRequest
+	if err := ctx.ShouldBindUri(&reqID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	secret := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	subscription, err := server.store.CreateWebhookSubscription(ctx, db.CreateWebhookSubscriptionParams{
+		CompanyID: reqID.ID,
+		Url:       req.URL,
+		Secret:    hex.EncodeToString(secret),
+		Events:    req.Events,
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.ForeignKeyViolation {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, createWebhookResponse{
+		webhookResponse: newWebhookResponse(subscription),
+		Secret:          subscription.Secret,
+	})
+}
+
+func (server *Server) listWebhooks(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	subscriptions, err := server.store.ListWebhookSubscriptions(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	response := make([]webhookResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		response = append(response, newWebhookResponse(subscription))
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// updateWebhook replaces the URL and the events of a subscription. Inactive subscriptions receive no new deliveries,
+// the pending deliveries of a subscription are still attempted.
+func (server *Server) updateWebhook(ctx *gin.Context) {
+	var reqURI webhookURIRequest
+	var req webhookRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateWebhookSubscriptionParams{
+		ID:        reqURI.WebhookID,
+		CompanyID: reqURI.ID,
+		Url:       req.URL,
+		Events:    req.Events,
+		Active:    true,
+	}
+	if req.Active != nil {
+		arg.Active = *req.Active
+	}
+	subscription, err := server.store.UpdateWebhookSubscription(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newWebhookResponse(subscription))
+}
+
+// deleteWebhook deletes a subscription together with its delivery log
+func (server *Server) deleteWebhook(ctx *gin.Context) {
+	var req webhookURIRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	subscription, err := server.store.DeleteWebhookSubscription(ctx, db.DeleteWebhookSubscriptionParams{
+		ID:        req.WebhookID,
+		CompanyID: req.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newWebhookResponse(subscription))
+}
+
+// listWebhookDeliveries returns the delivery log of a subscription, latest deliveries first
+func (server *Server) listWebhookDeliveries(ctx *gin.Context) {
+	var reqURI webhookURIRequest
+	var req PaginationRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	subscription, ok := server.companyWebhook(ctx, reqURI.ID, reqURI.WebhookID)
+	if !ok {
+		return
+	}
+
+	deliveries, err := server.store.ListWebhookDeliveries(ctx, db.ListWebhookDeliveriesParams{
+		SubscriptionID: subscription.ID,
+		Limit:          req.Limit,
+		Offset:         req.Offset,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, deliveries)
+}
+
+// redeliverWebhook queues a new delivery of the event of an earlier delivery, whatever the outcome of the earlier one
+func (server *Server) redeliverWebhook(ctx *gin.Context) {
+	var req webhookDeliveryURIRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	subscription, ok := server.companyWebhook(ctx, req.ID, req.WebhookID)
+	if !ok {
+		return
+	}
+
+	delivery, err := server.store.GetWebhookDelivery(ctx, db.GetWebhookDeliveryParams{
+		ID:             req.DeliveryID,
+		SubscriptionID: subscription.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	delivery, err = server.store.CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, delivery)
+}
+
+// companyWebhook returns the subscription of a company. It writes the error response and returns false otherwise.
+func (server *Server) companyWebhook(ctx *gin.Context, companyID, subscriptionID int64) (db.WebhookSubscription, bool) {
+	subscription, err := server.store.GetWebhookSubscription(ctx, db.GetWebhookSubscriptionParams{
+		ID:        subscriptionID,
+		CompanyID: companyID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return db.WebhookSubscription{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.WebhookSubscription{}, false
+	}
+	return subscription, true
+}
@@ -0,0 +1,316 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func randomWebhook() db.WebhookSubscription {
+	return db.WebhookSubscription{
+		ID:        util.RandomInt(1, 1000),
+		CompanyID: testCompanyID,
+		Url:       "https://example.com/hooks",
+		Secret:    util.RandomString(64),
+		Events:    []string{types.EventEntryCreated, types.EventAbsenceApproved},
+		Active:    true,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+func TestCreateWebhookAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	employee := randomUser()
+	webhook := randomWebhook()
+
+	testCases := []struct {
+		name          string
+		actor         db.User
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			actor: admin,
+			body:  gin.H{"url": webhook.Url, "events": webhook.Events},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					CreateWebhookSubscription(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateWebhookSubscriptionParams) (db.WebhookSubscription, error) {
+						require.Equal(t, testCompanyID, arg.CompanyID)
+						require.Equal(t, webhook.Url, arg.Url)
+						require.Equal(t, webhook.Events, arg.Events)
+						require.Len(t, arg.Secret, 2*webhookSecretBytes)
+						created := webhook
+						created.Secret = arg.Secret
+						return created, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var got createWebhookResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got.Secret, 2*webhookSecretBytes)
+				require.Equal(t, webhook.Url, got.URL)
+			},
+		},
+		{
+			name:  "UnknownEvent",
+			actor: admin,
+			body:  gin.H{"url": webhook.Url, "events": []string{"entry.exploded"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					CreateWebhookSubscription(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidURL",
+			actor: admin,
+			body:  gin.H{"url": "ftp://example.com", "events": webhook.Events},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					CreateWebhookSubscription(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "PrivateURL",
+			actor: admin,
+			body:  gin.H{"url": "http://169.254.169.254/latest/meta-data", "events": webhook.Events},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					CreateWebhookSubscription(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "Forbidden",
+			actor: employee,
+			body:  gin.H{"url": webhook.Url, "events": webhook.Events},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(employee.Username)).
+					Times(1).
+					Return(employee, nil)
+				store.EXPECT().
+					CreateWebhookSubscription(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/companies/%d/webhooks", testCompanyID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
+			require.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, tc.actor, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListWebhooksAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	webhook := randomWebhook()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+		Times(1).
+		Return(admin, nil)
+	store.EXPECT().
+		ListWebhookSubscriptions(gomock.Any(), gomock.Eq(testCompanyID)).
+		Times(1).
+		Return([]db.WebhookSubscription{webhook}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/companies/%d/webhooks", testCompanyID)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	// secrets are only returned when a subscription is created
+	require.NotContains(t, recorder.Body.String(), webhook.Secret)
+}
+
+func TestRedeliverWebhookAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	webhook := randomWebhook()
+	delivery := db.WebhookDelivery{
+		ID:             util.RandomInt(1, 1000),
+		SubscriptionID: webhook.ID,
+		EventID:        util.RandomInt(1, 1000),
+		Status:         types.DeliveryFailed,
+		Attempts:       10,
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWebhookSubscription(gomock.Any(), gomock.Eq(db.GetWebhookSubscriptionParams{ID: webhook.ID, CompanyID: testCompanyID})).
+					Times(1).
+					Return(webhook, nil)
+				store.EXPECT().
+					GetWebhookDelivery(gomock.Any(), gomock.Eq(db.GetWebhookDeliveryParams{ID: delivery.ID, SubscriptionID: webhook.ID})).
+					Times(1).
+					Return(delivery, nil)
+				store.EXPECT().
+					CreateWebhookDelivery(gomock.Any(), gomock.Eq(db.CreateWebhookDeliveryParams{
+						SubscriptionID: webhook.ID,
+						EventID:        delivery.EventID,
+					})).
+					Times(1).
+					Return(db.WebhookDelivery{
+						ID:             delivery.ID + 1,
+						SubscriptionID: webhook.ID,
+						EventID:        delivery.EventID,
+						Status:         types.DeliveryPending,
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var got db.WebhookDelivery
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, types.DeliveryPending, got.Status)
+				require.Equal(t, delivery.EventID, got.EventID)
+			},
+		},
+		{
+			name: "WebhookNotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWebhookSubscription(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.WebhookSubscription{}, pgx.ErrNoRows)
+				store.EXPECT().
+					CreateWebhookDelivery(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "DeliveryNotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWebhookSubscription(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(webhook, nil)
+				store.EXPECT().
+					GetWebhookDelivery(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.WebhookDelivery{}, pgx.ErrNoRows)
+				store.EXPECT().
+					CreateWebhookDelivery(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+				Times(1).
+				Return(admin, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/companies/%d/webhooks/%d/deliveries/%d/redeliver", testCompanyID, webhook.ID, delivery.ID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/worktime"
+)
+
+// maxBalanceDays limits the range of a work balance
+const maxBalanceDays = 366
+
+type workBalanceRequest struct {
+	From   time.Time `form:"from" binding:"required"`
+	To     time.Time `form:"to" binding:"required,gtfield=From"`
+	Period string    `form:"period,default=day" binding:"balance_period"`
+}
+
+// workBalanceResponse is the work balance of a user, whose days begin at midnight of their time zone
+type workBalanceResponse struct {
+	UserID   int64  `json:"user_id"`
+	Timezone string `json:"timezone"`
+	worktime.Balance
+}
+
+func (server *Server) getUserWorkBalance(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.GetUser(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.workBalanceOfUser(ctx, user)
+}
+
+// workBalanceOfUser writes the balance of the time the user worked or spent on paid absences against the target of
+// their work schedules for the calendar days within [from, to), together with the balance carried forward into it.
+func (server *Server) workBalanceOfUser(ctx *gin.Context, user db.User) {
+	var req workBalanceRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	from, to := worktime.Date(req.From), worktime.Date(req.To)
+	if !to.After(from) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidTimeRange))
+		return
+	}
+	if to.After(from.AddDate(0, 0, maxBalanceDays)) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errBalanceRangeTooLong))
+		return
+	}
+
+	location, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	balance, err := workBalance(ctx, server.store, user, location, from, to, req.Period)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, workBalanceResponse{
+		UserID:   user.ID,
+		Timezone: user.Timezone,
+		Balance:  balance,
+	})
+}
+
+// workBalance balances the time the user worked or spent on paid absences against the target of their work
+// schedules for the calendar days within [from, to), grouped by period.
+func workBalance(ctx context.Context, q db.Querier, user db.User, location *time.Location, from, to time.Time, period string) (worktime.Balance, error) {
+	calendar, err := worktime.LoadCalendar(ctx, q, user, location, to)
+	if err != nil {
+		return worktime.Balance{}, err
+	}
+
+	first := from
+	if calendar.Start.Before(first) {
+		first = calendar.Start
+	}
+	worked, err := q.ListDailyWorkedSeconds(ctx, db.ListDailyWorkedSecondsParams{
+		UserID: user.ID,
+		From:   first,
+		To:     to,
+	})
+	if err != nil {
+		return worktime.Balance{}, err
+	}
+	// a day of the user begins up to a day before or after midnight UTC of its date
+	absences, err := q.ListUserPaidAbsences(ctx, db.ListUserPaidAbsencesParams{
+		UserID: user.ID,
+		From:   first.AddDate(0, 0, -1),
+		To:     to.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		return worktime.Balance{}, err
+	}
+
+	return calendar.Compute(from, to, period, worked, absences), nil
+}
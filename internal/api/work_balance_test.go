@@ -0,0 +1,222 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestGetMyWorkBalanceAPI(t *testing.T) {
+	user := randomUser()
+	user.Timezone = "Europe/Zagreb"
+	user.CreatedAt = time.Date(2024, time.February, 29, 23, 30, 0, 0, time.UTC)
+	schedule := randomWorkSchedule()
+	company := db.Company{ID: testCompanyID, DefaultWorkScheduleID: &schedule.ID}
+
+	from := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC)
+	// midnight in Zagreb is still the last day of February in UTC
+	start := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			query: url.Values{
+				"from":   {from.Format(time.RFC3339)},
+				"to":     {to.Format(time.RFC3339)},
+				"period": {types.BalanceWeek},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetCompany(gomock.Any(), gomock.Eq(testCompanyID)).
+					Times(1).
+					Return(company, nil)
+				store.EXPECT().
+					GetWorkSchedule(gomock.Any(), gomock.Eq(schedule.ID)).
+					Times(1).
+					Return(schedule, nil)
+				store.EXPECT().
+					ListUserWorkScheduleAssignments(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.WorkScheduleAssignment{}, nil)
+				store.EXPECT().
+					ListCompanyHolidays(gomock.Any(), gomock.Eq(db.ListCompanyHolidaysParams{
+						CompanyID: testCompanyID,
+						From:      start,
+						To:        to,
+					})).
+					Times(1).
+					Return([]db.CompanyHoliday{}, nil)
+				store.EXPECT().
+					ListDailyWorkedSeconds(gomock.Any(), gomock.Eq(db.ListDailyWorkedSecondsParams{
+						UserID: user.ID,
+						From:   start,
+						To:     to,
+					})).
+					Times(1).
+					Return([]db.ListDailyWorkedSecondsRow{
+						{Day: start, WorkedSeconds: 9 * 3600},
+						{Day: from, WorkedSeconds: 10 * 3600},
+					}, nil)
+				store.EXPECT().
+					ListUserPaidAbsences(gomock.Any(), gomock.Eq(db.ListUserPaidAbsencesParams{
+						UserID: user.ID,
+						From:   start.AddDate(0, 0, -1),
+						To:     to.AddDate(0, 0, 1),
+					})).
+					Times(1).
+					Return([]db.Absence{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got workBalanceResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, user.ID, got.UserID)
+				require.Equal(t, user.Timezone, got.Timezone)
+				require.Equal(t, types.BalanceWeek, got.Period)
+				require.Equal(t, int64(3600), got.CarriedForwardSeconds)
+				require.Len(t, got.Periods, 1)
+				require.Equal(t, int64(40*3600), got.TargetSeconds)
+				require.Equal(t, int64(-30*3600), got.BalanceSeconds)
+				require.Equal(t, int64(-29*3600), got.ClosingSeconds)
+			},
+		},
+		{
+			name: "InvalidPeriod",
+			query: url.Values{
+				"from":   {from.Format(time.RFC3339)},
+				"to":     {to.Format(time.RFC3339)},
+				"period": {"year"},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					ListDailyWorkedSeconds(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ToBeforeFrom",
+			query: url.Values{
+				"from": {to.Format(time.RFC3339)},
+				"to":   {from.Format(time.RFC3339)},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					ListDailyWorkedSeconds(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "RangeTooLong",
+			query: url.Values{
+				"from": {from.Format(time.RFC3339)},
+				"to":   {from.AddDate(1, 1, 0).Format(time.RFC3339)},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					ListDailyWorkedSeconds(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/me/work-balance?"+tc.query.Encode(), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, user, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetUserWorkBalanceAPI(t *testing.T) {
+	user := randomUser()
+	other := randomUser()
+
+	query := url.Values{
+		"from": {"2024-03-04T00:00:00Z"},
+		"to":   {"2024-03-11T00:00:00Z"},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetUserByUsername(gomock.Any(), gomock.Eq(other.Username)).
+		Times(1).
+		Return(other, nil)
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.ID)).
+		Times(1).
+		Return(user, nil)
+	store.EXPECT().
+		ListDailyWorkedSeconds(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	path := fmt.Sprintf("/users/%d/work-balance?%s", user.ID, query.Encode())
+	request, err := http.NewRequest(http.MethodGet, path, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, other, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+}
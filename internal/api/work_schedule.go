@@ -0,0 +1,351 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/jackc/pgx/v5"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+)
+
+// workScheduleRequest is the weekly pattern of a work schedule. The minutes of every weekday are the working
+// time of a full-time employee, which is scaled by the part-time percentage.
+type workScheduleRequest struct {
+	Name             string `json:"name" binding:"required,min=1,max=255"`
+	MondayMinutes    int32  `json:"monday_minutes" binding:"min=0,max=1440"`
+	TuesdayMinutes   int32  `json:"tuesday_minutes" binding:"min=0,max=1440"`
+	WednesdayMinutes int32  `json:"wednesday_minutes" binding:"min=0,max=1440"`
+	ThursdayMinutes  int32  `json:"thursday_minutes" binding:"min=0,max=1440"`
+	FridayMinutes    int32  `json:"friday_minutes" binding:"min=0,max=1440"`
+	SaturdayMinutes  int32  `json:"saturday_minutes" binding:"min=0,max=1440"`
+	SundayMinutes    int32  `json:"sunday_minutes" binding:"min=0,max=1440"`
+	PartTimePercent  int32  `json:"part_time_percent" binding:"required,min=1,max=100"`
+}
+
+type createWorkScheduleRequest struct {
+	workScheduleRequest
+	CompanyID int64 `json:"company_id" binding:"required,min=1"`
+}
+
+func (server *Server) createWorkSchedule(ctx *gin.Context) {
+	var req createWorkScheduleRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateWorkScheduleParams{
+		CompanyID:        req.CompanyID,
+		Name:             req.Name,
+		MondayMinutes:    req.MondayMinutes,
+		TuesdayMinutes:   req.TuesdayMinutes,
+		WednesdayMinutes: req.WednesdayMinutes,
+		ThursdayMinutes:  req.ThursdayMinutes,
+		FridayMinutes:    req.FridayMinutes,
+		SaturdayMinutes:  req.SaturdayMinutes,
+		SundayMinutes:    req.SundayMinutes,
+		PartTimePercent:  req.PartTimePercent,
+	}
+	schedule, err := server.store.CreateWorkSchedule(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, schedule)
+}
+
+func (server *Server) getWorkSchedule(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	schedule, err := server.store.GetWorkSchedule(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedule)
+}
+
+// updateWorkSchedule replaces the pattern of a work schedule. The balances of its users are recalculated with it,
+// assign a new schedule from a day on to keep past balances instead.
+func (server *Server) updateWorkSchedule(ctx *gin.Context) {
+	var reqID RequestWithID
+	var req workScheduleRequest
+	if err := ctx.ShouldBindUri(&reqID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateWorkScheduleParams{
+		ID:               reqID.ID,
+		Name:             req.Name,
+		MondayMinutes:    req.MondayMinutes,
+		TuesdayMinutes:   req.TuesdayMinutes,
+		WednesdayMinutes: req.WednesdayMinutes,
+		ThursdayMinutes:  req.ThursdayMinutes,
+		FridayMinutes:    req.FridayMinutes,
+		SaturdayMinutes:  req.SaturdayMinutes,
+		SundayMinutes:    req.SundayMinutes,
+		PartTimePercent:  req.PartTimePercent,
+	}
+	schedule, err := server.store.UpdateWorkSchedule(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedule)
+}
+
+func (server *Server) deleteWorkSchedule(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	schedule, err := server.store.DeleteWorkSchedule(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if db.ErrorCode(err) == db.ForeignKeyViolation {
+			ctx.JSON(http.StatusConflict, errorResponse(errWorkScheduleAssigned))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedule)
+}
+
+func (server *Server) listWorkSchedules(ctx *gin.Context) {
+	var req PaginationRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	companyID, err := server.tenantScope(ctx)
+	if err != nil {
+		ctx.JSON(authorizationErrorStatus(err), errorResponse(err))
+		return
+	}
+
+	arg := db.ListWorkSchedulesParams{
+		CompanyID: companyID,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+	}
+	schedules, err := server.store.ListWorkSchedules(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedules)
+}
+
+type updateCompanyWorkScheduleRequest struct {
+	WorkScheduleID *int64 `json:"work_schedule_id" binding:"omitempty,min=1"`
+}
+
+// updateCompanyWorkSchedule sets the schedule followed by the users of a company who have no schedule assigned.
+// A null schedule removes the default.
+func (server *Server) updateCompanyWorkSchedule(ctx *gin.Context) {
+	var reqID RequestWithID
+	var req updateCompanyWorkScheduleRequest
+	if err := ctx.ShouldBindUri(&reqID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.WorkScheduleID != nil && !server.validCompanyWorkSchedule(ctx, *req.WorkScheduleID, &reqID.ID) {
+		return
+	}
+
+	arg := db.UpdateCompanyWorkScheduleParams{
+		ID:             reqID.ID,
+		WorkScheduleID: req.WorkScheduleID,
+	}
+	company, err := server.store.UpdateCompanyWorkSchedule(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, company)
+}
+
+type createWorkScheduleAssignmentRequest struct {
+	WorkScheduleID int64     `json:"work_schedule_id" binding:"required,min=1"`
+	EffectiveFrom  time.Time `json:"effective_from" binding:"required"`
+}
+
+// createWorkScheduleAssignment makes a user follow a work schedule from a day on.
+func (server *Server) createWorkScheduleAssignment(ctx *gin.Context) {
+	var reqID RequestWithID
+	var req createWorkScheduleAssignmentRequest
+	if err := ctx.ShouldBindUri(&reqID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.GetUser(ctx, reqID.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if !server.validCompanyWorkSchedule(ctx, req.WorkScheduleID, user.CompanyID) {
+		return
+	}
+
+	arg := db.CreateWorkScheduleAssignmentParams{
+		UserID:         user.ID,
+		WorkScheduleID: req.WorkScheduleID,
+		EffectiveFrom:  req.EffectiveFrom,
+	}
+	assignment, err := server.store.CreateWorkScheduleAssignment(ctx, arg)
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation && db.ConstraintName(err) == db.WorkScheduleEffectiveFromConstraint {
+			ctx.JSON(http.StatusConflict, errorResponse(errWorkScheduleEffectiveFromTaken))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, assignment)
+}
+
+func (server *Server) listUserWorkScheduleAssignments(ctx *gin.Context) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	assignments, err := server.store.ListUserWorkScheduleAssignments(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, assignments)
+}
+
+type userWorkScheduleAssignmentRequest struct {
+	ID           int64 `uri:"id" binding:"required,min=1"`
+	AssignmentID int64 `uri:"assignment_id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteWorkScheduleAssignment(ctx *gin.Context) {
+	var req userWorkScheduleAssignmentRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	assignment, err := server.store.GetWorkScheduleAssignment(ctx, req.AssignmentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if assignment.UserID != req.ID {
+		ctx.JSON(http.StatusNotFound, errorResponse(errAssignmentOutsideUser))
+		return
+	}
+
+	assignment, err = server.store.DeleteWorkScheduleAssignment(ctx, assignment.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, assignment)
+}
+
+// validCompanyWorkSchedule checks that the work schedule exists and belongs to the company.
+// It writes the error response and returns false otherwise.
+func (server *Server) validCompanyWorkSchedule(ctx *gin.Context, scheduleID int64, companyID *int64) bool {
+	schedule, err := server.store.GetWorkSchedule(ctx, scheduleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if !sameCompany(&schedule.CompanyID, companyID) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errWorkScheduleOutsideCompany))
+		return false
+	}
+	return true
+}
+
+// workScheduleCompanyFromURI resolves the company a request acts upon to the company of the work schedule
+// identified by the `:id` URI parameter.
+func (server *Server) workScheduleCompanyFromURI(ctx *gin.Context) (*int64, error) {
+	var req RequestWithID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		return nil, &requestError{err}
+	}
+	schedule, err := server.store.GetWorkSchedule(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &schedule.CompanyID, nil
+}
+
+// workScheduleCompanyFromBody resolves the company a request acts upon to the company of the work schedule in the request body.
+func workScheduleCompanyFromBody(ctx *gin.Context) (*int64, error) {
+	var req createWorkScheduleRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		return nil, &requestError{err}
+	}
+	return &req.CompanyID, nil
+}
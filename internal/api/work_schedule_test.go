@@ -0,0 +1,703 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/token"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func requireBodyMatchWorkSchedule(t *testing.T, body *bytes.Buffer, schedule db.WorkSchedule) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotSchedule db.WorkSchedule
+	err = json.Unmarshal(data, &gotSchedule)
+	require.NoError(t, err)
+	require.Equal(t, schedule, gotSchedule)
+}
+
+func randomWorkSchedule() db.WorkSchedule {
+	return db.WorkSchedule{
+		ID:               util.RandomInt(1, 1000),
+		CompanyID:        testCompanyID,
+		Name:             util.RandomString(10),
+		MondayMinutes:    480,
+		TuesdayMinutes:   480,
+		WednesdayMinutes: 480,
+		ThursdayMinutes:  480,
+		FridayMinutes:    480,
+		PartTimePercent:  100,
+		CreatedAt:        time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func workScheduleBody(schedule db.WorkSchedule) gin.H {
+	return gin.H{
+		"company_id":        schedule.CompanyID,
+		"name":              schedule.Name,
+		"monday_minutes":    schedule.MondayMinutes,
+		"tuesday_minutes":   schedule.TuesdayMinutes,
+		"wednesday_minutes": schedule.WednesdayMinutes,
+		"thursday_minutes":  schedule.ThursdayMinutes,
+		"friday_minutes":    schedule.FridayMinutes,
+		"saturday_minutes":  schedule.SaturdayMinutes,
+		"sunday_minutes":    schedule.SundayMinutes,
+		"part_time_percent": schedule.PartTimePercent,
+	}
+}
+
+func TestCreateWorkScheduleAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	manager := randomUserWithRole(types.ManagerRole)
+	schedule := randomWorkSchedule()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: workScheduleBody(schedule),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				arg := db.CreateWorkScheduleParams{
+					CompanyID:        schedule.CompanyID,
+					Name:             schedule.Name,
+					MondayMinutes:    schedule.MondayMinutes,
+					TuesdayMinutes:   schedule.TuesdayMinutes,
+					WednesdayMinutes: schedule.WednesdayMinutes,
+					ThursdayMinutes:  schedule.ThursdayMinutes,
+					FridayMinutes:    schedule.FridayMinutes,
+					PartTimePercent:  schedule.PartTimePercent,
+				}
+				store.EXPECT().
+					CreateWorkSchedule(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(schedule, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				requireBodyMatchWorkSchedule(t, recorder.Body, schedule)
+			},
+		},
+		{
+			name: "OtherCompanyNotFound",
+			body: func() gin.H {
+				body := workScheduleBody(schedule)
+				body["company_id"] = testCompanyID + 1
+				return body
+			}(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					CreateWorkSchedule(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidPartTimePercent",
+			body: func() gin.H {
+				body := workScheduleBody(schedule)
+				body["part_time_percent"] = 120
+				return body
+			}(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					CreateWorkSchedule(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidDayMinutes",
+			body: func() gin.H {
+				body := workScheduleBody(schedule)
+				body["sunday_minutes"] = 1500
+				return body
+			}(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					CreateWorkSchedule(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden",
+			body: workScheduleBody(schedule),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(manager.Username)).
+					Times(1).
+					Return(manager, nil)
+				store.EXPECT().
+					CreateWorkSchedule(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, util.AuthTypeBearer, manager, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/work-schedules", bytes.NewBuffer(jsonData))
+			require.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			tc.setupAuth(t, request, server.tokenMaker)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeleteWorkScheduleAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	schedule := randomWorkSchedule()
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWorkSchedule(gomock.Any(), gomock.Eq(schedule.ID)).
+					Times(1).
+					Return(schedule, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					DeleteWorkSchedule(gomock.Any(), gomock.Eq(schedule.ID)).
+					Times(1).
+					Return(schedule, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchWorkSchedule(t, recorder.Body, schedule)
+			},
+		},
+		{
+			name: "Assigned",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWorkSchedule(gomock.Any(), gomock.Eq(schedule.ID)).
+					Times(1).
+					Return(schedule, nil)
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					DeleteWorkSchedule(gomock.Any(), gomock.Eq(schedule.ID)).
+					Times(1).
+					Return(db.WorkSchedule{}, &pgconn.PgError{Code: db.ForeignKeyViolation})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetWorkSchedule(gomock.Any(), gomock.Eq(schedule.ID)).
+					Times(1).
+					Return(db.WorkSchedule{}, pgx.ErrNoRows)
+				store.EXPECT().
+					DeleteWorkSchedule(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/work-schedules/%d", schedule.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestUpdateCompanyWorkScheduleAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	schedule := randomWorkSchedule()
+	otherSchedule := randomWorkSchedule()
+	otherSchedule.CompanyID = testCompanyID + 1
+	company := db.Company{
+		ID:                    testCompanyID,
+		Name:                  util.RandomString(10),
+		DefaultWorkScheduleID: &schedule.ID,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"work_schedule_id": schedule.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetWorkSchedule(gomock.Any(), gomock.Eq(schedule.ID)).
+					Times(1).
+					Return(schedule, nil)
+				arg := db.UpdateCompanyWorkScheduleParams{
+					ID:             testCompanyID,
+					WorkScheduleID: &schedule.ID,
+				}
+				store.EXPECT().
+					UpdateCompanyWorkSchedule(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(company, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchCompany(t, recorder.Body, company)
+			},
+		},
+		{
+			name: "Unset",
+			body: gin.H{"work_schedule_id": nil},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetWorkSchedule(gomock.Any(), gomock.Any()).
+					Times(0)
+				arg := db.UpdateCompanyWorkScheduleParams{ID: testCompanyID}
+				store.EXPECT().
+					UpdateCompanyWorkSchedule(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Company{ID: testCompanyID}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ScheduleOutsideCompany",
+			body: gin.H{"work_schedule_id": otherSchedule.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetWorkSchedule(gomock.Any(), gomock.Eq(otherSchedule.ID)).
+					Times(1).
+					Return(otherSchedule, nil)
+				store.EXPECT().
+					UpdateCompanyWorkSchedule(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/companies/%d/work-schedule", testCompanyID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(jsonData))
+			require.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCreateWorkScheduleAssignmentAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	user := randomUser()
+	schedule := randomWorkSchedule()
+	otherSchedule := randomWorkSchedule()
+	otherSchedule.CompanyID = testCompanyID + 1
+	effectiveFrom := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	assignment := db.WorkScheduleAssignment{
+		ID:             util.RandomInt(1, 1000),
+		UserID:         user.ID,
+		WorkScheduleID: schedule.ID,
+		EffectiveFrom:  effectiveFrom,
+		CreatedAt:      time.Now().UTC().Truncate(time.Second),
+	}
+	arg := db.CreateWorkScheduleAssignmentParams{
+		UserID:         user.ID,
+		WorkScheduleID: schedule.ID,
+		EffectiveFrom:  effectiveFrom,
+	}
+
+	testCases := []struct {
+		name          string
+		actor         db.User
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			actor: admin,
+			body:  gin.H{"work_schedule_id": schedule.ID, "effective_from": effectiveFrom},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(2).
+					Return(user, nil)
+				store.EXPECT().
+					GetWorkSchedule(gomock.Any(), gomock.Eq(schedule.ID)).
+					Times(1).
+					Return(schedule, nil)
+				store.EXPECT().
+					CreateWorkScheduleAssignment(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(assignment, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var gotAssignment db.WorkScheduleAssignment
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &gotAssignment))
+				require.Equal(t, assignment, gotAssignment)
+			},
+		},
+		{
+			name:  "EffectiveFromTaken",
+			actor: admin,
+			body:  gin.H{"work_schedule_id": schedule.ID, "effective_from": effectiveFrom},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(2).
+					Return(user, nil)
+				store.EXPECT().
+					GetWorkSchedule(gomock.Any(), gomock.Eq(schedule.ID)).
+					Times(1).
+					Return(schedule, nil)
+				store.EXPECT().
+					CreateWorkScheduleAssignment(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.WorkScheduleAssignment{}, &pgconn.PgError{
+						Code:           db.UniqueViolation,
+						ConstraintName: db.WorkScheduleEffectiveFromConstraint,
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:  "ScheduleOutsideCompany",
+			actor: admin,
+			body:  gin.H{"work_schedule_id": otherSchedule.ID, "effective_from": effectiveFrom},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(2).
+					Return(user, nil)
+				store.EXPECT().
+					GetWorkSchedule(gomock.Any(), gomock.Eq(otherSchedule.ID)).
+					Times(1).
+					Return(otherSchedule, nil)
+				store.EXPECT().
+					CreateWorkScheduleAssignment(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "SelfForbidden",
+			actor: user,
+			body:  gin.H{"work_schedule_id": schedule.ID, "effective_from": effectiveFrom},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					AnyTimes().
+					Return(user, nil)
+				store.EXPECT().
+					CreateWorkScheduleAssignment(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "MissingEffectiveFrom",
+			actor: admin,
+			body:  gin.H{"work_schedule_id": schedule.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateWorkScheduleAssignment(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			jsonData, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/users/%d/work-schedules", user.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
+			require.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, tc.actor, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeleteWorkScheduleAssignmentAPI(t *testing.T) {
+	admin := randomUserWithRole(types.AdminRole)
+	user := randomUser()
+	assignment := db.WorkScheduleAssignment{
+		ID:             util.RandomInt(1, 1000),
+		UserID:         user.ID,
+		WorkScheduleID: util.RandomInt(1, 1000),
+		EffectiveFrom:  time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+	}
+	otherAssignment := assignment
+	otherAssignment.UserID = user.ID + 1
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetWorkScheduleAssignment(gomock.Any(), gomock.Eq(assignment.ID)).
+					Times(1).
+					Return(assignment, nil)
+				store.EXPECT().
+					DeleteWorkScheduleAssignment(gomock.Any(), gomock.Eq(assignment.ID)).
+					Times(1).
+					Return(assignment, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "OtherUser",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetWorkScheduleAssignment(gomock.Any(), gomock.Eq(assignment.ID)).
+					Times(1).
+					Return(otherAssignment, nil)
+				store.EXPECT().
+					DeleteWorkScheduleAssignment(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/users/%d/work-schedules/%d", user.ID, assignment.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, util.AuthTypeBearer, admin, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
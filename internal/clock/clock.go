@@ -0,0 +1,229 @@
+// Package clock enforces the clock policies of companies: it stops the timers users forgot to stop and reminds users
+// who tracked no time by the end of a working day.
+package clock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/mateoradman/tempus/internal/worktime"
+)
+
+// Kinds of the recurring jobs enforcing the policies
+const (
+	AutoCloseKind = "clock.auto_close"
+	RemindKind    = "clock.remind"
+)
+
+// Interval is the time between two runs of the jobs. Timers are stopped at the time the policy sets whatever the
+// interval, reminders are sent up to an interval late.
+const Interval = 5 * time.Minute
+
+// pageSize is the number of users loaded at once while sending reminders
+const pageSize = 100
+
+// CloseTime returns the time a policy stops a timer started at start by a user of location, the earliest of the
+// maximal duration and the first time of day of the policy after start. It returns false if the policy does not stop
+// timers.
+func CloseTime(policy db.ClockPolicy, start time.Time, location *time.Location) (time.Time, bool) {
+	var closeAt time.Time
+	if policy.AutoCloseAfterMinutes != nil {
+		closeAt = start.Add(time.Duration(*policy.AutoCloseAfterMinutes) * time.Minute)
+	}
+	if policy.AutoCloseAtMinute != nil {
+		at := timeOfDay(start.In(location), *policy.AutoCloseAtMinute)
+		if !at.After(start) {
+			at = timeOfDay(start.In(location).AddDate(0, 0, 1), *policy.AutoCloseAtMinute)
+		}
+		if closeAt.IsZero() || at.Before(closeAt) {
+			closeAt = at
+		}
+	}
+	return closeAt.UTC(), !closeAt.IsZero()
+}
+
+// timeOfDay returns the minute of the day of t in the location of t
+func timeOfDay(t time.Time, minute int32) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), int(minute/60), int(minute%60), 0, 0, t.Location())
+}
+
+// Enforcer runs the jobs enforcing the clock policies of all companies
+type Enforcer struct {
+	store db.Store
+	now   func() time.Time
+}
+
+// NewEnforcer creates an enforcer of the clock policies
+func NewEnforcer(store db.Store) *Enforcer {
+	return &Enforcer{store: store, now: time.Now}
+}
+
+// AutoClose stops the running timers whose close time has come. Stopped entries end at their close time, are flagged
+// for review and their users are notified.
+func (e *Enforcer) AutoClose(ctx context.Context) error {
+	policies, err := e.store.ListClockPolicies(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := e.now().UTC()
+	var errs []error
+	for _, policy := range policies {
+		if policy.AutoCloseAfterMinutes == nil && policy.AutoCloseAtMinute == nil {
+			continue
+		}
+		entries, err := e.store.ListCompanyRunningEntries(ctx, policy.CompanyID)
+		if err != nil {
+			// a failing company does not keep the timers of the others running
+			errs = append(errs, fmt.Errorf("cannot list running entries of company %d: %w", policy.CompanyID, err))
+			continue
+		}
+
+		locations := make(map[int64]*time.Location)
+		for _, entry := range entries {
+			location, ok := locations[entry.UserID]
+			if !ok {
+				user, err := e.store.GetUser(ctx, entry.UserID)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("cannot stop entry %d: %w", entry.ID, err))
+					continue
+				}
+				location = userLocation(user)
+				locations[entry.UserID] = location
+			}
+
+			closeAt, ok := CloseTime(policy, entry.StartTime, location)
+			if !ok || closeAt.After(now) {
+				continue
+			}
+			_, err := e.store.AutoCloseEntryTx(ctx, db.AutoCloseEntryParams{ID: entry.ID, EndTime: closeAt})
+			// the timer was stopped in the meantime
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			if err != nil {
+				// a failing entry, such as one in an approved timesheet, does not keep the others running
+				errs = append(errs, fmt.Errorf("cannot stop entry %d: %w", entry.ID, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Remind notifies the users of the companies with a reminder time who tracked no time on the current working day by
+// that time. Users on holiday or on a pending or approved absence are not reminded, and nobody is reminded twice on
+// a day.
+func (e *Enforcer) Remind(ctx context.Context) error {
+	policies, err := e.store.ListClockPolicies(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := e.now().UTC()
+	var errs []error
+	for _, policy := range policies {
+		if policy.ReminderAtMinute == nil {
+			continue
+		}
+		for offset := int32(0); ; offset += pageSize {
+			users, err := e.store.ListCompanyEmployees(ctx, db.ListCompanyEmployeesParams{
+				ID:     policy.CompanyID,
+				Limit:  pageSize,
+				Offset: offset,
+			})
+			if err != nil {
+				return err
+			}
+			for _, user := range users {
+				if err := e.remind(ctx, user, *policy.ReminderAtMinute, now); err != nil {
+					// a failing user does not keep the others from being reminded
+					errs = append(errs, fmt.Errorf("cannot remind user %d: %w", user.ID, err))
+				}
+			}
+			if len(users) < pageSize {
+				break
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// remind notifies the user if they tracked no time on the current working day by the minute of the day
+func (e *Enforcer) remind(ctx context.Context, user db.User, minute int32, now time.Time) error {
+	local := now.In(userLocation(user))
+	if now.Before(timeOfDay(local, minute)) {
+		return nil
+	}
+	dayStart := timeOfDay(local, 0)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	dedupeKey := fmt.Sprintf("%s:%d:%s", types.NotificationTimerReminder, user.ID, dayStart.Format(time.DateOnly))
+
+	_, err := e.store.GetNotificationByDedupeKey(ctx, &dedupeKey)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	// a timer running since the day before counts as tracked time
+	_, err = e.store.GetOverlappingEntry(ctx, db.GetOverlappingEntryParams{
+		UserID:    user.ID,
+		StartTime: dayStart.UTC(),
+		EndTime:   &now,
+	})
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	_, err = e.store.GetOverlappingAbsence(ctx, db.GetOverlappingAbsenceParams{
+		UserID:    user.ID,
+		StartTime: dayStart.UTC(),
+		EndTime:   util.Pointer(dayEnd.UTC()),
+	})
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	calendar, err := worktime.LoadCalendar(ctx, e.store, user, local.Location(), worktime.Date(local).AddDate(0, 0, 1))
+	if err != nil {
+		return err
+	}
+	if len(calendar.WorkingDays(types.AbsenceFullDay, dayStart, dayEnd)) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(map[string]string{"date": dayStart.Format(time.DateOnly)})
+	if err != nil {
+		return err
+	}
+	_, err = e.store.CreateUniqueNotification(ctx, db.CreateUniqueNotificationParams{
+		UserID:    user.ID,
+		Kind:      types.NotificationTimerReminder,
+		Data:      data,
+		DedupeKey: &dedupeKey,
+	})
+	return err
+}
+
+// userLocation returns the time zone of the user, or UTC if it is unknown
+func userLocation(user db.User) *time.Location {
+	location, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
@@ -0,0 +1,347 @@
+package clock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestCloseTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	// 08:30 in Berlin
+	start := time.Date(2024, time.March, 1, 7, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name   string
+		policy db.ClockPolicy
+		want   time.Time
+		ok     bool
+	}{
+		{
+			name: "Disabled",
+		},
+		{
+			name:   "After",
+			policy: db.ClockPolicy{AutoCloseAfterMinutes: util.Pointer(int32(600))},
+			want:   time.Date(2024, time.March, 1, 17, 30, 0, 0, time.UTC),
+			ok:     true,
+		},
+		{
+			name:   "AtLocalTime",
+			policy: db.ClockPolicy{AutoCloseAtMinute: util.Pointer(int32(22 * 60))},
+			want:   time.Date(2024, time.March, 1, 21, 0, 0, 0, time.UTC),
+			ok:     true,
+		},
+		{
+			name:   "AtLocalTimeNextDay",
+			policy: db.ClockPolicy{AutoCloseAtMinute: util.Pointer(int32(8 * 60))},
+			want:   time.Date(2024, time.March, 2, 7, 0, 0, 0, time.UTC),
+			ok:     true,
+		},
+		{
+			name: "Earliest",
+			policy: db.ClockPolicy{
+				AutoCloseAfterMinutes: util.Pointer(int32(24 * 60)),
+				AutoCloseAtMinute:     util.Pointer(int32(22 * 60)),
+			},
+			want: time.Date(2024, time.March, 1, 21, 0, 0, 0, time.UTC),
+			ok:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := CloseTime(tc.policy, start, berlin)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestAutoClose(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, time.March, 2, 6, 0, 0, 0, time.UTC)
+	policy := db.ClockPolicy{CompanyID: 1, AutoCloseAtMinute: util.Pointer(int32(22 * 60))}
+	user := db.User{ID: 5, Timezone: "Europe/Berlin"}
+	forgotten := db.Entry{ID: 7, UserID: user.ID, StartTime: time.Date(2024, time.March, 1, 7, 30, 0, 0, time.UTC)}
+	running := db.Entry{ID: 8, UserID: user.ID, StartTime: time.Date(2024, time.March, 2, 5, 0, 0, 0, time.UTC)}
+	stopped := db.Entry{ID: 9, UserID: user.ID, StartTime: forgotten.StartTime}
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListClockPolicies(gomock.Any()).
+		Times(1).
+		Return([]db.ClockPolicy{policy, {CompanyID: 2, ReminderAtMinute: util.Pointer(int32(600))}}, nil)
+	store.EXPECT().
+		ListCompanyRunningEntries(gomock.Any(), gomock.Eq(policy.CompanyID)).
+		Times(1).
+		Return([]db.Entry{forgotten, running, stopped}, nil)
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.ID)).
+		Times(1).
+		Return(user, nil)
+	store.EXPECT().
+		AutoCloseEntryTx(gomock.Any(), gomock.Eq(db.AutoCloseEntryParams{
+			ID:      forgotten.ID,
+			EndTime: time.Date(2024, time.March, 1, 21, 0, 0, 0, time.UTC),
+		})).
+		Times(1).
+		Return(db.Entry{ID: forgotten.ID, NeedsReview: true}, nil)
+	// stopped by its user in the meantime
+	store.EXPECT().
+		AutoCloseEntryTx(gomock.Any(), gomock.Eq(db.AutoCloseEntryParams{
+			ID:      stopped.ID,
+			EndTime: time.Date(2024, time.March, 1, 21, 0, 0, 0, time.UTC),
+		})).
+		Times(1).
+		Return(db.Entry{}, pgx.ErrNoRows)
+
+	enforcer := NewEnforcer(store)
+	enforcer.now = func() time.Time { return now }
+	require.NoError(t, enforcer.AutoClose(context.Background()))
+}
+
+func TestRemind(t *testing.T) {
+	// Friday 18:00 in Berlin
+	friday := time.Date(2024, time.March, 1, 17, 0, 0, 0, time.UTC)
+	user := db.User{ID: 5, Timezone: "Europe/Berlin", CreatedAt: friday.AddDate(-1, 0, 0)}
+	dedupeKey := "timer.reminder:5:2024-03-01"
+
+	testCases := []struct {
+		name       string
+		now        time.Time
+		buildStubs func(store *mockdb.MockStore)
+	}{
+		{
+			name: "Reminded",
+			now:  friday,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetNotificationByDedupeKey(gomock.Any(), gomock.Eq(&dedupeKey)).
+					Times(1).
+					Return(db.Notification{}, pgx.ErrNoRows)
+				store.EXPECT().
+					GetOverlappingEntry(gomock.Any(), gomock.Eq(db.GetOverlappingEntryParams{
+						UserID:    user.ID,
+						StartTime: time.Date(2024, time.February, 29, 23, 0, 0, 0, time.UTC),
+						EndTime:   &friday,
+					})).
+					Times(1).
+					Return(db.Entry{}, pgx.ErrNoRows)
+				store.EXPECT().
+					GetOverlappingAbsence(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Absence{}, pgx.ErrNoRows)
+				store.EXPECT().
+					ListUserWorkScheduleAssignments(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.WorkScheduleAssignment{}, nil)
+				store.EXPECT().
+					CreateUniqueNotification(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateUniqueNotificationParams) (int64, error) {
+						require.Equal(t, user.ID, arg.UserID)
+						require.Equal(t, types.NotificationTimerReminder, arg.Kind)
+						require.Equal(t, dedupeKey, *arg.DedupeKey)
+						require.JSONEq(t, `{"date":"2024-03-01"}`, string(arg.Data))
+						return 1, nil
+					})
+			},
+		},
+		{
+			name: "BeforeReminderTime",
+			now:  friday.Add(-2 * time.Hour),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetNotificationByDedupeKey(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+		},
+		{
+			name: "AlreadyReminded",
+			now:  friday,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetNotificationByDedupeKey(gomock.Any(), gomock.Eq(&dedupeKey)).
+					Times(1).
+					Return(db.Notification{ID: 1}, nil)
+				store.EXPECT().
+					CreateUniqueNotification(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+		},
+		{
+			name: "TrackedTime",
+			now:  friday,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetNotificationByDedupeKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Notification{}, pgx.ErrNoRows)
+				store.EXPECT().
+					GetOverlappingEntry(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Entry{ID: 7}, nil)
+				store.EXPECT().
+					CreateUniqueNotification(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+		},
+		{
+			name: "Weekend",
+			now:  friday.AddDate(0, 0, 1),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetNotificationByDedupeKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Notification{}, pgx.ErrNoRows)
+				store.EXPECT().
+					GetOverlappingEntry(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Entry{}, pgx.ErrNoRows)
+				store.EXPECT().
+					GetOverlappingAbsence(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Absence{}, pgx.ErrNoRows)
+				store.EXPECT().
+					ListUserWorkScheduleAssignments(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.WorkScheduleAssignment{}, nil)
+				store.EXPECT().
+					CreateUniqueNotification(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				ListClockPolicies(gomock.Any()).
+				Times(1).
+				Return([]db.ClockPolicy{{CompanyID: 1, ReminderAtMinute: util.Pointer(int32(17 * 60))}}, nil)
+			store.EXPECT().
+				ListCompanyEmployees(gomock.Any(), gomock.Eq(db.ListCompanyEmployeesParams{ID: 1, Limit: pageSize})).
+				Times(1).
+				Return([]db.User{user}, nil)
+			tc.buildStubs(store)
+
+			enforcer := NewEnforcer(store)
+			enforcer.now = func() time.Time { return tc.now }
+			require.NoError(t, enforcer.Remind(context.Background()))
+		})
+	}
+}
+
+func TestAutoClosePartialFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, time.March, 2, 6, 0, 0, 0, time.UTC)
+	failing := db.ClockPolicy{CompanyID: 1, AutoCloseAtMinute: util.Pointer(int32(22 * 60))}
+	policy := db.ClockPolicy{CompanyID: 2, AutoCloseAtMinute: util.Pointer(int32(22 * 60))}
+	start := time.Date(2024, time.March, 1, 7, 30, 0, 0, time.UTC)
+	unknown := db.Entry{ID: 7, UserID: 4, StartTime: start}
+	user := db.User{ID: 5, Timezone: "Europe/Berlin"}
+	forgotten := db.Entry{ID: 8, UserID: user.ID, StartTime: start}
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListClockPolicies(gomock.Any()).
+		Times(1).
+		Return([]db.ClockPolicy{failing, policy}, nil)
+	store.EXPECT().
+		ListCompanyRunningEntries(gomock.Any(), gomock.Eq(failing.CompanyID)).
+		Times(1).
+		Return(nil, errors.New("connection reset"))
+	store.EXPECT().
+		ListCompanyRunningEntries(gomock.Any(), gomock.Eq(policy.CompanyID)).
+		Times(1).
+		Return([]db.Entry{unknown, forgotten}, nil)
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(unknown.UserID)).
+		Times(1).
+		Return(db.User{}, errors.New("connection reset"))
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.ID)).
+		Times(1).
+		Return(user, nil)
+	// the failing company and user do not keep the other timers running
+	store.EXPECT().
+		AutoCloseEntryTx(gomock.Any(), gomock.Eq(db.AutoCloseEntryParams{
+			ID:      forgotten.ID,
+			EndTime: time.Date(2024, time.March, 1, 21, 0, 0, 0, time.UTC),
+		})).
+		Times(1).
+		Return(db.Entry{ID: forgotten.ID, NeedsReview: true}, nil)
+
+	enforcer := NewEnforcer(store)
+	enforcer.now = func() time.Time { return now }
+	err := enforcer.AutoClose(context.Background())
+	require.ErrorContains(t, err, "company 1")
+	require.ErrorContains(t, err, "entry 7")
+}
+
+func TestAutoCloseError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListClockPolicies(gomock.Any()).
+		Times(1).
+		Return(nil, errors.New("connection refused"))
+
+	require.Error(t, NewEnforcer(store).AutoClose(context.Background()))
+}
+
+func TestRemindError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Friday 18:00 in Berlin
+	friday := time.Date(2024, time.March, 1, 17, 0, 0, 0, time.UTC)
+	failing := db.User{ID: 5, Timezone: "Europe/Berlin", CreatedAt: friday.AddDate(-1, 0, 0)}
+	user := db.User{ID: 6, Timezone: "Europe/Berlin", CreatedAt: friday.AddDate(-1, 0, 0)}
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListClockPolicies(gomock.Any()).
+		Times(1).
+		Return([]db.ClockPolicy{{CompanyID: 1, ReminderAtMinute: util.Pointer(int32(17 * 60))}}, nil)
+	store.EXPECT().
+		ListCompanyEmployees(gomock.Any(), gomock.Eq(db.ListCompanyEmployeesParams{ID: 1, Limit: pageSize})).
+		Times(1).
+		Return([]db.User{failing, user}, nil)
+	store.EXPECT().
+		GetNotificationByDedupeKey(gomock.Any(), gomock.Eq(util.Pointer("timer.reminder:5:2024-03-01"))).
+		Times(1).
+		Return(db.Notification{}, errors.New("connection reset"))
+	// the failing user does not keep the next one from being reminded
+	store.EXPECT().
+		GetNotificationByDedupeKey(gomock.Any(), gomock.Eq(util.Pointer("timer.reminder:6:2024-03-01"))).
+		Times(1).
+		Return(db.Notification{ID: 1}, nil)
+
+	enforcer := NewEnforcer(store)
+	enforcer.now = func() time.Time { return friday }
+	err := enforcer.Remind(context.Background())
+	require.ErrorContains(t, err, "cannot remind user 5")
+}
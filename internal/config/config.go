@@ -14,6 +14,12 @@ type Config struct {
 	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
 	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
 	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	// RowLevelSecurity makes Postgres enforce tenant isolation in addition to the API
+	RowLevelSecurity bool `mapstructure:"ROW_LEVEL_SECURITY"`
+	// WebhookDispatchInterval is the time between two checks for due webhook deliveries, deliveries are not sent if zero
+	WebhookDispatchInterval time.Duration `mapstructure:"WEBHOOK_DISPATCH_INTERVAL"`
+	// Workers is the number of background job workers running inside the server, jobs only run in worker mode if zero
+	Workers int `mapstructure:"WORKERS"`
 
 	// SuperUser data
 	SuperUserUsername string `mapstructure:"SUPERUSER_USERNAME"`
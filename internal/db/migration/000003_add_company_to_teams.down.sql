@@ -0,0 +1 @@
+ALTER TABLE "teams" DROP COLUMN "company_id";
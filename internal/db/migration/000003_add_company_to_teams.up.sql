@@ -0,0 +1,5 @@
+ALTER TABLE "teams" ADD COLUMN "company_id" bigint DEFAULT NULL;
+
+CREATE INDEX ON "teams" ("company_id");
+
+ALTER TABLE "teams" ADD CONSTRAINT "company_teams" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
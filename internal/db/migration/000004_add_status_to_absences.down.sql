@@ -0,0 +1,7 @@
+COMMENT ON COLUMN "absences"."approved_by_id" IS NULL;
+
+ALTER TABLE "absences" DROP COLUMN "decision_comment";
+
+ALTER TABLE "absences" DROP COLUMN "decided_at";
+
+ALTER TABLE "absences" DROP COLUMN "status";
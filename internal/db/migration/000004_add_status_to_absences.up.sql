@@ -0,0 +1,13 @@
+ALTER TABLE "absences" ADD COLUMN "status" varchar(32) NOT NULL DEFAULT 'pending';
+
+ALTER TABLE "absences" ADD COLUMN "decided_at" timestamp DEFAULT NULL;
+
+ALTER TABLE "absences" ADD COLUMN "decision_comment" varchar(255) DEFAULT NULL;
+
+ALTER TABLE "absences" ADD CONSTRAINT "absence_status" CHECK ("status" IN ('pending', 'approved', 'rejected', 'cancelled'));
+
+UPDATE "absences" SET "status" = 'approved' WHERE "approved_by_id" IS NOT NULL;
+
+CREATE INDEX ON "absences" ("status");
+
+COMMENT ON COLUMN "absences"."approved_by_id" IS 'User who approved or rejected the absence';
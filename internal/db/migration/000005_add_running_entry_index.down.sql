@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "entries_running_user_id";
@@ -0,0 +1,17 @@
+-- Running entries of a user starting at the same time are duplicate clock-ins, keep the latest one
+DELETE FROM "entries" AS e
+USING "entries" AS d
+WHERE e."end_time" IS NULL AND d."end_time" IS NULL
+  AND e."user_id" = d."user_id" AND e."start_time" = d."start_time" AND e."id" < d."id";
+
+-- Close all but the latest running entry of every user at the start of the next one
+UPDATE "entries" AS e
+SET "end_time" = r."next_start_time"
+FROM (
+  SELECT "id", lead("start_time") OVER (PARTITION BY "user_id" ORDER BY "start_time", "id") AS "next_start_time"
+  FROM "entries"
+  WHERE "end_time" IS NULL
+) AS r
+WHERE e."id" = r."id" AND r."next_start_time" IS NOT NULL;
+
+CREATE UNIQUE INDEX "entries_running_user_id" ON "entries" ("user_id") WHERE "end_time" IS NULL;
@@ -0,0 +1,9 @@
+ALTER TABLE "entries" DROP CONSTRAINT IF EXISTS "entries_no_overlap";
+
+ALTER TABLE "entries" DROP CONSTRAINT IF EXISTS "entries_end_after_start";
+
+-- The entries moved aside by the up migration are restored as they were
+INSERT INTO "entries" ("id", "user_id", "start_time", "end_time", "created_at", "updated_at")
+SELECT "id", "user_id", "start_time", "end_time", "created_at", "updated_at" FROM "entry_conflicts";
+
+DROP TABLE "entry_conflicts";
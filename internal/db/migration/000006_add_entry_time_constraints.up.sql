@@ -0,0 +1,75 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Existing entries which break the constraints below are moved aside rather than changed, to be fixed by hand
+CREATE TABLE "entry_conflicts" (
+  "id" bigint PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "start_time" timestamp NOT NULL,
+  "end_time" timestamp DEFAULT NULL,
+  "created_at" timestamp NOT NULL,
+  "updated_at" timestamp DEFAULT NULL,
+  "reason" varchar(32) NOT NULL,
+  "moved_at" timestamp NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "entry_conflicts"."reason" IS 'end_not_after_start or overlap';
+
+ALTER TABLE "entry_conflicts" ADD CONSTRAINT "user_entry_conflicts" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+-- entries ending before or when they start
+WITH "moved" AS (
+  DELETE FROM "entries" WHERE "end_time" <= "start_time" RETURNING *
+)
+INSERT INTO "entry_conflicts" ("id", "user_id", "start_time", "end_time", "created_at", "updated_at", "reason")
+SELECT "id", "user_id", "start_time", "end_time", "created_at", "updated_at", 'end_not_after_start' FROM "moved";
+
+-- running entries followed by another entry of their user, which they overlap
+WITH "moved" AS (
+  DELETE FROM "entries" AS e
+  WHERE e."end_time" IS NULL AND EXISTS (
+    SELECT 1 FROM "entries" AS o WHERE o."user_id" = e."user_id" AND o."start_time" > e."start_time"
+  )
+  RETURNING *
+)
+INSERT INTO "entry_conflicts" ("id", "user_id", "start_time", "end_time", "created_at", "updated_at", "reason")
+SELECT "id", "user_id", "start_time", "end_time", "created_at", "updated_at", 'overlap' FROM "moved";
+
+-- entries overlapping an earlier entry of their user which is kept, entries are only compared with those kept
+DO $$
+DECLARE
+  e record;
+  kept_user_id bigint;
+  kept_end_time timestamp;
+  moved bigint;
+BEGIN
+  FOR e IN
+    SELECT "id", "user_id", "start_time", "end_time"
+    FROM "entries"
+    ORDER BY "user_id", "start_time", "id"
+  LOOP
+    IF e."user_id" = kept_user_id AND e."start_time" < kept_end_time THEN
+      WITH "moved" AS (
+        DELETE FROM "entries" WHERE "id" = e."id" RETURNING *
+      )
+      INSERT INTO "entry_conflicts" ("id", "user_id", "start_time", "end_time", "created_at", "updated_at", "reason")
+      SELECT "id", "user_id", "start_time", "end_time", "created_at", "updated_at", 'overlap' FROM "moved";
+    ELSE
+      kept_user_id := e."user_id";
+      kept_end_time := COALESCE(e."end_time", 'infinity');
+    END IF;
+  END LOOP;
+
+  SELECT count(*) INTO moved FROM "entry_conflicts";
+  IF moved > 0 THEN
+    RAISE NOTICE '% entries breaking the entry time constraints were moved to entry_conflicts', moved;
+  END IF;
+END
+$$;
+
+ALTER TABLE "entries" ADD CONSTRAINT "entries_end_after_start" CHECK ("end_time" IS NULL OR "end_time" > "start_time");
+
+-- A running entry has no end time and therefore overlaps everything after its start
+ALTER TABLE "entries" ADD CONSTRAINT "entries_no_overlap" EXCLUDE USING gist (
+  "user_id" WITH =,
+  tsrange("start_time", "end_time") WITH &&
+);
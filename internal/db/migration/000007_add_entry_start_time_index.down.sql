@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS "entries_user_id_idx" ON "entries" ("user_id");
+
+DROP INDEX IF EXISTS "entries_user_id_start_time";
@@ -0,0 +1,4 @@
+CREATE INDEX "entries_user_id_start_time" ON "entries" ("user_id", "start_time");
+
+-- The composite index also serves lookups by user_id alone
+DROP INDEX IF EXISTS "entries_user_id_idx";
@@ -0,0 +1,19 @@
+ALTER TABLE "sessions" ADD COLUMN "family_id" uuid;
+
+UPDATE "sessions" SET "family_id" = "id";
+
+ALTER TABLE "sessions" ALTER COLUMN "family_id" SET NOT NULL;
+
+ALTER TABLE "sessions" ADD COLUMN "parent_id" uuid DEFAULT NULL;
+
+ALTER TABLE "sessions" ADD COLUMN "rotated_at" timestamp DEFAULT NULL;
+
+ALTER TABLE "sessions" ADD CONSTRAINT "session_parent" FOREIGN KEY ("parent_id") REFERENCES "sessions" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "sessions" ("family_id");
+
+COMMENT ON COLUMN "sessions"."family_id" IS 'First session of the refresh token rotation chain';
+
+COMMENT ON COLUMN "sessions"."parent_id" IS 'Session whose refresh token was exchanged for this one';
+
+COMMENT ON COLUMN "sessions"."rotated_at" IS 'Time the refresh token was exchanged for a new one';
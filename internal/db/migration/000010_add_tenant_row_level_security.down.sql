@@ -0,0 +1,21 @@
+DROP POLICY IF EXISTS "absences_tenant_isolation" ON "absences";
+ALTER TABLE "absences" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "absences" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS "entries_tenant_isolation" ON "entries";
+ALTER TABLE "entries" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "entries" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS "teams_tenant_isolation" ON "teams";
+ALTER TABLE "teams" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "teams" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS "users_tenant_isolation" ON "users";
+ALTER TABLE "users" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "users" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS "companies_tenant_isolation" ON "companies";
+ALTER TABLE "companies" NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE "companies" DISABLE ROW LEVEL SECURITY;
+
+DROP FUNCTION IF EXISTS current_company_id();
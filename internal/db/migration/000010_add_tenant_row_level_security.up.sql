@@ -0,0 +1,35 @@
+-- Tenant isolation enforced by Postgres as a second line of defense behind the API.
+-- The company of a connection is read from the "tempus.company_id" setting; connections which
+-- do not set it (migrations, seeding, the platform superuser) are not restricted.
+CREATE FUNCTION current_company_id() RETURNS bigint
+LANGUAGE sql STABLE
+AS $$ SELECT NULLIF(current_setting('tempus.company_id', true), '')::bigint $$;
+
+ALTER TABLE "companies" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "companies" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "companies_tenant_isolation" ON "companies"
+    USING (current_company_id() IS NULL OR "id" = current_company_id());
+
+ALTER TABLE "users" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "users" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "users_tenant_isolation" ON "users"
+    USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "teams" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "teams" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "teams_tenant_isolation" ON "teams"
+    USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "entries" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "entries" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "entries_tenant_isolation" ON "entries"
+    USING (current_company_id() IS NULL OR EXISTS (
+        SELECT 1 FROM "users" WHERE "users"."id" = "entries"."user_id" AND "users"."company_id" = current_company_id()
+    ));
+
+ALTER TABLE "absences" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "absences" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "absences_tenant_isolation" ON "absences"
+    USING (current_company_id() IS NULL OR EXISTS (
+        SELECT 1 FROM "users" WHERE "users"."id" = "absences"."user_id" AND "users"."company_id" = current_company_id()
+    ));
@@ -0,0 +1,11 @@
+ALTER TABLE "entries" DROP COLUMN "tags";
+
+ALTER TABLE "entries" DROP COLUMN "description";
+
+ALTER TABLE "entries" DROP COLUMN "task_id";
+
+ALTER TABLE "entries" DROP COLUMN "project_id";
+
+DROP TABLE IF EXISTS tasks;
+
+DROP TABLE IF EXISTS projects;
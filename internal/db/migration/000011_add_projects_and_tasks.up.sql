@@ -0,0 +1,69 @@
+CREATE TABLE "projects" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "company_id" bigint NOT NULL,
+  "name" varchar(255) NOT NULL,
+  "archived" boolean NOT NULL DEFAULT false,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp DEFAULT NULL
+);
+
+CREATE TABLE "tasks" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "project_id" bigint NOT NULL,
+  "name" varchar(255) NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp DEFAULT NULL
+);
+
+CREATE INDEX ON "projects" ("company_id");
+
+CREATE INDEX ON "tasks" ("project_id");
+
+ALTER TABLE "projects" ADD CONSTRAINT "company_projects" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "tasks" ADD CONSTRAINT "project_tasks" FOREIGN KEY ("project_id") REFERENCES "projects" ("id") ON DELETE CASCADE;
+
+-- lets entries reference a task together with the project it belongs to
+ALTER TABLE "tasks" ADD CONSTRAINT "tasks_id_project_id" UNIQUE ("id", "project_id");
+
+ALTER TABLE "entries" ADD COLUMN "project_id" bigint DEFAULT NULL;
+
+ALTER TABLE "entries" ADD COLUMN "task_id" bigint DEFAULT NULL;
+
+ALTER TABLE "entries" ADD COLUMN "description" text DEFAULT NULL;
+
+ALTER TABLE "entries" ADD COLUMN "tags" varchar(64)[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX ON "entries" ("project_id");
+
+CREATE INDEX ON "entries" ("task_id");
+
+CREATE INDEX ON "entries" USING gin ("tags");
+
+ALTER TABLE "entries" ADD CONSTRAINT "project_entries" FOREIGN KEY ("project_id") REFERENCES "projects" ("id");
+
+ALTER TABLE "entries" ADD CONSTRAINT "task_entries" FOREIGN KEY ("task_id", "project_id") REFERENCES "tasks" ("id", "project_id");
+
+ALTER TABLE "entries" ADD CONSTRAINT "entries_task_with_project" CHECK ("task_id" IS NULL OR "project_id" IS NOT NULL);
+
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON projects
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON tasks
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+ALTER TABLE "projects" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "projects" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "projects_tenant_isolation" ON "projects"
+    USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "tasks" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "tasks" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "tasks_tenant_isolation" ON "tasks"
+    USING (current_company_id() IS NULL OR EXISTS (
+        SELECT 1 FROM "projects" WHERE "projects"."id" = "tasks"."project_id" AND "projects"."company_id" = current_company_id()
+    ));
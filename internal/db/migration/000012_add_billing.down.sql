@@ -0,0 +1,9 @@
+ALTER TABLE "entries" DROP COLUMN "billable";
+
+ALTER TABLE "projects" DROP COLUMN "client_id";
+
+DROP FUNCTION IF EXISTS effective_hourly_rate;
+
+DROP TABLE IF EXISTS hourly_rates;
+
+DROP TABLE IF EXISTS clients;
@@ -0,0 +1,91 @@
+CREATE TABLE "clients" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "company_id" bigint NOT NULL,
+  "name" varchar(255) NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp DEFAULT NULL
+);
+
+-- amount_cents is the hourly rate in minor units of the currency
+CREATE TABLE "hourly_rates" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "company_id" bigint NOT NULL,
+  "user_id" bigint DEFAULT NULL,
+  "project_id" bigint DEFAULT NULL,
+  "client_id" bigint DEFAULT NULL,
+  "amount_cents" bigint NOT NULL,
+  "currency" varchar(3) NOT NULL,
+  "effective_from" date NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "clients" ("company_id");
+
+CREATE INDEX ON "hourly_rates" ("company_id", "effective_from");
+
+ALTER TABLE "clients" ADD CONSTRAINT "company_clients" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "hourly_rates" ADD CONSTRAINT "company_hourly_rates" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "hourly_rates" ADD CONSTRAINT "user_hourly_rates" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "hourly_rates" ADD CONSTRAINT "project_hourly_rates" FOREIGN KEY ("project_id") REFERENCES "projects" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "hourly_rates" ADD CONSTRAINT "client_hourly_rates" FOREIGN KEY ("client_id") REFERENCES "clients" ("id") ON DELETE CASCADE;
+
+-- a rate applies to a user, a project or a client, or is the company default when none is set
+ALTER TABLE "hourly_rates" ADD CONSTRAINT "hourly_rates_single_scope" CHECK (num_nonnulls("user_id", "project_id", "client_id") <= 1);
+
+ALTER TABLE "hourly_rates" ADD CONSTRAINT "hourly_rates_amount_not_negative" CHECK ("amount_cents" >= 0);
+
+CREATE UNIQUE INDEX "hourly_rates_scope_effective_from" ON "hourly_rates" ("company_id", "user_id", "project_id", "client_id", "effective_from") NULLS NOT DISTINCT;
+
+-- The rate of an entry is the latest rate effective on its day, preferring the rate of its user,
+-- then of its project, then of the client of its project, then the company default
+CREATE FUNCTION effective_hourly_rate(company_id bigint, user_id bigint, project_id bigint, client_id bigint, day date)
+RETURNS SETOF hourly_rates
+LANGUAGE sql STABLE
+AS $$
+  SELECT hr.*
+  FROM hourly_rates hr
+  WHERE hr.company_id = $1
+  AND hr.effective_from <= $5
+  AND (
+    hr.user_id = $2
+    OR hr.project_id = $3
+    OR hr.client_id = $4
+    OR num_nonnulls(hr.user_id, hr.project_id, hr.client_id) = 0
+  )
+  ORDER BY
+    CASE
+      WHEN hr.user_id IS NOT NULL THEN 1
+      WHEN hr.project_id IS NOT NULL THEN 2
+      WHEN hr.client_id IS NOT NULL THEN 3
+      ELSE 4
+    END,
+    hr.effective_from DESC
+  LIMIT 1
+$$;
+
+ALTER TABLE "projects" ADD COLUMN "client_id" bigint DEFAULT NULL;
+
+CREATE INDEX ON "projects" ("client_id");
+
+ALTER TABLE "projects" ADD CONSTRAINT "client_projects" FOREIGN KEY ("client_id") REFERENCES "clients" ("id");
+
+ALTER TABLE "entries" ADD COLUMN "billable" boolean NOT NULL DEFAULT false;
+
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON clients
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+ALTER TABLE "clients" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "clients" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "clients_tenant_isolation" ON "clients"
+    USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "hourly_rates" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "hourly_rates" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "hourly_rates_tenant_isolation" ON "hourly_rates"
+    USING (current_company_id() IS NULL OR "company_id" = current_company_id());
@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS lock_invoiced_entry ON entries;
+
+DROP FUNCTION IF EXISTS trigger_lock_invoiced_entry;
+
+ALTER TABLE "entries" DROP COLUMN "invoice_id";
+
+DROP TABLE IF EXISTS invoice_lines;
+
+DROP TABLE IF EXISTS invoices;
+
+DROP TABLE IF EXISTS invoice_sequences;
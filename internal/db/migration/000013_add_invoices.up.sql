@@ -0,0 +1,115 @@
+CREATE TABLE "invoice_sequences" (
+  "company_id" bigint PRIMARY KEY,
+  "prefix" varchar(32) NOT NULL DEFAULT 'INV-',
+  "last_number" bigint NOT NULL DEFAULT 0,
+  "updated_at" timestamp DEFAULT NULL
+);
+
+CREATE TABLE "invoices" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "company_id" bigint NOT NULL,
+  "client_id" bigint NOT NULL,
+  "number" varchar(64) DEFAULT NULL,
+  "status" varchar(16) NOT NULL DEFAULT 'draft',
+  "currency" varchar(3) NOT NULL,
+  "period_from" timestamp NOT NULL,
+  "period_to" timestamp NOT NULL,
+  "total_cents" bigint NOT NULL DEFAULT 0,
+  "issued_at" timestamp DEFAULT NULL,
+  "paid_at" timestamp DEFAULT NULL,
+  "voided_at" timestamp DEFAULT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp DEFAULT NULL
+);
+
+-- quantity_seconds is the billed time, rate_cents and amount_cents are in minor units of the currency
+CREATE TABLE "invoice_lines" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "invoice_id" bigint NOT NULL,
+  "project_id" bigint DEFAULT NULL,
+  "task_id" bigint DEFAULT NULL,
+  "description" varchar(512) NOT NULL,
+  "quantity_seconds" bigint NOT NULL,
+  "rate_cents" bigint NOT NULL,
+  "amount_cents" bigint NOT NULL
+);
+
+CREATE INDEX ON "invoices" ("company_id", "status");
+
+CREATE INDEX ON "invoices" ("client_id");
+
+CREATE UNIQUE INDEX "invoices_company_id_number" ON "invoices" ("company_id", "number");
+
+CREATE INDEX ON "invoice_lines" ("invoice_id");
+
+ALTER TABLE "invoice_sequences" ADD CONSTRAINT "company_invoice_sequences" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "invoices" ADD CONSTRAINT "company_invoices" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "invoices" ADD CONSTRAINT "client_invoices" FOREIGN KEY ("client_id") REFERENCES "clients" ("id");
+
+ALTER TABLE "invoice_lines" ADD CONSTRAINT "invoice_invoice_lines" FOREIGN KEY ("invoice_id") REFERENCES "invoices" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "invoice_lines" ADD CONSTRAINT "project_invoice_lines" FOREIGN KEY ("project_id") REFERENCES "projects" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "invoice_lines" ADD CONSTRAINT "task_invoice_lines" FOREIGN KEY ("task_id") REFERENCES "tasks" ("id") ON DELETE SET NULL;
+
+-- only issued invoices have a number
+ALTER TABLE "invoices" ADD CONSTRAINT "invoices_number_when_issued" CHECK (("status" = 'draft') = ("number" IS NULL));
+
+ALTER TABLE "entries" ADD COLUMN "invoice_id" bigint DEFAULT NULL;
+
+CREATE INDEX ON "entries" ("invoice_id");
+
+ALTER TABLE "entries" ADD CONSTRAINT "invoice_entries" FOREIGN KEY ("invoice_id") REFERENCES "invoices" ("id") ON DELETE SET NULL;
+
+-- entries of an invoice are locked, only the invoice they belong to may change; deletes cascading from
+-- the user or company of the entry are still allowed, the invoice lines keep the billed amounts
+CREATE FUNCTION trigger_lock_invoiced_entry()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF OLD.invoice_id IS NOT NULL AND (
+    (TG_OP = 'DELETE' AND pg_trigger_depth() = 1)
+    OR to_jsonb(NEW) - 'invoice_id' - 'updated_at' <> to_jsonb(OLD) - 'invoice_id' - 'updated_at'
+  ) THEN
+    RAISE EXCEPTION 'entry % is invoiced', OLD.id
+      USING ERRCODE = 'check_violation', CONSTRAINT = 'entries_invoiced_locked';
+  END IF;
+  IF TG_OP = 'DELETE' THEN
+    RETURN OLD;
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER lock_invoiced_entry
+BEFORE UPDATE OR DELETE ON entries
+FOR EACH ROW
+EXECUTE FUNCTION trigger_lock_invoiced_entry();
+
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON invoices
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON invoice_sequences
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+ALTER TABLE "invoice_sequences" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "invoice_sequences" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "invoice_sequences_tenant_isolation" ON "invoice_sequences"
+    USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "invoices" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "invoices" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "invoices_tenant_isolation" ON "invoices"
+    USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "invoice_lines" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "invoice_lines" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "invoice_lines_tenant_isolation" ON "invoice_lines"
+    USING (current_company_id() IS NULL OR EXISTS (
+        SELECT 1 FROM "invoices" WHERE "invoices"."id" = "invoice_lines"."invoice_id" AND "invoices"."company_id" = current_company_id()
+    ));
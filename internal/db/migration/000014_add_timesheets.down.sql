@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS lock_approved_entry ON entries;
+
+DROP FUNCTION IF EXISTS trigger_lock_approved_entry;
+
+DROP TABLE IF EXISTS timesheets;
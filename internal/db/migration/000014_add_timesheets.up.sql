@@ -0,0 +1,84 @@
+CREATE TABLE "timesheets" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "period" varchar(8) NOT NULL,
+  "period_start" date NOT NULL,
+  "period_end" date NOT NULL,
+  "status" varchar(16) NOT NULL DEFAULT 'open',
+  "submitted_at" timestamp DEFAULT NULL,
+  "decided_by_id" bigint DEFAULT NULL,
+  "decided_at" timestamp DEFAULT NULL,
+  "decision_comment" varchar(255) DEFAULT NULL,
+  "reopened_by_id" bigint DEFAULT NULL,
+  "reopened_at" timestamp DEFAULT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp DEFAULT NULL
+);
+
+CREATE INDEX ON "timesheets" ("status");
+
+-- period_end is exclusive
+ALTER TABLE "timesheets" ADD CONSTRAINT "timesheets_end_after_start" CHECK ("period_end" > "period_start");
+
+ALTER TABLE "timesheets" ADD CONSTRAINT "timesheets_no_overlap" EXCLUDE USING gist ("user_id" WITH =, daterange("period_start", "period_end") WITH &&);
+
+ALTER TABLE "timesheets" ADD CONSTRAINT "user_timesheets" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "timesheets" ADD FOREIGN KEY ("decided_by_id") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "timesheets" ADD FOREIGN KEY ("reopened_by_id") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+-- entries starting within an approved timesheet of their user, on the days of the time zone of the user,
+-- are locked; changes to the invoice of an entry and deletes cascading from its user or company are still allowed
+CREATE FUNCTION trigger_lock_approved_entry()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP = 'DELETE' AND pg_trigger_depth() > 1 THEN
+    RETURN OLD;
+  END IF;
+  IF TG_OP = 'UPDATE' AND to_jsonb(NEW) - 'invoice_id' - 'updated_at' = to_jsonb(OLD) - 'invoice_id' - 'updated_at' THEN
+    RETURN NEW;
+  END IF;
+  IF TG_OP IN ('UPDATE', 'DELETE') AND EXISTS (
+    SELECT 1 FROM timesheets t
+    JOIN users u ON u.id = t.user_id
+    WHERE t.user_id = OLD.user_id AND t.status = 'approved'
+    AND t.period_start <= (OLD.start_time AT TIME ZONE 'UTC' AT TIME ZONE u.timezone)::date
+    AND (OLD.start_time AT TIME ZONE 'UTC' AT TIME ZONE u.timezone)::date < t.period_end
+  ) THEN
+    RAISE EXCEPTION 'entry % is in an approved timesheet', OLD.id
+      USING ERRCODE = 'check_violation', CONSTRAINT = 'entries_period_locked';
+  END IF;
+  IF TG_OP IN ('INSERT', 'UPDATE') AND EXISTS (
+    SELECT 1 FROM timesheets t
+    JOIN users u ON u.id = t.user_id
+    WHERE t.user_id = NEW.user_id AND t.status = 'approved'
+    AND t.period_start <= (NEW.start_time AT TIME ZONE 'UTC' AT TIME ZONE u.timezone)::date
+    AND (NEW.start_time AT TIME ZONE 'UTC' AT TIME ZONE u.timezone)::date < t.period_end
+  ) THEN
+    RAISE EXCEPTION 'entry starting at % is in an approved timesheet', NEW.start_time
+      USING ERRCODE = 'check_violation', CONSTRAINT = 'entries_period_locked';
+  END IF;
+  IF TG_OP = 'DELETE' THEN
+    RETURN OLD;
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER lock_approved_entry
+BEFORE INSERT OR UPDATE OR DELETE ON entries
+FOR EACH ROW
+EXECUTE FUNCTION trigger_lock_approved_entry();
+
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON timesheets
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+ALTER TABLE "timesheets" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "timesheets" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "timesheets_tenant_isolation" ON "timesheets"
+    USING (current_company_id() IS NULL OR EXISTS (
+        SELECT 1 FROM "users" WHERE "users"."id" = "timesheets"."user_id" AND "users"."company_id" = current_company_id()
+    ));
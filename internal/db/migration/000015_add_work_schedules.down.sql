@@ -0,0 +1,5 @@
+ALTER TABLE "companies" DROP COLUMN "default_work_schedule_id";
+
+DROP TABLE IF EXISTS work_schedule_assignments;
+
+DROP TABLE IF EXISTS work_schedules;
@@ -0,0 +1,75 @@
+-- the days of a user are counted in their time zone, which Postgres must know
+UPDATE "users"
+SET "timezone" = 'UTC'
+WHERE "timezone" NOT IN (SELECT "name" FROM pg_timezone_names);
+
+-- the minutes of every weekday are the working time of a full-time employee, scaled by part_time_percent
+CREATE TABLE "work_schedules" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "company_id" bigint NOT NULL,
+  "name" varchar(255) NOT NULL,
+  "monday_minutes" integer NOT NULL DEFAULT 0,
+  "tuesday_minutes" integer NOT NULL DEFAULT 0,
+  "wednesday_minutes" integer NOT NULL DEFAULT 0,
+  "thursday_minutes" integer NOT NULL DEFAULT 0,
+  "friday_minutes" integer NOT NULL DEFAULT 0,
+  "saturday_minutes" integer NOT NULL DEFAULT 0,
+  "sunday_minutes" integer NOT NULL DEFAULT 0,
+  "part_time_percent" integer NOT NULL DEFAULT 100,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp DEFAULT NULL
+);
+
+-- a user follows the schedule of their latest assignment effective on a day, or the company default
+CREATE TABLE "work_schedule_assignments" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "work_schedule_id" bigint NOT NULL,
+  "effective_from" date NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "work_schedules" ("company_id");
+
+CREATE UNIQUE INDEX "work_schedule_assignments_user_id_effective_from" ON "work_schedule_assignments" ("user_id", "effective_from");
+
+CREATE INDEX ON "work_schedule_assignments" ("work_schedule_id");
+
+ALTER TABLE "work_schedules" ADD CONSTRAINT "work_schedules_day_minutes" CHECK (
+  "monday_minutes" BETWEEN 0 AND 1440
+  AND "tuesday_minutes" BETWEEN 0 AND 1440
+  AND "wednesday_minutes" BETWEEN 0 AND 1440
+  AND "thursday_minutes" BETWEEN 0 AND 1440
+  AND "friday_minutes" BETWEEN 0 AND 1440
+  AND "saturday_minutes" BETWEEN 0 AND 1440
+  AND "sunday_minutes" BETWEEN 0 AND 1440
+);
+
+ALTER TABLE "work_schedules" ADD CONSTRAINT "work_schedules_part_time_percent" CHECK ("part_time_percent" BETWEEN 1 AND 100);
+
+ALTER TABLE "work_schedules" ADD CONSTRAINT "company_work_schedules" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "work_schedule_assignments" ADD CONSTRAINT "user_work_schedule_assignments" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "work_schedule_assignments" ADD CONSTRAINT "work_schedule_work_schedule_assignments" FOREIGN KEY ("work_schedule_id") REFERENCES "work_schedules" ("id");
+
+ALTER TABLE "companies" ADD COLUMN "default_work_schedule_id" bigint DEFAULT NULL;
+
+ALTER TABLE "companies" ADD CONSTRAINT "default_work_schedule_companies" FOREIGN KEY ("default_work_schedule_id") REFERENCES "work_schedules" ("id") ON DELETE SET NULL;
+
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON work_schedules
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+ALTER TABLE "work_schedules" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "work_schedules" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "work_schedules_tenant_isolation" ON "work_schedules"
+    USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "work_schedule_assignments" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "work_schedule_assignments" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "work_schedule_assignments_tenant_isolation" ON "work_schedule_assignments"
+    USING (current_company_id() IS NULL OR EXISTS (
+        SELECT 1 FROM "users" WHERE "users"."id" = "work_schedule_assignments"."user_id" AND "users"."company_id" = current_company_id()
+    ));
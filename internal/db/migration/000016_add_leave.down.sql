@@ -0,0 +1,5 @@
+ALTER TABLE "absences" DROP COLUMN "absence_type_id";
+
+DROP TABLE IF EXISTS leave_entitlements;
+
+DROP TABLE IF EXISTS absence_types;
@@ -0,0 +1,67 @@
+-- absences of a type which tracks a balance draw from the annual entitlements of their user
+CREATE TABLE "absence_types" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "company_id" bigint NOT NULL,
+  "name" varchar(255) NOT NULL,
+  "paid" boolean NOT NULL DEFAULT true,
+  "tracks_balance" boolean NOT NULL DEFAULT false,
+  "accrual" varchar(16) NOT NULL DEFAULT 'yearly',
+  "carry_over_max_days" integer NOT NULL DEFAULT 0,
+  "carry_over_expiry_months" integer DEFAULT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp DEFAULT NULL
+);
+
+-- an entitlement applies from from_year on, until a later entitlement of the same type
+CREATE TABLE "leave_entitlements" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "absence_type_id" bigint NOT NULL,
+  "from_year" integer NOT NULL,
+  "days" integer NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "absence_types_company_id_name" ON "absence_types" ("company_id", "name");
+
+CREATE UNIQUE INDEX "leave_entitlements_user_id_absence_type_id_from_year" ON "leave_entitlements" ("user_id", "absence_type_id", "from_year");
+
+CREATE INDEX ON "leave_entitlements" ("absence_type_id");
+
+ALTER TABLE "absence_types" ADD CONSTRAINT "absence_types_accrual" CHECK ("accrual" IN ('yearly', 'monthly'));
+
+ALTER TABLE "absence_types" ADD CONSTRAINT "absence_types_carry_over" CHECK (
+  "carry_over_max_days" >= 0
+  AND ("carry_over_expiry_months" IS NULL OR "carry_over_expiry_months" BETWEEN 1 AND 12)
+);
+
+ALTER TABLE "leave_entitlements" ADD CONSTRAINT "leave_entitlements_days_not_negative" CHECK ("days" >= 0);
+
+ALTER TABLE "absence_types" ADD CONSTRAINT "company_absence_types" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "leave_entitlements" ADD CONSTRAINT "user_leave_entitlements" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "leave_entitlements" ADD CONSTRAINT "absence_type_leave_entitlements" FOREIGN KEY ("absence_type_id") REFERENCES "absence_types" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "absences" ADD COLUMN "absence_type_id" bigint DEFAULT NULL;
+
+CREATE INDEX ON "absences" ("absence_type_id");
+
+ALTER TABLE "absences" ADD CONSTRAINT "absence_type_absences" FOREIGN KEY ("absence_type_id") REFERENCES "absence_types" ("id");
+
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON absence_types
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+ALTER TABLE "absence_types" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "absence_types" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "absence_types_tenant_isolation" ON "absence_types"
+    USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "leave_entitlements" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "leave_entitlements" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "leave_entitlements_tenant_isolation" ON "leave_entitlements"
+    USING (current_company_id() IS NULL OR EXISTS (
+        SELECT 1 FROM "users" WHERE "users"."id" = "leave_entitlements"."user_id" AND "users"."company_id" = current_company_id()
+    ));
@@ -0,0 +1,5 @@
+ALTER TABLE "users" DROP COLUMN "subdivision";
+
+DROP TABLE IF EXISTS company_holidays;
+
+DROP TABLE IF EXISTS public_holidays;
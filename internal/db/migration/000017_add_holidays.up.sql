@@ -0,0 +1,34 @@
+-- public holidays of a subdivision are observed in addition to those of the whole country, whose subdivision is null
+CREATE TABLE "public_holidays" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "country" varchar(2) NOT NULL,
+  "subdivision" varchar(3) DEFAULT NULL,
+  "date" date NOT NULL,
+  "name" varchar(255) NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+-- a company holiday replaces the public holidays on its date, it is a working day unless day_off is set
+CREATE TABLE "company_holidays" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "company_id" bigint NOT NULL,
+  "date" date NOT NULL,
+  "name" varchar(255) NOT NULL,
+  "day_off" boolean NOT NULL DEFAULT true,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "public_holidays_country_subdivision_date" ON "public_holidays" ("country", "subdivision", "date") NULLS NOT DISTINCT;
+
+CREATE UNIQUE INDEX "company_holidays_company_id_date" ON "company_holidays" ("company_id", "date");
+
+ALTER TABLE "company_holidays" ADD CONSTRAINT "company_company_holidays" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "users" ADD COLUMN "subdivision" varchar(3) DEFAULT NULL;
+
+COMMENT ON COLUMN "users"."subdivision" IS 'ISO 3166-2 subdivision code within the country';
+
+ALTER TABLE "company_holidays" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "company_holidays" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "company_holidays_tenant_isolation" ON "company_holidays"
+    USING (current_company_id() IS NULL OR "company_id" = current_company_id());
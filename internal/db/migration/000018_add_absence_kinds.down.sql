@@ -0,0 +1,5 @@
+ALTER TABLE "absences" DROP CONSTRAINT IF EXISTS "absences_no_overlap";
+
+ALTER TABLE "absences" DROP CONSTRAINT IF EXISTS "absences_end_after_start";
+
+ALTER TABLE "absences" DROP COLUMN "kind";
@@ -0,0 +1,46 @@
+ALTER TABLE "absences" ADD COLUMN "kind" varchar(16) NOT NULL DEFAULT 'hours';
+
+COMMENT ON COLUMN "absences"."kind" IS 'full_day, morning, afternoon or hours';
+
+ALTER TABLE "absences" ADD CONSTRAINT "absences_kind" CHECK ("kind" IN ('full_day', 'morning', 'afternoon', 'hours'));
+
+-- Absences ending before they start had their times swapped, absences ending when they start hold no time
+UPDATE "absences"
+SET "start_time" = "end_time", "end_time" = "start_time"
+WHERE "end_time" < "start_time";
+
+DELETE FROM "absences" WHERE "end_time" = "start_time";
+
+ALTER TABLE "absences" ADD CONSTRAINT "absences_end_after_start" CHECK ("end_time" IS NULL OR "end_time" > "start_time");
+
+-- Cancel the active absences which the overlap constraint rejects. Approved absences are kept over pending ones,
+-- then earlier ones over later ones, and absences are only compared with those kept.
+DO $$
+DECLARE
+  a record;
+BEGIN
+  FOR a IN
+    SELECT "id", "user_id", "status", "start_time", "end_time"
+    FROM "absences"
+    WHERE "status" IN ('pending', 'approved')
+    ORDER BY "user_id", "status" <> 'approved', "id"
+  LOOP
+    IF EXISTS (
+      SELECT 1
+      FROM "absences" AS kept
+      WHERE kept."user_id" = a."user_id"
+      AND kept."status" IN ('pending', 'approved')
+      AND (kept."status" <> 'approved', kept."id") < (a."status" <> 'approved', a."id")
+      AND tsrange(kept."start_time", kept."end_time") && tsrange(a."start_time", a."end_time")
+    ) THEN
+      UPDATE "absences" SET "status" = 'cancelled' WHERE "id" = a."id";
+    END IF;
+  END LOOP;
+END
+$$;
+
+-- Rejected and cancelled absences do not take any time and may overlap
+ALTER TABLE "absences" ADD CONSTRAINT "absences_no_overlap" EXCLUDE USING gist (
+  "user_id" WITH =,
+  tsrange("start_time", "end_time") WITH &&
+) WHERE ("status" IN ('pending', 'approved'));
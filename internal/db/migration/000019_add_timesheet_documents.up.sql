@@ -0,0 +1,26 @@
+-- a document is rendered whenever a timesheet is approved, documents of earlier approvals are kept
+CREATE TABLE "timesheet_documents" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "timesheet_id" bigint NOT NULL,
+  "language" varchar(2) NOT NULL,
+  "content" bytea NOT NULL,
+  "sha256" varchar(64) NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "timesheet_documents"."content" IS 'PDF rendered on approval, printed with signature lines for the employee and the approver';
+
+COMMENT ON COLUMN "timesheet_documents"."sha256" IS 'Hex encoded SHA-256 hash of the content';
+
+CREATE INDEX ON "timesheet_documents" ("timesheet_id");
+
+ALTER TABLE "timesheet_documents" ADD CONSTRAINT "timesheet_timesheet_documents" FOREIGN KEY ("timesheet_id") REFERENCES "timesheets" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "timesheet_documents" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "timesheet_documents" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "timesheet_documents_tenant_isolation" ON "timesheet_documents"
+    USING (current_company_id() IS NULL OR EXISTS (
+        SELECT 1 FROM "timesheets"
+        JOIN "users" ON "users"."id" = "timesheets"."user_id"
+        WHERE "timesheets"."id" = "timesheet_documents"."timesheet_id" AND "users"."company_id" = current_company_id()
+    ));
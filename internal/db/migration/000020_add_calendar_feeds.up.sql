@@ -0,0 +1,35 @@
+-- a calendar feed publishes the absences and holidays of a user or of the members of a team to anyone knowing its
+-- secret token, until it is revoked
+CREATE TABLE "calendar_feeds" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "token_hash" varchar(64) NOT NULL,
+  "user_id" bigint DEFAULT NULL,
+  "team_id" bigint DEFAULT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "revoked_at" timestamp DEFAULT NULL
+);
+
+COMMENT ON COLUMN "calendar_feeds"."token_hash" IS 'Hex encoded SHA-256 hash of the secret token';
+
+COMMENT ON COLUMN "calendar_feeds"."revoked_at" IS 'Time the token stopped being accepted';
+
+CREATE UNIQUE INDEX "calendar_feeds_token_hash" ON "calendar_feeds" ("token_hash");
+
+CREATE INDEX ON "calendar_feeds" ("user_id");
+
+CREATE INDEX ON "calendar_feeds" ("team_id");
+
+ALTER TABLE "calendar_feeds" ADD CONSTRAINT "calendar_feeds_owner" CHECK (num_nonnulls("user_id", "team_id") = 1);
+
+ALTER TABLE "calendar_feeds" ADD CONSTRAINT "user_calendar_feeds" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "calendar_feeds" ADD CONSTRAINT "team_calendar_feeds" FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "calendar_feeds" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "calendar_feeds" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "calendar_feeds_tenant_isolation" ON "calendar_feeds"
+    USING (current_company_id() IS NULL OR EXISTS (
+        SELECT 1 FROM "users" WHERE "users"."id" = "calendar_feeds"."user_id" AND "users"."company_id" = current_company_id()
+    ) OR EXISTS (
+        SELECT 1 FROM "teams" WHERE "teams"."id" = "calendar_feeds"."team_id" AND "teams"."company_id" = current_company_id()
+    ));
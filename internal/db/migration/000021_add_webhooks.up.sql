@@ -0,0 +1,81 @@
+-- a webhook subscription of a company receives the events of the listed types
+CREATE TABLE "webhook_subscriptions" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "company_id" bigint NOT NULL,
+  "url" varchar(2048) NOT NULL,
+  "secret" varchar(64) NOT NULL,
+  "events" varchar(64)[] NOT NULL,
+  "active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp DEFAULT NULL
+);
+
+COMMENT ON COLUMN "webhook_subscriptions"."secret" IS 'Key of the HMAC-SHA256 signature of the deliveries';
+
+-- the outbox: events are written in the transaction of the change they describe
+CREATE TABLE "webhook_events" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "company_id" bigint NOT NULL,
+  "type" varchar(64) NOT NULL,
+  "payload" jsonb NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+-- a delivery of an event to a subscription, updated after every attempt
+CREATE TABLE "webhook_deliveries" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "subscription_id" bigint NOT NULL,
+  "event_id" bigint NOT NULL,
+  "status" varchar(16) NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "next_attempt_at" timestamp NOT NULL DEFAULT (now()),
+  "last_attempt_at" timestamp DEFAULT NULL,
+  "response_status" int DEFAULT NULL,
+  "response_body" text DEFAULT NULL,
+  "error" text DEFAULT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "webhook_deliveries"."status" IS 'pending, succeeded or failed';
+
+COMMENT ON COLUMN "webhook_deliveries"."next_attempt_at" IS 'Time a pending delivery is attempted, or the attempt in progress times out';
+
+COMMENT ON COLUMN "webhook_deliveries"."response_body" IS 'Beginning of the response body of the last attempt';
+
+CREATE INDEX ON "webhook_subscriptions" ("company_id");
+
+CREATE INDEX ON "webhook_events" ("company_id");
+
+CREATE INDEX ON "webhook_deliveries" ("subscription_id");
+
+CREATE INDEX ON "webhook_deliveries" ("event_id");
+
+CREATE INDEX "webhook_deliveries_pending" ON "webhook_deliveries" ("next_attempt_at") WHERE "status" = 'pending';
+
+ALTER TABLE "webhook_deliveries" ADD CONSTRAINT "webhook_deliveries_status" CHECK ("status" IN ('pending', 'succeeded', 'failed'));
+
+ALTER TABLE "webhook_subscriptions" ADD CONSTRAINT "company_webhook_subscriptions" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "webhook_events" ADD CONSTRAINT "company_webhook_events" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "webhook_deliveries" ADD CONSTRAINT "webhook_subscription_webhook_deliveries" FOREIGN KEY ("subscription_id") REFERENCES "webhook_subscriptions" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "webhook_deliveries" ADD CONSTRAINT "webhook_event_webhook_deliveries" FOREIGN KEY ("event_id") REFERENCES "webhook_events" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "webhook_subscriptions" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "webhook_subscriptions" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "webhook_subscriptions_tenant_isolation" ON "webhook_subscriptions"
+    USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "webhook_events" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "webhook_events" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "webhook_events_tenant_isolation" ON "webhook_events"
+    USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "webhook_deliveries" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "webhook_deliveries" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "webhook_deliveries_tenant_isolation" ON "webhook_deliveries"
+    USING (current_company_id() IS NULL OR EXISTS (
+        SELECT 1 FROM "webhook_subscriptions"
+        WHERE "webhook_subscriptions"."id" = "webhook_deliveries"."subscription_id" AND "webhook_subscriptions"."company_id" = current_company_id()
+    ));
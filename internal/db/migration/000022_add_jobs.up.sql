@@ -0,0 +1,31 @@
+-- background jobs, claimed by workers with FOR UPDATE SKIP LOCKED
+CREATE TABLE "jobs" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "kind" varchar(64) NOT NULL,
+  "payload" jsonb NOT NULL DEFAULT '{}',
+  "status" varchar(16) NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "max_attempts" int NOT NULL DEFAULT 10,
+  "run_at" timestamp NOT NULL DEFAULT (now()),
+  "locked_until" timestamp DEFAULT NULL,
+  "last_error" text DEFAULT NULL,
+  "unique_key" varchar(255) DEFAULT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "finished_at" timestamp DEFAULT NULL
+);
+
+COMMENT ON COLUMN "jobs"."status" IS 'pending, running, succeeded or dead';
+
+COMMENT ON COLUMN "jobs"."run_at" IS 'Time a pending job is run at';
+
+COMMENT ON COLUMN "jobs"."locked_until" IS 'Time a running job is given up on and run again';
+
+COMMENT ON COLUMN "jobs"."unique_key" IS 'Key of jobs which are enqueued at most once, such as the runs of a schedule';
+
+CREATE UNIQUE INDEX "jobs_unique_key" ON "jobs" ("unique_key");
+
+CREATE INDEX "jobs_pending" ON "jobs" ("run_at") WHERE "status" = 'pending';
+
+CREATE INDEX "jobs_running" ON "jobs" ("locked_until") WHERE "status" = 'running';
+
+ALTER TABLE "jobs" ADD CONSTRAINT "jobs_status" CHECK ("status" IN ('pending', 'running', 'succeeded', 'dead'));
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS notifications;
+
+DROP INDEX IF EXISTS entries_running;
+
+ALTER TABLE "entries" DROP COLUMN "needs_review";
+
+ALTER TABLE "entries" DROP COLUMN "auto_closed_at";
+
+DROP TABLE IF EXISTS clock_policies;
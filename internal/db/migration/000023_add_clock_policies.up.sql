@@ -0,0 +1,62 @@
+-- the auto clock-out and reminder policy of a company, times of day are in the time zone of each user
+CREATE TABLE "clock_policies" (
+  "company_id" bigint PRIMARY KEY,
+  "auto_close_after_minutes" int DEFAULT NULL,
+  "auto_close_at_minute" int DEFAULT NULL,
+  "reminder_at_minute" int DEFAULT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now()),
+  "updated_at" timestamp DEFAULT NULL
+);
+
+COMMENT ON COLUMN "clock_policies"."auto_close_after_minutes" IS 'Running timers are stopped once they ran for this long';
+
+COMMENT ON COLUMN "clock_policies"."auto_close_at_minute" IS 'Running timers are stopped at this minute of the day';
+
+COMMENT ON COLUMN "clock_policies"."reminder_at_minute" IS 'Users without entries on a working day are reminded at this minute of the day';
+
+ALTER TABLE "clock_policies" ADD CONSTRAINT "clock_policies_auto_close_after" CHECK ("auto_close_after_minutes" > 0);
+
+ALTER TABLE "clock_policies" ADD CONSTRAINT "clock_policies_minutes_of_day" CHECK ("auto_close_at_minute" BETWEEN 0 AND 1439 AND "reminder_at_minute" BETWEEN 0 AND 1439);
+
+ALTER TABLE "clock_policies" ADD CONSTRAINT "company_clock_policies" FOREIGN KEY ("company_id") REFERENCES "companies" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "entries" ADD COLUMN "auto_closed_at" timestamp DEFAULT NULL;
+
+ALTER TABLE "entries" ADD COLUMN "needs_review" boolean NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN "entries"."auto_closed_at" IS 'Time the timer of the entry was stopped by the clock policy of the company';
+
+COMMENT ON COLUMN "entries"."needs_review" IS 'Set when the timer is stopped by the clock policy, cleared when the entry is updated';
+
+CREATE INDEX "entries_running" ON "entries" ("start_time") WHERE "end_time" IS NULL;
+
+-- notifications of a user, such as stopped timers and reminders to track time
+CREATE TABLE "notifications" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "kind" varchar(64) NOT NULL,
+  "data" jsonb NOT NULL DEFAULT '{}',
+  "dedupe_key" varchar(255) DEFAULT NULL,
+  "read_at" timestamp DEFAULT NULL,
+  "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "notifications"."dedupe_key" IS 'Key of notifications which are sent at most once, such as the reminder of a day';
+
+CREATE INDEX ON "notifications" ("user_id");
+
+CREATE UNIQUE INDEX "notifications_dedupe_key" ON "notifications" ("dedupe_key");
+
+ALTER TABLE "notifications" ADD CONSTRAINT "user_notifications" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "clock_policies" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "clock_policies" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "clock_policies_tenant_isolation" ON "clock_policies"
+    USING (current_company_id() IS NULL OR "company_id" = current_company_id());
+
+ALTER TABLE "notifications" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "notifications" FORCE ROW LEVEL SECURITY;
+CREATE POLICY "notifications_tenant_isolation" ON "notifications"
+    USING (current_company_id() IS NULL OR EXISTS (
+        SELECT 1 FROM "users" WHERE "users"."id" = "notifications"."user_id" AND "users"."company_id" = current_company_id()
+    ));
@@ -11,6 +11,7 @@ package mockdb
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	config "github.com/mateoradman/tempus/internal/config"
@@ -41,470 +42,2523 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
-// CreateAbsence mocks base method.
-func (m *MockStore) CreateAbsence(ctx context.Context, arg sqlc.CreateAbsenceParams) (sqlc.Absence, error) {
+// ApproveTimesheetTx mocks base method.
+func (m *MockStore) ApproveTimesheetTx(ctx context.Context, arg sqlc.ApproveTimesheetTxParams) (sqlc.ApproveTimesheetTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAbsence", ctx, arg)
-	ret0, _ := ret[0].(sqlc.Absence)
+	ret := m.ctrl.Call(m, "ApproveTimesheetTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ApproveTimesheetTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateAbsence indicates an expected call of CreateAbsence.
-func (mr *MockStoreMockRecorder) CreateAbsence(ctx, arg any) *gomock.Call {
+// ApproveTimesheetTx indicates an expected call of ApproveTimesheetTx.
+func (mr *MockStoreMockRecorder) ApproveTimesheetTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAbsence", reflect.TypeOf((*MockStore)(nil).CreateAbsence), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveTimesheetTx", reflect.TypeOf((*MockStore)(nil).ApproveTimesheetTx), ctx, arg)
 }
 
-// CreateCompany mocks base method.
-func (m *MockStore) CreateCompany(ctx context.Context, name string) (sqlc.Company, error) {
+// AutoCloseEntry mocks base method.
+func (m *MockStore) AutoCloseEntry(ctx context.Context, arg sqlc.AutoCloseEntryParams) (sqlc.Entry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCompany", ctx, name)
-	ret0, _ := ret[0].(sqlc.Company)
+	ret := m.ctrl.Call(m, "AutoCloseEntry", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Entry)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateCompany indicates an expected call of CreateCompany.
-func (mr *MockStoreMockRecorder) CreateCompany(ctx, name any) *gomock.Call {
+// AutoCloseEntry indicates an expected call of AutoCloseEntry.
+func (mr *MockStoreMockRecorder) AutoCloseEntry(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCompany", reflect.TypeOf((*MockStore)(nil).CreateCompany), ctx, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutoCloseEntry", reflect.TypeOf((*MockStore)(nil).AutoCloseEntry), ctx, arg)
 }
 
-// CreateEntry mocks base method.
-func (m *MockStore) CreateEntry(ctx context.Context, arg sqlc.CreateEntryParams) (sqlc.Entry, error) {
+// AutoCloseEntryTx mocks base method.
+func (m *MockStore) AutoCloseEntryTx(ctx context.Context, arg sqlc.AutoCloseEntryParams) (sqlc.Entry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateEntry", ctx, arg)
+	ret := m.ctrl.Call(m, "AutoCloseEntryTx", ctx, arg)
 	ret0, _ := ret[0].(sqlc.Entry)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateEntry indicates an expected call of CreateEntry.
-func (mr *MockStoreMockRecorder) CreateEntry(ctx, arg any) *gomock.Call {
+// AutoCloseEntryTx indicates an expected call of AutoCloseEntryTx.
+func (mr *MockStoreMockRecorder) AutoCloseEntryTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockStore)(nil).CreateEntry), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutoCloseEntryTx", reflect.TypeOf((*MockStore)(nil).AutoCloseEntryTx), ctx, arg)
 }
 
-// CreateSession mocks base method.
-func (m *MockStore) CreateSession(ctx context.Context, arg sqlc.CreateSessionParams) (sqlc.Session, error) {
+// BlockSession mocks base method.
+func (m *MockStore) BlockSession(ctx context.Context, id uuid.UUID) (sqlc.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSession", ctx, arg)
+	ret := m.ctrl.Call(m, "BlockSession", ctx, id)
 	ret0, _ := ret[0].(sqlc.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateSession indicates an expected call of CreateSession.
-func (mr *MockStoreMockRecorder) CreateSession(ctx, arg any) *gomock.Call {
+// BlockSession indicates an expected call of BlockSession.
+func (mr *MockStoreMockRecorder) BlockSession(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSession", reflect.TypeOf((*MockStore)(nil).BlockSession), ctx, id)
 }
 
-// CreateTeam mocks base method.
-func (m *MockStore) CreateTeam(ctx context.Context, arg sqlc.CreateTeamParams) (sqlc.Team, error) {
+// BlockSessionFamily mocks base method.
+func (m *MockStore) BlockSessionFamily(ctx context.Context, familyID uuid.UUID) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTeam", ctx, arg)
-	ret0, _ := ret[0].(sqlc.Team)
+	ret := m.ctrl.Call(m, "BlockSessionFamily", ctx, familyID)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateTeam indicates an expected call of CreateTeam.
-func (mr *MockStoreMockRecorder) CreateTeam(ctx, arg any) *gomock.Call {
+// BlockSessionFamily indicates an expected call of BlockSessionFamily.
+func (mr *MockStoreMockRecorder) BlockSessionFamily(ctx, familyID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeam", reflect.TypeOf((*MockStore)(nil).CreateTeam), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSessionFamily", reflect.TypeOf((*MockStore)(nil).BlockSessionFamily), ctx, familyID)
 }
 
-// CreateUser mocks base method.
-func (m *MockStore) CreateUser(ctx context.Context, arg sqlc.CreateUserParams) (sqlc.User, error) {
+// BlockUserSession mocks base method.
+func (m *MockStore) BlockUserSession(ctx context.Context, arg sqlc.BlockUserSessionParams) (sqlc.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
-	ret0, _ := ret[0].(sqlc.User)
+	ret := m.ctrl.Call(m, "BlockUserSession", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockStoreMockRecorder) CreateUser(ctx, arg any) *gomock.Call {
+// BlockUserSession indicates an expected call of BlockUserSession.
+func (mr *MockStoreMockRecorder) BlockUserSession(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUserSession", reflect.TypeOf((*MockStore)(nil).BlockUserSession), ctx, arg)
 }
 
-// DeleteAbsence mocks base method.
-func (m *MockStore) DeleteAbsence(ctx context.Context, id int64) (sqlc.Absence, error) {
+// BlockUserSessions mocks base method.
+func (m *MockStore) BlockUserSessions(ctx context.Context, username string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAbsence", ctx, id)
-	ret0, _ := ret[0].(sqlc.Absence)
+	ret := m.ctrl.Call(m, "BlockUserSessions", ctx, username)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteAbsence indicates an expected call of DeleteAbsence.
-func (mr *MockStoreMockRecorder) DeleteAbsence(ctx, id any) *gomock.Call {
+// BlockUserSessions indicates an expected call of BlockUserSessions.
+func (mr *MockStoreMockRecorder) BlockUserSessions(ctx, username any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAbsence", reflect.TypeOf((*MockStore)(nil).DeleteAbsence), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUserSessions", reflect.TypeOf((*MockStore)(nil).BlockUserSessions), ctx, username)
 }
 
-// DeleteCompany mocks base method.
-func (m *MockStore) DeleteCompany(ctx context.Context, id int64) (sqlc.Company, error) {
+// CancelAbsence mocks base method.
+func (m *MockStore) CancelAbsence(ctx context.Context, arg sqlc.CancelAbsenceParams) (sqlc.Absence, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCompany", ctx, id)
-	ret0, _ := ret[0].(sqlc.Company)
+	ret := m.ctrl.Call(m, "CancelAbsence", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Absence)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteCompany indicates an expected call of DeleteCompany.
-func (mr *MockStoreMockRecorder) DeleteCompany(ctx, id any) *gomock.Call {
+// CancelAbsence indicates an expected call of CancelAbsence.
+func (mr *MockStoreMockRecorder) CancelAbsence(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCompany", reflect.TypeOf((*MockStore)(nil).DeleteCompany), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelAbsence", reflect.TypeOf((*MockStore)(nil).CancelAbsence), ctx, arg)
 }
 
-// DeleteEntry mocks base method.
-func (m *MockStore) DeleteEntry(ctx context.Context, id int64) (sqlc.Entry, error) {
+// CancelAbsenceTx mocks base method.
+func (m *MockStore) CancelAbsenceTx(ctx context.Context, arg sqlc.CancelAbsenceParams) (sqlc.Absence, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteEntry", ctx, id)
-	ret0, _ := ret[0].(sqlc.Entry)
+	ret := m.ctrl.Call(m, "CancelAbsenceTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Absence)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteEntry indicates an expected call of DeleteEntry.
-func (mr *MockStoreMockRecorder) DeleteEntry(ctx, id any) *gomock.Call {
+// CancelAbsenceTx indicates an expected call of CancelAbsenceTx.
+func (mr *MockStoreMockRecorder) CancelAbsenceTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntry", reflect.TypeOf((*MockStore)(nil).DeleteEntry), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelAbsenceTx", reflect.TypeOf((*MockStore)(nil).CancelAbsenceTx), ctx, arg)
 }
 
-// DeleteTeam mocks base method.
-func (m *MockStore) DeleteTeam(ctx context.Context, id int64) (sqlc.Team, error) {
+// ChangePasswordTx mocks base method.
+func (m *MockStore) ChangePasswordTx(ctx context.Context, arg sqlc.ChangePasswordTxParams) (sqlc.ChangePasswordTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTeam", ctx, id)
-	ret0, _ := ret[0].(sqlc.Team)
+	ret := m.ctrl.Call(m, "ChangePasswordTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ChangePasswordTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteTeam indicates an expected call of DeleteTeam.
-func (mr *MockStoreMockRecorder) DeleteTeam(ctx, id any) *gomock.Call {
+// ChangePasswordTx indicates an expected call of ChangePasswordTx.
+func (mr *MockStoreMockRecorder) ChangePasswordTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTeam", reflect.TypeOf((*MockStore)(nil).DeleteTeam), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePasswordTx", reflect.TypeOf((*MockStore)(nil).ChangePasswordTx), ctx, arg)
 }
 
-// DeleteUser mocks base method.
-func (m *MockStore) DeleteUser(ctx context.Context, id int64) (sqlc.User, error) {
+// ClaimJobs mocks base method.
+func (m *MockStore) ClaimJobs(ctx context.Context, arg sqlc.ClaimJobsParams) ([]sqlc.Job, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", ctx, id)
-	ret0, _ := ret[0].(sqlc.User)
+	ret := m.ctrl.Call(m, "ClaimJobs", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Job)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockStoreMockRecorder) DeleteUser(ctx, id any) *gomock.Call {
+// ClaimJobs indicates an expected call of ClaimJobs.
+func (mr *MockStoreMockRecorder) ClaimJobs(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStore)(nil).DeleteUser), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimJobs", reflect.TypeOf((*MockStore)(nil).ClaimJobs), ctx, arg)
 }
 
-// GetAbsence mocks base method.
-func (m *MockStore) GetAbsence(ctx context.Context, id int64) (sqlc.Absence, error) {
+// ClaimWebhookDeliveries mocks base method.
+func (m *MockStore) ClaimWebhookDeliveries(ctx context.Context, arg sqlc.ClaimWebhookDeliveriesParams) ([]sqlc.WebhookDelivery, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAbsence", ctx, id)
-	ret0, _ := ret[0].(sqlc.Absence)
+	ret := m.ctrl.Call(m, "ClaimWebhookDeliveries", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.WebhookDelivery)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAbsence indicates an expected call of GetAbsence.
-func (mr *MockStoreMockRecorder) GetAbsence(ctx, id any) *gomock.Call {
+// ClaimWebhookDeliveries indicates an expected call of ClaimWebhookDeliveries.
+func (mr *MockStoreMockRecorder) ClaimWebhookDeliveries(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAbsence", reflect.TypeOf((*MockStore)(nil).GetAbsence), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).ClaimWebhookDeliveries), ctx, arg)
 }
 
-// GetCompany mocks base method.
-func (m *MockStore) GetCompany(ctx context.Context, id int64) (sqlc.Company, error) {
+// CompleteJob mocks base method.
+func (m *MockStore) CompleteJob(ctx context.Context, id int64) (sqlc.Job, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCompany", ctx, id)
-	ret0, _ := ret[0].(sqlc.Company)
+	ret := m.ctrl.Call(m, "CompleteJob", ctx, id)
+	ret0, _ := ret[0].(sqlc.Job)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCompany indicates an expected call of GetCompany.
-func (mr *MockStoreMockRecorder) GetCompany(ctx, id any) *gomock.Call {
+// CompleteJob indicates an expected call of CompleteJob.
+func (mr *MockStoreMockRecorder) CompleteJob(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompany", reflect.TypeOf((*MockStore)(nil).GetCompany), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteJob", reflect.TypeOf((*MockStore)(nil).CompleteJob), ctx, id)
 }
 
-// GetEntry mocks base method.
-func (m *MockStore) GetEntry(ctx context.Context, id int64) (sqlc.Entry, error) {
+// CreateAbsence mocks base method.
+func (m *MockStore) CreateAbsence(ctx context.Context, arg sqlc.CreateAbsenceParams) (sqlc.Absence, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEntry", ctx, id)
-	ret0, _ := ret[0].(sqlc.Entry)
+	ret := m.ctrl.Call(m, "CreateAbsence", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Absence)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEntry indicates an expected call of GetEntry.
-func (mr *MockStoreMockRecorder) GetEntry(ctx, id any) *gomock.Call {
+// CreateAbsence indicates an expected call of CreateAbsence.
+func (mr *MockStoreMockRecorder) CreateAbsence(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAbsence", reflect.TypeOf((*MockStore)(nil).CreateAbsence), ctx, arg)
 }
 
-// GetSession mocks base method.
-func (m *MockStore) GetSession(ctx context.Context, id uuid.UUID) (sqlc.Session, error) {
+// CreateAbsenceType mocks base method.
+func (m *MockStore) CreateAbsenceType(ctx context.Context, arg sqlc.CreateAbsenceTypeParams) (sqlc.AbsenceType, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSession", ctx, id)
-	ret0, _ := ret[0].(sqlc.Session)
+	ret := m.ctrl.Call(m, "CreateAbsenceType", ctx, arg)
+	ret0, _ := ret[0].(sqlc.AbsenceType)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSession indicates an expected call of GetSession.
-func (mr *MockStoreMockRecorder) GetSession(ctx, id any) *gomock.Call {
+// CreateAbsenceType indicates an expected call of CreateAbsenceType.
+func (mr *MockStoreMockRecorder) CreateAbsenceType(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAbsenceType", reflect.TypeOf((*MockStore)(nil).CreateAbsenceType), ctx, arg)
 }
 
-// GetTeam mocks base method.
-func (m *MockStore) GetTeam(ctx context.Context, id int64) (sqlc.Team, error) {
+// CreateCalendarFeed mocks base method.
+func (m *MockStore) CreateCalendarFeed(ctx context.Context, arg sqlc.CreateCalendarFeedParams) (sqlc.CalendarFeed, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTeam", ctx, id)
-	ret0, _ := ret[0].(sqlc.Team)
+	ret := m.ctrl.Call(m, "CreateCalendarFeed", ctx, arg)
+	ret0, _ := ret[0].(sqlc.CalendarFeed)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTeam indicates an expected call of GetTeam.
-func (mr *MockStoreMockRecorder) GetTeam(ctx, id any) *gomock.Call {
+// CreateCalendarFeed indicates an expected call of CreateCalendarFeed.
+func (mr *MockStoreMockRecorder) CreateCalendarFeed(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockStore)(nil).GetTeam), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCalendarFeed", reflect.TypeOf((*MockStore)(nil).CreateCalendarFeed), ctx, arg)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(ctx context.Context, id int64) (sqlc.User, error) {
+// CreateClient mocks base method.
+func (m *MockStore) CreateClient(ctx context.Context, arg sqlc.CreateClientParams) (sqlc.Client, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", ctx, id)
-	ret0, _ := ret[0].(sqlc.User)
+	ret := m.ctrl.Call(m, "CreateClient", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Client)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(ctx, id any) *gomock.Call {
+// CreateClient indicates an expected call of CreateClient.
+func (mr *MockStoreMockRecorder) CreateClient(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClient", reflect.TypeOf((*MockStore)(nil).CreateClient), ctx, arg)
 }
 
-// GetUserByEmail mocks base method.
-func (m *MockStore) GetUserByEmail(ctx context.Context, email string) (sqlc.User, error) {
+// CreateCompany mocks base method.
+func (m *MockStore) CreateCompany(ctx context.Context, name string) (sqlc.Company, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
-	ret0, _ := ret[0].(sqlc.User)
+	ret := m.ctrl.Call(m, "CreateCompany", ctx, name)
+	ret0, _ := ret[0].(sqlc.Company)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByEmail indicates an expected call of GetUserByEmail.
-func (mr *MockStoreMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+// CreateCompany indicates an expected call of CreateCompany.
+func (mr *MockStoreMockRecorder) CreateCompany(ctx, name any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), ctx, email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCompany", reflect.TypeOf((*MockStore)(nil).CreateCompany), ctx, name)
 }
 
-// GetUserByUsername mocks base method.
-func (m *MockStore) GetUserByUsername(ctx context.Context, username string) (sqlc.User, error) {
+// CreateCompanyHoliday mocks base method.
+func (m *MockStore) CreateCompanyHoliday(ctx context.Context, arg sqlc.CreateCompanyHolidayParams) (sqlc.CompanyHoliday, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByUsername", ctx, username)
-	ret0, _ := ret[0].(sqlc.User)
+	ret := m.ctrl.Call(m, "CreateCompanyHoliday", ctx, arg)
+	ret0, _ := ret[0].(sqlc.CompanyHoliday)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByUsername indicates an expected call of GetUserByUsername.
-func (mr *MockStoreMockRecorder) GetUserByUsername(ctx, username any) *gomock.Call {
+// CreateCompanyHoliday indicates an expected call of CreateCompanyHoliday.
+func (mr *MockStoreMockRecorder) CreateCompanyHoliday(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockStore)(nil).GetUserByUsername), ctx, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCompanyHoliday", reflect.TypeOf((*MockStore)(nil).CreateCompanyHoliday), ctx, arg)
 }
 
-// ListAbsences mocks base method.
-func (m *MockStore) ListAbsences(ctx context.Context, arg sqlc.ListAbsencesParams) ([]sqlc.Absence, error) {
+// CreateEntry mocks base method.
+func (m *MockStore) CreateEntry(ctx context.Context, arg sqlc.CreateEntryParams) (sqlc.Entry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAbsences", ctx, arg)
-	ret0, _ := ret[0].([]sqlc.Absence)
+	ret := m.ctrl.Call(m, "CreateEntry", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Entry)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAbsences indicates an expected call of ListAbsences.
-func (mr *MockStoreMockRecorder) ListAbsences(ctx, arg any) *gomock.Call {
+// CreateEntry indicates an expected call of CreateEntry.
+func (mr *MockStoreMockRecorder) CreateEntry(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAbsences", reflect.TypeOf((*MockStore)(nil).ListAbsences), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockStore)(nil).CreateEntry), ctx, arg)
 }
 
-// ListCompanies mocks base method.
-func (m *MockStore) ListCompanies(ctx context.Context, arg sqlc.ListCompaniesParams) ([]sqlc.Company, error) {
+// CreateEntryTx mocks base method.
+func (m *MockStore) CreateEntryTx(ctx context.Context, arg sqlc.CreateEntryParams) (sqlc.Entry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListCompanies", ctx, arg)
-	ret0, _ := ret[0].([]sqlc.Company)
+	ret := m.ctrl.Call(m, "CreateEntryTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Entry)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListCompanies indicates an expected call of ListCompanies.
-func (mr *MockStoreMockRecorder) ListCompanies(ctx, arg any) *gomock.Call {
+// CreateEntryTx indicates an expected call of CreateEntryTx.
+func (mr *MockStoreMockRecorder) CreateEntryTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCompanies", reflect.TypeOf((*MockStore)(nil).ListCompanies), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntryTx", reflect.TypeOf((*MockStore)(nil).CreateEntryTx), ctx, arg)
 }
 
-// ListCompanyEmployees mocks base method.
-func (m *MockStore) ListCompanyEmployees(ctx context.Context, arg sqlc.ListCompanyEmployeesParams) ([]sqlc.User, error) {
+// CreateHourlyRate mocks base method.
+func (m *MockStore) CreateHourlyRate(ctx context.Context, arg sqlc.CreateHourlyRateParams) (sqlc.HourlyRate, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListCompanyEmployees", ctx, arg)
-	ret0, _ := ret[0].([]sqlc.User)
+	ret := m.ctrl.Call(m, "CreateHourlyRate", ctx, arg)
+	ret0, _ := ret[0].(sqlc.HourlyRate)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListCompanyEmployees indicates an expected call of ListCompanyEmployees.
-func (mr *MockStoreMockRecorder) ListCompanyEmployees(ctx, arg any) *gomock.Call {
+// CreateHourlyRate indicates an expected call of CreateHourlyRate.
+func (mr *MockStoreMockRecorder) CreateHourlyRate(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCompanyEmployees", reflect.TypeOf((*MockStore)(nil).ListCompanyEmployees), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHourlyRate", reflect.TypeOf((*MockStore)(nil).CreateHourlyRate), ctx, arg)
 }
 
-// ListEntries mocks base method.
-func (m *MockStore) ListEntries(ctx context.Context, arg sqlc.ListEntriesParams) ([]sqlc.Entry, error) {
+// CreateInvoice mocks base method.
+func (m *MockStore) CreateInvoice(ctx context.Context, arg sqlc.CreateInvoiceParams) (sqlc.Invoice, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListEntries", ctx, arg)
-	ret0, _ := ret[0].([]sqlc.Entry)
+	ret := m.ctrl.Call(m, "CreateInvoice", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Invoice)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListEntries indicates an expected call of ListEntries.
-func (mr *MockStoreMockRecorder) ListEntries(ctx, arg any) *gomock.Call {
+// CreateInvoice indicates an expected call of CreateInvoice.
+func (mr *MockStoreMockRecorder) CreateInvoice(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockStore)(nil).ListEntries), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvoice", reflect.TypeOf((*MockStore)(nil).CreateInvoice), ctx, arg)
 }
 
-// ListTeamMembers mocks base method.
-func (m *MockStore) ListTeamMembers(ctx context.Context, arg sqlc.ListTeamMembersParams) ([]sqlc.User, error) {
+// CreateInvoiceLine mocks base method.
+func (m *MockStore) CreateInvoiceLine(ctx context.Context, arg sqlc.CreateInvoiceLineParams) (sqlc.InvoiceLine, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListTeamMembers", ctx, arg)
-	ret0, _ := ret[0].([]sqlc.User)
+	ret := m.ctrl.Call(m, "CreateInvoiceLine", ctx, arg)
+	ret0, _ := ret[0].(sqlc.InvoiceLine)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListTeamMembers indicates an expected call of ListTeamMembers.
-func (mr *MockStoreMockRecorder) ListTeamMembers(ctx, arg any) *gomock.Call {
+// CreateInvoiceLine indicates an expected call of CreateInvoiceLine.
+func (mr *MockStoreMockRecorder) CreateInvoiceLine(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeamMembers", reflect.TypeOf((*MockStore)(nil).ListTeamMembers), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvoiceLine", reflect.TypeOf((*MockStore)(nil).CreateInvoiceLine), ctx, arg)
 }
 
-// ListTeams mocks base method.
-func (m *MockStore) ListTeams(ctx context.Context, arg sqlc.ListTeamsParams) ([]sqlc.Team, error) {
+// CreateJob mocks base method.
+func (m *MockStore) CreateJob(ctx context.Context, arg sqlc.CreateJobParams) (sqlc.Job, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListTeams", ctx, arg)
-	ret0, _ := ret[0].([]sqlc.Team)
+	ret := m.ctrl.Call(m, "CreateJob", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Job)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListTeams indicates an expected call of ListTeams.
-func (mr *MockStoreMockRecorder) ListTeams(ctx, arg any) *gomock.Call {
+// CreateJob indicates an expected call of CreateJob.
+func (mr *MockStoreMockRecorder) CreateJob(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeams", reflect.TypeOf((*MockStore)(nil).ListTeams), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockStore)(nil).CreateJob), ctx, arg)
 }
 
-// ListUserAbsences mocks base method.
-func (m *MockStore) ListUserAbsences(ctx context.Context, arg sqlc.ListUserAbsencesParams) ([]sqlc.Absence, error) {
+// CreateLeaveEntitlement mocks base method.
+func (m *MockStore) CreateLeaveEntitlement(ctx context.Context, arg sqlc.CreateLeaveEntitlementParams) (sqlc.LeaveEntitlement, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUserAbsences", ctx, arg)
-	ret0, _ := ret[0].([]sqlc.Absence)
+	ret := m.ctrl.Call(m, "CreateLeaveEntitlement", ctx, arg)
+	ret0, _ := ret[0].(sqlc.LeaveEntitlement)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUserAbsences indicates an expected call of ListUserAbsences.
-func (mr *MockStoreMockRecorder) ListUserAbsences(ctx, arg any) *gomock.Call {
+// CreateLeaveEntitlement indicates an expected call of CreateLeaveEntitlement.
+func (mr *MockStoreMockRecorder) CreateLeaveEntitlement(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserAbsences", reflect.TypeOf((*MockStore)(nil).ListUserAbsences), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLeaveEntitlement", reflect.TypeOf((*MockStore)(nil).CreateLeaveEntitlement), ctx, arg)
 }
 
-// ListUserEntries mocks base method.
-func (m *MockStore) ListUserEntries(ctx context.Context, arg sqlc.ListUserEntriesParams) ([]sqlc.Entry, error) {
+// CreateNotification mocks base method.
+func (m *MockStore) CreateNotification(ctx context.Context, arg sqlc.CreateNotificationParams) (sqlc.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUserEntries", ctx, arg)
-	ret0, _ := ret[0].([]sqlc.Entry)
+	ret := m.ctrl.Call(m, "CreateNotification", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUserEntries indicates an expected call of ListUserEntries.
-func (mr *MockStoreMockRecorder) ListUserEntries(ctx, arg any) *gomock.Call {
+// CreateNotification indicates an expected call of CreateNotification.
+func (mr *MockStoreMockRecorder) CreateNotification(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserEntries", reflect.TypeOf((*MockStore)(nil).ListUserEntries), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotification", reflect.TypeOf((*MockStore)(nil).CreateNotification), ctx, arg)
 }
 
-// ListUsers mocks base method.
-func (m *MockStore) ListUsers(ctx context.Context, arg sqlc.ListUsersParams) ([]sqlc.User, error) {
+// CreateProject mocks base method.
+func (m *MockStore) CreateProject(ctx context.Context, arg sqlc.CreateProjectParams) (sqlc.Project, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", ctx, arg)
-	ret0, _ := ret[0].([]sqlc.User)
+	ret := m.ctrl.Call(m, "CreateProject", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Project)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUsers indicates an expected call of ListUsers.
-func (mr *MockStoreMockRecorder) ListUsers(ctx, arg any) *gomock.Call {
+// CreateProject indicates an expected call of CreateProject.
+func (mr *MockStoreMockRecorder) CreateProject(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStore)(nil).ListUsers), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockStore)(nil).CreateProject), ctx, arg)
 }
 
-// SeedDatabase mocks base method.
-func (m *MockStore) SeedDatabase(ctx context.Context, config config.Config) error {
+// CreateSession mocks base method.
+func (m *MockStore) CreateSession(ctx context.Context, arg sqlc.CreateSessionParams) (sqlc.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SeedDatabase", ctx, config)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateSession", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// SeedDatabase indicates an expected call of SeedDatabase.
-func (mr *MockStoreMockRecorder) SeedDatabase(ctx, config any) *gomock.Call {
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockStoreMockRecorder) CreateSession(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeedDatabase", reflect.TypeOf((*MockStore)(nil).SeedDatabase), ctx, config)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), ctx, arg)
 }
 
-// UpdateAbsence mocks base method.
-func (m *MockStore) UpdateAbsence(ctx context.Context, arg sqlc.UpdateAbsenceParams) (sqlc.Absence, error) {
+// CreateTask mocks base method.
+func (m *MockStore) CreateTask(ctx context.Context, arg sqlc.CreateTaskParams) (sqlc.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateAbsence", ctx, arg)
-	ret0, _ := ret[0].(sqlc.Absence)
+	ret := m.ctrl.Call(m, "CreateTask", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateAbsence indicates an expected call of UpdateAbsence.
-func (mr *MockStoreMockRecorder) UpdateAbsence(ctx, arg any) *gomock.Call {
+// CreateTask indicates an expected call of CreateTask.
+func (mr *MockStoreMockRecorder) CreateTask(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAbsence", reflect.TypeOf((*MockStore)(nil).UpdateAbsence), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockStore)(nil).CreateTask), ctx, arg)
 }
 
-// UpdateCompany mocks base method.
-func (m *MockStore) UpdateCompany(ctx context.Context, arg sqlc.UpdateCompanyParams) (sqlc.Company, error) {
+// CreateTeam mocks base method.
+func (m *MockStore) CreateTeam(ctx context.Context, arg sqlc.CreateTeamParams) (sqlc.Team, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCompany", ctx, arg)
-	ret0, _ := ret[0].(sqlc.Company)
+	ret := m.ctrl.Call(m, "CreateTeam", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Team)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateCompany indicates an expected call of UpdateCompany.
-func (mr *MockStoreMockRecorder) UpdateCompany(ctx, arg any) *gomock.Call {
+// CreateTeam indicates an expected call of CreateTeam.
+func (mr *MockStoreMockRecorder) CreateTeam(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeam", reflect.TypeOf((*MockStore)(nil).CreateTeam), ctx, arg)
+}
+
+// CreateTimesheet mocks base method.
+func (m *MockStore) CreateTimesheet(ctx context.Context, arg sqlc.CreateTimesheetParams) (sqlc.Timesheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTimesheet", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Timesheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTimesheet indicates an expected call of CreateTimesheet.
+func (mr *MockStoreMockRecorder) CreateTimesheet(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTimesheet", reflect.TypeOf((*MockStore)(nil).CreateTimesheet), ctx, arg)
+}
+
+// CreateTimesheetDocument mocks base method.
+func (m *MockStore) CreateTimesheetDocument(ctx context.Context, arg sqlc.CreateTimesheetDocumentParams) (sqlc.TimesheetDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTimesheetDocument", ctx, arg)
+	ret0, _ := ret[0].(sqlc.TimesheetDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTimesheetDocument indicates an expected call of CreateTimesheetDocument.
+func (mr *MockStoreMockRecorder) CreateTimesheetDocument(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTimesheetDocument", reflect.TypeOf((*MockStore)(nil).CreateTimesheetDocument), ctx, arg)
+}
+
+// CreateUniqueJob mocks base method.
+func (m *MockStore) CreateUniqueJob(ctx context.Context, arg sqlc.CreateUniqueJobParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUniqueJob", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUniqueJob indicates an expected call of CreateUniqueJob.
+func (mr *MockStoreMockRecorder) CreateUniqueJob(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUniqueJob", reflect.TypeOf((*MockStore)(nil).CreateUniqueJob), ctx, arg)
+}
+
+// CreateUniqueNotification mocks base method.
+func (m *MockStore) CreateUniqueNotification(ctx context.Context, arg sqlc.CreateUniqueNotificationParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUniqueNotification", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUniqueNotification indicates an expected call of CreateUniqueNotification.
+func (mr *MockStoreMockRecorder) CreateUniqueNotification(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUniqueNotification", reflect.TypeOf((*MockStore)(nil).CreateUniqueNotification), ctx, arg)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(ctx context.Context, arg sqlc.CreateUserParams) (sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
+	ret0, _ := ret[0].(sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), ctx, arg)
+}
+
+// CreateWebhookDeliveries mocks base method.
+func (m *MockStore) CreateWebhookDeliveries(ctx context.Context, arg sqlc.CreateWebhookDeliveriesParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDeliveries", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookDeliveries indicates an expected call of CreateWebhookDeliveries.
+func (mr *MockStoreMockRecorder) CreateWebhookDeliveries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).CreateWebhookDeliveries), ctx, arg)
+}
+
+// CreateWebhookDelivery mocks base method.
+func (m *MockStore) CreateWebhookDelivery(ctx context.Context, arg sqlc.CreateWebhookDeliveryParams) (sqlc.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDelivery", ctx, arg)
+	ret0, _ := ret[0].(sqlc.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookDelivery indicates an expected call of CreateWebhookDelivery.
+func (mr *MockStoreMockRecorder) CreateWebhookDelivery(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDelivery", reflect.TypeOf((*MockStore)(nil).CreateWebhookDelivery), ctx, arg)
+}
+
+// CreateWebhookEvent mocks base method.
+func (m *MockStore) CreateWebhookEvent(ctx context.Context, arg sqlc.CreateWebhookEventParams) (sqlc.WebhookEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookEvent", ctx, arg)
+	ret0, _ := ret[0].(sqlc.WebhookEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookEvent indicates an expected call of CreateWebhookEvent.
+func (mr *MockStoreMockRecorder) CreateWebhookEvent(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookEvent", reflect.TypeOf((*MockStore)(nil).CreateWebhookEvent), ctx, arg)
+}
+
+// CreateWebhookSubscription mocks base method.
+func (m *MockStore) CreateWebhookSubscription(ctx context.Context, arg sqlc.CreateWebhookSubscriptionParams) (sqlc.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookSubscription", ctx, arg)
+	ret0, _ := ret[0].(sqlc.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookSubscription indicates an expected call of CreateWebhookSubscription.
+func (mr *MockStoreMockRecorder) CreateWebhookSubscription(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookSubscription", reflect.TypeOf((*MockStore)(nil).CreateWebhookSubscription), ctx, arg)
+}
+
+// CreateWorkSchedule mocks base method.
+func (m *MockStore) CreateWorkSchedule(ctx context.Context, arg sqlc.CreateWorkScheduleParams) (sqlc.WorkSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkSchedule", ctx, arg)
+	ret0, _ := ret[0].(sqlc.WorkSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkSchedule indicates an expected call of CreateWorkSchedule.
+func (mr *MockStoreMockRecorder) CreateWorkSchedule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkSchedule", reflect.TypeOf((*MockStore)(nil).CreateWorkSchedule), ctx, arg)
+}
+
+// CreateWorkScheduleAssignment mocks base method.
+func (m *MockStore) CreateWorkScheduleAssignment(ctx context.Context, arg sqlc.CreateWorkScheduleAssignmentParams) (sqlc.WorkScheduleAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkScheduleAssignment", ctx, arg)
+	ret0, _ := ret[0].(sqlc.WorkScheduleAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkScheduleAssignment indicates an expected call of CreateWorkScheduleAssignment.
+func (mr *MockStoreMockRecorder) CreateWorkScheduleAssignment(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkScheduleAssignment", reflect.TypeOf((*MockStore)(nil).CreateWorkScheduleAssignment), ctx, arg)
+}
+
+// DeadLetterJob mocks base method.
+func (m *MockStore) DeadLetterJob(ctx context.Context, arg sqlc.DeadLetterJobParams) (sqlc.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeadLetterJob", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeadLetterJob indicates an expected call of DeadLetterJob.
+func (mr *MockStoreMockRecorder) DeadLetterJob(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeadLetterJob", reflect.TypeOf((*MockStore)(nil).DeadLetterJob), ctx, arg)
+}
+
+// DecideAbsence mocks base method.
+func (m *MockStore) DecideAbsence(ctx context.Context, arg sqlc.DecideAbsenceParams) (sqlc.Absence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecideAbsence", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Absence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecideAbsence indicates an expected call of DecideAbsence.
+func (mr *MockStoreMockRecorder) DecideAbsence(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecideAbsence", reflect.TypeOf((*MockStore)(nil).DecideAbsence), ctx, arg)
+}
+
+// DecideAbsenceTx mocks base method.
+func (m *MockStore) DecideAbsenceTx(ctx context.Context, arg sqlc.DecideAbsenceParams) (sqlc.Absence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecideAbsenceTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Absence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecideAbsenceTx indicates an expected call of DecideAbsenceTx.
+func (mr *MockStoreMockRecorder) DecideAbsenceTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecideAbsenceTx", reflect.TypeOf((*MockStore)(nil).DecideAbsenceTx), ctx, arg)
+}
+
+// DecideTimesheet mocks base method.
+func (m *MockStore) DecideTimesheet(ctx context.Context, arg sqlc.DecideTimesheetParams) (sqlc.Timesheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecideTimesheet", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Timesheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecideTimesheet indicates an expected call of DecideTimesheet.
+func (mr *MockStoreMockRecorder) DecideTimesheet(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecideTimesheet", reflect.TypeOf((*MockStore)(nil).DecideTimesheet), ctx, arg)
+}
+
+// DeleteAbsence mocks base method.
+func (m *MockStore) DeleteAbsence(ctx context.Context, id int64) (sqlc.Absence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAbsence", ctx, id)
+	ret0, _ := ret[0].(sqlc.Absence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAbsence indicates an expected call of DeleteAbsence.
+func (mr *MockStoreMockRecorder) DeleteAbsence(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAbsence", reflect.TypeOf((*MockStore)(nil).DeleteAbsence), ctx, id)
+}
+
+// DeleteAbsenceType mocks base method.
+func (m *MockStore) DeleteAbsenceType(ctx context.Context, id int64) (sqlc.AbsenceType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAbsenceType", ctx, id)
+	ret0, _ := ret[0].(sqlc.AbsenceType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAbsenceType indicates an expected call of DeleteAbsenceType.
+func (mr *MockStoreMockRecorder) DeleteAbsenceType(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAbsenceType", reflect.TypeOf((*MockStore)(nil).DeleteAbsenceType), ctx, id)
+}
+
+// DeleteClient mocks base method.
+func (m *MockStore) DeleteClient(ctx context.Context, id int64) (sqlc.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClient", ctx, id)
+	ret0, _ := ret[0].(sqlc.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteClient indicates an expected call of DeleteClient.
+func (mr *MockStoreMockRecorder) DeleteClient(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClient", reflect.TypeOf((*MockStore)(nil).DeleteClient), ctx, id)
+}
+
+// DeleteCompany mocks base method.
+func (m *MockStore) DeleteCompany(ctx context.Context, id int64) (sqlc.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCompany", ctx, id)
+	ret0, _ := ret[0].(sqlc.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCompany indicates an expected call of DeleteCompany.
+func (mr *MockStoreMockRecorder) DeleteCompany(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCompany", reflect.TypeOf((*MockStore)(nil).DeleteCompany), ctx, id)
+}
+
+// DeleteCompanyHoliday mocks base method.
+func (m *MockStore) DeleteCompanyHoliday(ctx context.Context, id int64) (sqlc.CompanyHoliday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCompanyHoliday", ctx, id)
+	ret0, _ := ret[0].(sqlc.CompanyHoliday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCompanyHoliday indicates an expected call of DeleteCompanyHoliday.
+func (mr *MockStoreMockRecorder) DeleteCompanyHoliday(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCompanyHoliday", reflect.TypeOf((*MockStore)(nil).DeleteCompanyHoliday), ctx, id)
+}
+
+// DeleteEntry mocks base method.
+func (m *MockStore) DeleteEntry(ctx context.Context, id int64) (sqlc.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEntry", ctx, id)
+	ret0, _ := ret[0].(sqlc.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteEntry indicates an expected call of DeleteEntry.
+func (mr *MockStoreMockRecorder) DeleteEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntry", reflect.TypeOf((*MockStore)(nil).DeleteEntry), ctx, id)
+}
+
+// DeleteEntryTx mocks base method.
+func (m *MockStore) DeleteEntryTx(ctx context.Context, id int64) (sqlc.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEntryTx", ctx, id)
+	ret0, _ := ret[0].(sqlc.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteEntryTx indicates an expected call of DeleteEntryTx.
+func (mr *MockStoreMockRecorder) DeleteEntryTx(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntryTx", reflect.TypeOf((*MockStore)(nil).DeleteEntryTx), ctx, id)
+}
+
+// DeleteHourlyRate mocks base method.
+func (m *MockStore) DeleteHourlyRate(ctx context.Context, id int64) (sqlc.HourlyRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHourlyRate", ctx, id)
+	ret0, _ := ret[0].(sqlc.HourlyRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteHourlyRate indicates an expected call of DeleteHourlyRate.
+func (mr *MockStoreMockRecorder) DeleteHourlyRate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHourlyRate", reflect.TypeOf((*MockStore)(nil).DeleteHourlyRate), ctx, id)
+}
+
+// DeleteInvoice mocks base method.
+func (m *MockStore) DeleteInvoice(ctx context.Context, id int64) (sqlc.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInvoice", ctx, id)
+	ret0, _ := ret[0].(sqlc.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteInvoice indicates an expected call of DeleteInvoice.
+func (mr *MockStoreMockRecorder) DeleteInvoice(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInvoice", reflect.TypeOf((*MockStore)(nil).DeleteInvoice), ctx, id)
+}
+
+// DeleteLeaveEntitlement mocks base method.
+func (m *MockStore) DeleteLeaveEntitlement(ctx context.Context, id int64) (sqlc.LeaveEntitlement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLeaveEntitlement", ctx, id)
+	ret0, _ := ret[0].(sqlc.LeaveEntitlement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteLeaveEntitlement indicates an expected call of DeleteLeaveEntitlement.
+func (mr *MockStoreMockRecorder) DeleteLeaveEntitlement(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLeaveEntitlement", reflect.TypeOf((*MockStore)(nil).DeleteLeaveEntitlement), ctx, id)
+}
+
+// DeleteProject mocks base method.
+func (m *MockStore) DeleteProject(ctx context.Context, id int64) (sqlc.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProject", ctx, id)
+	ret0, _ := ret[0].(sqlc.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProject indicates an expected call of DeleteProject.
+func (mr *MockStoreMockRecorder) DeleteProject(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockStore)(nil).DeleteProject), ctx, id)
+}
+
+// DeletePublicHoliday mocks base method.
+func (m *MockStore) DeletePublicHoliday(ctx context.Context, id int64) (sqlc.PublicHoliday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePublicHoliday", ctx, id)
+	ret0, _ := ret[0].(sqlc.PublicHoliday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePublicHoliday indicates an expected call of DeletePublicHoliday.
+func (mr *MockStoreMockRecorder) DeletePublicHoliday(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePublicHoliday", reflect.TypeOf((*MockStore)(nil).DeletePublicHoliday), ctx, id)
+}
+
+// DeleteSucceededJobs mocks base method.
+func (m *MockStore) DeleteSucceededJobs(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSucceededJobs", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSucceededJobs indicates an expected call of DeleteSucceededJobs.
+func (mr *MockStoreMockRecorder) DeleteSucceededJobs(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSucceededJobs", reflect.TypeOf((*MockStore)(nil).DeleteSucceededJobs), ctx, before)
+}
+
+// DeleteTask mocks base method.
+func (m *MockStore) DeleteTask(ctx context.Context, id int64) (sqlc.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTask", ctx, id)
+	ret0, _ := ret[0].(sqlc.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTask indicates an expected call of DeleteTask.
+func (mr *MockStoreMockRecorder) DeleteTask(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockStore)(nil).DeleteTask), ctx, id)
+}
+
+// DeleteTeam mocks base method.
+func (m *MockStore) DeleteTeam(ctx context.Context, id int64) (sqlc.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTeam", ctx, id)
+	ret0, _ := ret[0].(sqlc.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTeam indicates an expected call of DeleteTeam.
+func (mr *MockStoreMockRecorder) DeleteTeam(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTeam", reflect.TypeOf((*MockStore)(nil).DeleteTeam), ctx, id)
+}
+
+// DeleteTimesheet mocks base method.
+func (m *MockStore) DeleteTimesheet(ctx context.Context, arg sqlc.DeleteTimesheetParams) (sqlc.Timesheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTimesheet", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Timesheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTimesheet indicates an expected call of DeleteTimesheet.
+func (mr *MockStoreMockRecorder) DeleteTimesheet(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTimesheet", reflect.TypeOf((*MockStore)(nil).DeleteTimesheet), ctx, arg)
+}
+
+// DeleteUser mocks base method.
+func (m *MockStore) DeleteUser(ctx context.Context, id int64) (sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, id)
+	ret0, _ := ret[0].(sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockStoreMockRecorder) DeleteUser(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStore)(nil).DeleteUser), ctx, id)
+}
+
+// DeleteUserTx mocks base method.
+func (m *MockStore) DeleteUserTx(ctx context.Context, id int64) (sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserTx", ctx, id)
+	ret0, _ := ret[0].(sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserTx indicates an expected call of DeleteUserTx.
+func (mr *MockStoreMockRecorder) DeleteUserTx(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserTx", reflect.TypeOf((*MockStore)(nil).DeleteUserTx), ctx, id)
+}
+
+// DeleteWebhookSubscription mocks base method.
+func (m *MockStore) DeleteWebhookSubscription(ctx context.Context, arg sqlc.DeleteWebhookSubscriptionParams) (sqlc.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookSubscription", ctx, arg)
+	ret0, _ := ret[0].(sqlc.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWebhookSubscription indicates an expected call of DeleteWebhookSubscription.
+func (mr *MockStoreMockRecorder) DeleteWebhookSubscription(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookSubscription", reflect.TypeOf((*MockStore)(nil).DeleteWebhookSubscription), ctx, arg)
+}
+
+// DeleteWorkSchedule mocks base method.
+func (m *MockStore) DeleteWorkSchedule(ctx context.Context, id int64) (sqlc.WorkSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkSchedule", ctx, id)
+	ret0, _ := ret[0].(sqlc.WorkSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWorkSchedule indicates an expected call of DeleteWorkSchedule.
+func (mr *MockStoreMockRecorder) DeleteWorkSchedule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkSchedule", reflect.TypeOf((*MockStore)(nil).DeleteWorkSchedule), ctx, id)
+}
+
+// DeleteWorkScheduleAssignment mocks base method.
+func (m *MockStore) DeleteWorkScheduleAssignment(ctx context.Context, id int64) (sqlc.WorkScheduleAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkScheduleAssignment", ctx, id)
+	ret0, _ := ret[0].(sqlc.WorkScheduleAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWorkScheduleAssignment indicates an expected call of DeleteWorkScheduleAssignment.
+func (mr *MockStoreMockRecorder) DeleteWorkScheduleAssignment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkScheduleAssignment", reflect.TypeOf((*MockStore)(nil).DeleteWorkScheduleAssignment), ctx, id)
+}
+
+// DraftInvoiceTx mocks base method.
+func (m *MockStore) DraftInvoiceTx(ctx context.Context, arg sqlc.DraftInvoiceTxParams) (sqlc.DraftInvoiceTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DraftInvoiceTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.DraftInvoiceTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DraftInvoiceTx indicates an expected call of DraftInvoiceTx.
+func (mr *MockStoreMockRecorder) DraftInvoiceTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DraftInvoiceTx", reflect.TypeOf((*MockStore)(nil).DraftInvoiceTx), ctx, arg)
+}
+
+// ExportAbsences mocks base method.
+func (m *MockStore) ExportAbsences(ctx context.Context, arg sqlc.ExportAbsencesParams) ([]sqlc.ExportAbsencesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAbsences", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ExportAbsencesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportAbsences indicates an expected call of ExportAbsences.
+func (mr *MockStoreMockRecorder) ExportAbsences(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAbsences", reflect.TypeOf((*MockStore)(nil).ExportAbsences), ctx, arg)
+}
+
+// ExportAbsencesTx mocks base method.
+func (m *MockStore) ExportAbsencesTx(ctx context.Context, arg sqlc.ExportAbsencesParams, fn func(sqlc.ExportAbsencesRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAbsencesTx", ctx, arg, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportAbsencesTx indicates an expected call of ExportAbsencesTx.
+func (mr *MockStoreMockRecorder) ExportAbsencesTx(ctx, arg, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAbsencesTx", reflect.TypeOf((*MockStore)(nil).ExportAbsencesTx), ctx, arg, fn)
+}
+
+// ExportEntries mocks base method.
+func (m *MockStore) ExportEntries(ctx context.Context, arg sqlc.ExportEntriesParams) ([]sqlc.ExportEntriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportEntries", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ExportEntriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportEntries indicates an expected call of ExportEntries.
+func (mr *MockStoreMockRecorder) ExportEntries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportEntries", reflect.TypeOf((*MockStore)(nil).ExportEntries), ctx, arg)
+}
+
+// ExportEntriesTx mocks base method.
+func (m *MockStore) ExportEntriesTx(ctx context.Context, arg sqlc.ExportEntriesParams, fn func(sqlc.ExportEntriesRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportEntriesTx", ctx, arg, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportEntriesTx indicates an expected call of ExportEntriesTx.
+func (mr *MockStoreMockRecorder) ExportEntriesTx(ctx, arg, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportEntriesTx", reflect.TypeOf((*MockStore)(nil).ExportEntriesTx), ctx, arg, fn)
+}
+
+// GetAbsence mocks base method.
+func (m *MockStore) GetAbsence(ctx context.Context, id int64) (sqlc.Absence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAbsence", ctx, id)
+	ret0, _ := ret[0].(sqlc.Absence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAbsence indicates an expected call of GetAbsence.
+func (mr *MockStoreMockRecorder) GetAbsence(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAbsence", reflect.TypeOf((*MockStore)(nil).GetAbsence), ctx, id)
+}
+
+// GetAbsenceType mocks base method.
+func (m *MockStore) GetAbsenceType(ctx context.Context, id int64) (sqlc.AbsenceType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAbsenceType", ctx, id)
+	ret0, _ := ret[0].(sqlc.AbsenceType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAbsenceType indicates an expected call of GetAbsenceType.
+func (mr *MockStoreMockRecorder) GetAbsenceType(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAbsenceType", reflect.TypeOf((*MockStore)(nil).GetAbsenceType), ctx, id)
+}
+
+// GetActiveCalendarFeed mocks base method.
+func (m *MockStore) GetActiveCalendarFeed(ctx context.Context, tokenHash string) (sqlc.CalendarFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveCalendarFeed", ctx, tokenHash)
+	ret0, _ := ret[0].(sqlc.CalendarFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveCalendarFeed indicates an expected call of GetActiveCalendarFeed.
+func (mr *MockStoreMockRecorder) GetActiveCalendarFeed(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveCalendarFeed", reflect.TypeOf((*MockStore)(nil).GetActiveCalendarFeed), ctx, tokenHash)
+}
+
+// GetBillingReport mocks base method.
+func (m *MockStore) GetBillingReport(ctx context.Context, arg sqlc.GetBillingReportParams) ([]sqlc.GetBillingReportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBillingReport", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.GetBillingReportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBillingReport indicates an expected call of GetBillingReport.
+func (mr *MockStoreMockRecorder) GetBillingReport(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBillingReport", reflect.TypeOf((*MockStore)(nil).GetBillingReport), ctx, arg)
+}
+
+// GetClient mocks base method.
+func (m *MockStore) GetClient(ctx context.Context, id int64) (sqlc.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClient", ctx, id)
+	ret0, _ := ret[0].(sqlc.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClient indicates an expected call of GetClient.
+func (mr *MockStoreMockRecorder) GetClient(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClient", reflect.TypeOf((*MockStore)(nil).GetClient), ctx, id)
+}
+
+// GetClockPolicy mocks base method.
+func (m *MockStore) GetClockPolicy(ctx context.Context, companyID int64) (sqlc.ClockPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClockPolicy", ctx, companyID)
+	ret0, _ := ret[0].(sqlc.ClockPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClockPolicy indicates an expected call of GetClockPolicy.
+func (mr *MockStoreMockRecorder) GetClockPolicy(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClockPolicy", reflect.TypeOf((*MockStore)(nil).GetClockPolicy), ctx, companyID)
+}
+
+// GetCompany mocks base method.
+func (m *MockStore) GetCompany(ctx context.Context, id int64) (sqlc.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompany", ctx, id)
+	ret0, _ := ret[0].(sqlc.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompany indicates an expected call of GetCompany.
+func (mr *MockStoreMockRecorder) GetCompany(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompany", reflect.TypeOf((*MockStore)(nil).GetCompany), ctx, id)
+}
+
+// GetCompanyHoliday mocks base method.
+func (m *MockStore) GetCompanyHoliday(ctx context.Context, id int64) (sqlc.CompanyHoliday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanyHoliday", ctx, id)
+	ret0, _ := ret[0].(sqlc.CompanyHoliday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanyHoliday indicates an expected call of GetCompanyHoliday.
+func (mr *MockStoreMockRecorder) GetCompanyHoliday(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyHoliday", reflect.TypeOf((*MockStore)(nil).GetCompanyHoliday), ctx, id)
+}
+
+// GetEntry mocks base method.
+func (m *MockStore) GetEntry(ctx context.Context, id int64) (sqlc.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntry", ctx, id)
+	ret0, _ := ret[0].(sqlc.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntry indicates an expected call of GetEntry.
+func (mr *MockStoreMockRecorder) GetEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), ctx, id)
+}
+
+// GetHourlyRate mocks base method.
+func (m *MockStore) GetHourlyRate(ctx context.Context, id int64) (sqlc.HourlyRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHourlyRate", ctx, id)
+	ret0, _ := ret[0].(sqlc.HourlyRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHourlyRate indicates an expected call of GetHourlyRate.
+func (mr *MockStoreMockRecorder) GetHourlyRate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHourlyRate", reflect.TypeOf((*MockStore)(nil).GetHourlyRate), ctx, id)
+}
+
+// GetInvoice mocks base method.
+func (m *MockStore) GetInvoice(ctx context.Context, id int64) (sqlc.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoice", ctx, id)
+	ret0, _ := ret[0].(sqlc.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoice indicates an expected call of GetInvoice.
+func (mr *MockStoreMockRecorder) GetInvoice(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoice", reflect.TypeOf((*MockStore)(nil).GetInvoice), ctx, id)
+}
+
+// GetInvoiceSequence mocks base method.
+func (m *MockStore) GetInvoiceSequence(ctx context.Context, companyID int64) (sqlc.InvoiceSequence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceSequence", ctx, companyID)
+	ret0, _ := ret[0].(sqlc.InvoiceSequence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceSequence indicates an expected call of GetInvoiceSequence.
+func (mr *MockStoreMockRecorder) GetInvoiceSequence(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceSequence", reflect.TypeOf((*MockStore)(nil).GetInvoiceSequence), ctx, companyID)
+}
+
+// GetJob mocks base method.
+func (m *MockStore) GetJob(ctx context.Context, id int64) (sqlc.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", ctx, id)
+	ret0, _ := ret[0].(sqlc.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockStoreMockRecorder) GetJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockStore)(nil).GetJob), ctx, id)
+}
+
+// GetLatestTimesheetDocument mocks base method.
+func (m *MockStore) GetLatestTimesheetDocument(ctx context.Context, timesheetID int64) (sqlc.TimesheetDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestTimesheetDocument", ctx, timesheetID)
+	ret0, _ := ret[0].(sqlc.TimesheetDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestTimesheetDocument indicates an expected call of GetLatestTimesheetDocument.
+func (mr *MockStoreMockRecorder) GetLatestTimesheetDocument(ctx, timesheetID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestTimesheetDocument", reflect.TypeOf((*MockStore)(nil).GetLatestTimesheetDocument), ctx, timesheetID)
+}
+
+// GetLeaveEntitlement mocks base method.
+func (m *MockStore) GetLeaveEntitlement(ctx context.Context, id int64) (sqlc.LeaveEntitlement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaveEntitlement", ctx, id)
+	ret0, _ := ret[0].(sqlc.LeaveEntitlement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaveEntitlement indicates an expected call of GetLeaveEntitlement.
+func (mr *MockStoreMockRecorder) GetLeaveEntitlement(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaveEntitlement", reflect.TypeOf((*MockStore)(nil).GetLeaveEntitlement), ctx, id)
+}
+
+// GetNotificationByDedupeKey mocks base method.
+func (m *MockStore) GetNotificationByDedupeKey(ctx context.Context, dedupeKey *string) (sqlc.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationByDedupeKey", ctx, dedupeKey)
+	ret0, _ := ret[0].(sqlc.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationByDedupeKey indicates an expected call of GetNotificationByDedupeKey.
+func (mr *MockStoreMockRecorder) GetNotificationByDedupeKey(ctx, dedupeKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationByDedupeKey", reflect.TypeOf((*MockStore)(nil).GetNotificationByDedupeKey), ctx, dedupeKey)
+}
+
+// GetOverlappingAbsence mocks base method.
+func (m *MockStore) GetOverlappingAbsence(ctx context.Context, arg sqlc.GetOverlappingAbsenceParams) (sqlc.Absence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverlappingAbsence", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Absence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverlappingAbsence indicates an expected call of GetOverlappingAbsence.
+func (mr *MockStoreMockRecorder) GetOverlappingAbsence(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverlappingAbsence", reflect.TypeOf((*MockStore)(nil).GetOverlappingAbsence), ctx, arg)
+}
+
+// GetOverlappingEntry mocks base method.
+func (m *MockStore) GetOverlappingEntry(ctx context.Context, arg sqlc.GetOverlappingEntryParams) (sqlc.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverlappingEntry", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverlappingEntry indicates an expected call of GetOverlappingEntry.
+func (mr *MockStoreMockRecorder) GetOverlappingEntry(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverlappingEntry", reflect.TypeOf((*MockStore)(nil).GetOverlappingEntry), ctx, arg)
+}
+
+// GetProject mocks base method.
+func (m *MockStore) GetProject(ctx context.Context, id int64) (sqlc.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProject", ctx, id)
+	ret0, _ := ret[0].(sqlc.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProject indicates an expected call of GetProject.
+func (mr *MockStoreMockRecorder) GetProject(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProject", reflect.TypeOf((*MockStore)(nil).GetProject), ctx, id)
+}
+
+// GetPublicHoliday mocks base method.
+func (m *MockStore) GetPublicHoliday(ctx context.Context, id int64) (sqlc.PublicHoliday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicHoliday", ctx, id)
+	ret0, _ := ret[0].(sqlc.PublicHoliday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicHoliday indicates an expected call of GetPublicHoliday.
+func (mr *MockStoreMockRecorder) GetPublicHoliday(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicHoliday", reflect.TypeOf((*MockStore)(nil).GetPublicHoliday), ctx, id)
+}
+
+// GetRunningEntry mocks base method.
+func (m *MockStore) GetRunningEntry(ctx context.Context, userID int64) (sqlc.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunningEntry", ctx, userID)
+	ret0, _ := ret[0].(sqlc.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunningEntry indicates an expected call of GetRunningEntry.
+func (mr *MockStoreMockRecorder) GetRunningEntry(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningEntry", reflect.TypeOf((*MockStore)(nil).GetRunningEntry), ctx, userID)
+}
+
+// GetSession mocks base method.
+func (m *MockStore) GetSession(ctx context.Context, id uuid.UUID) (sqlc.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, id)
+	ret0, _ := ret[0].(sqlc.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockStoreMockRecorder) GetSession(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), ctx, id)
+}
+
+// GetTask mocks base method.
+func (m *MockStore) GetTask(ctx context.Context, id int64) (sqlc.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTask", ctx, id)
+	ret0, _ := ret[0].(sqlc.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTask indicates an expected call of GetTask.
+func (mr *MockStoreMockRecorder) GetTask(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockStore)(nil).GetTask), ctx, id)
+}
+
+// GetTeam mocks base method.
+func (m *MockStore) GetTeam(ctx context.Context, id int64) (sqlc.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeam", ctx, id)
+	ret0, _ := ret[0].(sqlc.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeam indicates an expected call of GetTeam.
+func (mr *MockStoreMockRecorder) GetTeam(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockStore)(nil).GetTeam), ctx, id)
+}
+
+// GetTimesheet mocks base method.
+func (m *MockStore) GetTimesheet(ctx context.Context, id int64) (sqlc.Timesheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimesheet", ctx, id)
+	ret0, _ := ret[0].(sqlc.Timesheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimesheet indicates an expected call of GetTimesheet.
+func (mr *MockStoreMockRecorder) GetTimesheet(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimesheet", reflect.TypeOf((*MockStore)(nil).GetTimesheet), ctx, id)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(ctx context.Context, id int64) (sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, id)
+	ret0, _ := ret[0].(sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), ctx, id)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockStore) GetUserByEmail(ctx context.Context, email string) (sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockStoreMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), ctx, email)
+}
+
+// GetUserByUsername mocks base method.
+func (m *MockStore) GetUserByUsername(ctx context.Context, username string) (sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", ctx, username)
+	ret0, _ := ret[0].(sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockStoreMockRecorder) GetUserByUsername(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockStore)(nil).GetUserByUsername), ctx, username)
+}
+
+// GetWebhookDelivery mocks base method.
+func (m *MockStore) GetWebhookDelivery(ctx context.Context, arg sqlc.GetWebhookDeliveryParams) (sqlc.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDelivery", ctx, arg)
+	ret0, _ := ret[0].(sqlc.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDelivery indicates an expected call of GetWebhookDelivery.
+func (mr *MockStoreMockRecorder) GetWebhookDelivery(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDelivery", reflect.TypeOf((*MockStore)(nil).GetWebhookDelivery), ctx, arg)
+}
+
+// GetWebhookDeliveryRequest mocks base method.
+func (m *MockStore) GetWebhookDeliveryRequest(ctx context.Context, id int64) (sqlc.GetWebhookDeliveryRequestRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveryRequest", ctx, id)
+	ret0, _ := ret[0].(sqlc.GetWebhookDeliveryRequestRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDeliveryRequest indicates an expected call of GetWebhookDeliveryRequest.
+func (mr *MockStoreMockRecorder) GetWebhookDeliveryRequest(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveryRequest", reflect.TypeOf((*MockStore)(nil).GetWebhookDeliveryRequest), ctx, id)
+}
+
+// GetWebhookSubscription mocks base method.
+func (m *MockStore) GetWebhookSubscription(ctx context.Context, arg sqlc.GetWebhookSubscriptionParams) (sqlc.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookSubscription", ctx, arg)
+	ret0, _ := ret[0].(sqlc.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookSubscription indicates an expected call of GetWebhookSubscription.
+func (mr *MockStoreMockRecorder) GetWebhookSubscription(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookSubscription", reflect.TypeOf((*MockStore)(nil).GetWebhookSubscription), ctx, arg)
+}
+
+// GetWorkSchedule mocks base method.
+func (m *MockStore) GetWorkSchedule(ctx context.Context, id int64) (sqlc.WorkSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkSchedule", ctx, id)
+	ret0, _ := ret[0].(sqlc.WorkSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkSchedule indicates an expected call of GetWorkSchedule.
+func (mr *MockStoreMockRecorder) GetWorkSchedule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkSchedule", reflect.TypeOf((*MockStore)(nil).GetWorkSchedule), ctx, id)
+}
+
+// GetWorkScheduleAssignment mocks base method.
+func (m *MockStore) GetWorkScheduleAssignment(ctx context.Context, id int64) (sqlc.WorkScheduleAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkScheduleAssignment", ctx, id)
+	ret0, _ := ret[0].(sqlc.WorkScheduleAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkScheduleAssignment indicates an expected call of GetWorkScheduleAssignment.
+func (mr *MockStoreMockRecorder) GetWorkScheduleAssignment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkScheduleAssignment", reflect.TypeOf((*MockStore)(nil).GetWorkScheduleAssignment), ctx, id)
+}
+
+// ImportPublicHolidaysTx mocks base method.
+func (m *MockStore) ImportPublicHolidaysTx(ctx context.Context, arg []sqlc.UpsertPublicHolidayParams) ([]sqlc.PublicHoliday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportPublicHolidaysTx", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.PublicHoliday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportPublicHolidaysTx indicates an expected call of ImportPublicHolidaysTx.
+func (mr *MockStoreMockRecorder) ImportPublicHolidaysTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportPublicHolidaysTx", reflect.TypeOf((*MockStore)(nil).ImportPublicHolidaysTx), ctx, arg)
+}
+
+// IssueInvoice mocks base method.
+func (m *MockStore) IssueInvoice(ctx context.Context, arg sqlc.IssueInvoiceParams) (sqlc.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueInvoice", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueInvoice indicates an expected call of IssueInvoice.
+func (mr *MockStoreMockRecorder) IssueInvoice(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueInvoice", reflect.TypeOf((*MockStore)(nil).IssueInvoice), ctx, arg)
+}
+
+// IssueInvoiceTx mocks base method.
+func (m *MockStore) IssueInvoiceTx(ctx context.Context, invoiceID int64) (sqlc.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueInvoiceTx", ctx, invoiceID)
+	ret0, _ := ret[0].(sqlc.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueInvoiceTx indicates an expected call of IssueInvoiceTx.
+func (mr *MockStoreMockRecorder) IssueInvoiceTx(ctx, invoiceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueInvoiceTx", reflect.TypeOf((*MockStore)(nil).IssueInvoiceTx), ctx, invoiceID)
+}
+
+// ListAbsenceTypes mocks base method.
+func (m *MockStore) ListAbsenceTypes(ctx context.Context, arg sqlc.ListAbsenceTypesParams) ([]sqlc.AbsenceType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAbsenceTypes", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.AbsenceType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAbsenceTypes indicates an expected call of ListAbsenceTypes.
+func (mr *MockStoreMockRecorder) ListAbsenceTypes(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAbsenceTypes", reflect.TypeOf((*MockStore)(nil).ListAbsenceTypes), ctx, arg)
+}
+
+// ListAbsences mocks base method.
+func (m *MockStore) ListAbsences(ctx context.Context, arg sqlc.ListAbsencesParams) ([]sqlc.Absence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAbsences", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Absence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAbsences indicates an expected call of ListAbsences.
+func (mr *MockStoreMockRecorder) ListAbsences(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAbsences", reflect.TypeOf((*MockStore)(nil).ListAbsences), ctx, arg)
+}
+
+// ListActiveUserSessions mocks base method.
+func (m *MockStore) ListActiveUserSessions(ctx context.Context, arg sqlc.ListActiveUserSessionsParams) ([]sqlc.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveUserSessions", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveUserSessions indicates an expected call of ListActiveUserSessions.
+func (mr *MockStoreMockRecorder) ListActiveUserSessions(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveUserSessions", reflect.TypeOf((*MockStore)(nil).ListActiveUserSessions), ctx, arg)
+}
+
+// ListCalendarFeeds mocks base method.
+func (m *MockStore) ListCalendarFeeds(ctx context.Context, arg sqlc.ListCalendarFeedsParams) ([]sqlc.CalendarFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCalendarFeeds", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.CalendarFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCalendarFeeds indicates an expected call of ListCalendarFeeds.
+func (mr *MockStoreMockRecorder) ListCalendarFeeds(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCalendarFeeds", reflect.TypeOf((*MockStore)(nil).ListCalendarFeeds), ctx, arg)
+}
+
+// ListClients mocks base method.
+func (m *MockStore) ListClients(ctx context.Context, arg sqlc.ListClientsParams) ([]sqlc.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClients", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClients indicates an expected call of ListClients.
+func (mr *MockStoreMockRecorder) ListClients(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClients", reflect.TypeOf((*MockStore)(nil).ListClients), ctx, arg)
+}
+
+// ListClockPolicies mocks base method.
+func (m *MockStore) ListClockPolicies(ctx context.Context) ([]sqlc.ClockPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClockPolicies", ctx)
+	ret0, _ := ret[0].([]sqlc.ClockPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClockPolicies indicates an expected call of ListClockPolicies.
+func (mr *MockStoreMockRecorder) ListClockPolicies(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClockPolicies", reflect.TypeOf((*MockStore)(nil).ListClockPolicies), ctx)
+}
+
+// ListCompanies mocks base method.
+func (m *MockStore) ListCompanies(ctx context.Context, arg sqlc.ListCompaniesParams) ([]sqlc.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCompanies", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCompanies indicates an expected call of ListCompanies.
+func (mr *MockStoreMockRecorder) ListCompanies(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCompanies", reflect.TypeOf((*MockStore)(nil).ListCompanies), ctx, arg)
+}
+
+// ListCompanyEmployees mocks base method.
+func (m *MockStore) ListCompanyEmployees(ctx context.Context, arg sqlc.ListCompanyEmployeesParams) ([]sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCompanyEmployees", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCompanyEmployees indicates an expected call of ListCompanyEmployees.
+func (mr *MockStoreMockRecorder) ListCompanyEmployees(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCompanyEmployees", reflect.TypeOf((*MockStore)(nil).ListCompanyEmployees), ctx, arg)
+}
+
+// ListCompanyHolidays mocks base method.
+func (m *MockStore) ListCompanyHolidays(ctx context.Context, arg sqlc.ListCompanyHolidaysParams) ([]sqlc.CompanyHoliday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCompanyHolidays", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.CompanyHoliday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCompanyHolidays indicates an expected call of ListCompanyHolidays.
+func (mr *MockStoreMockRecorder) ListCompanyHolidays(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCompanyHolidays", reflect.TypeOf((*MockStore)(nil).ListCompanyHolidays), ctx, arg)
+}
+
+// ListCompanyRunningEntries mocks base method.
+func (m *MockStore) ListCompanyRunningEntries(ctx context.Context, companyID int64) ([]sqlc.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCompanyRunningEntries", ctx, companyID)
+	ret0, _ := ret[0].([]sqlc.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCompanyRunningEntries indicates an expected call of ListCompanyRunningEntries.
+func (mr *MockStoreMockRecorder) ListCompanyRunningEntries(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCompanyRunningEntries", reflect.TypeOf((*MockStore)(nil).ListCompanyRunningEntries), ctx, companyID)
+}
+
+// ListDailyWorkedSeconds mocks base method.
+func (m *MockStore) ListDailyWorkedSeconds(ctx context.Context, arg sqlc.ListDailyWorkedSecondsParams) ([]sqlc.ListDailyWorkedSecondsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDailyWorkedSeconds", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ListDailyWorkedSecondsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDailyWorkedSeconds indicates an expected call of ListDailyWorkedSeconds.
+func (mr *MockStoreMockRecorder) ListDailyWorkedSeconds(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDailyWorkedSeconds", reflect.TypeOf((*MockStore)(nil).ListDailyWorkedSeconds), ctx, arg)
+}
+
+// ListEntries mocks base method.
+func (m *MockStore) ListEntries(ctx context.Context, arg sqlc.ListEntriesParams) ([]sqlc.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntries", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntries indicates an expected call of ListEntries.
+func (mr *MockStoreMockRecorder) ListEntries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockStore)(nil).ListEntries), ctx, arg)
+}
+
+// ListHourlyRates mocks base method.
+func (m *MockStore) ListHourlyRates(ctx context.Context, arg sqlc.ListHourlyRatesParams) ([]sqlc.HourlyRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHourlyRates", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.HourlyRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHourlyRates indicates an expected call of ListHourlyRates.
+func (mr *MockStoreMockRecorder) ListHourlyRates(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHourlyRates", reflect.TypeOf((*MockStore)(nil).ListHourlyRates), ctx, arg)
+}
+
+// ListInvoiceLines mocks base method.
+func (m *MockStore) ListInvoiceLines(ctx context.Context, invoiceID int64) ([]sqlc.InvoiceLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvoiceLines", ctx, invoiceID)
+	ret0, _ := ret[0].([]sqlc.InvoiceLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvoiceLines indicates an expected call of ListInvoiceLines.
+func (mr *MockStoreMockRecorder) ListInvoiceLines(ctx, invoiceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvoiceLines", reflect.TypeOf((*MockStore)(nil).ListInvoiceLines), ctx, invoiceID)
+}
+
+// ListInvoiceableEntries mocks base method.
+func (m *MockStore) ListInvoiceableEntries(ctx context.Context, arg sqlc.ListInvoiceableEntriesParams) ([]sqlc.ListInvoiceableEntriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvoiceableEntries", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ListInvoiceableEntriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvoiceableEntries indicates an expected call of ListInvoiceableEntries.
+func (mr *MockStoreMockRecorder) ListInvoiceableEntries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvoiceableEntries", reflect.TypeOf((*MockStore)(nil).ListInvoiceableEntries), ctx, arg)
+}
+
+// ListInvoices mocks base method.
+func (m *MockStore) ListInvoices(ctx context.Context, arg sqlc.ListInvoicesParams) ([]sqlc.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvoices", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvoices indicates an expected call of ListInvoices.
+func (mr *MockStoreMockRecorder) ListInvoices(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvoices", reflect.TypeOf((*MockStore)(nil).ListInvoices), ctx, arg)
+}
+
+// ListJobs mocks base method.
+func (m *MockStore) ListJobs(ctx context.Context, arg sqlc.ListJobsParams) ([]sqlc.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobs", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobs indicates an expected call of ListJobs.
+func (mr *MockStoreMockRecorder) ListJobs(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobs", reflect.TypeOf((*MockStore)(nil).ListJobs), ctx, arg)
+}
+
+// ListProjectTasks mocks base method.
+func (m *MockStore) ListProjectTasks(ctx context.Context, arg sqlc.ListProjectTasksParams) ([]sqlc.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProjectTasks", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProjectTasks indicates an expected call of ListProjectTasks.
+func (mr *MockStoreMockRecorder) ListProjectTasks(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjectTasks", reflect.TypeOf((*MockStore)(nil).ListProjectTasks), ctx, arg)
+}
+
+// ListProjects mocks base method.
+func (m *MockStore) ListProjects(ctx context.Context, arg sqlc.ListProjectsParams) ([]sqlc.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProjects", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProjects indicates an expected call of ListProjects.
+func (mr *MockStoreMockRecorder) ListProjects(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjects", reflect.TypeOf((*MockStore)(nil).ListProjects), ctx, arg)
+}
+
+// ListPublicHolidays mocks base method.
+func (m *MockStore) ListPublicHolidays(ctx context.Context, arg sqlc.ListPublicHolidaysParams) ([]sqlc.PublicHoliday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPublicHolidays", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.PublicHoliday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPublicHolidays indicates an expected call of ListPublicHolidays.
+func (mr *MockStoreMockRecorder) ListPublicHolidays(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPublicHolidays", reflect.TypeOf((*MockStore)(nil).ListPublicHolidays), ctx, arg)
+}
+
+// ListTeamMembers mocks base method.
+func (m *MockStore) ListTeamMembers(ctx context.Context, arg sqlc.ListTeamMembersParams) ([]sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTeamMembers", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeamMembers indicates an expected call of ListTeamMembers.
+func (mr *MockStoreMockRecorder) ListTeamMembers(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeamMembers", reflect.TypeOf((*MockStore)(nil).ListTeamMembers), ctx, arg)
+}
+
+// ListTeams mocks base method.
+func (m *MockStore) ListTeams(ctx context.Context, arg sqlc.ListTeamsParams) ([]sqlc.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTeams", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeams indicates an expected call of ListTeams.
+func (mr *MockStoreMockRecorder) ListTeams(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeams", reflect.TypeOf((*MockStore)(nil).ListTeams), ctx, arg)
+}
+
+// ListTimesheetAbsences mocks base method.
+func (m *MockStore) ListTimesheetAbsences(ctx context.Context, arg sqlc.ListTimesheetAbsencesParams) ([]sqlc.ListTimesheetAbsencesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTimesheetAbsences", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ListTimesheetAbsencesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTimesheetAbsences indicates an expected call of ListTimesheetAbsences.
+func (mr *MockStoreMockRecorder) ListTimesheetAbsences(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTimesheetAbsences", reflect.TypeOf((*MockStore)(nil).ListTimesheetAbsences), ctx, arg)
+}
+
+// ListTimesheetEntries mocks base method.
+func (m *MockStore) ListTimesheetEntries(ctx context.Context, arg sqlc.ListTimesheetEntriesParams) ([]sqlc.ListTimesheetEntriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTimesheetEntries", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ListTimesheetEntriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTimesheetEntries indicates an expected call of ListTimesheetEntries.
+func (mr *MockStoreMockRecorder) ListTimesheetEntries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTimesheetEntries", reflect.TypeOf((*MockStore)(nil).ListTimesheetEntries), ctx, arg)
+}
+
+// ListTimesheets mocks base method.
+func (m *MockStore) ListTimesheets(ctx context.Context, arg sqlc.ListTimesheetsParams) ([]sqlc.Timesheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTimesheets", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Timesheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTimesheets indicates an expected call of ListTimesheets.
+func (mr *MockStoreMockRecorder) ListTimesheets(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTimesheets", reflect.TypeOf((*MockStore)(nil).ListTimesheets), ctx, arg)
+}
+
+// ListTrackedAbsenceTypes mocks base method.
+func (m *MockStore) ListTrackedAbsenceTypes(ctx context.Context, companyID int64) ([]sqlc.AbsenceType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrackedAbsenceTypes", ctx, companyID)
+	ret0, _ := ret[0].([]sqlc.AbsenceType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrackedAbsenceTypes indicates an expected call of ListTrackedAbsenceTypes.
+func (mr *MockStoreMockRecorder) ListTrackedAbsenceTypes(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrackedAbsenceTypes", reflect.TypeOf((*MockStore)(nil).ListTrackedAbsenceTypes), ctx, companyID)
+}
+
+// ListUserAbsences mocks base method.
+func (m *MockStore) ListUserAbsences(ctx context.Context, arg sqlc.ListUserAbsencesParams) ([]sqlc.Absence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserAbsences", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Absence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserAbsences indicates an expected call of ListUserAbsences.
+func (mr *MockStoreMockRecorder) ListUserAbsences(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserAbsences", reflect.TypeOf((*MockStore)(nil).ListUserAbsences), ctx, arg)
+}
+
+// ListUserEntries mocks base method.
+func (m *MockStore) ListUserEntries(ctx context.Context, arg sqlc.ListUserEntriesParams) ([]sqlc.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserEntries", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserEntries indicates an expected call of ListUserEntries.
+func (mr *MockStoreMockRecorder) ListUserEntries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserEntries", reflect.TypeOf((*MockStore)(nil).ListUserEntries), ctx, arg)
+}
+
+// ListUserLeave mocks base method.
+func (m *MockStore) ListUserLeave(ctx context.Context, arg sqlc.ListUserLeaveParams) ([]sqlc.Absence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserLeave", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Absence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserLeave indicates an expected call of ListUserLeave.
+func (mr *MockStoreMockRecorder) ListUserLeave(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserLeave", reflect.TypeOf((*MockStore)(nil).ListUserLeave), ctx, arg)
+}
+
+// ListUserLeaveEntitlements mocks base method.
+func (m *MockStore) ListUserLeaveEntitlements(ctx context.Context, userID int64) ([]sqlc.LeaveEntitlement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserLeaveEntitlements", ctx, userID)
+	ret0, _ := ret[0].([]sqlc.LeaveEntitlement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserLeaveEntitlements indicates an expected call of ListUserLeaveEntitlements.
+func (mr *MockStoreMockRecorder) ListUserLeaveEntitlements(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserLeaveEntitlements", reflect.TypeOf((*MockStore)(nil).ListUserLeaveEntitlements), ctx, userID)
+}
+
+// ListUserNotifications mocks base method.
+func (m *MockStore) ListUserNotifications(ctx context.Context, arg sqlc.ListUserNotificationsParams) ([]sqlc.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserNotifications", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserNotifications indicates an expected call of ListUserNotifications.
+func (mr *MockStoreMockRecorder) ListUserNotifications(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserNotifications", reflect.TypeOf((*MockStore)(nil).ListUserNotifications), ctx, arg)
+}
+
+// ListUserPaidAbsences mocks base method.
+func (m *MockStore) ListUserPaidAbsences(ctx context.Context, arg sqlc.ListUserPaidAbsencesParams) ([]sqlc.Absence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserPaidAbsences", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Absence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserPaidAbsences indicates an expected call of ListUserPaidAbsences.
+func (mr *MockStoreMockRecorder) ListUserPaidAbsences(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserPaidAbsences", reflect.TypeOf((*MockStore)(nil).ListUserPaidAbsences), ctx, arg)
+}
+
+// ListUserTimesheets mocks base method.
+func (m *MockStore) ListUserTimesheets(ctx context.Context, arg sqlc.ListUserTimesheetsParams) ([]sqlc.Timesheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserTimesheets", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Timesheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserTimesheets indicates an expected call of ListUserTimesheets.
+func (mr *MockStoreMockRecorder) ListUserTimesheets(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserTimesheets", reflect.TypeOf((*MockStore)(nil).ListUserTimesheets), ctx, arg)
+}
+
+// ListUserWorkScheduleAssignments mocks base method.
+func (m *MockStore) ListUserWorkScheduleAssignments(ctx context.Context, userID int64) ([]sqlc.WorkScheduleAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserWorkScheduleAssignments", ctx, userID)
+	ret0, _ := ret[0].([]sqlc.WorkScheduleAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserWorkScheduleAssignments indicates an expected call of ListUserWorkScheduleAssignments.
+func (mr *MockStoreMockRecorder) ListUserWorkScheduleAssignments(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserWorkScheduleAssignments", reflect.TypeOf((*MockStore)(nil).ListUserWorkScheduleAssignments), ctx, userID)
+}
+
+// ListUsers mocks base method.
+func (m *MockStore) ListUsers(ctx context.Context, arg sqlc.ListUsersParams) ([]sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockStoreMockRecorder) ListUsers(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStore)(nil).ListUsers), ctx, arg)
+}
+
+// ListWebhookDeliveries mocks base method.
+func (m *MockStore) ListWebhookDeliveries(ctx context.Context, arg sqlc.ListWebhookDeliveriesParams) ([]sqlc.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockStoreMockRecorder) ListWebhookDeliveries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).ListWebhookDeliveries), ctx, arg)
+}
+
+// ListWebhookSubscriptions mocks base method.
+func (m *MockStore) ListWebhookSubscriptions(ctx context.Context, companyID int64) ([]sqlc.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookSubscriptions", ctx, companyID)
+	ret0, _ := ret[0].([]sqlc.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookSubscriptions indicates an expected call of ListWebhookSubscriptions.
+func (mr *MockStoreMockRecorder) ListWebhookSubscriptions(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookSubscriptions", reflect.TypeOf((*MockStore)(nil).ListWebhookSubscriptions), ctx, companyID)
+}
+
+// ListWorkSchedules mocks base method.
+func (m *MockStore) ListWorkSchedules(ctx context.Context, arg sqlc.ListWorkSchedulesParams) ([]sqlc.WorkSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkSchedules", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.WorkSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkSchedules indicates an expected call of ListWorkSchedules.
+func (mr *MockStoreMockRecorder) ListWorkSchedules(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkSchedules", reflect.TypeOf((*MockStore)(nil).ListWorkSchedules), ctx, arg)
+}
+
+// NextInvoiceNumber mocks base method.
+func (m *MockStore) NextInvoiceNumber(ctx context.Context, companyID int64) (sqlc.InvoiceSequence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextInvoiceNumber", ctx, companyID)
+	ret0, _ := ret[0].(sqlc.InvoiceSequence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextInvoiceNumber indicates an expected call of NextInvoiceNumber.
+func (mr *MockStoreMockRecorder) NextInvoiceNumber(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextInvoiceNumber", reflect.TypeOf((*MockStore)(nil).NextInvoiceNumber), ctx, companyID)
+}
+
+// PayInvoice mocks base method.
+func (m *MockStore) PayInvoice(ctx context.Context, id int64) (sqlc.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayInvoice", ctx, id)
+	ret0, _ := ret[0].(sqlc.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayInvoice indicates an expected call of PayInvoice.
+func (mr *MockStoreMockRecorder) PayInvoice(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayInvoice", reflect.TypeOf((*MockStore)(nil).PayInvoice), ctx, id)
+}
+
+// ReadNotification mocks base method.
+func (m *MockStore) ReadNotification(ctx context.Context, arg sqlc.ReadNotificationParams) (sqlc.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadNotification", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadNotification indicates an expected call of ReadNotification.
+func (mr *MockStoreMockRecorder) ReadNotification(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadNotification", reflect.TypeOf((*MockStore)(nil).ReadNotification), ctx, arg)
+}
+
+// RecordWebhookDeliveryAttempt mocks base method.
+func (m *MockStore) RecordWebhookDeliveryAttempt(ctx context.Context, arg sqlc.RecordWebhookDeliveryAttemptParams) (sqlc.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordWebhookDeliveryAttempt", ctx, arg)
+	ret0, _ := ret[0].(sqlc.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordWebhookDeliveryAttempt indicates an expected call of RecordWebhookDeliveryAttempt.
+func (mr *MockStoreMockRecorder) RecordWebhookDeliveryAttempt(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordWebhookDeliveryAttempt", reflect.TypeOf((*MockStore)(nil).RecordWebhookDeliveryAttempt), ctx, arg)
+}
+
+// ReleaseInvoiceEntries mocks base method.
+func (m *MockStore) ReleaseInvoiceEntries(ctx context.Context, invoiceID *int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseInvoiceEntries", ctx, invoiceID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseInvoiceEntries indicates an expected call of ReleaseInvoiceEntries.
+func (mr *MockStoreMockRecorder) ReleaseInvoiceEntries(ctx, invoiceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseInvoiceEntries", reflect.TypeOf((*MockStore)(nil).ReleaseInvoiceEntries), ctx, invoiceID)
+}
+
+// ReopenTimesheet mocks base method.
+func (m *MockStore) ReopenTimesheet(ctx context.Context, arg sqlc.ReopenTimesheetParams) (sqlc.Timesheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReopenTimesheet", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Timesheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReopenTimesheet indicates an expected call of ReopenTimesheet.
+func (mr *MockStoreMockRecorder) ReopenTimesheet(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenTimesheet", reflect.TypeOf((*MockStore)(nil).ReopenTimesheet), ctx, arg)
+}
+
+// RequeueDeadJob mocks base method.
+func (m *MockStore) RequeueDeadJob(ctx context.Context, id int64) (sqlc.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueDeadJob", ctx, id)
+	ret0, _ := ret[0].(sqlc.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueDeadJob indicates an expected call of RequeueDeadJob.
+func (mr *MockStoreMockRecorder) RequeueDeadJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueDeadJob", reflect.TypeOf((*MockStore)(nil).RequeueDeadJob), ctx, id)
+}
+
+// RetryJob mocks base method.
+func (m *MockStore) RetryJob(ctx context.Context, arg sqlc.RetryJobParams) (sqlc.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryJob", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryJob indicates an expected call of RetryJob.
+func (mr *MockStoreMockRecorder) RetryJob(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryJob", reflect.TypeOf((*MockStore)(nil).RetryJob), ctx, arg)
+}
+
+// RevokeCalendarFeed mocks base method.
+func (m *MockStore) RevokeCalendarFeed(ctx context.Context, arg sqlc.RevokeCalendarFeedParams) (sqlc.CalendarFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeCalendarFeed", ctx, arg)
+	ret0, _ := ret[0].(sqlc.CalendarFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeCalendarFeed indicates an expected call of RevokeCalendarFeed.
+func (mr *MockStoreMockRecorder) RevokeCalendarFeed(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeCalendarFeed", reflect.TypeOf((*MockStore)(nil).RevokeCalendarFeed), ctx, arg)
+}
+
+// RotateSession mocks base method.
+func (m *MockStore) RotateSession(ctx context.Context, id uuid.UUID) (sqlc.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSession", ctx, id)
+	ret0, _ := ret[0].(sqlc.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateSession indicates an expected call of RotateSession.
+func (mr *MockStoreMockRecorder) RotateSession(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSession", reflect.TypeOf((*MockStore)(nil).RotateSession), ctx, id)
+}
+
+// RotateSessionTx mocks base method.
+func (m *MockStore) RotateSessionTx(ctx context.Context, arg sqlc.RotateSessionTxParams) (sqlc.RotateSessionTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSessionTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.RotateSessionTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateSessionTx indicates an expected call of RotateSessionTx.
+func (mr *MockStoreMockRecorder) RotateSessionTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSessionTx", reflect.TypeOf((*MockStore)(nil).RotateSessionTx), ctx, arg)
+}
+
+// SeedDatabase mocks base method.
+func (m *MockStore) SeedDatabase(ctx context.Context, config config.Config) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SeedDatabase", ctx, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SeedDatabase indicates an expected call of SeedDatabase.
+func (mr *MockStoreMockRecorder) SeedDatabase(ctx, config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeedDatabase", reflect.TypeOf((*MockStore)(nil).SeedDatabase), ctx, config)
+}
+
+// SetEntriesInvoice mocks base method.
+func (m *MockStore) SetEntriesInvoice(ctx context.Context, arg sqlc.SetEntriesInvoiceParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEntriesInvoice", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetEntriesInvoice indicates an expected call of SetEntriesInvoice.
+func (mr *MockStoreMockRecorder) SetEntriesInvoice(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEntriesInvoice", reflect.TypeOf((*MockStore)(nil).SetEntriesInvoice), ctx, arg)
+}
+
+// SetInvoiceTotal mocks base method.
+func (m *MockStore) SetInvoiceTotal(ctx context.Context, arg sqlc.SetInvoiceTotalParams) (sqlc.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetInvoiceTotal", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetInvoiceTotal indicates an expected call of SetInvoiceTotal.
+func (mr *MockStoreMockRecorder) SetInvoiceTotal(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInvoiceTotal", reflect.TypeOf((*MockStore)(nil).SetInvoiceTotal), ctx, arg)
+}
+
+// StopRunningEntry mocks base method.
+func (m *MockStore) StopRunningEntry(ctx context.Context, arg sqlc.StopRunningEntryParams) (sqlc.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopRunningEntry", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopRunningEntry indicates an expected call of StopRunningEntry.
+func (mr *MockStoreMockRecorder) StopRunningEntry(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopRunningEntry", reflect.TypeOf((*MockStore)(nil).StopRunningEntry), ctx, arg)
+}
+
+// StopRunningEntryTx mocks base method.
+func (m *MockStore) StopRunningEntryTx(ctx context.Context, arg sqlc.StopRunningEntryParams) (sqlc.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopRunningEntryTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopRunningEntryTx indicates an expected call of StopRunningEntryTx.
+func (mr *MockStoreMockRecorder) StopRunningEntryTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopRunningEntryTx", reflect.TypeOf((*MockStore)(nil).StopRunningEntryTx), ctx, arg)
+}
+
+// SubmitTimesheet mocks base method.
+func (m *MockStore) SubmitTimesheet(ctx context.Context, arg sqlc.SubmitTimesheetParams) (sqlc.Timesheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitTimesheet", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Timesheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitTimesheet indicates an expected call of SubmitTimesheet.
+func (mr *MockStoreMockRecorder) SubmitTimesheet(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitTimesheet", reflect.TypeOf((*MockStore)(nil).SubmitTimesheet), ctx, arg)
+}
+
+// UpdateAbsence mocks base method.
+func (m *MockStore) UpdateAbsence(ctx context.Context, arg sqlc.UpdateAbsenceParams) (sqlc.Absence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAbsence", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Absence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAbsence indicates an expected call of UpdateAbsence.
+func (mr *MockStoreMockRecorder) UpdateAbsence(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAbsence", reflect.TypeOf((*MockStore)(nil).UpdateAbsence), ctx, arg)
+}
+
+// UpdateAbsenceType mocks base method.
+func (m *MockStore) UpdateAbsenceType(ctx context.Context, arg sqlc.UpdateAbsenceTypeParams) (sqlc.AbsenceType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAbsenceType", ctx, arg)
+	ret0, _ := ret[0].(sqlc.AbsenceType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAbsenceType indicates an expected call of UpdateAbsenceType.
+func (mr *MockStoreMockRecorder) UpdateAbsenceType(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAbsenceType", reflect.TypeOf((*MockStore)(nil).UpdateAbsenceType), ctx, arg)
+}
+
+// UpdateClient mocks base method.
+func (m *MockStore) UpdateClient(ctx context.Context, arg sqlc.UpdateClientParams) (sqlc.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateClient", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateClient indicates an expected call of UpdateClient.
+func (mr *MockStoreMockRecorder) UpdateClient(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClient", reflect.TypeOf((*MockStore)(nil).UpdateClient), ctx, arg)
+}
+
+// UpdateCompany mocks base method.
+func (m *MockStore) UpdateCompany(ctx context.Context, arg sqlc.UpdateCompanyParams) (sqlc.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCompany", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCompany indicates an expected call of UpdateCompany.
+func (mr *MockStoreMockRecorder) UpdateCompany(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCompany", reflect.TypeOf((*MockStore)(nil).UpdateCompany), ctx, arg)
 }
 
+// UpdateCompanyWorkSchedule mocks base method.
+func (m *MockStore) UpdateCompanyWorkSchedule(ctx context.Context, arg sqlc.UpdateCompanyWorkScheduleParams) (sqlc.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCompanyWorkSchedule", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCompanyWorkSchedule indicates an expected call of UpdateCompanyWorkSchedule.
+func (mr *MockStoreMockRecorder) UpdateCompanyWorkSchedule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCompanyWorkSchedule", reflect.TypeOf((*MockStore)(nil).UpdateCompanyWorkSchedule), ctx, arg)
+}
+
 // UpdateEntry mocks base method.
 func (m *MockStore) UpdateEntry(ctx context.Context, arg sqlc.UpdateEntryParams) (sqlc.Entry, error) {
 	m.ctrl.T.Helper()
@@ -520,6 +2574,51 @@ func (mr *MockStoreMockRecorder) UpdateEntry(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEntry", reflect.TypeOf((*MockStore)(nil).UpdateEntry), ctx, arg)
 }
 
+// UpdateEntryTx mocks base method.
+func (m *MockStore) UpdateEntryTx(ctx context.Context, arg sqlc.UpdateEntryParams) (sqlc.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEntryTx", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEntryTx indicates an expected call of UpdateEntryTx.
+func (mr *MockStoreMockRecorder) UpdateEntryTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEntryTx", reflect.TypeOf((*MockStore)(nil).UpdateEntryTx), ctx, arg)
+}
+
+// UpdateProject mocks base method.
+func (m *MockStore) UpdateProject(ctx context.Context, arg sqlc.UpdateProjectParams) (sqlc.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockStoreMockRecorder) UpdateProject(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockStore)(nil).UpdateProject), ctx, arg)
+}
+
+// UpdateTask mocks base method.
+func (m *MockStore) UpdateTask(ctx context.Context, arg sqlc.UpdateTaskParams) (sqlc.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockStoreMockRecorder) UpdateTask(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockStore)(nil).UpdateTask), ctx, arg)
+}
+
 // UpdateTeam mocks base method.
 func (m *MockStore) UpdateTeam(ctx context.Context, arg sqlc.UpdateTeamParams) (sqlc.Team, error) {
 	m.ctrl.T.Helper()
@@ -549,3 +2648,153 @@ func (mr *MockStoreMockRecorder) UpdateUser(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStore)(nil).UpdateUser), ctx, arg)
 }
+
+// UpdateUserPassword mocks base method.
+func (m *MockStore) UpdateUserPassword(ctx context.Context, arg sqlc.UpdateUserPasswordParams) (sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", ctx, arg)
+	ret0, _ := ret[0].(sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockStoreMockRecorder) UpdateUserPassword(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockStore)(nil).UpdateUserPassword), ctx, arg)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockStore) UpdateUserRole(ctx context.Context, arg sqlc.UpdateUserRoleParams) (sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", ctx, arg)
+	ret0, _ := ret[0].(sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockStoreMockRecorder) UpdateUserRole(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockStore)(nil).UpdateUserRole), ctx, arg)
+}
+
+// UpdateUserTeam mocks base method.
+func (m *MockStore) UpdateUserTeam(ctx context.Context, arg sqlc.UpdateUserTeamParams) (sqlc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserTeam", ctx, arg)
+	ret0, _ := ret[0].(sqlc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserTeam indicates an expected call of UpdateUserTeam.
+func (mr *MockStoreMockRecorder) UpdateUserTeam(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserTeam", reflect.TypeOf((*MockStore)(nil).UpdateUserTeam), ctx, arg)
+}
+
+// UpdateWebhookSubscription mocks base method.
+func (m *MockStore) UpdateWebhookSubscription(ctx context.Context, arg sqlc.UpdateWebhookSubscriptionParams) (sqlc.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookSubscription", ctx, arg)
+	ret0, _ := ret[0].(sqlc.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhookSubscription indicates an expected call of UpdateWebhookSubscription.
+func (mr *MockStoreMockRecorder) UpdateWebhookSubscription(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookSubscription", reflect.TypeOf((*MockStore)(nil).UpdateWebhookSubscription), ctx, arg)
+}
+
+// UpdateWorkSchedule mocks base method.
+func (m *MockStore) UpdateWorkSchedule(ctx context.Context, arg sqlc.UpdateWorkScheduleParams) (sqlc.WorkSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkSchedule", ctx, arg)
+	ret0, _ := ret[0].(sqlc.WorkSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWorkSchedule indicates an expected call of UpdateWorkSchedule.
+func (mr *MockStoreMockRecorder) UpdateWorkSchedule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkSchedule", reflect.TypeOf((*MockStore)(nil).UpdateWorkSchedule), ctx, arg)
+}
+
+// UpsertClockPolicy mocks base method.
+func (m *MockStore) UpsertClockPolicy(ctx context.Context, arg sqlc.UpsertClockPolicyParams) (sqlc.ClockPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertClockPolicy", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ClockPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertClockPolicy indicates an expected call of UpsertClockPolicy.
+func (mr *MockStoreMockRecorder) UpsertClockPolicy(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertClockPolicy", reflect.TypeOf((*MockStore)(nil).UpsertClockPolicy), ctx, arg)
+}
+
+// UpsertInvoiceSequence mocks base method.
+func (m *MockStore) UpsertInvoiceSequence(ctx context.Context, arg sqlc.UpsertInvoiceSequenceParams) (sqlc.InvoiceSequence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertInvoiceSequence", ctx, arg)
+	ret0, _ := ret[0].(sqlc.InvoiceSequence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertInvoiceSequence indicates an expected call of UpsertInvoiceSequence.
+func (mr *MockStoreMockRecorder) UpsertInvoiceSequence(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertInvoiceSequence", reflect.TypeOf((*MockStore)(nil).UpsertInvoiceSequence), ctx, arg)
+}
+
+// UpsertPublicHoliday mocks base method.
+func (m *MockStore) UpsertPublicHoliday(ctx context.Context, arg sqlc.UpsertPublicHolidayParams) (sqlc.PublicHoliday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertPublicHoliday", ctx, arg)
+	ret0, _ := ret[0].(sqlc.PublicHoliday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertPublicHoliday indicates an expected call of UpsertPublicHoliday.
+func (mr *MockStoreMockRecorder) UpsertPublicHoliday(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPublicHoliday", reflect.TypeOf((*MockStore)(nil).UpsertPublicHoliday), ctx, arg)
+}
+
+// VoidInvoice mocks base method.
+func (m *MockStore) VoidInvoice(ctx context.Context, id int64) (sqlc.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidInvoice", ctx, id)
+	ret0, _ := ret[0].(sqlc.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VoidInvoice indicates an expected call of VoidInvoice.
+func (mr *MockStoreMockRecorder) VoidInvoice(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidInvoice", reflect.TypeOf((*MockStore)(nil).VoidInvoice), ctx, id)
+}
+
+// VoidInvoiceTx mocks base method.
+func (m *MockStore) VoidInvoiceTx(ctx context.Context, invoiceID int64) (sqlc.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidInvoiceTx", ctx, invoiceID)
+	ret0, _ := ret[0].(sqlc.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VoidInvoiceTx indicates an expected call of VoidInvoiceTx.
+func (mr *MockStoreMockRecorder) VoidInvoiceTx(ctx, invoiceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidInvoiceTx", reflect.TypeOf((*MockStore)(nil).VoidInvoiceTx), ctx, invoiceID)
+}
@@ -1,13 +1,15 @@
 -- name: CreateAbsence :one
 INSERT INTO absences (
-user_id, start_time, end_time, reason, paid, approved_by_id
+user_id, start_time, end_time, reason, paid, approved_by_id, absence_type_id, kind
 ) VALUES (
 $1,
 $2,
 $3,
 $4,
 $5,
-$6
+$6,
+$7,
+$8
 )
 RETURNING *;
 
@@ -18,11 +20,13 @@ WHERE id = $1
 LIMIT 1;
 
 -- name: ListAbsences :many
-SELECT *
-FROM absences
-ORDER BY id
-LIMIT $1
-OFFSET $2;
+SELECT a.*
+FROM absences a
+JOIN users u ON u.id = a.user_id
+WHERE (sqlc.narg(company_id)::bigint IS NULL OR u.company_id = sqlc.narg(company_id))
+ORDER BY a.id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
 -- name: ListUserAbsences :many
 SELECT *
@@ -40,7 +44,8 @@ reason = $3,
 paid = $4, 
 start_time = $5, 
 end_time = $6, 
-approved_by_id = $7
+approved_by_id = $7,
+kind = $8
 WHERE id = $1
 RETURNING *;
 
@@ -48,4 +53,30 @@ RETURNING *;
 DELETE
 FROM absences
 WHERE id = $1
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: DecideAbsence :one
+UPDATE absences
+SET
+status = sqlc.arg(status),
+approved_by_id = sqlc.arg(approved_by_id),
+decision_comment = sqlc.narg(decision_comment),
+decided_at = now()
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(current_status)
+RETURNING *;
+
+-- name: CancelAbsence :one
+UPDATE absences
+SET status = 'cancelled'
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(current_status)
+RETURNING *;
+
+-- name: GetOverlappingAbsence :one
+SELECT *
+FROM absences
+WHERE user_id = sqlc.arg(user_id)
+AND id <> sqlc.arg(exclude_id)
+AND status IN ('pending', 'approved')
+AND tsrange(start_time, end_time) && tsrange(sqlc.arg(start_time)::timestamp, sqlc.narg(end_time)::timestamp)
+ORDER BY start_time
+LIMIT 1;
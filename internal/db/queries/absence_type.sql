@@ -0,0 +1,52 @@
+-- name: CreateAbsenceType :one
+INSERT INTO absence_types (
+    company_id,
+    name,
+    paid,
+    tracks_balance,
+    accrual,
+    carry_over_max_days,
+    carry_over_expiry_months
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: GetAbsenceType :one
+SELECT *
+FROM absence_types
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListAbsenceTypes :many
+SELECT *
+FROM absence_types
+WHERE (sqlc.narg(company_id)::bigint IS NULL OR company_id = sqlc.narg(company_id))
+ORDER BY id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: UpdateAbsenceType :one
+UPDATE absence_types
+SET
+    name = $2,
+    paid = $3,
+    tracks_balance = $4,
+    accrual = $5,
+    carry_over_max_days = $6,
+    carry_over_expiry_months = $7
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteAbsenceType :one
+DELETE
+FROM absence_types
+WHERE id = $1
+RETURNING *;
+
+-- name: ListTrackedAbsenceTypes :many
+SELECT *
+FROM absence_types
+WHERE company_id = $1
+AND tracks_balance
+ORDER BY id;
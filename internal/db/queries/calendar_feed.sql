@@ -0,0 +1,33 @@
+-- name: CreateCalendarFeed :one
+INSERT INTO calendar_feeds (
+    token_hash,
+    user_id,
+    team_id
+) VALUES (
+    sqlc.arg(token_hash),
+    sqlc.narg(user_id),
+    sqlc.narg(team_id)
+)
+RETURNING *;
+
+-- name: GetActiveCalendarFeed :one
+SELECT *
+FROM calendar_feeds
+WHERE token_hash = $1 AND revoked_at IS NULL
+LIMIT 1;
+
+-- name: ListCalendarFeeds :many
+SELECT *
+FROM calendar_feeds
+WHERE user_id IS NOT DISTINCT FROM sqlc.narg(user_id)::bigint
+AND team_id IS NOT DISTINCT FROM sqlc.narg(team_id)::bigint
+ORDER BY id;
+
+-- name: RevokeCalendarFeed :one
+UPDATE calendar_feeds
+SET revoked_at = now()
+WHERE id = sqlc.arg(id)
+AND user_id IS NOT DISTINCT FROM sqlc.narg(user_id)::bigint
+AND team_id IS NOT DISTINCT FROM sqlc.narg(team_id)::bigint
+AND revoked_at IS NULL
+RETURNING *;
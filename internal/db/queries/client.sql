@@ -0,0 +1,34 @@
+-- name: CreateClient :one
+INSERT INTO clients (
+    company_id,
+    name
+) VALUES (
+    $1, $2
+)
+RETURNING *;
+
+-- name: GetClient :one
+SELECT *
+FROM clients
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListClients :many
+SELECT *
+FROM clients
+WHERE (sqlc.narg(company_id)::bigint IS NULL OR company_id = sqlc.narg(company_id))
+ORDER BY id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: UpdateClient :one
+UPDATE clients
+SET name = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteClient :one
+DELETE
+FROM clients
+WHERE id = $1
+RETURNING *;
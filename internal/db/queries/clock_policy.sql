@@ -0,0 +1,30 @@
+-- name: GetClockPolicy :one
+SELECT *
+FROM clock_policies
+WHERE company_id = $1
+LIMIT 1;
+
+-- name: UpsertClockPolicy :one
+INSERT INTO clock_policies (
+    company_id,
+    auto_close_after_minutes,
+    auto_close_at_minute,
+    reminder_at_minute
+) VALUES (
+    sqlc.arg(company_id),
+    sqlc.narg(auto_close_after_minutes),
+    sqlc.narg(auto_close_at_minute),
+    sqlc.narg(reminder_at_minute)
+)
+ON CONFLICT (company_id) DO UPDATE
+SET
+    auto_close_after_minutes = EXCLUDED.auto_close_after_minutes,
+    auto_close_at_minute = EXCLUDED.auto_close_at_minute,
+    reminder_at_minute = EXCLUDED.reminder_at_minute,
+    updated_at = now()
+RETURNING *;
+
+-- name: ListClockPolicies :many
+SELECT *
+FROM clock_policies
+ORDER BY company_id;
@@ -40,4 +40,10 @@ OFFSET $3;
 DELETE
 FROM companies
 WHERE id = $1
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: UpdateCompanyWorkSchedule :one
+UPDATE companies
+SET default_work_schedule_id = sqlc.narg(work_schedule_id)
+WHERE id = sqlc.arg(id)
+RETURNING *;
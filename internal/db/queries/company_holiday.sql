@@ -0,0 +1,30 @@
+-- name: CreateCompanyHoliday :one
+INSERT INTO company_holidays (
+    company_id,
+    date,
+    name,
+    day_off
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetCompanyHoliday :one
+SELECT *
+FROM company_holidays
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListCompanyHolidays :many
+SELECT *
+FROM company_holidays
+WHERE company_id = sqlc.arg(company_id)
+AND date >= sqlc.arg('from')::date
+AND date < sqlc.arg('to')::date
+ORDER BY date;
+
+-- name: DeleteCompanyHoliday :one
+DELETE
+FROM company_holidays
+WHERE id = $1
+RETURNING *;
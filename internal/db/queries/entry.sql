@@ -1,10 +1,15 @@
 -- name: CreateEntry :one
 INSERT INTO entries (
-user_id, start_time, end_time
+user_id, start_time, end_time, project_id, task_id, description, tags, billable
 ) VALUES (
-$1,
-$2,
-$3
+sqlc.arg(user_id),
+sqlc.arg(start_time),
+sqlc.narg(end_time),
+sqlc.narg(project_id),
+sqlc.narg(task_id),
+sqlc.narg(description),
+COALESCE(sqlc.narg(tags)::varchar[], '{}'),
+sqlc.arg(billable)
 )
 RETURNING *;
 
@@ -15,31 +20,90 @@ WHERE id = $1
 LIMIT 1;
 
 -- name: ListEntries :many
-SELECT *
-FROM entries
-ORDER BY id
-LIMIT $1
-OFFSET $2;
+SELECT e.*
+FROM entries e
+JOIN users u ON u.id = e.user_id
+WHERE (sqlc.narg(user_id)::bigint IS NULL OR e.user_id = sqlc.narg(user_id))
+AND (sqlc.narg(team_id)::bigint IS NULL OR u.team_id = sqlc.narg(team_id))
+AND (sqlc.narg(company_id)::bigint IS NULL OR u.company_id = sqlc.narg(company_id))
+AND (sqlc.narg('from')::timestamp IS NULL OR e.start_time >= sqlc.narg('from'))
+AND (sqlc.narg('to')::timestamp IS NULL OR e.start_time < sqlc.narg('to'))
+AND (sqlc.narg(project_id)::bigint IS NULL OR e.project_id = sqlc.narg(project_id))
+AND (sqlc.narg(task_id)::bigint IS NULL OR e.task_id = sqlc.narg(task_id))
+AND (sqlc.narg(tag)::varchar IS NULL OR sqlc.narg(tag) = ANY(e.tags))
+AND (sqlc.narg(needs_review)::boolean IS NULL OR e.needs_review = sqlc.narg(needs_review))
+ORDER BY e.start_time, e.id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
 -- name: ListUserEntries :many
 SELECT *
 FROM entries
-WHERE user_id = $1
-ORDER BY id
-LIMIT $2
-OFFSET $3;
+WHERE user_id = sqlc.arg(user_id)
+AND (sqlc.narg('from')::timestamp IS NULL OR start_time >= sqlc.narg('from'))
+AND (sqlc.narg('to')::timestamp IS NULL OR start_time < sqlc.narg('to'))
+AND (sqlc.narg(project_id)::bigint IS NULL OR project_id = sqlc.narg(project_id))
+AND (sqlc.narg(task_id)::bigint IS NULL OR task_id = sqlc.narg(task_id))
+AND (sqlc.narg(tag)::varchar IS NULL OR sqlc.narg(tag) = ANY(tags))
+AND (sqlc.narg(needs_review)::boolean IS NULL OR needs_review = sqlc.narg(needs_review))
+ORDER BY start_time, id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
 -- name: UpdateEntry :one
 UPDATE entries
 SET 
-user_id = $2, 
-start_time = $3, 
-end_time = $4
-WHERE id = $1
+user_id = sqlc.arg(user_id), 
+start_time = sqlc.arg(start_time), 
+end_time = sqlc.narg(end_time),
+project_id = sqlc.narg(project_id),
+task_id = sqlc.narg(task_id),
+description = sqlc.narg(description),
+tags = COALESCE(sqlc.narg(tags)::varchar[], '{}'),
+billable = sqlc.arg(billable),
+needs_review = false
+WHERE id = sqlc.arg(id)
 RETURNING *;
 
 -- name: DeleteEntry :one
 DELETE
 FROM entries
 WHERE id = $1
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: GetRunningEntry :one
+SELECT *
+FROM entries
+WHERE user_id = $1 AND end_time IS NULL
+LIMIT 1;
+
+-- name: StopRunningEntry :one
+UPDATE entries
+SET end_time = $2
+WHERE user_id = $1 AND end_time IS NULL
+RETURNING *;
+
+-- name: GetOverlappingEntry :one
+SELECT *
+FROM entries
+WHERE user_id = sqlc.arg(user_id)
+AND id <> sqlc.arg(exclude_id)
+AND tsrange(start_time, end_time) && tsrange(sqlc.arg(start_time)::timestamp, sqlc.narg(end_time)::timestamp)
+ORDER BY start_time
+LIMIT 1;
+
+-- name: ListCompanyRunningEntries :many
+SELECT e.*
+FROM entries e
+JOIN users u ON u.id = e.user_id
+WHERE u.company_id = sqlc.arg(company_id)::bigint AND e.end_time IS NULL
+ORDER BY e.start_time, e.id;
+
+-- name: AutoCloseEntry :one
+UPDATE entries
+SET
+end_time = sqlc.arg(end_time)::timestamp,
+auto_closed_at = now(),
+needs_review = true
+WHERE id = sqlc.arg(id) AND end_time IS NULL
+RETURNING *;
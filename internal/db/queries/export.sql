@@ -0,0 +1,50 @@
+-- name: ExportEntries :many
+SELECT
+    e.id,
+    e.user_id,
+    u.username,
+    u.name,
+    u.surname,
+    u.timezone,
+    e.start_time,
+    e.end_time,
+    p.name AS project_name,
+    t.name AS task_name,
+    e.description,
+    e.tags,
+    e.billable
+FROM entries e
+JOIN users u ON u.id = e.user_id
+LEFT JOIN projects p ON p.id = e.project_id
+LEFT JOIN tasks t ON t.id = e.task_id
+WHERE u.company_id = sqlc.arg(company_id)::bigint
+AND (sqlc.narg(user_id)::bigint IS NULL OR e.user_id = sqlc.narg(user_id))
+AND (sqlc.narg(team_id)::bigint IS NULL OR u.team_id = sqlc.narg(team_id))
+AND e.start_time >= sqlc.arg('from')
+AND e.start_time < sqlc.arg('to')
+ORDER BY u.username, e.start_time, e.id;
+
+-- name: ExportAbsences :many
+SELECT
+    a.id,
+    a.user_id,
+    u.username,
+    u.name,
+    u.surname,
+    u.timezone,
+    at.name AS absence_type_name,
+    a.kind,
+    a.status,
+    a.start_time,
+    a.end_time,
+    a.paid,
+    a.reason
+FROM absences a
+JOIN users u ON u.id = a.user_id
+LEFT JOIN absence_types at ON at.id = a.absence_type_id
+WHERE u.company_id = sqlc.arg(company_id)::bigint
+AND (sqlc.narg(user_id)::bigint IS NULL OR a.user_id = sqlc.narg(user_id))
+AND (sqlc.narg(team_id)::bigint IS NULL OR u.team_id = sqlc.narg(team_id))
+AND (sqlc.narg(status)::varchar IS NULL OR a.status = sqlc.narg(status))
+AND tsrange(a.start_time, a.end_time) && tsrange(sqlc.arg('from')::timestamp, sqlc.arg('to')::timestamp)
+ORDER BY u.username, a.start_time, a.id;
@@ -0,0 +1,64 @@
+-- name: CreateHourlyRate :one
+INSERT INTO hourly_rates (
+    company_id,
+    user_id,
+    project_id,
+    client_id,
+    amount_cents,
+    currency,
+    effective_from
+) VALUES (
+    sqlc.arg(company_id),
+    sqlc.narg(user_id),
+    sqlc.narg(project_id),
+    sqlc.narg(client_id),
+    sqlc.arg(amount_cents),
+    sqlc.arg(currency),
+    sqlc.arg(effective_from)
+)
+RETURNING *;
+
+-- name: GetHourlyRate :one
+SELECT *
+FROM hourly_rates
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListHourlyRates :many
+SELECT *
+FROM hourly_rates
+WHERE (sqlc.narg(company_id)::bigint IS NULL OR company_id = sqlc.narg(company_id))
+AND (sqlc.narg(user_id)::bigint IS NULL OR user_id = sqlc.narg(user_id))
+AND (sqlc.narg(project_id)::bigint IS NULL OR project_id = sqlc.narg(project_id))
+AND (sqlc.narg(client_id)::bigint IS NULL OR client_id = sqlc.narg(client_id))
+ORDER BY effective_from DESC, id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: DeleteHourlyRate :one
+DELETE
+FROM hourly_rates
+WHERE id = $1
+RETURNING *;
+
+-- name: GetBillingReport :many
+SELECT
+    p.client_id,
+    e.project_id,
+    r.currency,
+    COUNT(*) AS entries,
+    SUM(EXTRACT(EPOCH FROM e.end_time - e.start_time))::bigint AS billable_seconds,
+    ROUND(SUM(EXTRACT(EPOCH FROM e.end_time - e.start_time) * r.amount_cents) / 3600)::bigint AS amount_cents
+FROM entries e
+JOIN users u ON u.id = e.user_id
+LEFT JOIN projects p ON p.id = e.project_id
+LEFT JOIN LATERAL effective_hourly_rate(u.company_id, e.user_id, e.project_id, p.client_id, (e.start_time AT TIME ZONE 'UTC' AT TIME ZONE u.timezone)::date) r ON true
+WHERE e.billable
+AND e.end_time IS NOT NULL
+AND u.company_id = sqlc.arg(company_id)::bigint
+AND e.start_time >= sqlc.arg('from')
+AND e.start_time < sqlc.arg('to')
+AND (sqlc.narg(client_id)::bigint IS NULL OR p.client_id = sqlc.narg(client_id))
+AND (sqlc.narg(project_id)::bigint IS NULL OR e.project_id = sqlc.narg(project_id))
+GROUP BY p.client_id, e.project_id, r.currency
+ORDER BY p.client_id NULLS LAST, e.project_id NULLS LAST, r.currency;
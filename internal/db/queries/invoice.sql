@@ -0,0 +1,147 @@
+-- name: CreateInvoice :one
+INSERT INTO invoices (
+    company_id,
+    client_id,
+    currency,
+    period_from,
+    period_to
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetInvoice :one
+SELECT *
+FROM invoices
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListInvoices :many
+SELECT *
+FROM invoices
+WHERE (sqlc.narg(company_id)::bigint IS NULL OR company_id = sqlc.narg(company_id))
+AND (sqlc.narg(client_id)::bigint IS NULL OR client_id = sqlc.narg(client_id))
+AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: SetInvoiceTotal :one
+UPDATE invoices
+SET total_cents = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: IssueInvoice :one
+UPDATE invoices
+SET status = 'issued', number = sqlc.arg(number), issued_at = now()
+WHERE id = sqlc.arg(id) AND status = 'draft'
+RETURNING *;
+
+-- name: PayInvoice :one
+UPDATE invoices
+SET status = 'paid', paid_at = now()
+WHERE id = $1 AND status = 'issued'
+RETURNING *;
+
+-- name: VoidInvoice :one
+UPDATE invoices
+SET status = 'void', voided_at = now()
+WHERE id = $1 AND status = 'issued'
+RETURNING *;
+
+-- name: DeleteInvoice :one
+DELETE
+FROM invoices
+WHERE id = $1 AND status = 'draft'
+RETURNING *;
+
+-- name: CreateInvoiceLine :one
+INSERT INTO invoice_lines (
+    invoice_id,
+    project_id,
+    task_id,
+    description,
+    quantity_seconds,
+    rate_cents,
+    amount_cents
+) VALUES (
+    sqlc.arg(invoice_id),
+    sqlc.narg(project_id),
+    sqlc.narg(task_id),
+    sqlc.arg(description),
+    sqlc.arg(quantity_seconds),
+    sqlc.arg(rate_cents),
+    sqlc.arg(amount_cents)
+)
+RETURNING *;
+
+-- name: ListInvoiceLines :many
+SELECT *
+FROM invoice_lines
+WHERE invoice_id = $1
+ORDER BY id;
+
+-- name: ListInvoiceableEntries :many
+SELECT
+    e.id,
+    e.project_id,
+    e.task_id,
+    p.name AS project_name,
+    t.name AS task_name,
+    EXTRACT(EPOCH FROM e.end_time - e.start_time)::bigint AS seconds,
+    r.amount_cents AS rate_cents,
+    r.currency
+FROM entries e
+JOIN users u ON u.id = e.user_id
+JOIN projects p ON p.id = e.project_id
+LEFT JOIN tasks t ON t.id = e.task_id
+LEFT JOIN LATERAL effective_hourly_rate(u.company_id, e.user_id, e.project_id, p.client_id, (e.start_time AT TIME ZONE 'UTC' AT TIME ZONE u.timezone)::date) r ON true
+WHERE e.billable
+AND e.end_time IS NOT NULL
+AND e.invoice_id IS NULL
+AND u.company_id = sqlc.arg(company_id)::bigint
+AND p.client_id = sqlc.arg(client_id)::bigint
+AND e.start_time >= sqlc.arg('from')
+AND e.start_time < sqlc.arg('to')
+ORDER BY e.project_id, e.task_id NULLS FIRST, e.start_time
+FOR UPDATE OF e;
+
+-- name: SetEntriesInvoice :execrows
+UPDATE entries
+SET invoice_id = sqlc.arg(invoice_id)::bigint
+WHERE id = ANY(sqlc.arg(ids)::bigint[]);
+
+-- name: ReleaseInvoiceEntries :execrows
+UPDATE entries
+SET invoice_id = NULL
+WHERE invoice_id = $1;
+
+-- name: NextInvoiceNumber :one
+INSERT INTO invoice_sequences (
+    company_id,
+    last_number
+) VALUES (
+    $1, 1
+)
+ON CONFLICT (company_id) DO UPDATE
+SET last_number = invoice_sequences.last_number + 1
+RETURNING *;
+
+-- name: GetInvoiceSequence :one
+SELECT *
+FROM invoice_sequences
+WHERE company_id = $1
+LIMIT 1;
+
+-- name: UpsertInvoiceSequence :one
+INSERT INTO invoice_sequences (
+    company_id,
+    prefix,
+    last_number
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (company_id) DO UPDATE
+SET prefix = EXCLUDED.prefix, last_number = EXCLUDED.last_number
+RETURNING *;
@@ -0,0 +1,102 @@
+-- name: CreateJob :one
+INSERT INTO jobs (
+    kind,
+    payload,
+    run_at,
+    max_attempts
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: CreateUniqueJob :execrows
+INSERT INTO jobs (
+    kind,
+    payload,
+    run_at,
+    max_attempts,
+    unique_key
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (unique_key) DO NOTHING;
+
+-- name: GetJob :one
+SELECT *
+FROM jobs
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListJobs :many
+SELECT *
+FROM jobs
+WHERE (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+AND (sqlc.narg(kind)::varchar IS NULL OR kind = sqlc.narg(kind))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ClaimJobs :many
+UPDATE jobs
+SET
+    status = 'running',
+    attempts = attempts + 1,
+    locked_until = sqlc.arg(locked_until)
+WHERE id IN (
+    SELECT id
+    FROM jobs
+    WHERE kind = ANY(sqlc.arg(kinds)::varchar[])
+    AND (
+        (status = 'pending' AND run_at <= now())
+        OR (status = 'running' AND locked_until <= now())
+    )
+    ORDER BY run_at, id
+    LIMIT sqlc.arg('limit')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CompleteJob :one
+UPDATE jobs
+SET
+    status = 'succeeded',
+    locked_until = NULL,
+    last_error = NULL,
+    finished_at = now()
+WHERE id = $1 AND status = 'running'
+RETURNING *;
+
+-- name: RetryJob :one
+UPDATE jobs
+SET
+    status = 'pending',
+    run_at = sqlc.arg(run_at),
+    locked_until = NULL,
+    last_error = sqlc.arg(last_error)
+WHERE id = sqlc.arg(id) AND status = 'running'
+RETURNING *;
+
+-- name: DeadLetterJob :one
+UPDATE jobs
+SET
+    status = 'dead',
+    locked_until = NULL,
+    last_error = sqlc.arg(last_error),
+    finished_at = now()
+WHERE id = sqlc.arg(id) AND status = 'running'
+RETURNING *;
+
+-- name: RequeueDeadJob :one
+UPDATE jobs
+SET
+    status = 'pending',
+    attempts = 0,
+    run_at = now(),
+    finished_at = NULL
+WHERE id = $1 AND status = 'dead'
+RETURNING *;
+
+-- name: DeleteSucceededJobs :execrows
+DELETE
+FROM jobs
+WHERE status = 'succeeded' AND finished_at < sqlc.arg(before)::timestamp;
@@ -0,0 +1,36 @@
+-- name: CreateLeaveEntitlement :one
+INSERT INTO leave_entitlements (
+    user_id,
+    absence_type_id,
+    from_year,
+    days
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetLeaveEntitlement :one
+SELECT *
+FROM leave_entitlements
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListUserLeaveEntitlements :many
+SELECT *
+FROM leave_entitlements
+WHERE user_id = $1
+ORDER BY absence_type_id, from_year;
+
+-- name: DeleteLeaveEntitlement :one
+DELETE
+FROM leave_entitlements
+WHERE id = $1
+RETURNING *;
+
+-- name: ListUserLeave :many
+SELECT *
+FROM absences
+WHERE user_id = sqlc.arg(user_id)
+AND absence_type_id = sqlc.arg(absence_type_id)::bigint
+AND status IN ('pending', 'approved')
+ORDER BY start_time;
@@ -0,0 +1,41 @@
+-- name: CreateNotification :one
+INSERT INTO notifications (
+    user_id,
+    kind,
+    data
+) VALUES (
+    $1, $2, $3
+)
+RETURNING *;
+
+-- name: CreateUniqueNotification :execrows
+INSERT INTO notifications (
+    user_id,
+    kind,
+    data,
+    dedupe_key
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (dedupe_key) DO NOTHING;
+
+-- name: GetNotificationByDedupeKey :one
+SELECT *
+FROM notifications
+WHERE dedupe_key = $1
+LIMIT 1;
+
+-- name: ListUserNotifications :many
+SELECT *
+FROM notifications
+WHERE user_id = sqlc.arg(user_id)
+AND (sqlc.narg(unread)::boolean IS NULL OR (read_at IS NULL) = sqlc.narg(unread))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ReadNotification :one
+UPDATE notifications
+SET read_at = COALESCE(read_at, now())
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id)
+RETURNING *;
@@ -0,0 +1,37 @@
+-- name: CreateProject :one
+INSERT INTO projects (
+    company_id,
+    name,
+    client_id
+) VALUES (
+    $1, $2, $3
+)
+RETURNING *;
+
+-- name: GetProject :one
+SELECT *
+FROM projects
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListProjects :many
+SELECT *
+FROM projects
+WHERE (sqlc.narg(company_id)::bigint IS NULL OR company_id = sqlc.narg(company_id))
+AND (sqlc.narg(archived)::boolean IS NULL OR archived = sqlc.narg(archived))
+AND (sqlc.narg(client_id)::bigint IS NULL OR client_id = sqlc.narg(client_id))
+ORDER BY id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: UpdateProject :one
+UPDATE projects
+SET name = $2, archived = $3, client_id = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteProject :one
+DELETE
+FROM projects
+WHERE id = $1
+RETURNING *;
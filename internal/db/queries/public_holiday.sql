@@ -0,0 +1,33 @@
+-- name: UpsertPublicHoliday :one
+INSERT INTO public_holidays (
+    country,
+    subdivision,
+    date,
+    name
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (country, subdivision, date) DO UPDATE
+SET name = EXCLUDED.name
+RETURNING *;
+
+-- name: GetPublicHoliday :one
+SELECT *
+FROM public_holidays
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListPublicHolidays :many
+SELECT *
+FROM public_holidays
+WHERE country = sqlc.arg(country)
+AND (subdivision IS NULL OR subdivision = sqlc.narg(subdivision))
+AND date >= sqlc.arg('from')::date
+AND date < sqlc.arg('to')::date
+ORDER BY date, subdivision NULLS FIRST;
+
+-- name: DeletePublicHoliday :one
+DELETE
+FROM public_holidays
+WHERE id = $1
+RETURNING *;
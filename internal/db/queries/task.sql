@@ -0,0 +1,34 @@
+-- name: CreateTask :one
+INSERT INTO tasks (
+    project_id,
+    name
+) VALUES (
+    $1, $2
+)
+RETURNING *;
+
+-- name: GetTask :one
+SELECT *
+FROM tasks
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListProjectTasks :many
+SELECT *
+FROM tasks
+WHERE project_id = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdateTask :one
+UPDATE tasks
+SET name = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteTask :one
+DELETE
+FROM tasks
+WHERE id = $1
+RETURNING *;
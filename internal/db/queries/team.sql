@@ -1,9 +1,10 @@
 -- name: CreateTeam :one
 INSERT INTO teams (
     name,
-    manager_id
+    manager_id,
+    company_id
 ) VALUES (
-    $1, $2
+    $1, $2, $3
 )
 RETURNING *;
 
@@ -16,9 +17,10 @@ LIMIT 1;
 -- name: ListTeams :many
 SELECT *
 FROM teams
+WHERE (sqlc.narg(company_id)::bigint IS NULL OR company_id = sqlc.narg(company_id))
 ORDER BY id
-LIMIT $1
-OFFSET $2;
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
 -- name: UpdateTeam :one
 UPDATE teams
@@ -0,0 +1,107 @@
+-- name: CreateTimesheet :one
+INSERT INTO timesheets (
+    user_id,
+    period,
+    period_start,
+    period_end
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetTimesheet :one
+SELECT *
+FROM timesheets
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListTimesheets :many
+SELECT t.*
+FROM timesheets t
+JOIN users u ON u.id = t.user_id
+WHERE (sqlc.narg(company_id)::bigint IS NULL OR u.company_id = sqlc.narg(company_id))
+-- managers manage their direct reports and the members of their teams
+AND (
+    sqlc.narg(manager_id)::bigint IS NULL
+    OR u.manager_id = sqlc.narg(manager_id)
+    OR EXISTS (SELECT 1 FROM teams tm WHERE tm.id = u.team_id AND tm.manager_id = sqlc.narg(manager_id))
+)
+AND (sqlc.narg(status)::varchar IS NULL OR t.status = sqlc.narg(status))
+ORDER BY t.period_start DESC, t.id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ListUserTimesheets :many
+SELECT *
+FROM timesheets
+WHERE user_id = $1
+ORDER BY period_start DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: DeleteTimesheet :one
+DELETE
+FROM timesheets
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(current_status)
+RETURNING *;
+
+-- name: SubmitTimesheet :one
+UPDATE timesheets
+SET
+status = 'submitted',
+submitted_at = now(),
+decided_by_id = NULL,
+decided_at = NULL,
+decision_comment = NULL
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(current_status)
+RETURNING *;
+
+-- name: DecideTimesheet :one
+UPDATE timesheets
+SET
+status = sqlc.arg(status),
+decided_by_id = sqlc.arg(decided_by_id),
+decision_comment = sqlc.narg(decision_comment),
+decided_at = now()
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(current_status)
+RETURNING *;
+
+-- name: ReopenTimesheet :one
+UPDATE timesheets
+SET
+status = 'open',
+reopened_by_id = sqlc.arg(reopened_by_id),
+reopened_at = now()
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(current_status)
+RETURNING *;
+
+-- name: ListTimesheetEntries :many
+SELECT
+    e.id,
+    e.start_time,
+    e.end_time,
+    p.name AS project_name,
+    t.name AS task_name,
+    e.description
+FROM entries e
+LEFT JOIN projects p ON p.id = e.project_id
+LEFT JOIN tasks t ON t.id = e.task_id
+WHERE e.user_id = sqlc.arg(user_id)
+AND e.start_time >= sqlc.arg('from')
+AND e.start_time < sqlc.arg('to')
+ORDER BY e.start_time, e.id;
+
+-- name: ListTimesheetAbsences :many
+SELECT
+    a.id,
+    at.name AS absence_type_name,
+    a.kind,
+    a.start_time,
+    a.end_time,
+    a.paid
+FROM absences a
+LEFT JOIN absence_types at ON at.id = a.absence_type_id
+WHERE a.user_id = sqlc.arg(user_id)
+AND a.status = 'approved'
+AND tsrange(a.start_time, a.end_time) && tsrange(sqlc.arg('from')::timestamp, sqlc.arg('to')::timestamp)
+ORDER BY a.start_time, a.id;
@@ -0,0 +1,17 @@
+-- name: CreateTimesheetDocument :one
+INSERT INTO timesheet_documents (
+    timesheet_id,
+    language,
+    content,
+    sha256
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetLatestTimesheetDocument :one
+SELECT *
+FROM timesheet_documents
+WHERE timesheet_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT 1;
@@ -1,6 +1,6 @@
 -- name: CreateUser :one
 
-INSERT INTO users ( username, password, email, name, surname, birth_date, gender, language, country, timezone, company_id, manager_id, team_id)
+INSERT INTO users ( username, password, email, name, surname, birth_date, gender, language, country, timezone, company_id, manager_id, team_id, subdivision)
 VALUES ($1,
         $2,
         $3,
@@ -13,7 +13,8 @@ VALUES ($1,
         $10,
         $11,
         $12,
-        $13) RETURNING *;
+        $13,
+        $14) RETURNING *;
 
 -- name: GetUser :one
 
@@ -40,9 +41,10 @@ LIMIT 1;
 
 SELECT *
 FROM users
+WHERE (sqlc.narg(company_id)::bigint IS NULL OR company_id = sqlc.narg(company_id))
 ORDER BY id
-LIMIT $1
-OFFSET $2;
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
 -- name: UpdateUser :one
 
@@ -52,7 +54,8 @@ SET name = COALESCE(sqlc.narg(name), name),
     gender = COALESCE(sqlc.narg(gender), gender),
     birth_date = COALESCE(sqlc.narg(birth_date)::timestamp, birth_date),
     language = COALESCE(sqlc.narg(language), language),
-    country = COALESCE(sqlc.narg(country), country)
+    country = COALESCE(sqlc.narg(country), country),
+    subdivision = COALESCE(sqlc.narg(subdivision), subdivision)
 WHERE id = sqlc.arg(id) RETURNING *;
 
 -- name: DeleteUser :one
@@ -60,3 +63,21 @@ WHERE id = sqlc.arg(id) RETURNING *;
 DELETE
 FROM users
 WHERE id = $1 RETURNING *;
+
+-- name: UpdateUserRole :one
+
+UPDATE users
+SET role = $2
+WHERE id = $1 RETURNING *;
+
+-- name: UpdateUserTeam :one
+
+UPDATE users
+SET team_id = $2
+WHERE id = $1 RETURNING *;
+
+-- name: UpdateUserPassword :one
+
+UPDATE users
+SET password = $2
+WHERE id = $1 RETURNING *;
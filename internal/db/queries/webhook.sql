@@ -0,0 +1,120 @@
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (
+    company_id,
+    url,
+    secret,
+    events
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetWebhookSubscription :one
+SELECT *
+FROM webhook_subscriptions
+WHERE id = $1 AND company_id = $2
+LIMIT 1;
+
+-- name: ListWebhookSubscriptions :many
+SELECT *
+FROM webhook_subscriptions
+WHERE company_id = $1
+ORDER BY id;
+
+-- name: UpdateWebhookSubscription :one
+UPDATE webhook_subscriptions
+SET
+    url = sqlc.arg(url),
+    events = sqlc.arg(events),
+    active = sqlc.arg(active),
+    updated_at = now()
+WHERE id = sqlc.arg(id) AND company_id = sqlc.arg(company_id)
+RETURNING *;
+
+-- name: DeleteWebhookSubscription :one
+DELETE
+FROM webhook_subscriptions
+WHERE id = $1 AND company_id = $2
+RETURNING *;
+
+-- name: CreateWebhookEvent :one
+INSERT INTO webhook_events (
+    company_id,
+    type,
+    payload
+) VALUES (
+    $1, $2, $3
+)
+RETURNING *;
+
+-- name: CreateWebhookDeliveries :execrows
+INSERT INTO webhook_deliveries (subscription_id, event_id)
+SELECT id, sqlc.arg(event_id)::bigint
+FROM webhook_subscriptions
+WHERE company_id = sqlc.arg(company_id)
+AND active
+AND sqlc.arg(type)::varchar = ANY(events);
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (
+    subscription_id,
+    event_id
+) VALUES (
+    $1, $2
+)
+RETURNING *;
+
+-- name: GetWebhookDelivery :one
+SELECT *
+FROM webhook_deliveries
+WHERE id = $1 AND subscription_id = $2
+LIMIT 1;
+
+-- name: ListWebhookDeliveries :many
+SELECT *
+FROM webhook_deliveries
+WHERE subscription_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: ClaimWebhookDeliveries :many
+UPDATE webhook_deliveries
+SET next_attempt_at = sqlc.arg(locked_until)
+WHERE id IN (
+    SELECT id
+    FROM webhook_deliveries
+    WHERE status = 'pending' AND next_attempt_at <= now()
+    ORDER BY next_attempt_at, id
+    LIMIT sqlc.arg('limit')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: GetWebhookDeliveryRequest :one
+SELECT
+    d.id,
+    s.url,
+    s.secret,
+    e.id AS event_id,
+    e.type,
+    e.payload,
+    e.created_at
+FROM webhook_deliveries d
+JOIN webhook_subscriptions s ON s.id = d.subscription_id
+JOIN webhook_events e ON e.id = d.event_id
+WHERE d.id = $1
+LIMIT 1;
+
+-- name: RecordWebhookDeliveryAttempt :one
+UPDATE webhook_deliveries
+SET
+    status = sqlc.arg(status),
+    attempts = attempts + 1,
+    next_attempt_at = sqlc.arg(next_attempt_at),
+    last_attempt_at = now(),
+    response_status = sqlc.narg(response_status),
+    response_body = sqlc.narg(response_body),
+    error = sqlc.narg(error)
+WHERE id = sqlc.arg(id)
+RETURNING *;
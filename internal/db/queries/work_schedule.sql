@@ -0,0 +1,102 @@
+-- name: CreateWorkSchedule :one
+INSERT INTO work_schedules (
+    company_id,
+    name,
+    monday_minutes,
+    tuesday_minutes,
+    wednesday_minutes,
+    thursday_minutes,
+    friday_minutes,
+    saturday_minutes,
+    sunday_minutes,
+    part_time_percent
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+)
+RETURNING *;
+
+-- name: GetWorkSchedule :one
+SELECT *
+FROM work_schedules
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListWorkSchedules :many
+SELECT *
+FROM work_schedules
+WHERE (sqlc.narg(company_id)::bigint IS NULL OR company_id = sqlc.narg(company_id))
+ORDER BY id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: UpdateWorkSchedule :one
+UPDATE work_schedules
+SET
+    name = $2,
+    monday_minutes = $3,
+    tuesday_minutes = $4,
+    wednesday_minutes = $5,
+    thursday_minutes = $6,
+    friday_minutes = $7,
+    saturday_minutes = $8,
+    sunday_minutes = $9,
+    part_time_percent = $10
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteWorkSchedule :one
+DELETE
+FROM work_schedules
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateWorkScheduleAssignment :one
+INSERT INTO work_schedule_assignments (
+    user_id,
+    work_schedule_id,
+    effective_from
+) VALUES (
+    $1, $2, $3
+)
+RETURNING *;
+
+-- name: GetWorkScheduleAssignment :one
+SELECT *
+FROM work_schedule_assignments
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListUserWorkScheduleAssignments :many
+SELECT *
+FROM work_schedule_assignments
+WHERE user_id = $1
+ORDER BY effective_from;
+
+-- name: DeleteWorkScheduleAssignment :one
+DELETE
+FROM work_schedule_assignments
+WHERE id = $1
+RETURNING *;
+
+-- name: ListDailyWorkedSeconds :many
+SELECT
+    (e.start_time AT TIME ZONE 'UTC' AT TIME ZONE u.timezone)::date AS day,
+    SUM(EXTRACT(EPOCH FROM e.end_time - e.start_time))::bigint AS worked_seconds
+FROM entries e
+JOIN users u ON u.id = e.user_id
+WHERE e.user_id = sqlc.arg(user_id)
+AND e.end_time IS NOT NULL
+AND (e.start_time AT TIME ZONE 'UTC' AT TIME ZONE u.timezone)::date >= sqlc.arg('from')::date
+AND (e.start_time AT TIME ZONE 'UTC' AT TIME ZONE u.timezone)::date < sqlc.arg('to')::date
+GROUP BY day
+ORDER BY day;
+
+-- name: ListUserPaidAbsences :many
+SELECT *
+FROM absences
+WHERE user_id = sqlc.arg(user_id)
+AND paid
+AND status = 'approved'
+AND (end_time IS NULL OR end_time > sqlc.arg('from')::timestamp)
+AND start_time < sqlc.arg('to')
+ORDER BY start_time;
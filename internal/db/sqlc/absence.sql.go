@@ -10,27 +10,65 @@ import (
 	"time"
 )
 
+const cancelAbsence = `-- name: CancelAbsence :one
+UPDATE absences
+SET status = 'cancelled'
+WHERE id = $1 AND status = $2
+RETURNING id, user_id, start_time, end_time, reason, paid, created_at, updated_at, approved_by_id, status, decided_at, decision_comment, absence_type_id, kind
+`
+
+type CancelAbsenceParams struct {
+	ID            int64  `json:"id"`
+	CurrentStatus string `json:"current_status"`
+}
+
+func (q *Queries) CancelAbsence(ctx context.Context, arg CancelAbsenceParams) (Absence, error) {
+	row := q.db.QueryRow(ctx, cancelAbsence, arg.ID, arg.CurrentStatus)
+	var i Absence
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Reason,
+		&i.Paid,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ApprovedByID,
+		&i.Status,
+		&i.DecidedAt,
+		&i.DecisionComment,
+		&i.AbsenceTypeID,
+		&i.Kind,
+	)
+	return i, err
+}
+
 const createAbsence = `-- name: CreateAbsence :one
 INSERT INTO absences (
-user_id, start_time, end_time, reason, paid, approved_by_id
+user_id, start_time, end_time, reason, paid, approved_by_id, absence_type_id, kind
 ) VALUES (
 $1,
 $2,
 $3,
 $4,
 $5,
-$6
+$6,
+$7,
+$8
 )
-RETURNING id, user_id, start_time, end_time, reason, paid, created_at, updated_at, approved_by_id
+RETURNING id, user_id, start_time, end_time, reason, paid, created_at, updated_at, approved_by_id, status, decided_at, decision_comment, absence_type_id, kind
 `
 
 type CreateAbsenceParams struct {
-	UserID       int64      `json:"user_id"`
-	StartTime    time.Time  `json:"start_time"`
-	EndTime      *time.Time `json:"end_time"`
-	Reason       string     `json:"reason"`
-	Paid         bool       `json:"paid"`
-	ApprovedByID *int64     `json:"approved_by_id"`
+	UserID        int64      `json:"user_id"`
+	StartTime     time.Time  `json:"start_time"`
+	EndTime       *time.Time `json:"end_time"`
+	Reason        string     `json:"reason"`
+	Paid          bool       `json:"paid"`
+	ApprovedByID  *int64     `json:"approved_by_id"`
+	AbsenceTypeID *int64     `json:"absence_type_id"`
+	Kind          string     `json:"kind"`
 }
 
 func (q *Queries) CreateAbsence(ctx context.Context, arg CreateAbsenceParams) (Absence, error) {
@@ -41,6 +79,8 @@ func (q *Queries) CreateAbsence(ctx context.Context, arg CreateAbsenceParams) (A
 		arg.Reason,
 		arg.Paid,
 		arg.ApprovedByID,
+		arg.AbsenceTypeID,
+		arg.Kind,
 	)
 	var i Absence
 	err := row.Scan(
@@ -53,6 +93,58 @@ func (q *Queries) CreateAbsence(ctx context.Context, arg CreateAbsenceParams) (A
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ApprovedByID,
+		&i.Status,
+		&i.DecidedAt,
+		&i.DecisionComment,
+		&i.AbsenceTypeID,
+		&i.Kind,
+	)
+	return i, err
+}
+
+const decideAbsence = `-- name: DecideAbsence :one
+UPDATE absences
+SET
+status = $1,
+approved_by_id = $2,
+decision_comment = $3,
+decided_at = now()
+WHERE id = $4 AND status = $5
+RETURNING id, user_id, start_time, end_time, reason, paid, created_at, updated_at, approved_by_id, status, decided_at, decision_comment, absence_type_id, kind
+`
+
+type DecideAbsenceParams struct {
+	Status          string  `json:"status"`
+	ApprovedByID    *int64  `json:"approved_by_id"`
+	DecisionComment *string `json:"decision_comment"`
+	ID              int64   `json:"id"`
+	CurrentStatus   string  `json:"current_status"`
+}
+
+func (q *Queries) DecideAbsence(ctx context.Context, arg DecideAbsenceParams) (Absence, error) {
+	row := q.db.QueryRow(ctx, decideAbsence,
+		arg.Status,
+		arg.ApprovedByID,
+		arg.DecisionComment,
+		arg.ID,
+		arg.CurrentStatus,
+	)
+	var i Absence
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Reason,
+		&i.Paid,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ApprovedByID,
+		&i.Status,
+		&i.DecidedAt,
+		&i.DecisionComment,
+		&i.AbsenceTypeID,
+		&i.Kind,
 	)
 	return i, err
 }
@@ -61,7 +153,7 @@ const deleteAbsence = `-- name: DeleteAbsence :one
 DELETE
 FROM absences
 WHERE id = $1
-RETURNING id, user_id, start_time, end_time, reason, paid, created_at, updated_at, approved_by_id
+RETURNING id, user_id, start_time, end_time, reason, paid, created_at, updated_at, approved_by_id, status, decided_at, decision_comment, absence_type_id, kind
 `
 
 func (q *Queries) DeleteAbsence(ctx context.Context, id int64) (Absence, error) {
@@ -77,12 +169,17 @@ func (q *Queries) DeleteAbsence(ctx context.Context, id int64) (Absence, error)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ApprovedByID,
+		&i.Status,
+		&i.DecidedAt,
+		&i.DecisionComment,
+		&i.AbsenceTypeID,
+		&i.Kind,
 	)
 	return i, err
 }
 
 const getAbsence = `-- name: GetAbsence :one
-SELECT id, user_id, start_time, end_time, reason, paid, created_at, updated_at, approved_by_id 
+SELECT id, user_id, start_time, end_time, reason, paid, created_at, updated_at, approved_by_id, status, decided_at, decision_comment, absence_type_id, kind 
 FROM absences
 WHERE id = $1
 LIMIT 1
@@ -101,25 +198,78 @@ func (q *Queries) GetAbsence(ctx context.Context, id int64) (Absence, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ApprovedByID,
+		&i.Status,
+		&i.DecidedAt,
+		&i.DecisionComment,
+		&i.AbsenceTypeID,
+		&i.Kind,
 	)
 	return i, err
 }
 
-const listAbsences = `-- name: ListAbsences :many
-SELECT id, user_id, start_time, end_time, reason, paid, created_at, updated_at, approved_by_id
+const getOverlappingAbsence = `-- name: GetOverlappingAbsence :one
+SELECT id, user_id, start_time, end_time, reason, paid, created_at, updated_at, approved_by_id, status, decided_at, decision_comment, absence_type_id, kind
 FROM absences
-ORDER BY id
-LIMIT $1
-OFFSET $2
+WHERE user_id = $1
+AND id <> $2
+AND status IN ('pending', 'approved')
+AND tsrange(start_time, end_time) && tsrange($3::timestamp, $4::timestamp)
+ORDER BY start_time
+LIMIT 1
+`
+
+type GetOverlappingAbsenceParams struct {
+	UserID    int64      `json:"user_id"`
+	ExcludeID int64      `json:"exclude_id"`
+	StartTime time.Time  `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+}
+
+func (q *Queries) GetOverlappingAbsence(ctx context.Context, arg GetOverlappingAbsenceParams) (Absence, error) {
+	row := q.db.QueryRow(ctx, getOverlappingAbsence,
+		arg.UserID,
+		arg.ExcludeID,
+		arg.StartTime,
+		arg.EndTime,
+	)
+	var i Absence
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Reason,
+		&i.Paid,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ApprovedByID,
+		&i.Status,
+		&i.DecidedAt,
+		&i.DecisionComment,
+		&i.AbsenceTypeID,
+		&i.Kind,
+	)
+	return i, err
+}
+
+const listAbsences = `-- name: ListAbsences :many
+SELECT a.id, a.user_id, a.start_time, a.end_time, a.reason, a.paid, a.created_at, a.updated_at, a.approved_by_id, a.status, a.decided_at, a.decision_comment, a.absence_type_id, a.kind
+FROM absences a
+JOIN users u ON u.id = a.user_id
+WHERE ($1::bigint IS NULL OR u.company_id = $1)
+ORDER BY a.id
+LIMIT $2
+OFFSET $3
 `
 
 type ListAbsencesParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	CompanyID *int64 `json:"company_id"`
+	Limit     int32  `json:"limit"`
+	Offset    int32  `json:"offset"`
 }
 
 func (q *Queries) ListAbsences(ctx context.Context, arg ListAbsencesParams) ([]Absence, error) {
-	rows, err := q.db.Query(ctx, listAbsences, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listAbsences, arg.CompanyID, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -137,6 +287,11 @@ func (q *Queries) ListAbsences(ctx context.Context, arg ListAbsencesParams) ([]A
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ApprovedByID,
+			&i.Status,
+			&i.DecidedAt,
+			&i.DecisionComment,
+			&i.AbsenceTypeID,
+			&i.Kind,
 		); err != nil {
 			return nil, err
 		}
@@ -149,7 +304,7 @@ func (q *Queries) ListAbsences(ctx context.Context, arg ListAbsencesParams) ([]A
 }
 
 const listUserAbsences = `-- name: ListUserAbsences :many
-SELECT id, user_id, start_time, end_time, reason, paid, created_at, updated_at, approved_by_id
+SELECT id, user_id, start_time, end_time, reason, paid, created_at, updated_at, approved_by_id, status, decided_at, decision_comment, absence_type_id, kind
 FROM absences
 WHERE user_id = $1
 ORDER BY id
@@ -182,6 +337,11 @@ func (q *Queries) ListUserAbsences(ctx context.Context, arg ListUserAbsencesPara
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ApprovedByID,
+			&i.Status,
+			&i.DecidedAt,
+			&i.DecisionComment,
+			&i.AbsenceTypeID,
+			&i.Kind,
 		); err != nil {
 			return nil, err
 		}
@@ -201,9 +361,10 @@ reason = $3,
 paid = $4, 
 start_time = $5, 
 end_time = $6, 
-approved_by_id = $7
+approved_by_id = $7,
+kind = $8
 WHERE id = $1
-RETURNING id, user_id, start_time, end_time, reason, paid, created_at, updated_at, approved_by_id
+RETURNING id, user_id, start_time, end_time, reason, paid, created_at, updated_at, approved_by_id, status, decided_at, decision_comment, absence_type_id, kind
 `
 
 type UpdateAbsenceParams struct {
@@ -214,6 +375,7 @@ type UpdateAbsenceParams struct {
 	StartTime    time.Time  `json:"start_time"`
 	EndTime      *time.Time `json:"end_time"`
 	ApprovedByID *int64     `json:"approved_by_id"`
+	Kind         string     `json:"kind"`
 }
 
 func (q *Queries) UpdateAbsence(ctx context.Context, arg UpdateAbsenceParams) (Absence, error) {
@@ -225,6 +387,7 @@ func (q *Queries) UpdateAbsence(ctx context.Context, arg UpdateAbsenceParams) (A
 		arg.StartTime,
 		arg.EndTime,
 		arg.ApprovedByID,
+		arg.Kind,
 	)
 	var i Absence
 	err := row.Scan(
@@ -237,6 +400,11 @@ func (q *Queries) UpdateAbsence(ctx context.Context, arg UpdateAbsenceParams) (A
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ApprovedByID,
+		&i.Status,
+		&i.DecidedAt,
+		&i.DecisionComment,
+		&i.AbsenceTypeID,
+		&i.Kind,
 	)
 	return i, err
 }
@@ -5,6 +5,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
 	"github.com/stretchr/testify/require"
 )
 
@@ -19,6 +22,7 @@ func createRandomAbsence(t *testing.T) Absence {
 		Reason:    "no reason",
 		StartTime: today,
 		EndTime:   &end,
+		Kind:      types.AbsenceHours,
 	}
 
 	absence, err := testStore.CreateAbsence(context.Background(), arg)
@@ -27,9 +31,12 @@ func createRandomAbsence(t *testing.T) Absence {
 	require.NotZero(t, absence.ID)
 	require.Equal(t, arg.UserID, absence.UserID)
 	require.Equal(t, arg.Paid, absence.Paid)
+	require.Equal(t, arg.Kind, absence.Kind)
 	require.WithinDuration(t, arg.StartTime, absence.StartTime, time.Second)
 	require.WithinDuration(t, *arg.EndTime, *absence.EndTime, time.Second)
 	require.Nil(t, absence.ApprovedByID)
+	require.Equal(t, types.AbsencePending, absence.Status)
+	require.Nil(t, absence.DecidedAt)
 	require.WithinDuration(t, time.Now(), absence.CreatedAt, 2*time.Second)
 	require.Nil(t, absence.UpdatedAt)
 
@@ -66,6 +73,7 @@ func TestUpdateAbsence(t *testing.T) {
 		Paid:      true,
 		StartTime: today,
 		EndTime:   &end,
+		Kind:      types.AbsenceFullDay,
 	}
 
 	updatedAbsence, err := testStore.UpdateAbsence(context.Background(), arg)
@@ -76,6 +84,7 @@ func TestUpdateAbsence(t *testing.T) {
 	require.Equal(t, arg.Paid, updatedAbsence.Paid)
 	require.WithinDuration(t, arg.StartTime, updatedAbsence.StartTime, time.Second)
 	require.WithinDuration(t, *arg.EndTime, *updatedAbsence.EndTime, time.Second)
+	require.Equal(t, arg.Kind, updatedAbsence.Kind)
 	require.NotNil(t, updatedAbsence.UpdatedAt)
 	require.WithinDuration(t, time.Now(), *updatedAbsence.UpdatedAt, time.Second)
 	require.Equal(t, absence.CreatedAt, updatedAbsence.CreatedAt)
@@ -119,11 +128,15 @@ func TestListUserAbsences(t *testing.T) {
 	user := createRandomUser(t, nil, nil)
 	var absences []Absence
 	for i := 0; i < 10; i++ {
-		today := time.Now().UTC()
+		// active absences of a user must not overlap
+		start := time.Now().UTC().AddDate(0, 0, i)
+		end := start.Add(time.Hour)
 		arg := CreateAbsenceParams{
 			UserID:    user.ID,
 			Paid:      false,
-			StartTime: today,
+			StartTime: start,
+			EndTime:   &end,
+			Kind:      types.AbsenceHours,
 		}
 		absence, err := testStore.CreateAbsence(context.Background(), arg)
 		require.NoError(t, err)
@@ -138,3 +151,110 @@ func TestListUserAbsences(t *testing.T) {
 	require.NoError(t, err)
 	require.Subset(t, userAbsences, absences)
 }
+
+func TestDecideAbsence(t *testing.T) {
+	absence := createRandomAbsence(t)
+	manager := createRandomUser(t, nil, nil)
+	comment := util.RandomString(30)
+
+	arg := DecideAbsenceParams{
+		ID:              absence.ID,
+		Status:          types.AbsenceRejected,
+		ApprovedByID:    &manager.ID,
+		DecisionComment: &comment,
+		CurrentStatus:   types.AbsencePending,
+	}
+	decidedAbsence, err := testStore.DecideAbsence(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, absence.ID, decidedAbsence.ID)
+	require.Equal(t, arg.Status, decidedAbsence.Status)
+	require.Equal(t, arg.ApprovedByID, decidedAbsence.ApprovedByID)
+	require.Equal(t, arg.DecisionComment, decidedAbsence.DecisionComment)
+	require.NotNil(t, decidedAbsence.DecidedAt)
+	require.WithinDuration(t, time.Now(), *decidedAbsence.DecidedAt, 2*time.Second)
+
+	// the absence is no longer pending, so deciding it again does not match any row
+	_, err = testStore.DecideAbsence(context.Background(), arg)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestCancelAbsence(t *testing.T) {
+	absence := createRandomAbsence(t)
+
+	arg := CancelAbsenceParams{
+		ID:            absence.ID,
+		CurrentStatus: types.AbsencePending,
+	}
+	cancelledAbsence, err := testStore.CancelAbsence(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, absence.ID, cancelledAbsence.ID)
+	require.Equal(t, types.AbsenceCancelled, cancelledAbsence.Status)
+
+	_, err = testStore.CancelAbsence(context.Background(), arg)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestCreateOverlappingAbsence(t *testing.T) {
+	user := createRandomUser(t, nil, nil)
+	manager := createRandomUser(t, nil, nil)
+	createAbsence := func(start time.Time, end *time.Time) (Absence, error) {
+		return testStore.CreateAbsence(context.Background(), CreateAbsenceParams{
+			UserID:    user.ID,
+			StartTime: start,
+			EndTime:   end,
+			Reason:    util.RandomString(10),
+			Paid:      true,
+			Kind:      types.AbsenceFullDay,
+		})
+	}
+
+	start := time.Now().UTC().Truncate(time.Second)
+	end := start.Add(48 * time.Hour)
+	absence, err := createAbsence(start, &end)
+	require.NoError(t, err)
+
+	overlapEnd := end.Add(24 * time.Hour)
+	_, err = createAbsence(start.Add(24*time.Hour), &overlapEnd)
+	require.Error(t, err)
+	require.Equal(t, ExclusionViolation, ErrorCode(err))
+	require.Equal(t, AbsenceOverlapConstraint, ConstraintName(err))
+
+	overlapping, err := testStore.GetOverlappingAbsence(context.Background(), GetOverlappingAbsenceParams{
+		UserID:    user.ID,
+		StartTime: start.Add(24 * time.Hour),
+		EndTime:   &overlapEnd,
+	})
+	require.NoError(t, err)
+	require.Equal(t, absence.ID, overlapping.ID)
+
+	// adjacent absences do not overlap
+	_, err = createAbsence(end, &overlapEnd)
+	require.NoError(t, err)
+
+	// rejected absences do not take any time
+	_, err = testStore.DecideAbsence(context.Background(), DecideAbsenceParams{
+		ID:            absence.ID,
+		Status:        types.AbsenceRejected,
+		ApprovedByID:  &manager.ID,
+		CurrentStatus: types.AbsencePending,
+	})
+	require.NoError(t, err)
+	_, err = createAbsence(start, &end)
+	require.NoError(t, err)
+}
+
+func TestCreateAbsenceEndBeforeStart(t *testing.T) {
+	user := createRandomUser(t, nil, nil)
+	start := time.Now().UTC()
+	end := start.Add(-time.Hour)
+	_, err := testStore.CreateAbsence(context.Background(), CreateAbsenceParams{
+		UserID:    user.ID,
+		StartTime: start,
+		EndTime:   &end,
+		Reason:    util.RandomString(10),
+		Kind:      types.AbsenceHours,
+	})
+	require.Error(t, err)
+	require.Equal(t, CheckViolation, ErrorCode(err))
+	require.Equal(t, AbsenceTimeConstraint, ConstraintName(err))
+}
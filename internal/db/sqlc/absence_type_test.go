@@ -0,0 +1,138 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomAbsenceType(t *testing.T, companyID int64, tracksBalance bool) AbsenceType {
+	months := int32(3)
+	arg := CreateAbsenceTypeParams{
+		CompanyID:             companyID,
+		Name:                  util.RandomString(10),
+		Paid:                  true,
+		TracksBalance:         tracksBalance,
+		Accrual:               types.AccrualMonthly,
+		CarryOverMaxDays:      5,
+		CarryOverExpiryMonths: &months,
+	}
+	absenceType, err := testStore.CreateAbsenceType(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, absenceType.ID)
+	require.Equal(t, arg.CompanyID, absenceType.CompanyID)
+	require.Equal(t, arg.Name, absenceType.Name)
+	require.Equal(t, arg.Paid, absenceType.Paid)
+	require.Equal(t, arg.TracksBalance, absenceType.TracksBalance)
+	require.Equal(t, arg.Accrual, absenceType.Accrual)
+	require.Equal(t, arg.CarryOverMaxDays, absenceType.CarryOverMaxDays)
+	require.Equal(t, arg.CarryOverExpiryMonths, absenceType.CarryOverExpiryMonths)
+	require.WithinDuration(t, time.Now(), absenceType.CreatedAt, 2*time.Second)
+	return absenceType
+}
+
+func TestCreateAbsenceType(t *testing.T) {
+	company := createRandomCompany(t)
+	createRandomAbsenceType(t, company.ID, true)
+}
+
+func TestAbsenceTypeConstraints(t *testing.T) {
+	company := createRandomCompany(t)
+	absenceType := createRandomAbsenceType(t, company.ID, true)
+
+	_, err := testStore.CreateAbsenceType(context.Background(), CreateAbsenceTypeParams{
+		CompanyID: company.ID,
+		Name:      absenceType.Name,
+		Accrual:   types.AccrualYearly,
+	})
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+	require.Equal(t, AbsenceTypeNameConstraint, ConstraintName(err))
+
+	_, err = testStore.CreateAbsenceType(context.Background(), CreateAbsenceTypeParams{
+		CompanyID: company.ID,
+		Name:      util.RandomString(10),
+		Accrual:   "weekly",
+	})
+	require.Equal(t, CheckViolation, ErrorCode(err))
+
+	months := int32(13)
+	_, err = testStore.CreateAbsenceType(context.Background(), CreateAbsenceTypeParams{
+		CompanyID:             company.ID,
+		Name:                  util.RandomString(10),
+		Accrual:               types.AccrualYearly,
+		CarryOverExpiryMonths: &months,
+	})
+	require.Equal(t, CheckViolation, ErrorCode(err))
+}
+
+func TestUpdateAbsenceType(t *testing.T) {
+	company := createRandomCompany(t)
+	absenceType := createRandomAbsenceType(t, company.ID, true)
+
+	arg := UpdateAbsenceTypeParams{
+		ID:            absenceType.ID,
+		Name:          util.RandomString(10),
+		Paid:          false,
+		TracksBalance: false,
+		Accrual:       types.AccrualYearly,
+	}
+	updated, err := testStore.UpdateAbsenceType(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, updated.Name)
+	require.False(t, updated.Paid)
+	require.False(t, updated.TracksBalance)
+	require.Equal(t, arg.Accrual, updated.Accrual)
+	require.Zero(t, updated.CarryOverMaxDays)
+	require.Nil(t, updated.CarryOverExpiryMonths)
+	require.NotNil(t, updated.UpdatedAt)
+}
+
+func TestListTrackedAbsenceTypes(t *testing.T) {
+	company := createRandomCompany(t)
+	vacation := createRandomAbsenceType(t, company.ID, true)
+	createRandomAbsenceType(t, company.ID, false)
+	createRandomAbsenceType(t, createRandomCompany(t).ID, true)
+
+	absenceTypes, err := testStore.ListTrackedAbsenceTypes(context.Background(), company.ID)
+	require.NoError(t, err)
+	require.Len(t, absenceTypes, 1)
+	require.Equal(t, vacation.ID, absenceTypes[0].ID)
+
+	all, err := testStore.ListAbsenceTypes(context.Background(), ListAbsenceTypesParams{
+		CompanyID: &company.ID,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+}
+
+func TestDeleteAbsenceTypeInUse(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	absenceType := createRandomAbsenceType(t, company.ID, false)
+
+	end := time.Now().UTC().Add(time.Hour)
+	absence, err := testStore.CreateAbsence(context.Background(), CreateAbsenceParams{
+		UserID:        user.ID,
+		StartTime:     time.Now().UTC(),
+		EndTime:       &end,
+		Reason:        util.RandomString(10),
+		Paid:          true,
+		AbsenceTypeID: &absenceType.ID,
+		Kind:          types.AbsenceHours,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &absenceType.ID, absence.AbsenceTypeID)
+
+	_, err = testStore.DeleteAbsenceType(context.Background(), absenceType.ID)
+	require.Equal(t, ForeignKeyViolation, ErrorCode(err))
+
+	_, err = testStore.DeleteAbsence(context.Background(), absence.ID)
+	require.NoError(t, err)
+	_, err = testStore.DeleteAbsenceType(context.Background(), absenceType.ID)
+	require.NoError(t, err)
+}
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarFeeds(t *testing.T) {
+	team := createRandomTeam(t)
+	user := createRandomUser(t, team.CompanyID, &team.ID)
+
+	userFeed, err := testStore.CreateCalendarFeed(context.Background(), CreateCalendarFeedParams{
+		TokenHash: util.RandomString(64),
+		UserID:    &user.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &user.ID, userFeed.UserID)
+	require.Nil(t, userFeed.TeamID)
+	require.WithinDuration(t, time.Now(), userFeed.CreatedAt, 2*time.Second)
+	require.Nil(t, userFeed.RevokedAt)
+
+	teamFeed, err := testStore.CreateCalendarFeed(context.Background(), CreateCalendarFeedParams{
+		TokenHash: util.RandomString(64),
+		TeamID:    &team.ID,
+	})
+	require.NoError(t, err)
+
+	// a feed belongs to either a user or a team
+	_, err = testStore.CreateCalendarFeed(context.Background(), CreateCalendarFeedParams{
+		TokenHash: util.RandomString(64),
+		UserID:    &user.ID,
+		TeamID:    &team.ID,
+	})
+	require.Equal(t, CheckViolation, ErrorCode(err))
+
+	feeds, err := testStore.ListCalendarFeeds(context.Background(), ListCalendarFeedsParams{UserID: &user.ID})
+	require.NoError(t, err)
+	require.Len(t, feeds, 1)
+	require.Equal(t, userFeed.ID, feeds[0].ID)
+
+	got, err := testStore.GetActiveCalendarFeed(context.Background(), teamFeed.TokenHash)
+	require.NoError(t, err)
+	require.Equal(t, teamFeed.ID, got.ID)
+
+	// feeds are only revoked through their owner
+	_, err = testStore.RevokeCalendarFeed(context.Background(), RevokeCalendarFeedParams{ID: teamFeed.ID, UserID: &user.ID})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	revoked, err := testStore.RevokeCalendarFeed(context.Background(), RevokeCalendarFeedParams{ID: teamFeed.ID, TeamID: &team.ID})
+	require.NoError(t, err)
+	require.NotNil(t, revoked.RevokedAt)
+
+	_, err = testStore.GetActiveCalendarFeed(context.Background(), teamFeed.TokenHash)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+	_, err = testStore.RevokeCalendarFeed(context.Background(), RevokeCalendarFeedParams{ID: teamFeed.ID, TeamID: &team.ID})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomClient(t *testing.T, companyID int64) Client {
+	arg := CreateClientParams{
+		CompanyID: companyID,
+		Name:      util.RandomString(20),
+	}
+
+	client, err := testStore.CreateClient(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, client)
+	require.NotZero(t, client.ID)
+	require.Equal(t, arg.CompanyID, client.CompanyID)
+	require.Equal(t, arg.Name, client.Name)
+	require.WithinDuration(t, time.Now(), client.CreatedAt, 2*time.Second)
+	require.Nil(t, client.UpdatedAt)
+
+	return client
+}
+
+func TestCreateClient(t *testing.T) {
+	company := createRandomCompany(t)
+	createRandomClient(t, company.ID)
+}
+
+func TestGetClient(t *testing.T) {
+	company := createRandomCompany(t)
+	client := createRandomClient(t, company.ID)
+	gotClient, err := testStore.GetClient(context.Background(), client.ID)
+	require.NoError(t, err)
+	require.Equal(t, client, gotClient)
+}
+
+func TestUpdateClient(t *testing.T) {
+	company := createRandomCompany(t)
+	client := createRandomClient(t, company.ID)
+	arg := UpdateClientParams{
+		ID:   client.ID,
+		Name: util.RandomString(20),
+	}
+
+	updatedClient, err := testStore.UpdateClient(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, client.ID, updatedClient.ID)
+	require.Equal(t, arg.Name, updatedClient.Name)
+	require.NotNil(t, updatedClient.UpdatedAt)
+	require.WithinDuration(t, time.Now(), *updatedClient.UpdatedAt, time.Second)
+	require.Equal(t, client.CreatedAt, updatedClient.CreatedAt)
+}
+
+func TestDeleteClient(t *testing.T) {
+	company := createRandomCompany(t)
+	client := createRandomClient(t, company.ID)
+	deletedClient, err := testStore.DeleteClient(context.Background(), client.ID)
+	require.NoError(t, err)
+	require.Equal(t, client, deletedClient)
+
+	_, err = testStore.GetClient(context.Background(), client.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestDeleteClientWithProjects(t *testing.T) {
+	company := createRandomCompany(t)
+	client := createRandomClient(t, company.ID)
+	_, err := testStore.CreateProject(context.Background(), CreateProjectParams{
+		CompanyID: company.ID,
+		Name:      util.RandomString(20),
+		ClientID:  &client.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.DeleteClient(context.Background(), client.ID)
+	require.Error(t, err)
+	require.Equal(t, ForeignKeyViolation, ErrorCode(err))
+}
+
+func TestListClients(t *testing.T) {
+	company := createRandomCompany(t)
+	for i := 0; i < 3; i++ {
+		createRandomClient(t, company.ID)
+	}
+	otherCompany := createRandomCompany(t)
+	createRandomClient(t, otherCompany.ID)
+
+	clients, err := testStore.ListClients(context.Background(), ListClientsParams{
+		CompanyID: &company.ID,
+		Limit:     100,
+	})
+	require.NoError(t, err)
+	require.Len(t, clients, 3)
+	for _, client := range clients {
+		require.Equal(t, company.ID, client.CompanyID)
+	}
+}
@@ -0,0 +1,134 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertClockPolicy(t *testing.T) {
+	company := createRandomCompany(t)
+
+	arg := UpsertClockPolicyParams{
+		CompanyID:             company.ID,
+		AutoCloseAfterMinutes: util.Pointer(int32(720)),
+		ReminderAtMinute:      util.Pointer(int32(17 * 60)),
+	}
+	policy, err := testStore.UpsertClockPolicy(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.AutoCloseAfterMinutes, policy.AutoCloseAfterMinutes)
+	require.Nil(t, policy.AutoCloseAtMinute)
+	require.Nil(t, policy.UpdatedAt)
+
+	arg.AutoCloseAfterMinutes = nil
+	arg.AutoCloseAtMinute = util.Pointer(int32(22 * 60))
+	policy, err = testStore.UpsertClockPolicy(context.Background(), arg)
+	require.NoError(t, err)
+	require.Nil(t, policy.AutoCloseAfterMinutes)
+	require.Equal(t, arg.AutoCloseAtMinute, policy.AutoCloseAtMinute)
+	require.NotNil(t, policy.UpdatedAt)
+
+	got, err := testStore.GetClockPolicy(context.Background(), company.ID)
+	require.NoError(t, err)
+	require.Equal(t, policy, got)
+
+	arg.ReminderAtMinute = util.Pointer(int32(24 * 60))
+	_, err = testStore.UpsertClockPolicy(context.Background(), arg)
+	require.Error(t, err)
+}
+
+func TestAutoCloseEntryTx(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+
+	entry, err := testStore.CreateEntryTx(context.Background(), CreateEntryParams{
+		UserID:    user.ID,
+		StartTime: time.Now().UTC().Add(-20 * time.Hour).Truncate(time.Microsecond),
+	})
+	require.NoError(t, err)
+
+	running, err := testStore.ListCompanyRunningEntries(context.Background(), company.ID)
+	require.NoError(t, err)
+	require.Len(t, running, 1)
+	require.Equal(t, entry.ID, running[0].ID)
+
+	endTime := entry.StartTime.Add(12 * time.Hour)
+	closed, err := testStore.AutoCloseEntryTx(context.Background(), AutoCloseEntryParams{ID: entry.ID, EndTime: endTime})
+	require.NoError(t, err)
+	require.Equal(t, endTime, *closed.EndTime)
+	require.True(t, closed.NeedsReview)
+	require.NotNil(t, closed.AutoClosedAt)
+
+	// stopped entries are not stopped again
+	_, err = testStore.AutoCloseEntryTx(context.Background(), AutoCloseEntryParams{ID: entry.ID, EndTime: endTime})
+	require.Error(t, err)
+
+	notifications, err := testStore.ListUserNotifications(context.Background(), ListUserNotificationsParams{
+		UserID: user.ID,
+		Unread: util.Pointer(true),
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	require.Equal(t, types.NotificationEntryAutoClosed, notifications[0].Kind)
+
+	review, err := testStore.ListUserEntries(context.Background(), ListUserEntriesParams{
+		UserID:      user.ID,
+		NeedsReview: util.Pointer(true),
+		Limit:       10,
+	})
+	require.NoError(t, err)
+	require.Len(t, review, 1)
+
+	// updating an entry reviews it
+	updated, err := testStore.UpdateEntryTx(context.Background(), UpdateEntryParams{
+		ID:        closed.ID,
+		UserID:    closed.UserID,
+		StartTime: closed.StartTime,
+		EndTime:   util.Pointer(closed.StartTime.Add(8 * time.Hour)),
+	})
+	require.NoError(t, err)
+	require.False(t, updated.NeedsReview)
+	require.NotNil(t, updated.AutoClosedAt)
+}
+
+func TestUniqueNotification(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+
+	arg := CreateUniqueNotificationParams{
+		UserID:    user.ID,
+		Kind:      types.NotificationTimerReminder,
+		Data:      []byte(`{"date": "2024-03-01"}`),
+		DedupeKey: util.Pointer(util.RandomString(20)),
+	}
+	n, err := testStore.CreateUniqueNotification(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+	n, err = testStore.CreateUniqueNotification(context.Background(), arg)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	notification, err := testStore.GetNotificationByDedupeKey(context.Background(), arg.DedupeKey)
+	require.NoError(t, err)
+	require.Nil(t, notification.ReadAt)
+
+	read, err := testStore.ReadNotification(context.Background(), ReadNotificationParams{
+		ID:     notification.ID,
+		UserID: user.ID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, read.ReadAt)
+
+	unread, err := testStore.ListUserNotifications(context.Background(), ListUserNotificationsParams{
+		UserID: user.ID,
+		Unread: util.Pointer(true),
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, unread)
+}
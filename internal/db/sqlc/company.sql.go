@@ -15,7 +15,7 @@ INSERT INTO companies (
 ) VALUES (
     $1
 )
-RETURNING id, name, created_at, updated_at
+RETURNING id, name, created_at, updated_at, default_work_schedule_id
 `
 
 func (q *Queries) CreateCompany(ctx context.Context, name string) (Company, error) {
@@ -26,6 +26,7 @@ func (q *Queries) CreateCompany(ctx context.Context, name string) (Company, erro
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DefaultWorkScheduleID,
 	)
 	return i, err
 }
@@ -34,7 +35,7 @@ const deleteCompany = `-- name: DeleteCompany :one
 DELETE
 FROM companies
 WHERE id = $1
-RETURNING id, name, created_at, updated_at
+RETURNING id, name, created_at, updated_at, default_work_schedule_id
 `
 
 func (q *Queries) DeleteCompany(ctx context.Context, id int64) (Company, error) {
@@ -45,12 +46,13 @@ func (q *Queries) DeleteCompany(ctx context.Context, id int64) (Company, error)
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DefaultWorkScheduleID,
 	)
 	return i, err
 }
 
 const getCompany = `-- name: GetCompany :one
-SELECT id, name, created_at, updated_at 
+SELECT id, name, created_at, updated_at, default_work_schedule_id 
 FROM companies
 WHERE id = $1
 LIMIT 1
@@ -64,12 +66,13 @@ func (q *Queries) GetCompany(ctx context.Context, id int64) (Company, error) {
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DefaultWorkScheduleID,
 	)
 	return i, err
 }
 
 const listCompanies = `-- name: ListCompanies :many
-SELECT id, name, created_at, updated_at
+SELECT id, name, created_at, updated_at, default_work_schedule_id
 FROM companies
 ORDER BY id
 LIMIT $1
@@ -95,6 +98,7 @@ func (q *Queries) ListCompanies(ctx context.Context, arg ListCompaniesParams) ([
 			&i.Name,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DefaultWorkScheduleID,
 		); err != nil {
 			return nil, err
 		}
@@ -107,7 +111,7 @@ func (q *Queries) ListCompanies(ctx context.Context, arg ListCompaniesParams) ([
 }
 
 const listCompanyEmployees = `-- name: ListCompanyEmployees :many
-SELECT id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role
+SELECT id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role, subdivision
 FROM users
 WHERE users.company_id =
     (SELECT companies.id
@@ -151,6 +155,7 @@ func (q *Queries) ListCompanyEmployees(ctx context.Context, arg ListCompanyEmplo
 			&i.ManagerID,
 			&i.TeamID,
 			&i.Role,
+			&i.Subdivision,
 		); err != nil {
 			return nil, err
 		}
@@ -166,7 +171,7 @@ const updateCompany = `-- name: UpdateCompany :one
 UPDATE companies
 SET name = $2
 WHERE id = $1
-RETURNING id, name, created_at, updated_at
+RETURNING id, name, created_at, updated_at, default_work_schedule_id
 `
 
 type UpdateCompanyParams struct {
@@ -182,6 +187,32 @@ func (q *Queries) UpdateCompany(ctx context.Context, arg UpdateCompanyParams) (C
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DefaultWorkScheduleID,
+	)
+	return i, err
+}
+
+const updateCompanyWorkSchedule = `-- name: UpdateCompanyWorkSchedule :one
+UPDATE companies
+SET default_work_schedule_id = $1
+WHERE id = $2
+RETURNING id, name, created_at, updated_at, default_work_schedule_id
+`
+
+type UpdateCompanyWorkScheduleParams struct {
+	WorkScheduleID *int64 `json:"work_schedule_id"`
+	ID             int64  `json:"id"`
+}
+
+func (q *Queries) UpdateCompanyWorkSchedule(ctx context.Context, arg UpdateCompanyWorkScheduleParams) (Company, error) {
+	row := q.db.QueryRow(ctx, updateCompanyWorkSchedule, arg.WorkScheduleID, arg.ID)
+	var i Company
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DefaultWorkScheduleID,
 	)
 	return i, err
 }
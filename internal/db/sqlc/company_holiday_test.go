@@ -0,0 +1,54 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompanyHolidays(t *testing.T) {
+	company := createRandomCompany(t)
+
+	arg := CreateCompanyHolidayParams{
+		CompanyID: company.ID,
+		Date:      date(2024, time.May, 31),
+		Name:      "Bridge day",
+		DayOff:    true,
+	}
+	holiday, err := testStore.CreateCompanyHoliday(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.CompanyID, holiday.CompanyID)
+	require.True(t, arg.Date.Equal(holiday.Date))
+	require.Equal(t, arg.Name, holiday.Name)
+	require.True(t, holiday.DayOff)
+
+	_, err = testStore.CreateCompanyHoliday(context.Background(), arg)
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+	require.Equal(t, CompanyHolidayDateConstraint, ConstraintName(err))
+
+	working, err := testStore.CreateCompanyHoliday(context.Background(), CreateCompanyHolidayParams{
+		CompanyID: company.ID,
+		Date:      date(2024, time.June, 22),
+		Name:      "Working day",
+	})
+	require.NoError(t, err)
+	require.False(t, working.DayOff)
+
+	holidays, err := testStore.ListCompanyHolidays(context.Background(), ListCompanyHolidaysParams{
+		CompanyID: company.ID,
+		From:      date(2024, time.January, 1),
+		To:        date(2024, time.June, 22),
+	})
+	require.NoError(t, err)
+	require.Len(t, holidays, 1)
+	require.Equal(t, holiday.ID, holidays[0].ID)
+
+	// holidays are deleted together with their company
+	_, err = testStore.DeleteCompany(context.Background(), company.ID)
+	require.NoError(t, err)
+	_, err = testStore.GetCompanyHoliday(context.Background(), working.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
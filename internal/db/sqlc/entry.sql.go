@@ -10,25 +10,81 @@ import (
 	"time"
 )
 
+const autoCloseEntry = `-- name: AutoCloseEntry :one
+UPDATE entries
+SET
+end_time = $1::timestamp,
+auto_closed_at = now(),
+needs_review = true
+WHERE id = $2 AND end_time IS NULL
+RETURNING id, user_id, start_time, end_time, created_at, updated_at, project_id, task_id, description, tags, billable, invoice_id, auto_closed_at, needs_review
+`
+
+type AutoCloseEntryParams struct {
+	EndTime time.Time `json:"end_time"`
+	ID      int64     `json:"id"`
+}
+
+func (q *Queries) AutoCloseEntry(ctx context.Context, arg AutoCloseEntryParams) (Entry, error) {
+	row := q.db.QueryRow(ctx, autoCloseEntry, arg.EndTime, arg.ID)
+	var i Entry
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ProjectID,
+		&i.TaskID,
+		&i.Description,
+		&i.Tags,
+		&i.Billable,
+		&i.InvoiceID,
+		&i.AutoClosedAt,
+		&i.NeedsReview,
+	)
+	return i, err
+}
+
 const createEntry = `-- name: CreateEntry :one
 INSERT INTO entries (
-user_id, start_time, end_time
+user_id, start_time, end_time, project_id, task_id, description, tags, billable
 ) VALUES (
 $1,
 $2,
-$3
+$3,
+$4,
+$5,
+$6,
+COALESCE($7::varchar[], '{}'),
+$8
 )
-RETURNING id, user_id, start_time, end_time, created_at, updated_at
+RETURNING id, user_id, start_time, end_time, created_at, updated_at, project_id, task_id, description, tags, billable, invoice_id, auto_closed_at, needs_review
 `
 
 type CreateEntryParams struct {
-	UserID    int64      `json:"user_id"`
-	StartTime time.Time  `json:"start_time"`
-	EndTime   *time.Time `json:"end_time"`
+	UserID      int64      `json:"user_id"`
+	StartTime   time.Time  `json:"start_time"`
+	EndTime     *time.Time `json:"end_time"`
+	ProjectID   *int64     `json:"project_id"`
+	TaskID      *int64     `json:"task_id"`
+	Description *string    `json:"description"`
+	Tags        []string   `json:"tags"`
+	Billable    bool       `json:"billable"`
 }
 
 func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
-	row := q.db.QueryRow(ctx, createEntry, arg.UserID, arg.StartTime, arg.EndTime)
+	row := q.db.QueryRow(ctx, createEntry,
+		arg.UserID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.ProjectID,
+		arg.TaskID,
+		arg.Description,
+		arg.Tags,
+		arg.Billable,
+	)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
@@ -37,6 +93,14 @@ func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry
 		&i.EndTime,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ProjectID,
+		&i.TaskID,
+		&i.Description,
+		&i.Tags,
+		&i.Billable,
+		&i.InvoiceID,
+		&i.AutoClosedAt,
+		&i.NeedsReview,
 	)
 	return i, err
 }
@@ -45,7 +109,7 @@ const deleteEntry = `-- name: DeleteEntry :one
 DELETE
 FROM entries
 WHERE id = $1
-RETURNING id, user_id, start_time, end_time, created_at, updated_at
+RETURNING id, user_id, start_time, end_time, created_at, updated_at, project_id, task_id, description, tags, billable, invoice_id, auto_closed_at, needs_review
 `
 
 func (q *Queries) DeleteEntry(ctx context.Context, id int64) (Entry, error) {
@@ -58,12 +122,20 @@ func (q *Queries) DeleteEntry(ctx context.Context, id int64) (Entry, error) {
 		&i.EndTime,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ProjectID,
+		&i.TaskID,
+		&i.Description,
+		&i.Tags,
+		&i.Billable,
+		&i.InvoiceID,
+		&i.AutoClosedAt,
+		&i.NeedsReview,
 	)
 	return i, err
 }
 
 const getEntry = `-- name: GetEntry :one
-SELECT id, user_id, start_time, end_time, created_at, updated_at 
+SELECT id, user_id, start_time, end_time, created_at, updated_at, project_id, task_id, description, tags, billable, invoice_id, auto_closed_at, needs_review 
 FROM entries
 WHERE id = $1
 LIMIT 1
@@ -79,25 +151,180 @@ func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
 		&i.EndTime,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ProjectID,
+		&i.TaskID,
+		&i.Description,
+		&i.Tags,
+		&i.Billable,
+		&i.InvoiceID,
+		&i.AutoClosedAt,
+		&i.NeedsReview,
 	)
 	return i, err
 }
 
-const listEntries = `-- name: ListEntries :many
-SELECT id, user_id, start_time, end_time, created_at, updated_at
+const getOverlappingEntry = `-- name: GetOverlappingEntry :one
+SELECT id, user_id, start_time, end_time, created_at, updated_at, project_id, task_id, description, tags, billable, invoice_id, auto_closed_at, needs_review
 FROM entries
-ORDER BY id
-LIMIT $1
-OFFSET $2
+WHERE user_id = $1
+AND id <> $2
+AND tsrange(start_time, end_time) && tsrange($3::timestamp, $4::timestamp)
+ORDER BY start_time
+LIMIT 1
+`
+
+type GetOverlappingEntryParams struct {
+	UserID    int64      `json:"user_id"`
+	ExcludeID int64      `json:"exclude_id"`
+	StartTime time.Time  `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+}
+
+func (q *Queries) GetOverlappingEntry(ctx context.Context, arg GetOverlappingEntryParams) (Entry, error) {
+	row := q.db.QueryRow(ctx, getOverlappingEntry,
+		arg.UserID,
+		arg.ExcludeID,
+		arg.StartTime,
+		arg.EndTime,
+	)
+	var i Entry
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ProjectID,
+		&i.TaskID,
+		&i.Description,
+		&i.Tags,
+		&i.Billable,
+		&i.InvoiceID,
+		&i.AutoClosedAt,
+		&i.NeedsReview,
+	)
+	return i, err
+}
+
+const getRunningEntry = `-- name: GetRunningEntry :one
+SELECT id, user_id, start_time, end_time, created_at, updated_at, project_id, task_id, description, tags, billable, invoice_id, auto_closed_at, needs_review
+FROM entries
+WHERE user_id = $1 AND end_time IS NULL
+LIMIT 1
+`
+
+func (q *Queries) GetRunningEntry(ctx context.Context, userID int64) (Entry, error) {
+	row := q.db.QueryRow(ctx, getRunningEntry, userID)
+	var i Entry
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ProjectID,
+		&i.TaskID,
+		&i.Description,
+		&i.Tags,
+		&i.Billable,
+		&i.InvoiceID,
+		&i.AutoClosedAt,
+		&i.NeedsReview,
+	)
+	return i, err
+}
+
+const listCompanyRunningEntries = `-- name: ListCompanyRunningEntries :many
+SELECT e.id, e.user_id, e.start_time, e.end_time, e.created_at, e.updated_at, e.project_id, e.task_id, e.description, e.tags, e.billable, e.invoice_id, e.auto_closed_at, e.needs_review
+FROM entries e
+JOIN users u ON u.id = e.user_id
+WHERE u.company_id = $1::bigint AND e.end_time IS NULL
+ORDER BY e.start_time, e.id
+`
+
+func (q *Queries) ListCompanyRunningEntries(ctx context.Context, companyID int64) ([]Entry, error) {
+	rows, err := q.db.Query(ctx, listCompanyRunningEntries, companyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Entry{}
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ProjectID,
+			&i.TaskID,
+			&i.Description,
+			&i.Tags,
+			&i.Billable,
+			&i.InvoiceID,
+			&i.AutoClosedAt,
+			&i.NeedsReview,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEntries = `-- name: ListEntries :many
+SELECT e.id, e.user_id, e.start_time, e.end_time, e.created_at, e.updated_at, e.project_id, e.task_id, e.description, e.tags, e.billable, e.invoice_id, e.auto_closed_at, e.needs_review
+FROM entries e
+JOIN users u ON u.id = e.user_id
+WHERE ($1::bigint IS NULL OR e.user_id = $1)
+AND ($2::bigint IS NULL OR u.team_id = $2)
+AND ($3::bigint IS NULL OR u.company_id = $3)
+AND ($4::timestamp IS NULL OR e.start_time >= $4)
+AND ($5::timestamp IS NULL OR e.start_time < $5)
+AND ($6::bigint IS NULL OR e.project_id = $6)
+AND ($7::bigint IS NULL OR e.task_id = $7)
+AND ($8::varchar IS NULL OR $8 = ANY(e.tags))
+AND ($9::boolean IS NULL OR e.needs_review = $9)
+ORDER BY e.start_time, e.id
+LIMIT $10
+OFFSET $11
 `
 
 type ListEntriesParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	UserID      *int64     `json:"user_id"`
+	TeamID      *int64     `json:"team_id"`
+	CompanyID   *int64     `json:"company_id"`
+	From        *time.Time `json:"from"`
+	To          *time.Time `json:"to"`
+	ProjectID   *int64     `json:"project_id"`
+	TaskID      *int64     `json:"task_id"`
+	Tag         *string    `json:"tag"`
+	NeedsReview *bool      `json:"needs_review"`
+	Limit       int32      `json:"limit"`
+	Offset      int32      `json:"offset"`
 }
 
 func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error) {
-	rows, err := q.db.Query(ctx, listEntries, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listEntries,
+		arg.UserID,
+		arg.TeamID,
+		arg.CompanyID,
+		arg.From,
+		arg.To,
+		arg.ProjectID,
+		arg.TaskID,
+		arg.Tag,
+		arg.NeedsReview,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -112,6 +339,14 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 			&i.EndTime,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ProjectID,
+			&i.TaskID,
+			&i.Description,
+			&i.Tags,
+			&i.Billable,
+			&i.InvoiceID,
+			&i.AutoClosedAt,
+			&i.NeedsReview,
 		); err != nil {
 			return nil, err
 		}
@@ -124,22 +359,44 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 }
 
 const listUserEntries = `-- name: ListUserEntries :many
-SELECT id, user_id, start_time, end_time, created_at, updated_at
+SELECT id, user_id, start_time, end_time, created_at, updated_at, project_id, task_id, description, tags, billable, invoice_id, auto_closed_at, needs_review
 FROM entries
 WHERE user_id = $1
-ORDER BY id
-LIMIT $2
-OFFSET $3
+AND ($2::timestamp IS NULL OR start_time >= $2)
+AND ($3::timestamp IS NULL OR start_time < $3)
+AND ($4::bigint IS NULL OR project_id = $4)
+AND ($5::bigint IS NULL OR task_id = $5)
+AND ($6::varchar IS NULL OR $6 = ANY(tags))
+AND ($7::boolean IS NULL OR needs_review = $7)
+ORDER BY start_time, id
+LIMIT $8
+OFFSET $9
 `
 
 type ListUserEntriesParams struct {
-	UserID int64 `json:"user_id"`
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	UserID      int64      `json:"user_id"`
+	From        *time.Time `json:"from"`
+	To          *time.Time `json:"to"`
+	ProjectID   *int64     `json:"project_id"`
+	TaskID      *int64     `json:"task_id"`
+	Tag         *string    `json:"tag"`
+	NeedsReview *bool      `json:"needs_review"`
+	Limit       int32      `json:"limit"`
+	Offset      int32      `json:"offset"`
 }
 
 func (q *Queries) ListUserEntries(ctx context.Context, arg ListUserEntriesParams) ([]Entry, error) {
-	rows, err := q.db.Query(ctx, listUserEntries, arg.UserID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listUserEntries,
+		arg.UserID,
+		arg.From,
+		arg.To,
+		arg.ProjectID,
+		arg.TaskID,
+		arg.Tag,
+		arg.NeedsReview,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -154,6 +411,14 @@ func (q *Queries) ListUserEntries(ctx context.Context, arg ListUserEntriesParams
 			&i.EndTime,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ProjectID,
+			&i.TaskID,
+			&i.Description,
+			&i.Tags,
+			&i.Billable,
+			&i.InvoiceID,
+			&i.AutoClosedAt,
+			&i.NeedsReview,
 		); err != nil {
 			return nil, err
 		}
@@ -165,29 +430,79 @@ func (q *Queries) ListUserEntries(ctx context.Context, arg ListUserEntriesParams
 	return items, nil
 }
 
+const stopRunningEntry = `-- name: StopRunningEntry :one
+UPDATE entries
+SET end_time = $2
+WHERE user_id = $1 AND end_time IS NULL
+RETURNING id, user_id, start_time, end_time, created_at, updated_at, project_id, task_id, description, tags, billable, invoice_id, auto_closed_at, needs_review
+`
+
+type StopRunningEntryParams struct {
+	UserID  int64      `json:"user_id"`
+	EndTime *time.Time `json:"end_time"`
+}
+
+func (q *Queries) StopRunningEntry(ctx context.Context, arg StopRunningEntryParams) (Entry, error) {
+	row := q.db.QueryRow(ctx, stopRunningEntry, arg.UserID, arg.EndTime)
+	var i Entry
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ProjectID,
+		&i.TaskID,
+		&i.Description,
+		&i.Tags,
+		&i.Billable,
+		&i.InvoiceID,
+		&i.AutoClosedAt,
+		&i.NeedsReview,
+	)
+	return i, err
+}
+
 const updateEntry = `-- name: UpdateEntry :one
 UPDATE entries
 SET 
-user_id = $2, 
-start_time = $3, 
-end_time = $4
-WHERE id = $1
-RETURNING id, user_id, start_time, end_time, created_at, updated_at
+user_id = $1, 
+start_time = $2, 
+end_time = $3,
+project_id = $4,
+task_id = $5,
+description = $6,
+tags = COALESCE($7::varchar[], '{}'),
+billable = $8,
+needs_review = false
+WHERE id = $9
+RETURNING id, user_id, start_time, end_time, created_at, updated_at, project_id, task_id, description, tags, billable, invoice_id, auto_closed_at, needs_review
 `
 
 type UpdateEntryParams struct {
-	ID        int64      `json:"id"`
-	UserID    int64      `json:"user_id"`
-	StartTime time.Time  `json:"start_time"`
-	EndTime   *time.Time `json:"end_time"`
+	UserID      int64      `json:"user_id"`
+	StartTime   time.Time  `json:"start_time"`
+	EndTime     *time.Time `json:"end_time"`
+	ProjectID   *int64     `json:"project_id"`
+	TaskID      *int64     `json:"task_id"`
+	Description *string    `json:"description"`
+	Tags        []string   `json:"tags"`
+	Billable    bool       `json:"billable"`
+	ID          int64      `json:"id"`
 }
 
 func (q *Queries) UpdateEntry(ctx context.Context, arg UpdateEntryParams) (Entry, error) {
 	row := q.db.QueryRow(ctx, updateEntry,
-		arg.ID,
 		arg.UserID,
 		arg.StartTime,
 		arg.EndTime,
+		arg.ProjectID,
+		arg.TaskID,
+		arg.Description,
+		arg.Tags,
+		arg.Billable,
+		arg.ID,
 	)
 	var i Entry
 	err := row.Scan(
@@ -197,6 +512,14 @@ func (q *Queries) UpdateEntry(ctx context.Context, arg UpdateEntryParams) (Entry
 		&i.EndTime,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ProjectID,
+		&i.TaskID,
+		&i.Description,
+		&i.Tags,
+		&i.Billable,
+		&i.InvoiceID,
+		&i.AutoClosedAt,
+		&i.NeedsReview,
 	)
 	return i, err
 }
@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/mateoradman/tempus/internal/util"
 	"github.com/stretchr/testify/require"
 )
 
@@ -24,6 +26,12 @@ func createRandomEntry(t *testing.T) Entry {
 	require.Equal(t, arg.UserID, entry.UserID)
 	require.WithinDuration(t, entry.StartTime, arg.StartTime, 500*time.Millisecond)
 	require.Nil(t, entry.EndTime)
+	require.Nil(t, entry.ProjectID)
+	require.Nil(t, entry.TaskID)
+	require.Nil(t, entry.Description)
+	require.NotNil(t, entry.Tags)
+	require.Empty(t, entry.Tags)
+	require.False(t, entry.Billable)
 	require.WithinDuration(t, time.Now(), entry.CreatedAt, time.Second)
 	require.Nil(t, entry.UpdatedAt)
 
@@ -106,11 +114,15 @@ func TestListEntries(t *testing.T) {
 func TestListUserEntries(t *testing.T) {
 	user := createRandomUser(t, nil, nil)
 	var entries []Entry
+	today := time.Now().UTC()
 	for i := 0; i < 10; i++ {
-		today := time.Now().UTC()
+		// only one entry of a user may be running, so the listed entries are closed
+		startTime := today.Add(time.Duration(-2*(i+1)) * time.Hour)
+		endTime := startTime.Add(time.Hour)
 		arg := CreateEntryParams{
 			UserID:    user.ID,
-			StartTime: today,
+			StartTime: startTime,
+			EndTime:   &endTime,
 		}
 		entry, err := testStore.CreateEntry(context.Background(), arg)
 		require.NoError(t, err)
@@ -125,3 +137,226 @@ func TestListUserEntries(t *testing.T) {
 	require.NoError(t, err)
 	require.Subset(t, userEntries, entries)
 }
+
+func TestGetRunningEntry(t *testing.T) {
+	entry := createRandomEntry(t)
+	runningEntry, err := testStore.GetRunningEntry(context.Background(), entry.UserID)
+	require.NoError(t, err)
+	require.Equal(t, entry, runningEntry)
+
+	endTime := time.Now().UTC()
+	_, err = testStore.StopRunningEntry(context.Background(), StopRunningEntryParams{
+		UserID:  entry.UserID,
+		EndTime: &endTime,
+	})
+	require.NoError(t, err)
+
+	runningEntry, err = testStore.GetRunningEntry(context.Background(), entry.UserID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+	require.Empty(t, runningEntry)
+}
+
+func TestStopRunningEntry(t *testing.T) {
+	entry := createRandomEntry(t)
+	endTime := time.Now().UTC()
+	arg := StopRunningEntryParams{
+		UserID:  entry.UserID,
+		EndTime: &endTime,
+	}
+
+	stoppedEntry, err := testStore.StopRunningEntry(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, entry.ID, stoppedEntry.ID)
+	require.NotNil(t, stoppedEntry.EndTime)
+	require.WithinDuration(t, endTime, *stoppedEntry.EndTime, 500*time.Millisecond)
+
+	// the entry is no longer running
+	_, err = testStore.StopRunningEntry(context.Background(), arg)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestCreateSecondRunningEntry(t *testing.T) {
+	entry := createRandomEntry(t)
+	arg := CreateEntryParams{
+		UserID:    entry.UserID,
+		StartTime: time.Now().UTC(),
+	}
+
+	secondEntry, err := testStore.CreateEntry(context.Background(), arg)
+	require.Error(t, err)
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+	require.Equal(t, RunningEntryConstraint, ConstraintName(err))
+	require.Empty(t, secondEntry)
+}
+
+func createClosedEntry(t *testing.T, userID int64, startTime time.Time) Entry {
+	endTime := startTime.Add(time.Hour)
+	entry, err := testStore.CreateEntry(context.Background(), CreateEntryParams{
+		UserID:    userID,
+		StartTime: startTime,
+		EndTime:   &endTime,
+	})
+	require.NoError(t, err)
+	return entry
+}
+
+func TestCreateOverlappingEntry(t *testing.T) {
+	user := createRandomUser(t, nil, nil)
+	startTime := time.Now().UTC().Add(-24 * time.Hour)
+	entry := createClosedEntry(t, user.ID, startTime)
+
+	endTime := startTime.Add(90 * time.Minute)
+	arg := CreateEntryParams{
+		UserID:    user.ID,
+		StartTime: startTime.Add(30 * time.Minute),
+		EndTime:   &endTime,
+	}
+	_, err := testStore.CreateEntry(context.Background(), arg)
+	require.Error(t, err)
+	require.Equal(t, ExclusionViolation, ErrorCode(err))
+	require.Equal(t, EntryOverlapConstraint, ConstraintName(err))
+
+	overlapping, err := testStore.GetOverlappingEntry(context.Background(), GetOverlappingEntryParams{
+		UserID:    arg.UserID,
+		StartTime: arg.StartTime,
+		EndTime:   arg.EndTime,
+	})
+	require.NoError(t, err)
+	require.Equal(t, entry.ID, overlapping.ID)
+
+	// adjacent entries do not overlap
+	adjacent := createClosedEntry(t, user.ID, startTime.Add(time.Hour))
+	require.NotZero(t, adjacent.ID)
+
+	// entries of other users do not conflict
+	otherUser := createRandomUser(t, nil, nil)
+	other := createClosedEntry(t, otherUser.ID, startTime)
+	require.NotZero(t, other.ID)
+}
+
+func TestGetOverlappingEntryExcludesEntry(t *testing.T) {
+	user := createRandomUser(t, nil, nil)
+	entry := createClosedEntry(t, user.ID, time.Now().UTC().Add(-24*time.Hour))
+
+	_, err := testStore.GetOverlappingEntry(context.Background(), GetOverlappingEntryParams{
+		UserID:    user.ID,
+		ExcludeID: entry.ID,
+		StartTime: entry.StartTime,
+		EndTime:   entry.EndTime,
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestCreateEntryEndBeforeStart(t *testing.T) {
+	user := createRandomUser(t, nil, nil)
+	startTime := time.Now().UTC()
+	endTime := startTime.Add(-time.Hour)
+
+	_, err := testStore.CreateEntry(context.Background(), CreateEntryParams{
+		UserID:    user.ID,
+		StartTime: startTime,
+		EndTime:   &endTime,
+	})
+	require.Error(t, err)
+	require.Equal(t, CheckViolation, ErrorCode(err))
+	require.Equal(t, EntryTimeConstraint, ConstraintName(err))
+}
+
+func TestListEntriesFilters(t *testing.T) {
+	team := createRandomTeam(t)
+	user := createRandomUser(t, team.CompanyID, &team.ID)
+	otherUser := createRandomUser(t, nil, nil)
+
+	from := time.Now().UTC().Add(-10 * 24 * time.Hour)
+	inside := createClosedEntry(t, user.ID, from.Add(time.Hour))
+	createClosedEntry(t, user.ID, from.Add(-2*time.Hour))
+	createClosedEntry(t, otherUser.ID, from.Add(time.Hour))
+	to := from.Add(24 * time.Hour)
+
+	testCases := []struct {
+		name string
+		arg  ListEntriesParams
+	}{
+		{name: "User", arg: ListEntriesParams{UserID: &user.ID}},
+		{name: "Team", arg: ListEntriesParams{TeamID: &team.ID}},
+		{name: "Company", arg: ListEntriesParams{CompanyID: team.CompanyID}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.arg.From = &from
+			tc.arg.To = &to
+			tc.arg.Limit = 100
+			entries, err := testStore.ListEntries(context.Background(), tc.arg)
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+			require.Equal(t, inside.ID, entries[0].ID)
+		})
+	}
+}
+
+func TestListEntriesProjectFilters(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	project := createRandomProject(t, company.ID)
+	task := createRandomTask(t, project.ID)
+	tag := util.RandomString(10)
+	description := util.RandomString(50)
+
+	from := time.Now().UTC().Add(-10 * 24 * time.Hour)
+	endTime := from.Add(2 * time.Hour)
+	inside, err := testStore.CreateEntry(context.Background(), CreateEntryParams{
+		UserID:      user.ID,
+		StartTime:   from.Add(time.Hour),
+		EndTime:     &endTime,
+		ProjectID:   &project.ID,
+		TaskID:      &task.ID,
+		Description: &description,
+		Tags:        []string{tag, util.RandomString(10)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &project.ID, inside.ProjectID)
+	require.Equal(t, &task.ID, inside.TaskID)
+	require.Equal(t, &description, inside.Description)
+	require.Contains(t, inside.Tags, tag)
+	createClosedEntry(t, user.ID, from.Add(3*time.Hour))
+
+	testCases := []struct {
+		name string
+		arg  ListEntriesParams
+	}{
+		{name: "Project", arg: ListEntriesParams{ProjectID: &project.ID}},
+		{name: "Task", arg: ListEntriesParams{TaskID: &task.ID}},
+		{name: "Tag", arg: ListEntriesParams{Tag: &tag}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.arg.UserID = &user.ID
+			tc.arg.Limit = 100
+			entries, err := testStore.ListEntries(context.Background(), tc.arg)
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+			require.Equal(t, inside.ID, entries[0].ID)
+		})
+	}
+}
+
+func TestListUserEntriesTimeRange(t *testing.T) {
+	user := createRandomUser(t, nil, nil)
+	from := time.Now().UTC().Add(-10 * 24 * time.Hour)
+	inside := createClosedEntry(t, user.ID, from)
+	createClosedEntry(t, user.ID, from.Add(-2*time.Hour))
+	to := from.Add(time.Hour)
+	createClosedEntry(t, user.ID, to)
+
+	entries, err := testStore.ListUserEntries(context.Background(), ListUserEntriesParams{
+		UserID: user.ID,
+		From:   &from,
+		To:     &to,
+		Limit:  100,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, inside.ID, entries[0].ID)
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Postgres error codes of the constraint violations the API reports to clients.
+const (
+	ForeignKeyViolation = "23503"
+	UniqueViolation     = "23505"
+	CheckViolation      = "23514"
+	ExclusionViolation  = "23P01"
+)
+
+// Constraint names referenced when mapping violations to API errors.
+const (
+	RunningEntryConstraint      = "entries_running_user_id"
+	EntryTimeConstraint         = "entries_end_after_start"
+	EntryOverlapConstraint      = "entries_no_overlap"
+	EntryInvoicedConstraint     = "entries_invoiced_locked"
+	EntryPeriodLockedConstraint = "entries_period_locked"
+
+	AbsenceTimeConstraint    = "absences_end_after_start"
+	AbsenceOverlapConstraint = "absences_no_overlap"
+
+	HourlyRateEffectiveFromConstraint   = "hourly_rates_scope_effective_from"
+	InvoiceNumberConstraint             = "invoices_company_id_number"
+	TimesheetOverlapConstraint          = "timesheets_no_overlap"
+	WorkScheduleEffectiveFromConstraint = "work_schedule_assignments_user_id_effective_from"
+	AbsenceTypeNameConstraint           = "absence_types_company_id_name"
+	LeaveEntitlementYearConstraint      = "leave_entitlements_user_id_absence_type_id_from_year"
+	CompanyHolidayDateConstraint        = "company_holidays_company_id_date"
+)
+
+// ErrorCode returns the Postgres error code of err or an empty string if err is not a Postgres error.
+func ErrorCode(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+	return ""
+}
+
+// ConstraintName returns the name of the constraint violated by err or an empty string.
+func ConstraintName(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.ConstraintName
+	}
+	return ""
+}
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportEntriesTx(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	otherUser := createRandomUser(t, &company.ID, nil)
+	outsider := createRandomUser(t, nil, nil)
+
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	first := createClosedEntry(t, user.ID, from.Add(8*time.Hour))
+	second := createClosedEntry(t, user.ID, from.Add(32*time.Hour))
+	other := createClosedEntry(t, otherUser.ID, from.Add(8*time.Hour))
+	createClosedEntry(t, user.ID, from.AddDate(0, 1, 0))
+	createClosedEntry(t, outsider.ID, from.Add(8*time.Hour))
+
+	arg := ExportEntriesParams{
+		CompanyID: company.ID,
+		From:      from,
+		To:        from.AddDate(0, 1, 0),
+	}
+	var ids []int64
+	err := testStore.ExportEntriesTx(context.Background(), arg, func(row ExportEntriesRow) error {
+		ids = append(ids, row.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, ids, 3)
+	require.Subset(t, ids, []int64{first.ID, second.ID, other.ID})
+
+	arg.UserID = &user.ID
+	var rows []ExportEntriesRow
+	err = testStore.ExportEntriesTx(context.Background(), arg, func(row ExportEntriesRow) error {
+		rows = append(rows, row)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, first.ID, rows[0].ID)
+	require.Equal(t, user.Username, rows[0].Username)
+	require.Equal(t, first.StartTime, rows[0].StartTime)
+	require.Nil(t, rows[0].ProjectName)
+	require.Equal(t, second.ID, rows[1].ID)
+
+	// an error of the callback stops the export
+	errStop := errors.New("stop")
+	var calls int
+	err = testStore.ExportEntriesTx(context.Background(), arg, func(row ExportEntriesRow) error {
+		calls++
+		return errStop
+	})
+	require.ErrorIs(t, err, errStop)
+	require.Equal(t, 1, calls)
+}
+
+func TestExportAbsencesTx(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := from.Add(24 * time.Hour)
+	absence, err := testStore.CreateAbsence(context.Background(), CreateAbsenceParams{
+		UserID:    user.ID,
+		StartTime: from.Add(-24 * time.Hour),
+		EndTime:   &end,
+		Reason:    util.RandomString(10),
+		Paid:      true,
+		Kind:      types.AbsenceFullDay,
+	})
+	require.NoError(t, err)
+
+	arg := ExportAbsencesParams{
+		CompanyID: company.ID,
+		From:      from,
+		To:        from.AddDate(0, 1, 0),
+	}
+	var rows []ExportAbsencesRow
+	err = testStore.ExportAbsencesTx(context.Background(), arg, func(row ExportAbsencesRow) error {
+		rows = append(rows, row)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, absence.ID, rows[0].ID)
+	require.Equal(t, types.AbsenceFullDay, rows[0].Kind)
+	require.Nil(t, rows[0].AbsenceTypeName)
+
+	// the absence is not approved yet
+	status := types.AbsenceApproved
+	arg.Status = &status
+	rows = nil
+	err = testStore.ExportAbsencesTx(context.Background(), arg, func(row ExportAbsencesRow) error {
+		rows = append(rows, row)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Empty(t, rows)
+}
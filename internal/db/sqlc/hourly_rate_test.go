@@ -0,0 +1,228 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRate(t *testing.T, arg CreateHourlyRateParams) HourlyRate {
+	rate, err := testStore.CreateHourlyRate(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, rate.ID)
+	require.Equal(t, arg.CompanyID, rate.CompanyID)
+	require.Equal(t, arg.UserID, rate.UserID)
+	require.Equal(t, arg.ProjectID, rate.ProjectID)
+	require.Equal(t, arg.ClientID, rate.ClientID)
+	require.Equal(t, arg.AmountCents, rate.AmountCents)
+	require.Equal(t, arg.Currency, rate.Currency)
+	require.Equal(t, arg.EffectiveFrom, rate.EffectiveFrom)
+	require.WithinDuration(t, time.Now(), rate.CreatedAt, 2*time.Second)
+
+	return rate
+}
+
+func createBillableEntry(t *testing.T, userID int64, projectID *int64, start time.Time, duration time.Duration) {
+	end := start.Add(duration)
+	_, err := testStore.CreateEntry(context.Background(), CreateEntryParams{
+		UserID:    userID,
+		StartTime: start,
+		EndTime:   &end,
+		ProjectID: projectID,
+		Billable:  true,
+	})
+	require.NoError(t, err)
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestHourlyRate(t *testing.T) {
+	company := createRandomCompany(t)
+	rate := createRate(t, CreateHourlyRateParams{
+		CompanyID:     company.ID,
+		AmountCents:   util.RandomInt(1000, 20000),
+		Currency:      "EUR",
+		EffectiveFrom: date(2024, time.January, 1),
+	})
+
+	gotRate, err := testStore.GetHourlyRate(context.Background(), rate.ID)
+	require.NoError(t, err)
+	require.Equal(t, rate, gotRate)
+
+	rates, err := testStore.ListHourlyRates(context.Background(), ListHourlyRatesParams{
+		CompanyID: &company.ID,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []HourlyRate{rate}, rates)
+
+	deletedRate, err := testStore.DeleteHourlyRate(context.Background(), rate.ID)
+	require.NoError(t, err)
+	require.Equal(t, rate, deletedRate)
+
+	_, err = testStore.GetHourlyRate(context.Background(), rate.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestHourlyRateConstraints(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	project := createRandomProject(t, company.ID)
+	arg := CreateHourlyRateParams{
+		CompanyID:     company.ID,
+		AmountCents:   10000,
+		Currency:      "EUR",
+		EffectiveFrom: date(2024, time.January, 1),
+	}
+	createRate(t, arg)
+
+	// a second company default effective from the same day
+	_, err := testStore.CreateHourlyRate(context.Background(), arg)
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+	require.Equal(t, HourlyRateEffectiveFromConstraint, ConstraintName(err))
+
+	arg.UserID = &user.ID
+	arg.ProjectID = &project.ID
+	_, err = testStore.CreateHourlyRate(context.Background(), arg)
+	require.Equal(t, CheckViolation, ErrorCode(err))
+
+	arg.ProjectID = nil
+	arg.AmountCents = -1
+	_, err = testStore.CreateHourlyRate(context.Background(), arg)
+	require.Equal(t, CheckViolation, ErrorCode(err))
+}
+
+func TestGetBillingReport(t *testing.T) {
+	company := createRandomCompany(t)
+	employee := createRandomUser(t, &company.ID, nil)
+	contractor := createRandomUser(t, &company.ID, nil)
+	client := createRandomClient(t, company.ID)
+	clientProject, err := testStore.CreateProject(context.Background(), CreateProjectParams{
+		CompanyID: company.ID,
+		Name:      util.RandomString(20),
+		ClientID:  &client.ID,
+	})
+	require.NoError(t, err)
+	internalProject := createRandomProject(t, company.ID)
+
+	createRate(t, CreateHourlyRateParams{
+		CompanyID:     company.ID,
+		AmountCents:   5000,
+		Currency:      "EUR",
+		EffectiveFrom: date(2024, time.January, 1),
+	})
+	createRate(t, CreateHourlyRateParams{
+		CompanyID:     company.ID,
+		ClientID:      &client.ID,
+		AmountCents:   8000,
+		Currency:      "EUR",
+		EffectiveFrom: date(2024, time.January, 1),
+	})
+	createRate(t, CreateHourlyRateParams{
+		CompanyID:     company.ID,
+		ProjectID:     &clientProject.ID,
+		AmountCents:   9000,
+		Currency:      "EUR",
+		EffectiveFrom: date(2024, time.March, 15),
+	})
+	createRate(t, CreateHourlyRateParams{
+		CompanyID:     company.ID,
+		UserID:        &contractor.ID,
+		AmountCents:   12000,
+		Currency:      "EUR",
+		EffectiveFrom: date(2024, time.January, 1),
+	})
+
+	at := func(day int) time.Time {
+		return date(2024, time.March, day).Add(10 * time.Hour)
+	}
+	// the project rate is not effective yet, the client rate applies: 80.00
+	createBillableEntry(t, employee.ID, &clientProject.ID, at(1), time.Hour)
+	// the project rate applies: 30.00
+	createBillableEntry(t, employee.ID, &clientProject.ID, at(20), 20*time.Minute)
+	// the rate of the user wins over the project rate: 2.00
+	createBillableEntry(t, contractor.ID, &clientProject.ID, at(21), time.Minute)
+	// the company default applies: 8.333... twice, rounded once to 16.67
+	createBillableEntry(t, employee.ID, &internalProject.ID, at(5), 10*time.Minute)
+	createBillableEntry(t, employee.ID, &internalProject.ID, at(6), 10*time.Minute)
+	// neither non billable entries nor entries outside of the range are reported
+	end := at(7).Add(time.Hour)
+	_, err = testStore.CreateEntry(context.Background(), CreateEntryParams{
+		UserID:    employee.ID,
+		StartTime: at(7),
+		EndTime:   &end,
+		ProjectID: &clientProject.ID,
+	})
+	require.NoError(t, err)
+	createBillableEntry(t, employee.ID, &clientProject.ID, date(2024, time.April, 1).Add(time.Hour), time.Hour)
+
+	arg := GetBillingReportParams{
+		CompanyID: company.ID,
+		From:      date(2024, time.March, 1),
+		To:        date(2024, time.April, 1),
+	}
+	lines, err := testStore.GetBillingReport(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, []GetBillingReportRow{
+		{
+			ClientID:        &client.ID,
+			ProjectID:       &clientProject.ID,
+			Currency:        util.Pointer("EUR"),
+			Entries:         3,
+			BillableSeconds: 3600 + 1200 + 60,
+			AmountCents:     util.Pointer(int64(8000 + 3000 + 200)),
+		},
+		{
+			ClientID:        nil,
+			ProjectID:       &internalProject.ID,
+			Currency:        util.Pointer("EUR"),
+			Entries:         2,
+			BillableSeconds: 1200,
+			AmountCents:     util.Pointer(int64(1667)),
+		},
+	}, lines)
+
+	arg.ClientID = &client.ID
+	lines, err = testStore.GetBillingReport(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, lines, 1)
+	require.Equal(t, &clientProject.ID, lines[0].ProjectID)
+}
+
+func TestGetBillingReportLocalDay(t *testing.T) {
+	company := createRandomCompany(t)
+	// users are created in Europe/Zagreb, an hour ahead of UTC in March
+	employee := createRandomUser(t, &company.ID, nil)
+	project := createRandomProject(t, company.ID)
+
+	createRate(t, CreateHourlyRateParams{
+		CompanyID:     company.ID,
+		AmountCents:   5000,
+		Currency:      "EUR",
+		EffectiveFrom: date(2024, time.January, 1),
+	})
+	createRate(t, CreateHourlyRateParams{
+		CompanyID:     company.ID,
+		AmountCents:   9000,
+		Currency:      "EUR",
+		EffectiveFrom: date(2024, time.March, 2),
+	})
+
+	// starts on March 1 in UTC but on March 2 for the user, so the new rate applies
+	createBillableEntry(t, employee.ID, &project.ID, date(2024, time.March, 1).Add(23*time.Hour+30*time.Minute), time.Hour)
+
+	lines, err := testStore.GetBillingReport(context.Background(), GetBillingReportParams{
+		CompanyID: company.ID,
+		From:      date(2024, time.March, 1),
+		To:        date(2024, time.April, 1),
+	})
+	require.NoError(t, err)
+	require.Len(t, lines, 1)
+	require.Equal(t, util.Pointer(int64(9000)), lines[0].AmountCents)
+}
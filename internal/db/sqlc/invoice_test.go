@@ -0,0 +1,238 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// invoiceFixture is a company with a client project whose billable entries of March 2024 can be invoiced.
+type invoiceFixture struct {
+	company Company
+	client  Client
+	project Project
+	task    Task
+	entries []Entry
+}
+
+func createInvoiceFixture(t *testing.T) invoiceFixture {
+	var fixture invoiceFixture
+	fixture.company = createRandomCompany(t)
+	user := createRandomUser(t, &fixture.company.ID, nil)
+	fixture.client = createRandomClient(t, fixture.company.ID)
+	project, err := testStore.CreateProject(context.Background(), CreateProjectParams{
+		CompanyID: fixture.company.ID,
+		Name:      util.RandomString(20),
+		ClientID:  &fixture.client.ID,
+	})
+	require.NoError(t, err)
+	fixture.project = project
+	fixture.task = createRandomTask(t, project.ID)
+
+	createRate(t, CreateHourlyRateParams{
+		CompanyID:     fixture.company.ID,
+		ClientID:      &fixture.client.ID,
+		AmountCents:   10000,
+		Currency:      "EUR",
+		EffectiveFrom: date(2024, time.January, 1),
+	})
+
+	entry := func(day int, duration time.Duration, taskID *int64) Entry {
+		start := date(2024, time.March, day).Add(9 * time.Hour)
+		end := start.Add(duration)
+		entry, err := testStore.CreateEntry(context.Background(), CreateEntryParams{
+			UserID:    user.ID,
+			StartTime: start,
+			EndTime:   &end,
+			ProjectID: &project.ID,
+			TaskID:    taskID,
+			Billable:  true,
+		})
+		require.NoError(t, err)
+		return entry
+	}
+	fixture.entries = []Entry{
+		entry(4, time.Hour, nil),
+		entry(5, 30*time.Minute, nil),
+		entry(6, 20*time.Minute, &fixture.task.ID),
+	}
+	return fixture
+}
+
+func draftFixtureInvoice(t *testing.T, fixture invoiceFixture) DraftInvoiceTxResult {
+	result, err := testStore.DraftInvoiceTx(context.Background(), DraftInvoiceTxParams{
+		CompanyID: fixture.company.ID,
+		ClientID:  fixture.client.ID,
+		From:      date(2024, time.March, 1),
+		To:        date(2024, time.April, 1),
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func TestDraftInvoiceTx(t *testing.T) {
+	fixture := createInvoiceFixture(t)
+	result := draftFixtureInvoice(t, fixture)
+
+	invoice := result.Invoice
+	require.NotZero(t, invoice.ID)
+	require.Equal(t, fixture.company.ID, invoice.CompanyID)
+	require.Equal(t, fixture.client.ID, invoice.ClientID)
+	require.Equal(t, "draft", invoice.Status)
+	require.Nil(t, invoice.Number)
+	require.Equal(t, "EUR", invoice.Currency)
+	// 1.5 hours and a third of an hour at 100.00
+	require.Equal(t, int64(15000+3333), invoice.TotalCents)
+
+	require.Len(t, result.Lines, 2)
+	require.Equal(t, fixture.project.Name, result.Lines[0].Description)
+	require.Nil(t, result.Lines[0].TaskID)
+	require.Equal(t, int64(5400), result.Lines[0].QuantitySeconds)
+	require.Equal(t, int64(10000), result.Lines[0].RateCents)
+	require.Equal(t, int64(15000), result.Lines[0].AmountCents)
+	require.Equal(t, fmt.Sprintf("%s: %s", fixture.project.Name, fixture.task.Name), result.Lines[1].Description)
+	require.Equal(t, &fixture.task.ID, result.Lines[1].TaskID)
+	require.Equal(t, int64(1200), result.Lines[1].QuantitySeconds)
+	require.Equal(t, int64(3333), result.Lines[1].AmountCents)
+
+	lines, err := testStore.ListInvoiceLines(context.Background(), invoice.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.Lines, lines)
+
+	for _, entry := range fixture.entries {
+		gotEntry, err := testStore.GetEntry(context.Background(), entry.ID)
+		require.NoError(t, err)
+		require.Equal(t, &invoice.ID, gotEntry.InvoiceID)
+	}
+
+	// invoiced entries are not invoiced twice
+	_, err = testStore.DraftInvoiceTx(context.Background(), DraftInvoiceTxParams{
+		CompanyID: fixture.company.ID,
+		ClientID:  fixture.client.ID,
+		From:      date(2024, time.March, 1),
+		To:        date(2024, time.April, 1),
+	})
+	require.ErrorIs(t, err, ErrNoInvoiceableEntries)
+}
+
+func TestDraftInvoiceTxUnpricedEntries(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	client := createRandomClient(t, company.ID)
+	project, err := testStore.CreateProject(context.Background(), CreateProjectParams{
+		CompanyID: company.ID,
+		Name:      util.RandomString(20),
+		ClientID:  &client.ID,
+	})
+	require.NoError(t, err)
+	createBillableEntry(t, user.ID, &project.ID, date(2024, time.March, 4), time.Hour)
+
+	_, err = testStore.DraftInvoiceTx(context.Background(), DraftInvoiceTxParams{
+		CompanyID: company.ID,
+		ClientID:  client.ID,
+		From:      date(2024, time.March, 1),
+		To:        date(2024, time.April, 1),
+	})
+	require.ErrorIs(t, err, ErrUnpricedEntries)
+
+	invoices, err := testStore.ListInvoices(context.Background(), ListInvoicesParams{CompanyID: &company.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, invoices)
+}
+
+func TestInvoicedEntryLocked(t *testing.T) {
+	fixture := createInvoiceFixture(t)
+	draftFixtureInvoice(t, fixture)
+	entry := fixture.entries[0]
+
+	_, err := testStore.UpdateEntry(context.Background(), UpdateEntryParams{
+		ID:        entry.ID,
+		UserID:    entry.UserID,
+		StartTime: entry.StartTime,
+		EndTime:   util.Pointer(entry.EndTime.Add(time.Hour)),
+		ProjectID: entry.ProjectID,
+		Billable:  true,
+	})
+	require.Error(t, err)
+	require.Equal(t, CheckViolation, ErrorCode(err))
+	require.Equal(t, EntryInvoicedConstraint, ConstraintName(err))
+
+	_, err = testStore.DeleteEntry(context.Background(), entry.ID)
+	require.Equal(t, EntryInvoicedConstraint, ConstraintName(err))
+}
+
+func TestIssueInvoiceTx(t *testing.T) {
+	fixture := createInvoiceFixture(t)
+	draft := draftFixtureInvoice(t, fixture).Invoice
+
+	_, err := testStore.UpsertInvoiceSequence(context.Background(), UpsertInvoiceSequenceParams{
+		CompanyID:  fixture.company.ID,
+		Prefix:     "ACME-",
+		LastNumber: 41,
+	})
+	require.NoError(t, err)
+
+	invoice, err := testStore.IssueInvoiceTx(context.Background(), draft.ID)
+	require.NoError(t, err)
+	require.Equal(t, "issued", invoice.Status)
+	require.Equal(t, util.Pointer("ACME-00042"), invoice.Number)
+	require.NotNil(t, invoice.IssuedAt)
+
+	// a failed issue does not consume a number
+	_, err = testStore.IssueInvoiceTx(context.Background(), draft.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+	sequence, err := testStore.GetInvoiceSequence(context.Background(), fixture.company.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), sequence.LastNumber)
+
+	paid, err := testStore.PayInvoice(context.Background(), invoice.ID)
+	require.NoError(t, err)
+	require.Equal(t, "paid", paid.Status)
+	require.NotNil(t, paid.PaidAt)
+
+	_, err = testStore.DeleteInvoice(context.Background(), invoice.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestVoidInvoiceTx(t *testing.T) {
+	fixture := createInvoiceFixture(t)
+	draft := draftFixtureInvoice(t, fixture).Invoice
+
+	_, err := testStore.VoidInvoiceTx(context.Background(), draft.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	issued, err := testStore.IssueInvoiceTx(context.Background(), draft.ID)
+	require.NoError(t, err)
+
+	voided, err := testStore.VoidInvoiceTx(context.Background(), issued.ID)
+	require.NoError(t, err)
+	require.Equal(t, "void", voided.Status)
+	require.Equal(t, issued.Number, voided.Number)
+	require.NotNil(t, voided.VoidedAt)
+
+	// the entries of a void invoice can be invoiced again
+	redraft := draftFixtureInvoice(t, fixture)
+	require.Equal(t, issued.TotalCents, redraft.Invoice.TotalCents)
+}
+
+func TestDeleteDraftInvoice(t *testing.T) {
+	fixture := createInvoiceFixture(t)
+	draft := draftFixtureInvoice(t, fixture).Invoice
+
+	deleted, err := testStore.DeleteInvoice(context.Background(), draft.ID)
+	require.NoError(t, err)
+	require.Equal(t, draft.ID, deleted.ID)
+
+	for _, entry := range fixture.entries {
+		gotEntry, err := testStore.GetEntry(context.Background(), entry.ID)
+		require.NoError(t, err)
+		require.Nil(t, gotEntry.InvoiceID)
+	}
+	_, err = testStore.ListInvoiceLines(context.Background(), draft.ID)
+	require.NoError(t, err)
+}
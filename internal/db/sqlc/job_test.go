@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomJob(t *testing.T, kind string, runAt time.Time) Job {
+	arg := CreateJobParams{
+		Kind:        kind,
+		Payload:     []byte(`{"id": 42}`),
+		RunAt:       runAt,
+		MaxAttempts: 3,
+	}
+	job, err := testStore.CreateJob(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Kind, job.Kind)
+	require.JSONEq(t, string(arg.Payload), string(job.Payload))
+	require.Equal(t, types.JobPending, job.Status)
+	require.Zero(t, job.Attempts)
+	return job
+}
+
+func claimTestJobs(t *testing.T, kind string, lockedUntil time.Time) []Job {
+	jobs, err := testStore.ClaimJobs(context.Background(), ClaimJobsParams{
+		Kinds:       []string{kind},
+		LockedUntil: &lockedUntil,
+		Limit:       10,
+	})
+	require.NoError(t, err)
+	return jobs
+}
+
+func TestClaimJobs(t *testing.T) {
+	kind := util.RandomString(10)
+	due := createRandomJob(t, kind, time.Now().UTC().Add(-time.Minute))
+	createRandomJob(t, kind, time.Now().UTC().Add(time.Hour))
+	createRandomJob(t, util.RandomString(10), time.Now().UTC().Add(-time.Minute))
+
+	// only due jobs of the kinds are claimed, and only once
+	claimed := claimTestJobs(t, kind, time.Now().UTC().Add(time.Minute))
+	require.Len(t, claimed, 1)
+	require.Equal(t, due.ID, claimed[0].ID)
+	require.Equal(t, types.JobRunning, claimed[0].Status)
+	require.Equal(t, int32(1), claimed[0].Attempts)
+	require.Empty(t, claimTestJobs(t, kind, time.Now().UTC().Add(time.Minute)))
+
+	// jobs whose lease is over are claimed again
+	_, err := testStore.RetryJob(context.Background(), RetryJobParams{
+		ID:        due.ID,
+		RunAt:     time.Now().UTC().Add(-time.Second),
+		LastError: util.Pointer("failure"),
+	})
+	require.NoError(t, err)
+	claimed = claimTestJobs(t, kind, time.Now().UTC().Add(-time.Second))
+	require.Len(t, claimed, 1)
+	claimed = claimTestJobs(t, kind, time.Now().UTC().Add(time.Minute))
+	require.Len(t, claimed, 1)
+	require.Equal(t, int32(3), claimed[0].Attempts)
+
+	completed, err := testStore.CompleteJob(context.Background(), due.ID)
+	require.NoError(t, err)
+	require.Equal(t, types.JobSucceeded, completed.Status)
+	require.Nil(t, completed.LastError)
+	require.NotNil(t, completed.FinishedAt)
+
+	n, err := testStore.DeleteSucceededJobs(context.Background(), time.Now().UTC().Add(time.Minute))
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, n, int64(1))
+}
+
+func TestDeadLetterJob(t *testing.T) {
+	kind := util.RandomString(10)
+	job := createRandomJob(t, kind, time.Now().UTC().Add(-time.Minute))
+	require.Len(t, claimTestJobs(t, kind, time.Now().UTC().Add(time.Minute)), 1)
+
+	dead, err := testStore.DeadLetterJob(context.Background(), DeadLetterJobParams{
+		ID:        job.ID,
+		LastError: util.Pointer("failure"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, types.JobDead, dead.Status)
+	require.Equal(t, "failure", *dead.LastError)
+	require.Empty(t, claimTestJobs(t, kind, time.Now().UTC().Add(time.Minute)))
+
+	jobs, err := testStore.ListJobs(context.Background(), ListJobsParams{
+		Status: util.Pointer(types.JobDead),
+		Kind:   &kind,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+
+	requeued, err := testStore.RequeueDeadJob(context.Background(), job.ID)
+	require.NoError(t, err)
+	require.Equal(t, types.JobPending, requeued.Status)
+	require.Zero(t, requeued.Attempts)
+	require.Len(t, claimTestJobs(t, kind, time.Now().UTC().Add(time.Minute)), 1)
+}
+
+func TestCreateUniqueJob(t *testing.T) {
+	arg := CreateUniqueJobParams{
+		Kind:        util.RandomString(10),
+		Payload:     []byte(`{}`),
+		RunAt:       time.Now().UTC(),
+		MaxAttempts: 1,
+		UniqueKey:   util.Pointer(util.RandomString(20)),
+	}
+	n, err := testStore.CreateUniqueJob(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	n, err = testStore.CreateUniqueJob(context.Background(), arg)
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
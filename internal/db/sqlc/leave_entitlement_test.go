@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaveEntitlements(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	vacation := createRandomAbsenceType(t, company.ID, true)
+
+	arg := CreateLeaveEntitlementParams{
+		UserID:        user.ID,
+		AbsenceTypeID: vacation.ID,
+		FromYear:      2024,
+		Days:          25,
+	}
+	entitlement, err := testStore.CreateLeaveEntitlement(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.UserID, entitlement.UserID)
+	require.Equal(t, arg.AbsenceTypeID, entitlement.AbsenceTypeID)
+	require.Equal(t, arg.FromYear, entitlement.FromYear)
+	require.Equal(t, arg.Days, entitlement.Days)
+
+	_, err = testStore.CreateLeaveEntitlement(context.Background(), arg)
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+	require.Equal(t, LeaveEntitlementYearConstraint, ConstraintName(err))
+
+	arg.FromYear = 2022
+	arg.Days = -1
+	_, err = testStore.CreateLeaveEntitlement(context.Background(), arg)
+	require.Equal(t, CheckViolation, ErrorCode(err))
+
+	arg.Days = 20
+	earlier, err := testStore.CreateLeaveEntitlement(context.Background(), arg)
+	require.NoError(t, err)
+
+	entitlements, err := testStore.ListUserLeaveEntitlements(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, entitlements, 2)
+	require.Equal(t, earlier.ID, entitlements[0].ID)
+	require.Equal(t, entitlement.ID, entitlements[1].ID)
+
+	// entitlements are deleted together with their absence type
+	_, err = testStore.DeleteAbsenceType(context.Background(), vacation.ID)
+	require.NoError(t, err)
+	_, err = testStore.GetLeaveEntitlement(context.Background(), entitlement.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestListUserLeave(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	manager := createRandomUser(t, &company.ID, nil)
+	vacation := createRandomAbsenceType(t, company.ID, true)
+	sickLeave := createRandomAbsenceType(t, company.ID, false)
+
+	createLeave := func(absenceTypeID *int64, start time.Time) Absence {
+		end := start.Add(24 * time.Hour)
+		absence, err := testStore.CreateAbsence(context.Background(), CreateAbsenceParams{
+			UserID:        user.ID,
+			StartTime:     start,
+			EndTime:       &end,
+			Reason:        util.RandomString(10),
+			Paid:          true,
+			AbsenceTypeID: absenceTypeID,
+			Kind:          types.AbsenceHours,
+		})
+		require.NoError(t, err)
+		return absence
+	}
+
+	later := createLeave(&vacation.ID, date(2024, time.May, 6))
+	pending := createLeave(&vacation.ID, date(2024, time.March, 4))
+	rejected := createLeave(&vacation.ID, date(2024, time.April, 1))
+	createLeave(&sickLeave.ID, date(2024, time.March, 5))
+	createLeave(nil, date(2024, time.March, 6))
+
+	_, err := testStore.DecideAbsence(context.Background(), DecideAbsenceParams{
+		ID:            later.ID,
+		Status:        types.AbsenceApproved,
+		ApprovedByID:  &manager.ID,
+		CurrentStatus: types.AbsencePending,
+	})
+	require.NoError(t, err)
+	_, err = testStore.DecideAbsence(context.Background(), DecideAbsenceParams{
+		ID:            rejected.ID,
+		Status:        types.AbsenceRejected,
+		ApprovedByID:  &manager.ID,
+		CurrentStatus: types.AbsencePending,
+	})
+	require.NoError(t, err)
+
+	absences, err := testStore.ListUserLeave(context.Background(), ListUserLeaveParams{
+		UserID:        user.ID,
+		AbsenceTypeID: vacation.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, absences, 2)
+	require.Equal(t, pending.ID, absences[0].ID)
+	require.Equal(t, later.ID, absences[1].ID)
+	require.Equal(t, types.AbsenceApproved, absences[1].Status)
+}
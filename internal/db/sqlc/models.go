@@ -11,31 +11,219 @@ import (
 )
 
 type Absence struct {
-	ID           int64      `json:"id"`
-	UserID       int64      `json:"user_id"`
-	StartTime    time.Time  `json:"start_time"`
-	EndTime      *time.Time `json:"end_time"`
-	Reason       string     `json:"reason"`
-	Paid         bool       `json:"paid"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    *time.Time `json:"updated_at"`
-	ApprovedByID *int64     `json:"approved_by_id"`
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"user_id"`
+	StartTime time.Time  `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+	Reason    string     `json:"reason"`
+	Paid      bool       `json:"paid"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+	// User who approved or rejected the absence
+	ApprovedByID    *int64     `json:"approved_by_id"`
+	Status          string     `json:"status"`
+	DecidedAt       *time.Time `json:"decided_at"`
+	DecisionComment *string    `json:"decision_comment"`
+	AbsenceTypeID   *int64     `json:"absence_type_id"`
+	// full_day, morning, afternoon or hours
+	Kind string `json:"kind"`
 }
 
-type Company struct {
+type AbsenceType struct {
+	ID                    int64      `json:"id"`
+	CompanyID             int64      `json:"company_id"`
+	Name                  string     `json:"name"`
+	Paid                  bool       `json:"paid"`
+	TracksBalance         bool       `json:"tracks_balance"`
+	Accrual               string     `json:"accrual"`
+	CarryOverMaxDays      int32      `json:"carry_over_max_days"`
+	CarryOverExpiryMonths *int32     `json:"carry_over_expiry_months"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             *time.Time `json:"updated_at"`
+}
+
+type CalendarFeed struct {
+	ID int64 `json:"id"`
+	// Hex encoded SHA-256 hash of the secret token
+	TokenHash string    `json:"token_hash"`
+	UserID    *int64    `json:"user_id"`
+	TeamID    *int64    `json:"team_id"`
+	CreatedAt time.Time `json:"created_at"`
+	// Time the token stopped being accepted
+	RevokedAt *time.Time `json:"revoked_at"`
+}
+
+type Client struct {
 	ID        int64      `json:"id"`
+	CompanyID int64      `json:"company_id"`
 	Name      string     `json:"name"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt *time.Time `json:"updated_at"`
 }
 
+type ClockPolicy struct {
+	CompanyID int64 `json:"company_id"`
+	// Running timers are stopped once they ran for this long
+	AutoCloseAfterMinutes *int32 `json:"auto_close_after_minutes"`
+	// Running timers are stopped at this minute of the day
+	AutoCloseAtMinute *int32 `json:"auto_close_at_minute"`
+	// Users without entries on a working day are reminded at this minute of the day
+	ReminderAtMinute *int32     `json:"reminder_at_minute"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at"`
+}
+
+type Company struct {
+	ID                    int64      `json:"id"`
+	Name                  string     `json:"name"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             *time.Time `json:"updated_at"`
+	DefaultWorkScheduleID *int64     `json:"default_work_schedule_id"`
+}
+
+type CompanyHoliday struct {
+	ID        int64     `json:"id"`
+	CompanyID int64     `json:"company_id"`
+	Date      time.Time `json:"date"`
+	Name      string    `json:"name"`
+	DayOff    bool      `json:"day_off"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type Entry struct {
+	ID          int64      `json:"id"`
+	UserID      int64      `json:"user_id"`
+	StartTime   time.Time  `json:"start_time"`
+	EndTime     *time.Time `json:"end_time"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+	ProjectID   *int64     `json:"project_id"`
+	TaskID      *int64     `json:"task_id"`
+	Description *string    `json:"description"`
+	Tags        []string   `json:"tags"`
+	Billable    bool       `json:"billable"`
+	InvoiceID   *int64     `json:"invoice_id"`
+	// Time the timer of the entry was stopped by the clock policy of the company
+	AutoClosedAt *time.Time `json:"auto_closed_at"`
+	// Set when the timer is stopped by the clock policy, cleared when the entry is updated
+	NeedsReview bool `json:"needs_review"`
+}
+
+type EntryConflict struct {
 	ID        int64      `json:"id"`
 	UserID    int64      `json:"user_id"`
 	StartTime time.Time  `json:"start_time"`
 	EndTime   *time.Time `json:"end_time"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt *time.Time `json:"updated_at"`
+	// end_not_after_start or overlap
+	Reason  string    `json:"reason"`
+	MovedAt time.Time `json:"moved_at"`
+}
+
+type HourlyRate struct {
+	ID            int64     `json:"id"`
+	CompanyID     int64     `json:"company_id"`
+	UserID        *int64    `json:"user_id"`
+	ProjectID     *int64    `json:"project_id"`
+	ClientID      *int64    `json:"client_id"`
+	AmountCents   int64     `json:"amount_cents"`
+	Currency      string    `json:"currency"`
+	EffectiveFrom time.Time `json:"effective_from"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type Invoice struct {
+	ID         int64      `json:"id"`
+	CompanyID  int64      `json:"company_id"`
+	ClientID   int64      `json:"client_id"`
+	Number     *string    `json:"number"`
+	Status     string     `json:"status"`
+	Currency   string     `json:"currency"`
+	PeriodFrom time.Time  `json:"period_from"`
+	PeriodTo   time.Time  `json:"period_to"`
+	TotalCents int64      `json:"total_cents"`
+	IssuedAt   *time.Time `json:"issued_at"`
+	PaidAt     *time.Time `json:"paid_at"`
+	VoidedAt   *time.Time `json:"voided_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  *time.Time `json:"updated_at"`
+}
+
+type InvoiceLine struct {
+	ID              int64  `json:"id"`
+	InvoiceID       int64  `json:"invoice_id"`
+	ProjectID       *int64 `json:"project_id"`
+	TaskID          *int64 `json:"task_id"`
+	Description     string `json:"description"`
+	QuantitySeconds int64  `json:"quantity_seconds"`
+	RateCents       int64  `json:"rate_cents"`
+	AmountCents     int64  `json:"amount_cents"`
+}
+
+type InvoiceSequence struct {
+	CompanyID  int64      `json:"company_id"`
+	Prefix     string     `json:"prefix"`
+	LastNumber int64      `json:"last_number"`
+	UpdatedAt  *time.Time `json:"updated_at"`
+}
+
+type Job struct {
+	ID      int64  `json:"id"`
+	Kind    string `json:"kind"`
+	Payload []byte `json:"payload"`
+	// pending, running, succeeded or dead
+	Status      string `json:"status"`
+	Attempts    int32  `json:"attempts"`
+	MaxAttempts int32  `json:"max_attempts"`
+	// Time a pending job is run at
+	RunAt time.Time `json:"run_at"`
+	// Time a running job is given up on and run again
+	LockedUntil *time.Time `json:"locked_until"`
+	LastError   *string    `json:"last_error"`
+	// Key of jobs which are enqueued at most once, such as the runs of a schedule
+	UniqueKey  *string    `json:"unique_key"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+}
+
+type LeaveEntitlement struct {
+	ID            int64     `json:"id"`
+	UserID        int64     `json:"user_id"`
+	AbsenceTypeID int64     `json:"absence_type_id"`
+	FromYear      int32     `json:"from_year"`
+	Days          int32     `json:"days"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type Notification struct {
+	ID     int64  `json:"id"`
+	UserID int64  `json:"user_id"`
+	Kind   string `json:"kind"`
+	Data   []byte `json:"data"`
+	// Key of notifications which are sent at most once, such as the reminder of a day
+	DedupeKey *string    `json:"dedupe_key"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type Project struct {
+	ID        int64      `json:"id"`
+	CompanyID int64      `json:"company_id"`
+	Name      string     `json:"name"`
+	Archived  bool       `json:"archived"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+	ClientID  *int64     `json:"client_id"`
+}
+
+type PublicHoliday struct {
+	ID          int64     `json:"id"`
+	Country     string    `json:"country"`
+	Subdivision *string   `json:"subdivision"`
+	Date        time.Time `json:"date"`
+	Name        string    `json:"name"`
+	CreatedAt   time.Time `json:"created_at"`
 }
 
 type Session struct {
@@ -47,6 +235,20 @@ type Session struct {
 	IsBlocked    bool      `json:"is_blocked"`
 	ExpiresAt    time.Time `json:"expires_at"`
 	CreatedAt    time.Time `json:"created_at"`
+	// First session of the refresh token rotation chain
+	FamilyID uuid.UUID `json:"family_id"`
+	// Session whose refresh token was exchanged for this one
+	ParentID *uuid.UUID `json:"parent_id"`
+	// Time the refresh token was exchanged for a new one
+	RotatedAt *time.Time `json:"rotated_at"`
+}
+
+type Task struct {
+	ID        int64      `json:"id"`
+	ProjectID int64      `json:"project_id"`
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
 }
 
 type Team struct {
@@ -55,6 +257,35 @@ type Team struct {
 	ManagerID *int64     `json:"manager_id"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt *time.Time `json:"updated_at"`
+	CompanyID *int64     `json:"company_id"`
+}
+
+type Timesheet struct {
+	ID              int64      `json:"id"`
+	UserID          int64      `json:"user_id"`
+	Period          string     `json:"period"`
+	PeriodStart     time.Time  `json:"period_start"`
+	PeriodEnd       time.Time  `json:"period_end"`
+	Status          string     `json:"status"`
+	SubmittedAt     *time.Time `json:"submitted_at"`
+	DecidedByID     *int64     `json:"decided_by_id"`
+	DecidedAt       *time.Time `json:"decided_at"`
+	DecisionComment *string    `json:"decision_comment"`
+	ReopenedByID    *int64     `json:"reopened_by_id"`
+	ReopenedAt      *time.Time `json:"reopened_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       *time.Time `json:"updated_at"`
+}
+
+type TimesheetDocument struct {
+	ID          int64  `json:"id"`
+	TimesheetID int64  `json:"timesheet_id"`
+	Language    string `json:"language"`
+	// PDF rendered on approval, printed with signature lines for the employee and the approver
+	Content []byte `json:"content"`
+	// Hex encoded SHA-256 hash of the content
+	Sha256    string    `json:"sha256"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type User struct {
@@ -78,4 +309,67 @@ type User struct {
 	ManagerID *int64 `json:"manager_id"`
 	TeamID    *int64 `json:"team_id"`
 	Role      string `json:"role"`
+	// ISO 3166-2 subdivision code within the country
+	Subdivision *string `json:"subdivision"`
+}
+
+type WebhookDelivery struct {
+	ID             int64 `json:"id"`
+	SubscriptionID int64 `json:"subscription_id"`
+	EventID        int64 `json:"event_id"`
+	// pending, succeeded or failed
+	Status   string `json:"status"`
+	Attempts int32  `json:"attempts"`
+	// Time a pending delivery is attempted, or the attempt in progress times out
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at"`
+	ResponseStatus *int32     `json:"response_status"`
+	// Beginning of the response body of the last attempt
+	ResponseBody *string   `json:"response_body"`
+	Error        *string   `json:"error"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type WebhookEvent struct {
+	ID        int64     `json:"id"`
+	CompanyID int64     `json:"company_id"`
+	Type      string    `json:"type"`
+	Payload   []byte    `json:"payload"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WebhookSubscription struct {
+	ID        int64  `json:"id"`
+	CompanyID int64  `json:"company_id"`
+	Url       string `json:"url"`
+	// Key of the HMAC-SHA256 signature of the deliveries
+	Secret    string     `json:"secret"`
+	Events    []string   `json:"events"`
+	Active    bool       `json:"active"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+type WorkSchedule struct {
+	ID               int64      `json:"id"`
+	CompanyID        int64      `json:"company_id"`
+	Name             string     `json:"name"`
+	MondayMinutes    int32      `json:"monday_minutes"`
+	TuesdayMinutes   int32      `json:"tuesday_minutes"`
+	WednesdayMinutes int32      `json:"wednesday_minutes"`
+	ThursdayMinutes  int32      `json:"thursday_minutes"`
+	FridayMinutes    int32      `json:"friday_minutes"`
+	SaturdayMinutes  int32      `json:"saturday_minutes"`
+	SundayMinutes    int32      `json:"sunday_minutes"`
+	PartTimePercent  int32      `json:"part_time_percent"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at"`
+}
+
+type WorkScheduleAssignment struct {
+	ID             int64     `json:"id"`
+	UserID         int64     `json:"user_id"`
+	WorkScheduleID int64     `json:"work_schedule_id"`
+	EffectiveFrom  time.Time `json:"effective_from"`
+	CreatedAt      time.Time `json:"created_at"`
 }
@@ -0,0 +1,120 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomProject(t *testing.T, companyID int64) Project {
+	arg := CreateProjectParams{
+		CompanyID: companyID,
+		Name:      util.RandomString(20),
+	}
+
+	project, err := testStore.CreateProject(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, project)
+	require.NotZero(t, project.ID)
+	require.Equal(t, arg.CompanyID, project.CompanyID)
+	require.Equal(t, arg.Name, project.Name)
+	require.False(t, project.Archived)
+	require.Nil(t, project.ClientID)
+	require.WithinDuration(t, time.Now(), project.CreatedAt, 2*time.Second)
+	require.Nil(t, project.UpdatedAt)
+
+	return project
+}
+
+func TestCreateProject(t *testing.T) {
+	company := createRandomCompany(t)
+	createRandomProject(t, company.ID)
+}
+
+func TestGetProject(t *testing.T) {
+	company := createRandomCompany(t)
+	project := createRandomProject(t, company.ID)
+	gotProject, err := testStore.GetProject(context.Background(), project.ID)
+	require.NoError(t, err)
+	require.Equal(t, project, gotProject)
+}
+
+func TestUpdateProject(t *testing.T) {
+	company := createRandomCompany(t)
+	project := createRandomProject(t, company.ID)
+	arg := UpdateProjectParams{
+		ID:       project.ID,
+		Name:     util.RandomString(20),
+		Archived: true,
+	}
+
+	updatedProject, err := testStore.UpdateProject(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, project.ID, updatedProject.ID)
+	require.Equal(t, arg.Name, updatedProject.Name)
+	require.True(t, updatedProject.Archived)
+	require.NotNil(t, updatedProject.UpdatedAt)
+	require.WithinDuration(t, time.Now(), *updatedProject.UpdatedAt, time.Second)
+	require.Equal(t, project.CreatedAt, updatedProject.CreatedAt)
+}
+
+func TestDeleteProject(t *testing.T) {
+	company := createRandomCompany(t)
+	project := createRandomProject(t, company.ID)
+	deletedProject, err := testStore.DeleteProject(context.Background(), project.ID)
+	require.NoError(t, err)
+	require.Equal(t, project, deletedProject)
+
+	_, err = testStore.GetProject(context.Background(), project.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestDeleteProjectWithEntries(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	project := createRandomProject(t, company.ID)
+	_, err := testStore.CreateEntry(context.Background(), CreateEntryParams{
+		UserID:    user.ID,
+		StartTime: time.Now().UTC(),
+		ProjectID: &project.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.DeleteProject(context.Background(), project.ID)
+	require.Error(t, err)
+	require.Equal(t, ForeignKeyViolation, ErrorCode(err))
+}
+
+func TestListProjects(t *testing.T) {
+	company := createRandomCompany(t)
+	active := createRandomProject(t, company.ID)
+	archived := createRandomProject(t, company.ID)
+	_, err := testStore.UpdateProject(context.Background(), UpdateProjectParams{
+		ID:       archived.ID,
+		Name:     archived.Name,
+		Archived: true,
+	})
+	require.NoError(t, err)
+	otherCompany := createRandomCompany(t)
+	createRandomProject(t, otherCompany.ID)
+
+	projects, err := testStore.ListProjects(context.Background(), ListProjectsParams{
+		CompanyID: &company.ID,
+		Limit:     100,
+	})
+	require.NoError(t, err)
+	require.Len(t, projects, 2)
+
+	projects, err = testStore.ListProjects(context.Background(), ListProjectsParams{
+		CompanyID: &company.ID,
+		Archived:  util.Pointer(false),
+		Limit:     100,
+	})
+	require.NoError(t, err)
+	require.Len(t, projects, 1)
+	require.Equal(t, active.ID, projects[0].ID)
+}
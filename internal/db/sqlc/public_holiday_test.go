@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportPublicHolidaysTx(t *testing.T) {
+	country := util.RandomString(2)
+	subdivision := "BY"
+	arg := []UpsertPublicHolidayParams{
+		{Country: country, Date: date(2024, time.January, 1), Name: "New Year"},
+		{Country: country, Date: date(2024, time.May, 1), Name: "Labour Day"},
+		{Country: country, Subdivision: &subdivision, Date: date(2024, time.January, 6), Name: "Epiphany"},
+	}
+	holidays, err := testStore.ImportPublicHolidaysTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, holidays, 3)
+	for i, holiday := range holidays {
+		require.NotZero(t, holiday.ID)
+		require.Equal(t, arg[i].Country, holiday.Country)
+		require.Equal(t, arg[i].Subdivision, holiday.Subdivision)
+		require.True(t, arg[i].Date.Equal(holiday.Date))
+		require.Equal(t, arg[i].Name, holiday.Name)
+	}
+
+	// importing again renames the holidays of the whole country
+	renamed, err := testStore.ImportPublicHolidaysTx(context.Background(), []UpsertPublicHolidayParams{
+		{Country: country, Date: date(2024, time.January, 1), Name: "New Year's Day"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, holidays[0].ID, renamed[0].ID)
+	require.Equal(t, "New Year's Day", renamed[0].Name)
+
+	nationwide, err := testStore.ListPublicHolidays(context.Background(), ListPublicHolidaysParams{
+		Country: country,
+		From:    date(2024, time.January, 1),
+		To:      date(2025, time.January, 1),
+	})
+	require.NoError(t, err)
+	require.Len(t, nationwide, 2)
+	require.Equal(t, renamed[0].ID, nationwide[0].ID)
+
+	regional, err := testStore.ListPublicHolidays(context.Background(), ListPublicHolidaysParams{
+		Country:     country,
+		Subdivision: &subdivision,
+		From:        date(2024, time.January, 1),
+		To:          date(2024, time.May, 1),
+	})
+	require.NoError(t, err)
+	require.Len(t, regional, 2)
+	require.Equal(t, holidays[2].ID, regional[1].ID)
+}
+
+func TestDeletePublicHoliday(t *testing.T) {
+	holiday, err := testStore.UpsertPublicHoliday(context.Background(), UpsertPublicHolidayParams{
+		Country: util.RandomString(2),
+		Date:    date(2024, time.December, 25),
+		Name:    "Christmas Day",
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.DeletePublicHoliday(context.Background(), holiday.ID)
+	require.NoError(t, err)
+	_, err = testStore.GetPublicHoliday(context.Background(), holiday.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
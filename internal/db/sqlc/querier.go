@@ -6,44 +6,178 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AutoCloseEntry(ctx context.Context, arg AutoCloseEntryParams) (Entry, error)
+	BlockSession(ctx context.Context, id uuid.UUID) (Session, error)
+	BlockSessionFamily(ctx context.Context, familyID uuid.UUID) (int64, error)
+	BlockUserSession(ctx context.Context, arg BlockUserSessionParams) (Session, error)
+	BlockUserSessions(ctx context.Context, username string) (int64, error)
+	CancelAbsence(ctx context.Context, arg CancelAbsenceParams) (Absence, error)
+	ClaimJobs(ctx context.Context, arg ClaimJobsParams) ([]Job, error)
+	ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	CompleteJob(ctx context.Context, id int64) (Job, error)
 	CreateAbsence(ctx context.Context, arg CreateAbsenceParams) (Absence, error)
+	CreateAbsenceType(ctx context.Context, arg CreateAbsenceTypeParams) (AbsenceType, error)
+	CreateCalendarFeed(ctx context.Context, arg CreateCalendarFeedParams) (CalendarFeed, error)
+	CreateClient(ctx context.Context, arg CreateClientParams) (Client, error)
 	CreateCompany(ctx context.Context, name string) (Company, error)
+	CreateCompanyHoliday(ctx context.Context, arg CreateCompanyHolidayParams) (CompanyHoliday, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateHourlyRate(ctx context.Context, arg CreateHourlyRateParams) (HourlyRate, error)
+	CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (Invoice, error)
+	CreateInvoiceLine(ctx context.Context, arg CreateInvoiceLineParams) (InvoiceLine, error)
+	CreateJob(ctx context.Context, arg CreateJobParams) (Job, error)
+	CreateLeaveEntitlement(ctx context.Context, arg CreateLeaveEntitlementParams) (LeaveEntitlement, error)
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
+	CreateProject(ctx context.Context, arg CreateProjectParams) (Project, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateTask(ctx context.Context, arg CreateTaskParams) (Task, error)
 	CreateTeam(ctx context.Context, arg CreateTeamParams) (Team, error)
+	CreateTimesheet(ctx context.Context, arg CreateTimesheetParams) (Timesheet, error)
+	CreateTimesheetDocument(ctx context.Context, arg CreateTimesheetDocumentParams) (TimesheetDocument, error)
+	CreateUniqueJob(ctx context.Context, arg CreateUniqueJobParams) (int64, error)
+	CreateUniqueNotification(ctx context.Context, arg CreateUniqueNotificationParams) (int64, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateWebhookDeliveries(ctx context.Context, arg CreateWebhookDeliveriesParams) (int64, error)
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error)
+	CreateWebhookEvent(ctx context.Context, arg CreateWebhookEventParams) (WebhookEvent, error)
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error)
+	CreateWorkSchedule(ctx context.Context, arg CreateWorkScheduleParams) (WorkSchedule, error)
+	CreateWorkScheduleAssignment(ctx context.Context, arg CreateWorkScheduleAssignmentParams) (WorkScheduleAssignment, error)
+	DeadLetterJob(ctx context.Context, arg DeadLetterJobParams) (Job, error)
+	DecideAbsence(ctx context.Context, arg DecideAbsenceParams) (Absence, error)
+	DecideTimesheet(ctx context.Context, arg DecideTimesheetParams) (Timesheet, error)
 	DeleteAbsence(ctx context.Context, id int64) (Absence, error)
+	DeleteAbsenceType(ctx context.Context, id int64) (AbsenceType, error)
+	DeleteClient(ctx context.Context, id int64) (Client, error)
 	DeleteCompany(ctx context.Context, id int64) (Company, error)
+	DeleteCompanyHoliday(ctx context.Context, id int64) (CompanyHoliday, error)
 	DeleteEntry(ctx context.Context, id int64) (Entry, error)
+	DeleteHourlyRate(ctx context.Context, id int64) (HourlyRate, error)
+	DeleteInvoice(ctx context.Context, id int64) (Invoice, error)
+	DeleteLeaveEntitlement(ctx context.Context, id int64) (LeaveEntitlement, error)
+	DeleteProject(ctx context.Context, id int64) (Project, error)
+	DeletePublicHoliday(ctx context.Context, id int64) (PublicHoliday, error)
+	DeleteSucceededJobs(ctx context.Context, before time.Time) (int64, error)
+	DeleteTask(ctx context.Context, id int64) (Task, error)
 	DeleteTeam(ctx context.Context, id int64) (Team, error)
+	DeleteTimesheet(ctx context.Context, arg DeleteTimesheetParams) (Timesheet, error)
 	DeleteUser(ctx context.Context, id int64) (User, error)
+	DeleteWebhookSubscription(ctx context.Context, arg DeleteWebhookSubscriptionParams) (WebhookSubscription, error)
+	DeleteWorkSchedule(ctx context.Context, id int64) (WorkSchedule, error)
+	DeleteWorkScheduleAssignment(ctx context.Context, id int64) (WorkScheduleAssignment, error)
+	ExportAbsences(ctx context.Context, arg ExportAbsencesParams) ([]ExportAbsencesRow, error)
+	ExportEntries(ctx context.Context, arg ExportEntriesParams) ([]ExportEntriesRow, error)
 	GetAbsence(ctx context.Context, id int64) (Absence, error)
+	GetAbsenceType(ctx context.Context, id int64) (AbsenceType, error)
+	GetActiveCalendarFeed(ctx context.Context, tokenHash string) (CalendarFeed, error)
+	GetBillingReport(ctx context.Context, arg GetBillingReportParams) ([]GetBillingReportRow, error)
+	GetClient(ctx context.Context, id int64) (Client, error)
+	GetClockPolicy(ctx context.Context, companyID int64) (ClockPolicy, error)
 	GetCompany(ctx context.Context, id int64) (Company, error)
+	GetCompanyHoliday(ctx context.Context, id int64) (CompanyHoliday, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetHourlyRate(ctx context.Context, id int64) (HourlyRate, error)
+	GetInvoice(ctx context.Context, id int64) (Invoice, error)
+	GetInvoiceSequence(ctx context.Context, companyID int64) (InvoiceSequence, error)
+	GetJob(ctx context.Context, id int64) (Job, error)
+	GetLatestTimesheetDocument(ctx context.Context, timesheetID int64) (TimesheetDocument, error)
+	GetLeaveEntitlement(ctx context.Context, id int64) (LeaveEntitlement, error)
+	GetNotificationByDedupeKey(ctx context.Context, dedupeKey *string) (Notification, error)
+	GetOverlappingAbsence(ctx context.Context, arg GetOverlappingAbsenceParams) (Absence, error)
+	GetOverlappingEntry(ctx context.Context, arg GetOverlappingEntryParams) (Entry, error)
+	GetProject(ctx context.Context, id int64) (Project, error)
+	GetPublicHoliday(ctx context.Context, id int64) (PublicHoliday, error)
+	GetRunningEntry(ctx context.Context, userID int64) (Entry, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
+	GetTask(ctx context.Context, id int64) (Task, error)
 	GetTeam(ctx context.Context, id int64) (Team, error)
+	GetTimesheet(ctx context.Context, id int64) (Timesheet, error)
 	GetUser(ctx context.Context, id int64) (User, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByUsername(ctx context.Context, username string) (User, error)
+	GetWebhookDelivery(ctx context.Context, arg GetWebhookDeliveryParams) (WebhookDelivery, error)
+	GetWebhookDeliveryRequest(ctx context.Context, id int64) (GetWebhookDeliveryRequestRow, error)
+	GetWebhookSubscription(ctx context.Context, arg GetWebhookSubscriptionParams) (WebhookSubscription, error)
+	GetWorkSchedule(ctx context.Context, id int64) (WorkSchedule, error)
+	GetWorkScheduleAssignment(ctx context.Context, id int64) (WorkScheduleAssignment, error)
+	IssueInvoice(ctx context.Context, arg IssueInvoiceParams) (Invoice, error)
+	ListAbsenceTypes(ctx context.Context, arg ListAbsenceTypesParams) ([]AbsenceType, error)
 	ListAbsences(ctx context.Context, arg ListAbsencesParams) ([]Absence, error)
+	ListActiveUserSessions(ctx context.Context, arg ListActiveUserSessionsParams) ([]Session, error)
+	ListCalendarFeeds(ctx context.Context, arg ListCalendarFeedsParams) ([]CalendarFeed, error)
+	ListClients(ctx context.Context, arg ListClientsParams) ([]Client, error)
+	ListClockPolicies(ctx context.Context) ([]ClockPolicy, error)
 	ListCompanies(ctx context.Context, arg ListCompaniesParams) ([]Company, error)
 	ListCompanyEmployees(ctx context.Context, arg ListCompanyEmployeesParams) ([]User, error)
+	ListCompanyHolidays(ctx context.Context, arg ListCompanyHolidaysParams) ([]CompanyHoliday, error)
+	ListCompanyRunningEntries(ctx context.Context, companyID int64) ([]Entry, error)
+	ListDailyWorkedSeconds(ctx context.Context, arg ListDailyWorkedSecondsParams) ([]ListDailyWorkedSecondsRow, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	ListHourlyRates(ctx context.Context, arg ListHourlyRatesParams) ([]HourlyRate, error)
+	ListInvoiceLines(ctx context.Context, invoiceID int64) ([]InvoiceLine, error)
+	ListInvoiceableEntries(ctx context.Context, arg ListInvoiceableEntriesParams) ([]ListInvoiceableEntriesRow, error)
+	ListInvoices(ctx context.Context, arg ListInvoicesParams) ([]Invoice, error)
+	ListJobs(ctx context.Context, arg ListJobsParams) ([]Job, error)
+	ListProjectTasks(ctx context.Context, arg ListProjectTasksParams) ([]Task, error)
+	ListProjects(ctx context.Context, arg ListProjectsParams) ([]Project, error)
+	ListPublicHolidays(ctx context.Context, arg ListPublicHolidaysParams) ([]PublicHoliday, error)
 	ListTeamMembers(ctx context.Context, arg ListTeamMembersParams) ([]User, error)
 	ListTeams(ctx context.Context, arg ListTeamsParams) ([]Team, error)
+	ListTimesheetAbsences(ctx context.Context, arg ListTimesheetAbsencesParams) ([]ListTimesheetAbsencesRow, error)
+	ListTimesheetEntries(ctx context.Context, arg ListTimesheetEntriesParams) ([]ListTimesheetEntriesRow, error)
+	ListTimesheets(ctx context.Context, arg ListTimesheetsParams) ([]Timesheet, error)
+	ListTrackedAbsenceTypes(ctx context.Context, companyID int64) ([]AbsenceType, error)
 	ListUserAbsences(ctx context.Context, arg ListUserAbsencesParams) ([]Absence, error)
 	ListUserEntries(ctx context.Context, arg ListUserEntriesParams) ([]Entry, error)
+	ListUserLeave(ctx context.Context, arg ListUserLeaveParams) ([]Absence, error)
+	ListUserLeaveEntitlements(ctx context.Context, userID int64) ([]LeaveEntitlement, error)
+	ListUserNotifications(ctx context.Context, arg ListUserNotificationsParams) ([]Notification, error)
+	ListUserPaidAbsences(ctx context.Context, arg ListUserPaidAbsencesParams) ([]Absence, error)
+	ListUserTimesheets(ctx context.Context, arg ListUserTimesheetsParams) ([]Timesheet, error)
+	ListUserWorkScheduleAssignments(ctx context.Context, userID int64) ([]WorkScheduleAssignment, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhookSubscriptions(ctx context.Context, companyID int64) ([]WebhookSubscription, error)
+	ListWorkSchedules(ctx context.Context, arg ListWorkSchedulesParams) ([]WorkSchedule, error)
+	NextInvoiceNumber(ctx context.Context, companyID int64) (InvoiceSequence, error)
+	PayInvoice(ctx context.Context, id int64) (Invoice, error)
+	ReadNotification(ctx context.Context, arg ReadNotificationParams) (Notification, error)
+	RecordWebhookDeliveryAttempt(ctx context.Context, arg RecordWebhookDeliveryAttemptParams) (WebhookDelivery, error)
+	ReleaseInvoiceEntries(ctx context.Context, invoiceID *int64) (int64, error)
+	ReopenTimesheet(ctx context.Context, arg ReopenTimesheetParams) (Timesheet, error)
+	RequeueDeadJob(ctx context.Context, id int64) (Job, error)
+	RetryJob(ctx context.Context, arg RetryJobParams) (Job, error)
+	RevokeCalendarFeed(ctx context.Context, arg RevokeCalendarFeedParams) (CalendarFeed, error)
+	RotateSession(ctx context.Context, id uuid.UUID) (Session, error)
+	SetEntriesInvoice(ctx context.Context, arg SetEntriesInvoiceParams) (int64, error)
+	SetInvoiceTotal(ctx context.Context, arg SetInvoiceTotalParams) (Invoice, error)
+	StopRunningEntry(ctx context.Context, arg StopRunningEntryParams) (Entry, error)
+	SubmitTimesheet(ctx context.Context, arg SubmitTimesheetParams) (Timesheet, error)
 	UpdateAbsence(ctx context.Context, arg UpdateAbsenceParams) (Absence, error)
+	UpdateAbsenceType(ctx context.Context, arg UpdateAbsenceTypeParams) (AbsenceType, error)
+	UpdateClient(ctx context.Context, arg UpdateClientParams) (Client, error)
 	UpdateCompany(ctx context.Context, arg UpdateCompanyParams) (Company, error)
+	UpdateCompanyWorkSchedule(ctx context.Context, arg UpdateCompanyWorkScheduleParams) (Company, error)
 	UpdateEntry(ctx context.Context, arg UpdateEntryParams) (Entry, error)
+	UpdateProject(ctx context.Context, arg UpdateProjectParams) (Project, error)
+	UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, error)
 	UpdateTeam(ctx context.Context, arg UpdateTeamParams) (Team, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error)
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
+	UpdateUserTeam(ctx context.Context, arg UpdateUserTeamParams) (User, error)
+	UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) (WebhookSubscription, error)
+	UpdateWorkSchedule(ctx context.Context, arg UpdateWorkScheduleParams) (WorkSchedule, error)
+	UpsertClockPolicy(ctx context.Context, arg UpsertClockPolicyParams) (ClockPolicy, error)
+	UpsertInvoiceSequence(ctx context.Context, arg UpsertInvoiceSequenceParams) (InvoiceSequence, error)
+	UpsertPublicHoliday(ctx context.Context, arg UpsertPublicHolidayParams) (PublicHoliday, error)
+	VoidInvoice(ctx context.Context, id int64) (Invoice, error)
 }
 
 var _ Querier = (*Queries)(nil)
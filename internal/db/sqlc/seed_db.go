@@ -6,14 +6,21 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/mateoradman/tempus/internal/config"
+	"github.com/mateoradman/tempus/internal/types"
 	"github.com/mateoradman/tempus/internal/util"
 )
 
+// seedSuperUser creates the platform superuser. It belongs to no company and is the only user
+// allowed to work across companies.
 func seedSuperUser(ctx context.Context, q *Queries, config config.Config) error {
-	_, err := q.GetUserByEmail(ctx, config.SuperUserEmail)
+	user, err := q.GetUserByEmail(ctx, config.SuperUserEmail)
 	if err == nil {
-		// this means that the user already exists
-		return nil
+		// this means that the user already exists, make sure it is the superuser
+		if user.Role == types.SuperuserRole {
+			return nil
+		}
+		_, err = q.UpdateUserRole(ctx, UpdateUserRoleParams{ID: user.ID, Role: types.SuperuserRole})
+		return err
 	} else if err != pgx.ErrNoRows {
 		// if error is not that the user doesn't exist, return it
 		return err
@@ -38,6 +45,11 @@ func seedSuperUser(ctx context.Context, q *Queries, config config.Config) error
 		Country:   util.Pointer("HR"),
 	}
 
-	_, err = q.CreateUser(ctx, arg)
+	user, err = q.CreateUser(ctx, arg)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.UpdateUserRole(ctx, UpdateUserRoleParams{ID: user.ID, Role: types.SuperuserRole})
 	return err
 }
@@ -6,20 +6,23 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/mateoradman/tempus/internal/util"
 	"github.com/stretchr/testify/require"
 )
 
 func createRandomSession(t *testing.T) Session {
 	user := createRandomUser(t, nil, nil)
+	id := uuid.New()
 	arg := CreateSessionParams{
-		ID:           uuid.New(),
+		ID:           id,
 		Username:     user.Username,
 		RefreshToken: util.RandomString(32),
 		UserAgent:    util.RandomString(20),
 		ClientIp:     util.RandomString(20),
 		IsBlocked:    false,
 		ExpiresAt:    time.Now().Add(24 * time.Hour),
+		FamilyID:     id,
 	}
 
 	session, err := testStore.CreateSession(context.Background(), arg)
@@ -30,6 +33,9 @@ func createRandomSession(t *testing.T) Session {
 	require.Equal(t, arg.RefreshToken, session.RefreshToken)
 	require.Equal(t, arg.UserAgent, session.UserAgent)
 	require.Equal(t, arg.IsBlocked, session.IsBlocked)
+	require.Equal(t, arg.FamilyID, session.FamilyID)
+	require.Nil(t, session.ParentID)
+	require.Nil(t, session.RotatedAt)
 	require.WithinDuration(t, time.Now(), session.CreatedAt, 2*time.Second)
 
 	return session
@@ -51,3 +57,173 @@ func TestGetSession(t *testing.T) {
 	require.Equal(t, session.IsBlocked, gotSession.IsBlocked)
 	require.WithinDuration(t, time.Now(), gotSession.CreatedAt, 2*time.Second)
 }
+
+func TestBlockSession(t *testing.T) {
+	session := createRandomSession(t)
+	blocked, err := testStore.BlockSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.Equal(t, session.ID, blocked.ID)
+	require.True(t, blocked.IsBlocked)
+}
+
+func TestBlockUserSession(t *testing.T) {
+	session := createRandomSession(t)
+	other := createRandomSession(t)
+
+	// sessions of other users cannot be blocked
+	_, err := testStore.BlockUserSession(context.Background(), BlockUserSessionParams{
+		ID:       other.ID,
+		Username: session.Username,
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	blocked, err := testStore.BlockUserSession(context.Background(), BlockUserSessionParams{
+		ID:       session.ID,
+		Username: session.Username,
+	})
+	require.NoError(t, err)
+	require.True(t, blocked.IsBlocked)
+
+	sessions, err := testStore.ListActiveUserSessions(context.Background(), ListActiveUserSessionsParams{
+		Username: session.Username,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+}
+
+func TestBlockUserSessions(t *testing.T) {
+	session := createRandomSession(t)
+	id := uuid.New()
+	arg := CreateSessionParams{
+		ID:           id,
+		Username:     session.Username,
+		RefreshToken: util.RandomString(32),
+		UserAgent:    util.RandomString(20),
+		ClientIp:     util.RandomString(20),
+		ExpiresAt:    time.Now().Add(24 * time.Hour),
+		FamilyID:     id,
+	}
+	_, err := testStore.CreateSession(context.Background(), arg)
+	require.NoError(t, err)
+
+	sessions, err := testStore.ListActiveUserSessions(context.Background(), ListActiveUserSessionsParams{
+		Username: session.Username,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+
+	revoked, err := testStore.BlockUserSessions(context.Background(), session.Username)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), revoked)
+
+	gotSession, err := testStore.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, gotSession.IsBlocked)
+}
+
+func rotateRandomSession(t *testing.T, session Session) RotateSessionTxResult {
+	arg := RotateSessionTxParams{
+		ID: session.ID,
+		CreateSessionParams: CreateSessionParams{
+			ID:           uuid.New(),
+			RefreshToken: util.RandomString(32),
+			UserAgent:    util.RandomString(20),
+			ClientIp:     util.RandomString(20),
+			ExpiresAt:    time.Now().Add(24 * time.Hour),
+		},
+	}
+
+	result, err := testStore.RotateSessionTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, session.ID, result.Rotated.ID)
+	require.NotNil(t, result.Rotated.RotatedAt)
+	require.WithinDuration(t, time.Now(), *result.Rotated.RotatedAt, 2*time.Second)
+
+	require.Equal(t, arg.CreateSessionParams.ID, result.Session.ID)
+	require.Equal(t, session.Username, result.Session.Username)
+	require.Equal(t, arg.RefreshToken, result.Session.RefreshToken)
+	require.Equal(t, session.FamilyID, result.Session.FamilyID)
+	require.NotNil(t, result.Session.ParentID)
+	require.Equal(t, session.ID, *result.Session.ParentID)
+	require.Nil(t, result.Session.RotatedAt)
+
+	return result
+}
+
+func TestRotateSessionTx(t *testing.T) {
+	session := createRandomSession(t)
+	first := rotateRandomSession(t, session)
+	rotateRandomSession(t, first.Session)
+
+	// a session can only be rotated once
+	_, err := testStore.RotateSessionTx(context.Background(), RotateSessionTxParams{
+		ID: session.ID,
+		CreateSessionParams: CreateSessionParams{
+			ID:           uuid.New(),
+			RefreshToken: util.RandomString(32),
+			ExpiresAt:    time.Now().Add(24 * time.Hour),
+		},
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// only the latest session of a chain is listed
+	sessions, err := testStore.ListActiveUserSessions(context.Background(), ListActiveUserSessionsParams{
+		Username: session.Username,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+}
+
+func TestChangePasswordTx(t *testing.T) {
+	session := createRandomSession(t)
+	user, err := testStore.GetUserByUsername(context.Background(), session.Username)
+	require.NoError(t, err)
+	hashedPassword, err := util.HashPassword(util.RandomString(10))
+	require.NoError(t, err)
+
+	arg := ChangePasswordTxParams{
+		UserID:   user.ID,
+		Password: hashedPassword,
+		CreateSessionParams: CreateSessionParams{
+			ID:           uuid.New(),
+			RefreshToken: util.RandomString(32),
+			ExpiresAt:    time.Now().Add(24 * time.Hour),
+		},
+	}
+	arg.FamilyID = arg.ID
+
+	result, err := testStore.ChangePasswordTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, hashedPassword, result.User.Password)
+	require.Equal(t, arg.ID, result.Session.ID)
+	require.Equal(t, user.Username, result.Session.Username)
+	require.False(t, result.Session.IsBlocked)
+
+	// the sessions of the old password are blocked
+	blocked, err := testStore.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, blocked.IsBlocked)
+}
+
+func TestBlockSessionFamily(t *testing.T) {
+	session := createRandomSession(t)
+	result := rotateRandomSession(t, session)
+	other := createRandomSession(t)
+
+	blocked, err := testStore.BlockSessionFamily(context.Background(), session.FamilyID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), blocked)
+
+	for _, id := range []uuid.UUID{session.ID, result.Session.ID} {
+		gotSession, err := testStore.GetSession(context.Background(), id)
+		require.NoError(t, err)
+		require.True(t, gotSession.IsBlocked)
+	}
+
+	gotOther, err := testStore.GetSession(context.Background(), other.ID)
+	require.NoError(t, err)
+	require.False(t, gotOther.IsBlocked)
+}
@@ -10,6 +10,23 @@ import (
 type Store interface {
 	Querier
 	SeedDatabase(ctx context.Context, config config.Config) error
+	RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (RotateSessionTxResult, error)
+	ChangePasswordTx(ctx context.Context, arg ChangePasswordTxParams) (ChangePasswordTxResult, error)
+	DraftInvoiceTx(ctx context.Context, arg DraftInvoiceTxParams) (DraftInvoiceTxResult, error)
+	IssueInvoiceTx(ctx context.Context, invoiceID int64) (Invoice, error)
+	VoidInvoiceTx(ctx context.Context, invoiceID int64) (Invoice, error)
+	ImportPublicHolidaysTx(ctx context.Context, arg []UpsertPublicHolidayParams) ([]PublicHoliday, error)
+	ExportEntriesTx(ctx context.Context, arg ExportEntriesParams, fn func(ExportEntriesRow) error) error
+	ExportAbsencesTx(ctx context.Context, arg ExportAbsencesParams, fn func(ExportAbsencesRow) error) error
+	ApproveTimesheetTx(ctx context.Context, arg ApproveTimesheetTxParams) (ApproveTimesheetTxResult, error)
+	CreateEntryTx(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	UpdateEntryTx(ctx context.Context, arg UpdateEntryParams) (Entry, error)
+	StopRunningEntryTx(ctx context.Context, arg StopRunningEntryParams) (Entry, error)
+	DeleteEntryTx(ctx context.Context, id int64) (Entry, error)
+	AutoCloseEntryTx(ctx context.Context, arg AutoCloseEntryParams) (Entry, error)
+	DecideAbsenceTx(ctx context.Context, arg DecideAbsenceParams) (Absence, error)
+	CancelAbsenceTx(ctx context.Context, arg CancelAbsenceParams) (Absence, error)
+	DeleteUserTx(ctx context.Context, id int64) (User, error)
 }
 type SQLStore struct {
 	connPool *pgxpool.Pool
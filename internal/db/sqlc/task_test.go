@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomTask(t *testing.T, projectID int64) Task {
+	arg := CreateTaskParams{
+		ProjectID: projectID,
+		Name:      util.RandomString(20),
+	}
+
+	task, err := testStore.CreateTask(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, task)
+	require.NotZero(t, task.ID)
+	require.Equal(t, arg.ProjectID, task.ProjectID)
+	require.Equal(t, arg.Name, task.Name)
+	require.WithinDuration(t, time.Now(), task.CreatedAt, 2*time.Second)
+	require.Nil(t, task.UpdatedAt)
+
+	return task
+}
+
+func TestCreateTask(t *testing.T) {
+	company := createRandomCompany(t)
+	project := createRandomProject(t, company.ID)
+	createRandomTask(t, project.ID)
+}
+
+func TestGetTask(t *testing.T) {
+	company := createRandomCompany(t)
+	project := createRandomProject(t, company.ID)
+	task := createRandomTask(t, project.ID)
+	gotTask, err := testStore.GetTask(context.Background(), task.ID)
+	require.NoError(t, err)
+	require.Equal(t, task, gotTask)
+}
+
+func TestUpdateTask(t *testing.T) {
+	company := createRandomCompany(t)
+	project := createRandomProject(t, company.ID)
+	task := createRandomTask(t, project.ID)
+	arg := UpdateTaskParams{
+		ID:   task.ID,
+		Name: util.RandomString(20),
+	}
+
+	updatedTask, err := testStore.UpdateTask(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, task.ID, updatedTask.ID)
+	require.Equal(t, arg.Name, updatedTask.Name)
+	require.NotNil(t, updatedTask.UpdatedAt)
+	require.Equal(t, task.CreatedAt, updatedTask.CreatedAt)
+}
+
+func TestDeleteTask(t *testing.T) {
+	company := createRandomCompany(t)
+	project := createRandomProject(t, company.ID)
+	task := createRandomTask(t, project.ID)
+	deletedTask, err := testStore.DeleteTask(context.Background(), task.ID)
+	require.NoError(t, err)
+	require.Equal(t, task, deletedTask)
+
+	_, err = testStore.GetTask(context.Background(), task.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestListProjectTasks(t *testing.T) {
+	company := createRandomCompany(t)
+	project := createRandomProject(t, company.ID)
+	tasks := []Task{}
+	for i := 0; i < 5; i++ {
+		tasks = append(tasks, createRandomTask(t, project.ID))
+	}
+	otherProject := createRandomProject(t, company.ID)
+	createRandomTask(t, otherProject.ID)
+
+	gotTasks, err := testStore.ListProjectTasks(context.Background(), ListProjectTasksParams{
+		ProjectID: project.ID,
+		Limit:     100,
+	})
+	require.NoError(t, err)
+	require.Equal(t, tasks, gotTasks)
+}
+
+func TestCreateEntryWithTaskOfOtherProject(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	project := createRandomProject(t, company.ID)
+	otherProject := createRandomProject(t, company.ID)
+	task := createRandomTask(t, otherProject.ID)
+
+	_, err := testStore.CreateEntry(context.Background(), CreateEntryParams{
+		UserID:    user.ID,
+		StartTime: time.Now().UTC(),
+		ProjectID: &project.ID,
+		TaskID:    &task.ID,
+	})
+	require.Error(t, err)
+	require.Equal(t, ForeignKeyViolation, ErrorCode(err))
+}
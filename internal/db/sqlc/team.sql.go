@@ -12,20 +12,22 @@ import (
 const createTeam = `-- name: CreateTeam :one
 INSERT INTO teams (
     name,
-    manager_id
+    manager_id,
+    company_id
 ) VALUES (
-    $1, $2
+    $1, $2, $3
 )
-RETURNING id, name, manager_id, created_at, updated_at
+RETURNING id, name, manager_id, created_at, updated_at, company_id
 `
 
 type CreateTeamParams struct {
 	Name      string `json:"name"`
 	ManagerID *int64 `json:"manager_id"`
+	CompanyID *int64 `json:"company_id"`
 }
 
 func (q *Queries) CreateTeam(ctx context.Context, arg CreateTeamParams) (Team, error) {
-	row := q.db.QueryRow(ctx, createTeam, arg.Name, arg.ManagerID)
+	row := q.db.QueryRow(ctx, createTeam, arg.Name, arg.ManagerID, arg.CompanyID)
 	var i Team
 	err := row.Scan(
 		&i.ID,
@@ -33,6 +35,7 @@ func (q *Queries) CreateTeam(ctx context.Context, arg CreateTeamParams) (Team, e
 		&i.ManagerID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CompanyID,
 	)
 	return i, err
 }
@@ -41,7 +44,7 @@ const deleteTeam = `-- name: DeleteTeam :one
 DELETE
 FROM teams
 WHERE id = $1
-RETURNING id, name, manager_id, created_at, updated_at
+RETURNING id, name, manager_id, created_at, updated_at, company_id
 `
 
 func (q *Queries) DeleteTeam(ctx context.Context, id int64) (Team, error) {
@@ -53,12 +56,13 @@ func (q *Queries) DeleteTeam(ctx context.Context, id int64) (Team, error) {
 		&i.ManagerID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CompanyID,
 	)
 	return i, err
 }
 
 const getTeam = `-- name: GetTeam :one
-SELECT id, name, manager_id, created_at, updated_at 
+SELECT id, name, manager_id, created_at, updated_at, company_id 
 FROM teams
 WHERE id = $1
 LIMIT 1
@@ -73,12 +77,13 @@ func (q *Queries) GetTeam(ctx context.Context, id int64) (Team, error) {
 		&i.ManagerID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CompanyID,
 	)
 	return i, err
 }
 
 const listTeamMembers = `-- name: ListTeamMembers :many
-SELECT id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role
+SELECT id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role, subdivision
 FROM users
 WHERE team_id = $1
 ORDER BY id
@@ -119,6 +124,7 @@ func (q *Queries) ListTeamMembers(ctx context.Context, arg ListTeamMembersParams
 			&i.ManagerID,
 			&i.TeamID,
 			&i.Role,
+			&i.Subdivision,
 		); err != nil {
 			return nil, err
 		}
@@ -131,20 +137,22 @@ func (q *Queries) ListTeamMembers(ctx context.Context, arg ListTeamMembersParams
 }
 
 const listTeams = `-- name: ListTeams :many
-SELECT id, name, manager_id, created_at, updated_at
+SELECT id, name, manager_id, created_at, updated_at, company_id
 FROM teams
+WHERE ($1::bigint IS NULL OR company_id = $1)
 ORDER BY id
-LIMIT $1
-OFFSET $2
+LIMIT $2
+OFFSET $3
 `
 
 type ListTeamsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	CompanyID *int64 `json:"company_id"`
+	Limit     int32  `json:"limit"`
+	Offset    int32  `json:"offset"`
 }
 
 func (q *Queries) ListTeams(ctx context.Context, arg ListTeamsParams) ([]Team, error) {
-	rows, err := q.db.Query(ctx, listTeams, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listTeams, arg.CompanyID, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -158,6 +166,7 @@ func (q *Queries) ListTeams(ctx context.Context, arg ListTeamsParams) ([]Team, e
 			&i.ManagerID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CompanyID,
 		); err != nil {
 			return nil, err
 		}
@@ -173,7 +182,7 @@ const updateTeam = `-- name: UpdateTeam :one
 UPDATE teams
 SET name = $2, manager_id = $3
 WHERE id = $1
-RETURNING id, name, manager_id, created_at, updated_at
+RETURNING id, name, manager_id, created_at, updated_at, company_id
 `
 
 type UpdateTeamParams struct {
@@ -191,6 +200,7 @@ func (q *Queries) UpdateTeam(ctx context.Context, arg UpdateTeamParams) (Team, e
 		&i.ManagerID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CompanyID,
 	)
 	return i, err
 }
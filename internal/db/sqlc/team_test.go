@@ -10,10 +10,12 @@ import (
 )
 
 func createRandomTeam(t *testing.T) Team {
-	user := createRandomUser(t, nil, nil)
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
 	arg := CreateTeamParams{
 		Name:      util.RandomString(100),
 		ManagerID: &user.ID,
+		CompanyID: &company.ID,
 	}
 
 	team, err := testStore.CreateTeam(context.Background(), arg)
@@ -21,6 +23,7 @@ func createRandomTeam(t *testing.T) Team {
 	require.NotEmpty(t, team)
 	require.Equal(t, arg.Name, team.Name)
 	require.Equal(t, arg.ManagerID, team.ManagerID)
+	require.Equal(t, arg.CompanyID, team.CompanyID)
 	require.NotZero(t, team.ID)
 	require.WithinDuration(t, time.Now(), team.CreatedAt, 2*time.Second)
 	require.Nil(t, team.UpdatedAt)
@@ -40,6 +43,7 @@ func TestGetTeam(t *testing.T) {
 	require.Equal(t, team.ID, gotTeam.ID)
 	require.Equal(t, team.Name, gotTeam.Name)
 	require.Equal(t, team.ManagerID, gotTeam.ManagerID)
+	require.Equal(t, team.CompanyID, gotTeam.CompanyID)
 	require.Equal(t, team.CreatedAt, gotTeam.CreatedAt)
 	require.Equal(t, team.UpdatedAt, gotTeam.UpdatedAt)
 }
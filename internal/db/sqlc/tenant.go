@@ -0,0 +1,34 @@
+package db
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TenantKey is the context key holding the ID of the company the queries of a request are restricted to.
+// A string key is used so that the value can be set on a gin context.
+const TenantKey = "tempus.company_id"
+
+// TenantFromContext returns the company the queries run with ctx are restricted to.
+func TenantFromContext(ctx context.Context) (int64, bool) {
+	companyID, ok := ctx.Value(TenantKey).(int64)
+	return companyID, ok
+}
+
+// EnableRowLevelSecurity makes every connection acquired from the pool apply the row-level security
+// policies of the company in the context of the acquiring query. Connections acquired without a company
+// clear the setting and are not restricted.
+func EnableRowLevelSecurity(config *pgxpool.Config) {
+	config.BeforeAcquire = func(ctx context.Context, conn *pgx.Conn) bool {
+		var companyID string
+		if id, ok := TenantFromContext(ctx); ok {
+			companyID = strconv.FormatInt(id, 10)
+		}
+		_, err := conn.Exec(ctx, "SELECT set_config('tempus.company_id', $1, false)", companyID)
+		// a connection whose setting is unknown must not be used
+		return err == nil
+	}
+}
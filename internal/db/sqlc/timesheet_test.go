@@ -0,0 +1,430 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createMarchTimesheet(t *testing.T, userID int64) Timesheet {
+	arg := CreateTimesheetParams{
+		UserID:      userID,
+		Period:      "month",
+		PeriodStart: date(2024, time.March, 1),
+		PeriodEnd:   date(2024, time.April, 1),
+	}
+	timesheet, err := testStore.CreateTimesheet(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, timesheet.ID)
+	require.Equal(t, arg.UserID, timesheet.UserID)
+	require.Equal(t, arg.Period, timesheet.Period)
+	require.Equal(t, arg.PeriodStart, timesheet.PeriodStart)
+	require.Equal(t, arg.PeriodEnd, timesheet.PeriodEnd)
+	require.Equal(t, "open", timesheet.Status)
+	require.Nil(t, timesheet.SubmittedAt)
+	return timesheet
+}
+
+func approveTimesheet(t *testing.T, timesheet Timesheet, approverID int64) Timesheet {
+	submitted, err := testStore.SubmitTimesheet(context.Background(), SubmitTimesheetParams{
+		ID:            timesheet.ID,
+		CurrentStatus: "open",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "submitted", submitted.Status)
+	require.NotNil(t, submitted.SubmittedAt)
+
+	approved, err := testStore.DecideTimesheet(context.Background(), DecideTimesheetParams{
+		Status:        "approved",
+		DecidedByID:   &approverID,
+		ID:            timesheet.ID,
+		CurrentStatus: "submitted",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "approved", approved.Status)
+	require.Equal(t, &approverID, approved.DecidedByID)
+	require.NotNil(t, approved.DecidedAt)
+	return approved
+}
+
+func TestCreateTimesheet(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	createMarchTimesheet(t, user.ID)
+}
+
+func TestCreateOverlappingTimesheet(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	createMarchTimesheet(t, user.ID)
+
+	_, err := testStore.CreateTimesheet(context.Background(), CreateTimesheetParams{
+		UserID:      user.ID,
+		Period:      "week",
+		PeriodStart: date(2024, time.March, 25),
+		PeriodEnd:   date(2024, time.April, 1),
+	})
+	require.Equal(t, ExclusionViolation, ErrorCode(err))
+	require.Equal(t, TimesheetOverlapConstraint, ConstraintName(err))
+
+	// the period end is exclusive
+	_, err = testStore.CreateTimesheet(context.Background(), CreateTimesheetParams{
+		UserID:      user.ID,
+		Period:      "week",
+		PeriodStart: date(2024, time.April, 1),
+		PeriodEnd:   date(2024, time.April, 8),
+	})
+	require.NoError(t, err)
+}
+
+func TestListTimesheets(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	admin := createRandomUser(t, &company.ID, nil)
+	timesheet := createMarchTimesheet(t, user.ID)
+	approved := approveTimesheet(t, timesheet, admin.ID)
+
+	timesheets, err := testStore.ListTimesheets(context.Background(), ListTimesheetsParams{
+		CompanyID: &company.ID,
+		Status:    util.Pointer("approved"),
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, timesheets, 1)
+	require.Equal(t, approved.ID, timesheets[0].ID)
+
+	timesheets, err = testStore.ListTimesheets(context.Background(), ListTimesheetsParams{
+		CompanyID: &company.ID,
+		Status:    util.Pointer("submitted"),
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, timesheets)
+
+	// managers list the timesheets of their direct reports and of the members of their teams
+	manager := createRandomUser(t, &company.ID, nil)
+	team, err := testStore.CreateTeam(context.Background(), CreateTeamParams{
+		Name:      util.RandomString(20),
+		ManagerID: &manager.ID,
+		CompanyID: &company.ID,
+	})
+	require.NoError(t, err)
+	member := createRandomUser(t, &company.ID, &team.ID)
+	memberTimesheet := createMarchTimesheet(t, member.ID)
+
+	timesheets, err = testStore.ListTimesheets(context.Background(), ListTimesheetsParams{
+		CompanyID: &company.ID,
+		ManagerID: &manager.ID,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, timesheets, 1)
+	require.Equal(t, memberTimesheet.ID, timesheets[0].ID)
+
+	timesheets, err = testStore.ListUserTimesheets(context.Background(), ListUserTimesheetsParams{
+		UserID: user.ID,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, timesheets, 1)
+}
+
+func TestTimesheetTransitions(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	manager := createRandomUser(t, &company.ID, nil)
+	timesheet := createMarchTimesheet(t, user.ID)
+
+	// only submitted timesheets are decided
+	_, err := testStore.DecideTimesheet(context.Background(), DecideTimesheetParams{
+		Status:        "approved",
+		DecidedByID:   &manager.ID,
+		ID:            timesheet.ID,
+		CurrentStatus: "submitted",
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	_, err = testStore.SubmitTimesheet(context.Background(), SubmitTimesheetParams{
+		ID:            timesheet.ID,
+		CurrentStatus: "open",
+	})
+	require.NoError(t, err)
+
+	comment := util.RandomString(20)
+	rejected, err := testStore.DecideTimesheet(context.Background(), DecideTimesheetParams{
+		Status:          "rejected",
+		DecidedByID:     &manager.ID,
+		DecisionComment: &comment,
+		ID:              timesheet.ID,
+		CurrentStatus:   "submitted",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "rejected", rejected.Status)
+	require.Equal(t, &comment, rejected.DecisionComment)
+
+	// resubmitting clears the previous decision
+	resubmitted, err := testStore.SubmitTimesheet(context.Background(), SubmitTimesheetParams{
+		ID:            timesheet.ID,
+		CurrentStatus: "rejected",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "submitted", resubmitted.Status)
+	require.Nil(t, resubmitted.DecidedByID)
+	require.Nil(t, resubmitted.DecisionComment)
+
+	_, err = testStore.DeleteTimesheet(context.Background(), DeleteTimesheetParams{
+		ID:            timesheet.ID,
+		CurrentStatus: "open",
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestApprovedTimesheetLocksEntries(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	admin := createRandomUser(t, &company.ID, nil)
+
+	start := date(2024, time.March, 4).Add(9 * time.Hour)
+	end := start.Add(time.Hour)
+	entry, err := testStore.CreateEntry(context.Background(), CreateEntryParams{
+		UserID:    user.ID,
+		StartTime: start,
+		EndTime:   &end,
+	})
+	require.NoError(t, err)
+
+	timesheet := approveTimesheet(t, createMarchTimesheet(t, user.ID), admin.ID)
+
+	requirePeriodLocked := func(err error) {
+		require.Error(t, err)
+		require.Equal(t, CheckViolation, ErrorCode(err))
+		require.Equal(t, EntryPeriodLockedConstraint, ConstraintName(err))
+	}
+
+	later := end.Add(time.Hour)
+	_, err = testStore.UpdateEntry(context.Background(), UpdateEntryParams{
+		ID:        entry.ID,
+		UserID:    entry.UserID,
+		StartTime: entry.StartTime,
+		EndTime:   &later,
+	})
+	requirePeriodLocked(err)
+
+	// moving an entry into an approved period is locked as well
+	aprilStart := date(2024, time.April, 2).Add(9 * time.Hour)
+	aprilEnd := aprilStart.Add(time.Hour)
+	aprilEntry, err := testStore.CreateEntry(context.Background(), CreateEntryParams{
+		UserID:    user.ID,
+		StartTime: aprilStart,
+		EndTime:   &aprilEnd,
+	})
+	require.NoError(t, err)
+	_, err = testStore.UpdateEntry(context.Background(), UpdateEntryParams{
+		ID:        aprilEntry.ID,
+		UserID:    aprilEntry.UserID,
+		StartTime: start.AddDate(0, 0, 1),
+		EndTime:   util.Pointer(end.AddDate(0, 0, 1)),
+	})
+	requirePeriodLocked(err)
+
+	_, err = testStore.CreateEntry(context.Background(), CreateEntryParams{
+		UserID:    user.ID,
+		StartTime: start.AddDate(0, 0, 2),
+		EndTime:   util.Pointer(end.AddDate(0, 0, 2)),
+	})
+	requirePeriodLocked(err)
+
+	_, err = testStore.DeleteEntry(context.Background(), entry.ID)
+	requirePeriodLocked(err)
+
+	// reopening unlocks the period
+	reopened, err := testStore.ReopenTimesheet(context.Background(), ReopenTimesheetParams{
+		ReopenedByID:  &admin.ID,
+		ID:            timesheet.ID,
+		CurrentStatus: "approved",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "open", reopened.Status)
+	require.Equal(t, &admin.ID, reopened.ReopenedByID)
+	require.NotNil(t, reopened.ReopenedAt)
+
+	_, err = testStore.DeleteEntry(context.Background(), entry.ID)
+	require.NoError(t, err)
+}
+
+func TestApprovedTimesheetLocksLocalDays(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	admin := createRandomUser(t, &company.ID, nil)
+	approveTimesheet(t, createMarchTimesheet(t, user.ID), admin.ID)
+
+	// 1 March 00:30 in Zagreb is within the period
+	start := date(2024, time.February, 29).Add(23*time.Hour + 30*time.Minute)
+	_, err := testStore.CreateEntry(context.Background(), CreateEntryParams{
+		UserID:    user.ID,
+		StartTime: start,
+		EndTime:   util.Pointer(start.Add(time.Hour)),
+	})
+	require.Equal(t, CheckViolation, ErrorCode(err))
+	require.Equal(t, EntryPeriodLockedConstraint, ConstraintName(err))
+
+	// 1 April 00:30 in Zagreb is not
+	start = date(2024, time.March, 31).Add(22*time.Hour + 30*time.Minute)
+	_, err = testStore.CreateEntry(context.Background(), CreateEntryParams{
+		UserID:    user.ID,
+		StartTime: start,
+		EndTime:   util.Pointer(start.Add(time.Hour)),
+	})
+	require.NoError(t, err)
+}
+
+func TestDeleteUserWithApprovedTimesheet(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	admin := createRandomUser(t, &company.ID, nil)
+
+	start := date(2024, time.March, 4).Add(9 * time.Hour)
+	end := start.Add(time.Hour)
+	_, err := testStore.CreateEntry(context.Background(), CreateEntryParams{
+		UserID:    user.ID,
+		StartTime: start,
+		EndTime:   &end,
+	})
+	require.NoError(t, err)
+	timesheet := approveTimesheet(t, createMarchTimesheet(t, user.ID), admin.ID)
+
+	// entries deleted along with their user are not locked
+	_, err = testStore.DeleteUser(context.Background(), user.ID)
+	require.NoError(t, err)
+
+	_, err = testStore.GetTimesheet(context.Background(), timesheet.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestApproveTimesheetTx(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	admin := createRandomUser(t, &company.ID, nil)
+	timesheet := createMarchTimesheet(t, user.ID)
+	_, err := testStore.SubmitTimesheet(context.Background(), SubmitTimesheetParams{
+		ID:            timesheet.ID,
+		CurrentStatus: "open",
+	})
+	require.NoError(t, err)
+
+	arg := ApproveTimesheetTxParams{
+		DecideTimesheetParams: DecideTimesheetParams{
+			Status:        "approved",
+			DecidedByID:   &admin.ID,
+			ID:            timesheet.ID,
+			CurrentStatus: "submitted",
+		},
+	}
+
+	// the timesheet stays submitted if its document cannot be rendered
+	errRender := errors.New("render failed")
+	arg.Render = func(Querier, Timesheet) ([]byte, string, error) {
+		return nil, "", errRender
+	}
+	_, err = testStore.ApproveTimesheetTx(context.Background(), arg)
+	require.ErrorIs(t, err, errRender)
+	submitted, err := testStore.GetTimesheet(context.Background(), timesheet.ID)
+	require.NoError(t, err)
+	require.Equal(t, "submitted", submitted.Status)
+	_, err = testStore.GetLatestTimesheetDocument(context.Background(), timesheet.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	content := []byte("%PDF-" + util.RandomString(20))
+	arg.Render = func(q Querier, approved Timesheet) ([]byte, string, error) {
+		require.Equal(t, "approved", approved.Status)
+		// the queries of the transaction see the approval
+		got, err := q.GetTimesheet(context.Background(), approved.ID)
+		require.NoError(t, err)
+		require.Equal(t, "approved", got.Status)
+		return content, "en", nil
+	}
+	result, err := testStore.ApproveTimesheetTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, "approved", result.Timesheet.Status)
+	require.Equal(t, &admin.ID, result.Timesheet.DecidedByID)
+
+	sum := sha256.Sum256(content)
+	require.Equal(t, timesheet.ID, result.Document.TimesheetID)
+	require.Equal(t, "en", result.Document.Language)
+	require.Equal(t, content, result.Document.Content)
+	require.Equal(t, hex.EncodeToString(sum[:]), result.Document.Sha256)
+
+	document, err := testStore.GetLatestTimesheetDocument(context.Background(), timesheet.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.Document, document)
+
+	// an approved timesheet cannot be approved again
+	_, err = testStore.ApproveTimesheetTx(context.Background(), arg)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestListTimesheetEntriesAndAbsences(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	admin := createRandomUser(t, &company.ID, nil)
+
+	first := createClosedEntry(t, user.ID, date(2024, time.March, 4).Add(9*time.Hour))
+	second := createClosedEntry(t, user.ID, date(2024, time.March, 4).Add(13*time.Hour))
+	createClosedEntry(t, user.ID, date(2024, time.April, 1).Add(9*time.Hour))
+
+	entries, err := testStore.ListTimesheetEntries(context.Background(), ListTimesheetEntriesParams{
+		UserID: user.ID,
+		From:   date(2024, time.March, 1),
+		To:     date(2024, time.April, 1),
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, first.ID, entries[0].ID)
+	require.Equal(t, second.ID, entries[1].ID)
+	require.Nil(t, entries[0].ProjectName)
+	require.Nil(t, entries[0].TaskName)
+
+	createAbsence := func(start time.Time, days int) Absence {
+		end := start.AddDate(0, 0, days)
+		absence, err := testStore.CreateAbsence(context.Background(), CreateAbsenceParams{
+			UserID:    user.ID,
+			Paid:      true,
+			Reason:    "vacation",
+			StartTime: start,
+			EndTime:   &end,
+			Kind:      types.AbsenceFullDay,
+		})
+		require.NoError(t, err)
+		return absence
+	}
+	approved := createAbsence(date(2024, time.February, 28), 3)
+	_, err = testStore.DecideAbsence(context.Background(), DecideAbsenceParams{
+		ID:            approved.ID,
+		Status:        types.AbsenceApproved,
+		ApprovedByID:  &admin.ID,
+		CurrentStatus: types.AbsencePending,
+	})
+	require.NoError(t, err)
+	// pending absences are not part of a timesheet
+	createAbsence(date(2024, time.March, 11), 1)
+
+	absences, err := testStore.ListTimesheetAbsences(context.Background(), ListTimesheetAbsencesParams{
+		UserID: user.ID,
+		From:   date(2024, time.March, 1),
+		To:     date(2024, time.April, 1),
+	})
+	require.NoError(t, err)
+	require.Len(t, absences, 1)
+	require.Equal(t, approved.ID, absences[0].ID)
+	require.Equal(t, types.AbsenceFullDay, absences[0].Kind)
+	require.True(t, absences[0].Paid)
+	require.Nil(t, absences[0].AbsenceTypeName)
+}
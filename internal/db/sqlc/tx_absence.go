@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+
+	"github.com/mateoradman/tempus/internal/types"
+)
+
+// DecideAbsenceTx approves or rejects an absence and writes the absence.approved or absence.rejected webhook event
+// within a single transaction. It returns pgx.ErrNoRows if the status of the absence was changed.
+func (store SQLStore) DecideAbsenceTx(ctx context.Context, arg DecideAbsenceParams) (Absence, error) {
+	var absence Absence
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		absence, err = q.DecideAbsence(ctx, arg)
+		if err != nil {
+			return err
+		}
+		return recordUserWebhookEvent(ctx, q, absence.UserID, types.AbsenceEvent(absence.Status), absence)
+	})
+
+	return absence, err
+}
+
+// CancelAbsenceTx cancels an absence and writes the absence.cancelled webhook event within a single transaction.
+// It returns pgx.ErrNoRows if the status of the absence was changed.
+func (store SQLStore) CancelAbsenceTx(ctx context.Context, arg CancelAbsenceParams) (Absence, error) {
+	var absence Absence
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		absence, err = q.CancelAbsence(ctx, arg)
+		if err != nil {
+			return err
+		}
+		return recordUserWebhookEvent(ctx, q, absence.UserID, types.EventAbsenceCancelled, absence)
+	})
+
+	return absence, err
+}
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mateoradman/tempus/internal/types"
+)
+
+// CreateEntryTx creates an entry and writes the entry.created webhook event within a single transaction
+func (store SQLStore) CreateEntryTx(ctx context.Context, arg CreateEntryParams) (Entry, error) {
+	var entry Entry
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		entry, err = q.CreateEntry(ctx, arg)
+		if err != nil {
+			return err
+		}
+		return recordUserWebhookEvent(ctx, q, entry.UserID, types.EventEntryCreated, entry)
+	})
+
+	return entry, err
+}
+
+// UpdateEntryTx updates an entry and writes the entry.updated webhook event within a single transaction
+func (store SQLStore) UpdateEntryTx(ctx context.Context, arg UpdateEntryParams) (Entry, error) {
+	var entry Entry
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		entry, err = q.UpdateEntry(ctx, arg)
+		if err != nil {
+			return err
+		}
+		return recordUserWebhookEvent(ctx, q, entry.UserID, types.EventEntryUpdated, entry)
+	})
+
+	return entry, err
+}
+
+// StopRunningEntryTx stops the running entry of a user and writes the entry.updated webhook event within a single
+// transaction. It returns pgx.ErrNoRows if no entry of the user is running.
+func (store SQLStore) StopRunningEntryTx(ctx context.Context, arg StopRunningEntryParams) (Entry, error) {
+	var entry Entry
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		entry, err = q.StopRunningEntry(ctx, arg)
+		if err != nil {
+			return err
+		}
+		return recordUserWebhookEvent(ctx, q, entry.UserID, types.EventEntryUpdated, entry)
+	})
+
+	return entry, err
+}
+
+// DeleteEntryTx deletes an entry and writes the entry.deleted webhook event within a single transaction
+func (store SQLStore) DeleteEntryTx(ctx context.Context, id int64) (Entry, error) {
+	var entry Entry
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		entry, err = q.DeleteEntry(ctx, id)
+		if err != nil {
+			return err
+		}
+		return recordUserWebhookEvent(ctx, q, entry.UserID, types.EventEntryDeleted, entry)
+	})
+
+	return entry, err
+}
+
+// AutoCloseEntryTx stops a running entry at arg.EndTime and flags it for review, then writes the entry.auto_closed
+// webhook event and notifies the user of the entry within a single transaction. It returns pgx.ErrNoRows if the entry
+// is not running anymore.
+func (store SQLStore) AutoCloseEntryTx(ctx context.Context, arg AutoCloseEntryParams) (Entry, error) {
+	var entry Entry
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		entry, err = q.AutoCloseEntry(ctx, arg)
+		if err != nil {
+			return err
+		}
+		if err = recordUserWebhookEvent(ctx, q, entry.UserID, types.EventEntryAutoClosed, entry); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(map[string]any{
+			"entry_id":   entry.ID,
+			"start_time": entry.StartTime,
+			"end_time":   entry.EndTime,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = q.CreateNotification(ctx, CreateNotificationParams{
+			UserID: entry.UserID,
+			Kind:   types.NotificationEntryAutoClosed,
+			Data:   data,
+		})
+		return err
+	})
+
+	return entry, err
+}
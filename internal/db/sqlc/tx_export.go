@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// cursorBatchSize is the number of rows fetched from a cursor at a time
+const cursorBatchSize = 500
+
+// ExportEntriesTx calls fn with every entry of an export in order, reading them from a cursor
+// so that the entries are never all held in memory. It stops at the first error returned by fn.
+func (store SQLStore) ExportEntriesTx(ctx context.Context, arg ExportEntriesParams, fn func(ExportEntriesRow) error) error {
+	return streamRows(ctx, store, "export_entries", exportEntries, []interface{}{
+		arg.CompanyID,
+		arg.UserID,
+		arg.TeamID,
+		arg.From,
+		arg.To,
+	}, fn)
+}
+
+// ExportAbsencesTx calls fn with every absence of an export in order, reading them from a cursor
+// so that the absences are never all held in memory. It stops at the first error returned by fn.
+func (store SQLStore) ExportAbsencesTx(ctx context.Context, arg ExportAbsencesParams, fn func(ExportAbsencesRow) error) error {
+	return streamRows(ctx, store, "export_absences", exportAbsences, []interface{}{
+		arg.CompanyID,
+		arg.UserID,
+		arg.TeamID,
+		arg.Status,
+		arg.From,
+		arg.To,
+	}, fn)
+}
+
+// streamRows declares the cursor name for query within a transaction and fetches its rows in batches,
+// scanning them into T by column position. The rows are fetched over the simple protocol, as the
+// result of a FETCH depends on the cursor and must not be described from the statement cache.
+func streamRows[T any](ctx context.Context, store SQLStore, name, query string, args []interface{}, fn func(T) error) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		if _, err := q.db.Exec(ctx, "DECLARE "+name+" NO SCROLL CURSOR FOR "+query, args...); err != nil {
+			return err
+		}
+
+		fetch := fmt.Sprintf("FETCH %d FROM %s", cursorBatchSize, name)
+		for {
+			rows, err := q.db.Query(ctx, fetch, pgx.QueryExecModeSimpleProtocol)
+			if err != nil {
+				return err
+			}
+			var fetched int
+			for rows.Next() {
+				fetched++
+				row, err := pgx.RowToStructByPos[T](rows)
+				if err != nil {
+					rows.Close()
+					return err
+				}
+				if err := fn(row); err != nil {
+					rows.Close()
+					return err
+				}
+			}
+			rows.Close()
+			if err := rows.Err(); err != nil {
+				return err
+			}
+			if fetched < cursorBatchSize {
+				return nil
+			}
+		}
+	})
+}
@@ -0,0 +1,197 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Errors of the invoice drafting transaction caused by the entries of the period.
+var (
+	ErrNoInvoiceableEntries = errors.New("no billable entries to invoice")
+	ErrUnpricedEntries      = errors.New("no hourly rate applies to some billable entries")
+	ErrMixedCurrencies      = errors.New("billable entries are priced in more than one currency")
+)
+
+// invoiceNumberFormat formats the prefix and the number of an invoice sequence into an invoice number.
+const invoiceNumberFormat = "%s%05d"
+
+// DraftInvoiceTxParams contains the input parameters of the invoice drafting transaction
+type DraftInvoiceTxParams struct {
+	CompanyID int64
+	ClientID  int64
+	From      time.Time
+	To        time.Time
+}
+
+// DraftInvoiceTxResult is the result of the invoice drafting transaction
+type DraftInvoiceTxResult struct {
+	Invoice Invoice       `json:"invoice"`
+	Lines   []InvoiceLine `json:"lines"`
+}
+
+// invoiceLineKey identifies the entries billed on the same invoice line.
+type invoiceLineKey struct {
+	projectID int64
+	taskID    int64
+	rateCents int64
+}
+
+// DraftInvoiceTx drafts an invoice of the billable entries of a client which started within [From, To)
+// and are not invoiced yet. Entries are grouped into a line per project, task and rate, and are locked
+// by linking them to the invoice.
+func (store SQLStore) DraftInvoiceTx(ctx context.Context, arg DraftInvoiceTxParams) (DraftInvoiceTxResult, error) {
+	var result DraftInvoiceTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		entries, err := q.ListInvoiceableEntries(ctx, ListInvoiceableEntriesParams{
+			CompanyID: arg.CompanyID,
+			ClientID:  arg.ClientID,
+			From:      arg.From,
+			To:        arg.To,
+		})
+		if err != nil {
+			return err
+		}
+
+		lines, currency, err := invoiceLines(entries)
+		if err != nil {
+			return err
+		}
+
+		result.Invoice, err = q.CreateInvoice(ctx, CreateInvoiceParams{
+			CompanyID:  arg.CompanyID,
+			ClientID:   arg.ClientID,
+			Currency:   currency,
+			PeriodFrom: arg.From,
+			PeriodTo:   arg.To,
+		})
+		if err != nil {
+			return err
+		}
+
+		var total int64
+		result.Lines = make([]InvoiceLine, 0, len(lines))
+		for _, params := range lines {
+			params.InvoiceID = result.Invoice.ID
+			line, err := q.CreateInvoiceLine(ctx, params)
+			if err != nil {
+				return err
+			}
+			total += line.AmountCents
+			result.Lines = append(result.Lines, line)
+		}
+
+		ids := make([]int64, len(entries))
+		for i, entry := range entries {
+			ids[i] = entry.ID
+		}
+		if _, err := q.SetEntriesInvoice(ctx, SetEntriesInvoiceParams{InvoiceID: result.Invoice.ID, Ids: ids}); err != nil {
+			return err
+		}
+
+		result.Invoice, err = q.SetInvoiceTotal(ctx, SetInvoiceTotalParams{ID: result.Invoice.ID, TotalCents: total})
+		return err
+	})
+
+	return result, err
+}
+
+// invoiceLines groups invoiceable entries into invoice lines and returns them with the currency they are priced in.
+// The amount of a line is its time priced at its hourly rate, rounded half up to minor units.
+func invoiceLines(entries []ListInvoiceableEntriesRow) ([]CreateInvoiceLineParams, string, error) {
+	if len(entries) == 0 {
+		return nil, "", ErrNoInvoiceableEntries
+	}
+
+	var currency string
+	var lines []CreateInvoiceLineParams
+	index := make(map[invoiceLineKey]int)
+	for _, entry := range entries {
+		if entry.RateCents == nil || entry.Currency == nil {
+			return nil, "", ErrUnpricedEntries
+		}
+		if currency == "" {
+			currency = *entry.Currency
+		} else if currency != *entry.Currency {
+			return nil, "", ErrMixedCurrencies
+		}
+
+		key := invoiceLineKey{rateCents: *entry.RateCents}
+		if entry.ProjectID != nil {
+			key.projectID = *entry.ProjectID
+		}
+		if entry.TaskID != nil {
+			key.taskID = *entry.TaskID
+		}
+		i, ok := index[key]
+		if !ok {
+			description := entry.ProjectName
+			if entry.TaskName != nil {
+				description = fmt.Sprintf("%s: %s", entry.ProjectName, *entry.TaskName)
+			}
+			i = len(lines)
+			index[key] = i
+			lines = append(lines, CreateInvoiceLineParams{
+				ProjectID:   entry.ProjectID,
+				TaskID:      entry.TaskID,
+				Description: description,
+				RateCents:   *entry.RateCents,
+			})
+		}
+		lines[i].QuantitySeconds += entry.Seconds
+	}
+
+	for i := range lines {
+		lines[i].AmountCents = (lines[i].QuantitySeconds*lines[i].RateCents + 1800) / 3600
+	}
+	return lines, currency, nil
+}
+
+// IssueInvoiceTx issues a draft invoice with the next number of the invoice sequence of its company.
+// Numbers are gapless as a failed issue rolls back the sequence. It returns pgx.ErrNoRows if the invoice
+// is not a draft anymore.
+func (store SQLStore) IssueInvoiceTx(ctx context.Context, invoiceID int64) (Invoice, error) {
+	var invoice Invoice
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		invoice, err = q.GetInvoice(ctx, invoiceID)
+		if err != nil {
+			return err
+		}
+
+		sequence, err := q.NextInvoiceNumber(ctx, invoice.CompanyID)
+		if err != nil {
+			return err
+		}
+
+		number := fmt.Sprintf(invoiceNumberFormat, sequence.Prefix, sequence.LastNumber)
+		invoice, err = q.IssueInvoice(ctx, IssueInvoiceParams{ID: invoiceID, Number: &number})
+		return err
+	})
+
+	return invoice, err
+}
+
+// VoidInvoiceTx voids an issued invoice and releases its entries so that they can be invoiced again.
+// It returns pgx.ErrNoRows if the invoice is not issued.
+func (store SQLStore) VoidInvoiceTx(ctx context.Context, invoiceID int64) (Invoice, error) {
+	var invoice Invoice
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		invoice, err = q.VoidInvoice(ctx, invoiceID)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.ReleaseInvoiceEntries(ctx, &invoiceID)
+		return err
+	})
+
+	return invoice, err
+}
@@ -0,0 +1,22 @@
+package db
+
+import "context"
+
+// ImportPublicHolidaysTx creates the public holidays within a single transaction, renaming holidays
+// which already exist in the same country and subdivision on the same date.
+func (store SQLStore) ImportPublicHolidaysTx(ctx context.Context, arg []UpsertPublicHolidayParams) ([]PublicHoliday, error) {
+	holidays := make([]PublicHoliday, 0, len(arg))
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		for _, params := range arg {
+			holiday, err := q.UpsertPublicHoliday(ctx, params)
+			if err != nil {
+				return err
+			}
+			holidays = append(holidays, holiday)
+		}
+		return nil
+	})
+
+	return holidays, err
+}
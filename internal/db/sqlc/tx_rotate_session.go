@@ -0,0 +1,45 @@
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// RotateSessionTxParams contains the input parameters of the session rotation transaction
+type RotateSessionTxParams struct {
+	// ID of the session whose refresh token is exchanged
+	ID uuid.UUID
+	// New session, its username, family and parent are taken from the rotated session
+	CreateSessionParams
+}
+
+// RotateSessionTxResult is the result of the session rotation transaction
+type RotateSessionTxResult struct {
+	Rotated Session `json:"rotated"`
+	Session Session `json:"session"`
+}
+
+// RotateSessionTx marks a session as rotated and creates its successor within a single transaction.
+// It returns pgx.ErrNoRows if the session was already rotated or blocked.
+func (store SQLStore) RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (RotateSessionTxResult, error) {
+	var result RotateSessionTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Rotated, err = q.RotateSession(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		params := arg.CreateSessionParams
+		params.Username = result.Rotated.Username
+		params.FamilyID = result.Rotated.FamilyID
+		params.ParentID = &result.Rotated.ID
+		result.Session, err = q.CreateSession(ctx, params)
+		return err
+	})
+
+	return result, err
+}
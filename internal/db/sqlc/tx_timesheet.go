@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// ApproveTimesheetTxParams contains the input parameters of the timesheet approval transaction
+type ApproveTimesheetTxParams struct {
+	DecideTimesheetParams
+	// Render renders the document of the approved timesheet with the queries of the transaction, which see the
+	// entries of the timesheet as locked by its approval, and returns it along with the language it is rendered in
+	Render func(q Querier, timesheet Timesheet) (content []byte, language string, err error)
+}
+
+// ApproveTimesheetTxResult is the result of the timesheet approval transaction
+type ApproveTimesheetTxResult struct {
+	Timesheet Timesheet         `json:"timesheet"`
+	Document  TimesheetDocument `json:"document"`
+}
+
+// ApproveTimesheetTx approves a timesheet and stores its document together with the SHA-256 hash of the document
+// within a single transaction. It returns pgx.ErrNoRows if the status of the timesheet was changed.
+func (store SQLStore) ApproveTimesheetTx(ctx context.Context, arg ApproveTimesheetTxParams) (ApproveTimesheetTxResult, error) {
+	var result ApproveTimesheetTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Timesheet, err = q.DecideTimesheet(ctx, arg.DecideTimesheetParams)
+		if err != nil {
+			return err
+		}
+
+		content, language, err := arg.Render(q, result.Timesheet)
+		if err != nil {
+			return err
+		}
+
+		sum := sha256.Sum256(content)
+		result.Document, err = q.CreateTimesheetDocument(ctx, CreateTimesheetDocumentParams{
+			TimesheetID: result.Timesheet.ID,
+			Language:    language,
+			Content:     content,
+			Sha256:      hex.EncodeToString(sum[:]),
+		})
+		return err
+	})
+
+	return result, err
+}
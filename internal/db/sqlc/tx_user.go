@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+
+	"github.com/mateoradman/tempus/internal/types"
+)
+
+// userEventData is the payload of webhook events about a user, which leaves out the credentials of the user
+type userEventData struct {
+	ID        int64  `json:"id"`
+	CompanyID *int64 `json:"company_id"`
+	TeamID    *int64 `json:"team_id"`
+	Username  string `json:"username"`
+	Email     string `json:"email"`
+	Name      string `json:"name"`
+	Surname   string `json:"surname"`
+	Role      string `json:"role"`
+}
+
+// DeleteUserTx deletes a user and writes the user.deleted webhook event within a single transaction
+func (store SQLStore) DeleteUserTx(ctx context.Context, id int64) (User, error) {
+	var user User
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		user, err = q.DeleteUser(ctx, id)
+		if err != nil {
+			return err
+		}
+		return recordWebhookEvent(ctx, q, user.CompanyID, types.EventUserDeleted, userEventData{
+			ID:        user.ID,
+			CompanyID: user.CompanyID,
+			TeamID:    user.TeamID,
+			Username:  user.Username,
+			Email:     user.Email,
+			Name:      user.Name,
+			Surname:   user.Surname,
+			Role:      user.Role,
+		})
+	})
+
+	return user, err
+}
+
+// ChangePasswordTxParams contains the input parameters of the password change transaction
+type ChangePasswordTxParams struct {
+	UserID int64
+	// Hash of the new password
+	Password string
+	// New session, its username is taken from the user
+	CreateSessionParams
+}
+
+// ChangePasswordTxResult is the result of the password change transaction
+type ChangePasswordTxResult struct {
+	User    User    `json:"user"`
+	Session Session `json:"session"`
+}
+
+// ChangePasswordTx changes the password of a user, blocks all their sessions and creates a new one within a single
+// transaction, so that the old sessions never outlive the old password.
+func (store SQLStore) ChangePasswordTx(ctx context.Context, arg ChangePasswordTxParams) (ChangePasswordTxResult, error) {
+	var result ChangePasswordTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.User, err = q.UpdateUserPassword(ctx, UpdateUserPasswordParams{
+			ID:       arg.UserID,
+			Password: arg.Password,
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err = q.BlockUserSessions(ctx, result.User.Username); err != nil {
+			return err
+		}
+
+		params := arg.CreateSessionParams
+		params.Username = result.User.Username
+		result.Session, err = q.CreateSession(ctx, params)
+		return err
+	})
+
+	return result, err
+}
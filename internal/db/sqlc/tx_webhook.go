@@ -0,0 +1,46 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// recordWebhookEvent writes an event of a company to the outbox and queues its delivery to every active subscription
+// of the company to events of its type. Called within the transaction of the change the event describes, so that no
+// event of a committed change is lost and no event of a rolled back change is sent. Changes outside of any company
+// raise no events.
+func recordWebhookEvent(ctx context.Context, q *Queries, companyID *int64, eventType string, data any) error {
+	if companyID == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	event, err := q.CreateWebhookEvent(ctx, CreateWebhookEventParams{
+		CompanyID: *companyID,
+		Type:      eventType,
+		Payload:   payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = q.CreateWebhookDeliveries(ctx, CreateWebhookDeliveriesParams{
+		EventID:   event.ID,
+		CompanyID: event.CompanyID,
+		Type:      event.Type,
+	})
+	return err
+}
+
+// recordUserWebhookEvent writes an event about a record of a user to the outbox of the company of the user
+func recordUserWebhookEvent(ctx context.Context, q *Queries, userID int64, eventType string, data any) error {
+	user, err := q.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return recordWebhookEvent(ctx, q, user.CompanyID, eventType, data)
+}
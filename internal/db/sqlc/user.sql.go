@@ -12,7 +12,7 @@ import (
 
 const createUser = `-- name: CreateUser :one
 
-INSERT INTO users ( username, password, email, name, surname, birth_date, gender, language, country, timezone, company_id, manager_id, team_id)
+INSERT INTO users ( username, password, email, name, surname, birth_date, gender, language, country, timezone, company_id, manager_id, team_id, subdivision)
 VALUES ($1,
         $2,
         $3,
@@ -25,23 +25,25 @@ VALUES ($1,
         $10,
         $11,
         $12,
-        $13) RETURNING id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role
+        $13,
+        $14) RETURNING id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role, subdivision
 `
 
 type CreateUserParams struct {
-	Username  string    `json:"username"`
-	Password  string    `json:"password"`
-	Email     string    `json:"email"`
-	Name      string    `json:"name"`
-	Surname   string    `json:"surname"`
-	BirthDate time.Time `json:"birth_date"`
-	Gender    string    `json:"gender"`
-	Language  string    `json:"language"`
-	Country   *string   `json:"country"`
-	Timezone  string    `json:"timezone"`
-	CompanyID *int64    `json:"company_id"`
-	ManagerID *int64    `json:"manager_id"`
-	TeamID    *int64    `json:"team_id"`
+	Username    string    `json:"username"`
+	Password    string    `json:"password"`
+	Email       string    `json:"email"`
+	Name        string    `json:"name"`
+	Surname     string    `json:"surname"`
+	BirthDate   time.Time `json:"birth_date"`
+	Gender      string    `json:"gender"`
+	Language    string    `json:"language"`
+	Country     *string   `json:"country"`
+	Timezone    string    `json:"timezone"`
+	CompanyID   *int64    `json:"company_id"`
+	ManagerID   *int64    `json:"manager_id"`
+	TeamID      *int64    `json:"team_id"`
+	Subdivision *string   `json:"subdivision"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
@@ -59,6 +61,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		arg.CompanyID,
 		arg.ManagerID,
 		arg.TeamID,
+		arg.Subdivision,
 	)
 	var i User
 	err := row.Scan(
@@ -79,6 +82,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.ManagerID,
 		&i.TeamID,
 		&i.Role,
+		&i.Subdivision,
 	)
 	return i, err
 }
@@ -87,7 +91,7 @@ const deleteUser = `-- name: DeleteUser :one
 
 DELETE
 FROM users
-WHERE id = $1 RETURNING id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role
+WHERE id = $1 RETURNING id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role, subdivision
 `
 
 func (q *Queries) DeleteUser(ctx context.Context, id int64) (User, error) {
@@ -111,13 +115,14 @@ func (q *Queries) DeleteUser(ctx context.Context, id int64) (User, error) {
 		&i.ManagerID,
 		&i.TeamID,
 		&i.Role,
+		&i.Subdivision,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
 
-SELECT id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role
+SELECT id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role, subdivision
 FROM users
 WHERE id = $1
 LIMIT 1
@@ -144,13 +149,14 @@ func (q *Queries) GetUser(ctx context.Context, id int64) (User, error) {
 		&i.ManagerID,
 		&i.TeamID,
 		&i.Role,
+		&i.Subdivision,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
 
-SELECT id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role
+SELECT id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role, subdivision
 FROM users
 WHERE email = $1
 LIMIT 1
@@ -177,13 +183,14 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.ManagerID,
 		&i.TeamID,
 		&i.Role,
+		&i.Subdivision,
 	)
 	return i, err
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
 
-SELECT id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role
+SELECT id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role, subdivision
 FROM users
 WHERE username = $1
 LIMIT 1
@@ -210,26 +217,29 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User,
 		&i.ManagerID,
 		&i.TeamID,
 		&i.Role,
+		&i.Subdivision,
 	)
 	return i, err
 }
 
 const listUsers = `-- name: ListUsers :many
 
-SELECT id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role
+SELECT id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role, subdivision
 FROM users
+WHERE ($1::bigint IS NULL OR company_id = $1)
 ORDER BY id
-LIMIT $1
-OFFSET $2
+LIMIT $2
+OFFSET $3
 `
 
 type ListUsersParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	CompanyID *int64 `json:"company_id"`
+	Limit     int32  `json:"limit"`
+	Offset    int32  `json:"offset"`
 }
 
 func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
-	rows, err := q.db.Query(ctx, listUsers, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listUsers, arg.CompanyID, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -255,6 +265,7 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 			&i.ManagerID,
 			&i.TeamID,
 			&i.Role,
+			&i.Subdivision,
 		); err != nil {
 			return nil, err
 		}
@@ -274,18 +285,20 @@ SET name = COALESCE($1, name),
     gender = COALESCE($3, gender),
     birth_date = COALESCE($4::timestamp, birth_date),
     language = COALESCE($5, language),
-    country = COALESCE($6, country)
-WHERE id = $7 RETURNING id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role
+    country = COALESCE($6, country),
+    subdivision = COALESCE($7, subdivision)
+WHERE id = $8 RETURNING id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role, subdivision
 `
 
 type UpdateUserParams struct {
-	Name      *string    `json:"name"`
-	Surname   *string    `json:"surname"`
-	Gender    *string    `json:"gender"`
-	BirthDate *time.Time `json:"birth_date"`
-	Language  *string    `json:"language"`
-	Country   *string    `json:"country"`
-	ID        int64      `json:"id"`
+	Name        *string    `json:"name"`
+	Surname     *string    `json:"surname"`
+	Gender      *string    `json:"gender"`
+	BirthDate   *time.Time `json:"birth_date"`
+	Language    *string    `json:"language"`
+	Country     *string    `json:"country"`
+	Subdivision *string    `json:"subdivision"`
+	ID          int64      `json:"id"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
@@ -296,6 +309,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.BirthDate,
 		arg.Language,
 		arg.Country,
+		arg.Subdivision,
 		arg.ID,
 	)
 	var i User
@@ -317,6 +331,121 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.ManagerID,
 		&i.TeamID,
 		&i.Role,
+		&i.Subdivision,
+	)
+	return i, err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :one
+
+UPDATE users
+SET password = $2
+WHERE id = $1 RETURNING id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role, subdivision
+`
+
+type UpdateUserPasswordParams struct {
+	ID       int64  `json:"id"`
+	Password string `json:"password"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserPassword, arg.ID, arg.Password)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.Name,
+		&i.Surname,
+		&i.CompanyID,
+		&i.Password,
+		&i.Gender,
+		&i.BirthDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Language,
+		&i.Country,
+		&i.Timezone,
+		&i.ManagerID,
+		&i.TeamID,
+		&i.Role,
+		&i.Subdivision,
+	)
+	return i, err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :one
+
+UPDATE users
+SET role = $2
+WHERE id = $1 RETURNING id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role, subdivision
+`
+
+type UpdateUserRoleParams struct {
+	ID   int64  `json:"id"`
+	Role string `json:"role"`
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserRole, arg.ID, arg.Role)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.Name,
+		&i.Surname,
+		&i.CompanyID,
+		&i.Password,
+		&i.Gender,
+		&i.BirthDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Language,
+		&i.Country,
+		&i.Timezone,
+		&i.ManagerID,
+		&i.TeamID,
+		&i.Role,
+		&i.Subdivision,
+	)
+	return i, err
+}
+
+const updateUserTeam = `-- name: UpdateUserTeam :one
+
+UPDATE users
+SET team_id = $2
+WHERE id = $1 RETURNING id, username, email, name, surname, company_id, password, gender, birth_date, created_at, updated_at, language, country, timezone, manager_id, team_id, role, subdivision
+`
+
+type UpdateUserTeamParams struct {
+	ID     int64  `json:"id"`
+	TeamID *int64 `json:"team_id"`
+}
+
+func (q *Queries) UpdateUserTeam(ctx context.Context, arg UpdateUserTeamParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserTeam, arg.ID, arg.TeamID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.Name,
+		&i.Surname,
+		&i.CompanyID,
+		&i.Password,
+		&i.Gender,
+		&i.BirthDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Language,
+		&i.Country,
+		&i.Timezone,
+		&i.ManagerID,
+		&i.TeamID,
+		&i.Role,
+		&i.Subdivision,
 	)
 	return i, err
 }
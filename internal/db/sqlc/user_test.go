@@ -128,6 +128,44 @@ func TestUpdateUser(t *testing.T) {
 	require.WithinDuration(t, time.Now(), *updatedUser.UpdatedAt, time.Second)
 }
 
+func TestUpdateUserRole(t *testing.T) {
+	user := createRandomUser(t, nil, nil)
+	require.Equal(t, types.EmployeeRole, user.Role)
+
+	arg := UpdateUserRoleParams{
+		ID:   user.ID,
+		Role: types.ManagerRole,
+	}
+
+	updatedUser, err := testStore.UpdateUserRole(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, updatedUser.ID)
+	require.Equal(t, arg.Role, updatedUser.Role)
+	require.Equal(t, user.Username, updatedUser.Username)
+	require.NotNil(t, updatedUser.UpdatedAt)
+	require.WithinDuration(t, time.Now(), *updatedUser.UpdatedAt, time.Second)
+}
+
+func TestUpdateUserTeam(t *testing.T) {
+	team := createRandomTeam(t)
+	user := createRandomUser(t, team.CompanyID, nil)
+
+	arg := UpdateUserTeamParams{
+		ID:     user.ID,
+		TeamID: &team.ID,
+	}
+	updatedUser, err := testStore.UpdateUserTeam(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, updatedUser.ID)
+	require.Equal(t, arg.TeamID, updatedUser.TeamID)
+	require.NotNil(t, updatedUser.UpdatedAt)
+
+	arg.TeamID = nil
+	updatedUser, err = testStore.UpdateUserTeam(context.Background(), arg)
+	require.NoError(t, err)
+	require.Nil(t, updatedUser.TeamID)
+}
+
 func TestDeleteUser(t *testing.T) {
 	user := createRandomUser(t, nil, nil)
 	deletedUser, err := testStore.DeleteUser(context.Background(), user.ID)
@@ -161,3 +199,23 @@ func TestListUsers(t *testing.T) {
 		require.NotEmpty(t, user)
 	}
 }
+
+func TestUpdateUserPassword(t *testing.T) {
+	user := createRandomUser(t, nil, nil)
+	password := util.RandomString(10)
+	hashedPassword, err := util.HashPassword(password)
+	require.NoError(t, err)
+
+	arg := UpdateUserPasswordParams{
+		ID:       user.ID,
+		Password: hashedPassword,
+	}
+
+	updatedUser, err := testStore.UpdateUserPassword(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, updatedUser.ID)
+	require.Equal(t, arg.Password, updatedUser.Password)
+	require.NoError(t, util.CheckPassword(password, updatedUser.Password))
+	require.NotNil(t, updatedUser.UpdatedAt)
+	require.WithinDuration(t, time.Now(), *updatedUser.UpdatedAt, time.Second)
+}
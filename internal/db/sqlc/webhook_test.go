@@ -0,0 +1,158 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomWebhook(t *testing.T, companyID int64, events ...string) WebhookSubscription {
+	arg := CreateWebhookSubscriptionParams{
+		CompanyID: companyID,
+		Url:       "https://example.com/" + util.RandomString(10),
+		Secret:    util.RandomString(64),
+		Events:    events,
+	}
+	subscription, err := testStore.CreateWebhookSubscription(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Url, subscription.Url)
+	require.Equal(t, arg.Events, subscription.Events)
+	require.True(t, subscription.Active)
+	return subscription
+}
+
+func TestWebhookOutbox(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	entries := createRandomWebhook(t, company.ID, types.EventEntryCreated, types.EventEntryUpdated)
+	users := createRandomWebhook(t, company.ID, types.EventUserDeleted)
+
+	entry, err := testStore.CreateEntryTx(context.Background(), CreateEntryParams{
+		UserID:    user.ID,
+		StartTime: time.Now().UTC().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+
+	// the event is delivered to the subscriptions of its type only
+	deliveries, err := testStore.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		SubscriptionID: entries.ID,
+		Limit:          10,
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, types.DeliveryPending, deliveries[0].Status)
+	require.Zero(t, deliveries[0].Attempts)
+
+	none, err := testStore.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		SubscriptionID: users.ID,
+		Limit:          10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, none)
+
+	request, err := testStore.GetWebhookDeliveryRequest(context.Background(), deliveries[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, entries.Url, request.Url)
+	require.Equal(t, types.EventEntryCreated, request.Type)
+	var payload Entry
+	require.NoError(t, json.Unmarshal(request.Payload, &payload))
+	require.Equal(t, entry.ID, payload.ID)
+
+	// a failing change writes no event
+	_, err = testStore.CreateEntryTx(context.Background(), CreateEntryParams{
+		UserID:    user.ID,
+		StartTime: time.Now().UTC(),
+	})
+	require.Error(t, err)
+	deliveries, err = testStore.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		SubscriptionID: entries.ID,
+		Limit:          10,
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+
+	// deleted users are delivered without their credentials
+	_, err = testStore.DeleteEntryTx(context.Background(), entry.ID)
+	require.NoError(t, err)
+	_, err = testStore.DeleteUserTx(context.Background(), user.ID)
+	require.NoError(t, err)
+	deliveries, err = testStore.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		SubscriptionID: users.ID,
+		Limit:          10,
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	request, err = testStore.GetWebhookDeliveryRequest(context.Background(), deliveries[0].ID)
+	require.NoError(t, err)
+	require.NotContains(t, string(request.Payload), user.Password)
+}
+
+func TestWebhookDeliveryAttempts(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	subscription := createRandomWebhook(t, company.ID, types.EventEntryCreated)
+
+	_, err := testStore.CreateEntryTx(context.Background(), CreateEntryParams{
+		UserID:    user.ID,
+		StartTime: time.Now().UTC().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+
+	deliveries, err := testStore.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		SubscriptionID: subscription.ID,
+		Limit:          10,
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	delivery := deliveries[0]
+
+	// claimed deliveries are locked until the attempt times out
+	lockedUntil := time.Now().UTC().Add(time.Minute)
+	claimed, err := testStore.ClaimWebhookDeliveries(context.Background(), ClaimWebhookDeliveriesParams{
+		LockedUntil: lockedUntil,
+		Limit:       1000,
+	})
+	require.NoError(t, err)
+	var found bool
+	for _, c := range claimed {
+		if c.ID == delivery.ID {
+			found = true
+			require.WithinDuration(t, lockedUntil, c.NextAttemptAt, time.Millisecond)
+		}
+	}
+	require.True(t, found)
+
+	claimed, err = testStore.ClaimWebhookDeliveries(context.Background(), ClaimWebhookDeliveriesParams{
+		LockedUntil: lockedUntil,
+		Limit:       1000,
+	})
+	require.NoError(t, err)
+	for _, c := range claimed {
+		require.NotEqual(t, delivery.ID, c.ID)
+	}
+
+	recorded, err := testStore.RecordWebhookDeliveryAttempt(context.Background(), RecordWebhookDeliveryAttemptParams{
+		ID:             delivery.ID,
+		Status:         types.DeliveryFailed,
+		NextAttemptAt:  time.Now().UTC(),
+		ResponseStatus: util.Pointer(int32(500)),
+		ResponseBody:   util.Pointer("failure"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, types.DeliveryFailed, recorded.Status)
+	require.Equal(t, int32(1), recorded.Attempts)
+	require.NotNil(t, recorded.LastAttemptAt)
+
+	redelivery, err := testStore.CreateWebhookDelivery(context.Background(), CreateWebhookDeliveryParams{
+		SubscriptionID: subscription.ID,
+		EventID:        delivery.EventID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, types.DeliveryPending, redelivery.Status)
+	require.NotEqual(t, delivery.ID, redelivery.ID)
+}
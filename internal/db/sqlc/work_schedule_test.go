@@ -0,0 +1,235 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomWorkSchedule(t *testing.T, companyID int64) WorkSchedule {
+	arg := CreateWorkScheduleParams{
+		CompanyID:        companyID,
+		Name:             util.RandomString(10),
+		MondayMinutes:    480,
+		TuesdayMinutes:   480,
+		WednesdayMinutes: 480,
+		ThursdayMinutes:  480,
+		FridayMinutes:    300,
+		PartTimePercent:  80,
+	}
+	schedule, err := testStore.CreateWorkSchedule(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, schedule.ID)
+	require.Equal(t, arg.CompanyID, schedule.CompanyID)
+	require.Equal(t, arg.Name, schedule.Name)
+	require.Equal(t, arg.FridayMinutes, schedule.FridayMinutes)
+	require.Zero(t, schedule.SaturdayMinutes)
+	require.Equal(t, arg.PartTimePercent, schedule.PartTimePercent)
+	require.WithinDuration(t, time.Now(), schedule.CreatedAt, 2*time.Second)
+	require.Nil(t, schedule.UpdatedAt)
+	return schedule
+}
+
+func TestCreateWorkSchedule(t *testing.T) {
+	company := createRandomCompany(t)
+	createRandomWorkSchedule(t, company.ID)
+}
+
+func TestWorkScheduleConstraints(t *testing.T) {
+	company := createRandomCompany(t)
+
+	_, err := testStore.CreateWorkSchedule(context.Background(), CreateWorkScheduleParams{
+		CompanyID:       company.ID,
+		Name:            util.RandomString(10),
+		MondayMinutes:   1441,
+		PartTimePercent: 100,
+	})
+	require.Equal(t, CheckViolation, ErrorCode(err))
+
+	_, err = testStore.CreateWorkSchedule(context.Background(), CreateWorkScheduleParams{
+		CompanyID:       company.ID,
+		Name:            util.RandomString(10),
+		PartTimePercent: 0,
+	})
+	require.Equal(t, CheckViolation, ErrorCode(err))
+}
+
+func TestUpdateWorkSchedule(t *testing.T) {
+	company := createRandomCompany(t)
+	schedule := createRandomWorkSchedule(t, company.ID)
+
+	arg := UpdateWorkScheduleParams{
+		ID:              schedule.ID,
+		Name:            util.RandomString(10),
+		MondayMinutes:   240,
+		SundayMinutes:   120,
+		PartTimePercent: 50,
+	}
+	updated, err := testStore.UpdateWorkSchedule(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, schedule.ID, updated.ID)
+	require.Equal(t, arg.Name, updated.Name)
+	require.Equal(t, arg.MondayMinutes, updated.MondayMinutes)
+	require.Zero(t, updated.TuesdayMinutes)
+	require.Equal(t, arg.SundayMinutes, updated.SundayMinutes)
+	require.Equal(t, arg.PartTimePercent, updated.PartTimePercent)
+	require.NotNil(t, updated.UpdatedAt)
+}
+
+func TestListWorkSchedules(t *testing.T) {
+	company := createRandomCompany(t)
+	otherCompany := createRandomCompany(t)
+	for i := 0; i < 3; i++ {
+		createRandomWorkSchedule(t, company.ID)
+	}
+	createRandomWorkSchedule(t, otherCompany.ID)
+
+	schedules, err := testStore.ListWorkSchedules(context.Background(), ListWorkSchedulesParams{
+		CompanyID: &company.ID,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, schedules, 3)
+	for _, schedule := range schedules {
+		require.Equal(t, company.ID, schedule.CompanyID)
+	}
+}
+
+func TestCompanyDefaultWorkSchedule(t *testing.T) {
+	company := createRandomCompany(t)
+	schedule := createRandomWorkSchedule(t, company.ID)
+
+	updated, err := testStore.UpdateCompanyWorkSchedule(context.Background(), UpdateCompanyWorkScheduleParams{
+		ID:             company.ID,
+		WorkScheduleID: &schedule.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &schedule.ID, updated.DefaultWorkScheduleID)
+
+	// deleting the default schedule unsets it
+	_, err = testStore.DeleteWorkSchedule(context.Background(), schedule.ID)
+	require.NoError(t, err)
+	got, err := testStore.GetCompany(context.Background(), company.ID)
+	require.NoError(t, err)
+	require.Nil(t, got.DefaultWorkScheduleID)
+}
+
+func TestWorkScheduleAssignments(t *testing.T) {
+	company := createRandomCompany(t)
+	user := createRandomUser(t, &company.ID, nil)
+	schedule := createRandomWorkSchedule(t, company.ID)
+
+	later, err := testStore.CreateWorkScheduleAssignment(context.Background(), CreateWorkScheduleAssignmentParams{
+		UserID:         user.ID,
+		WorkScheduleID: schedule.ID,
+		EffectiveFrom:  date(2024, time.June, 1),
+	})
+	require.NoError(t, err)
+	earlier, err := testStore.CreateWorkScheduleAssignment(context.Background(), CreateWorkScheduleAssignmentParams{
+		UserID:         user.ID,
+		WorkScheduleID: schedule.ID,
+		EffectiveFrom:  date(2024, time.January, 1),
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.CreateWorkScheduleAssignment(context.Background(), CreateWorkScheduleAssignmentParams{
+		UserID:         user.ID,
+		WorkScheduleID: schedule.ID,
+		EffectiveFrom:  date(2024, time.June, 1),
+	})
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+	require.Equal(t, WorkScheduleEffectiveFromConstraint, ConstraintName(err))
+
+	assignments, err := testStore.ListUserWorkScheduleAssignments(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, assignments, 2)
+	require.Equal(t, earlier.ID, assignments[0].ID)
+	require.Equal(t, later.ID, assignments[1].ID)
+
+	// an assigned schedule cannot be deleted
+	_, err = testStore.DeleteWorkSchedule(context.Background(), schedule.ID)
+	require.Equal(t, ForeignKeyViolation, ErrorCode(err))
+
+	_, err = testStore.DeleteWorkScheduleAssignment(context.Background(), later.ID)
+	require.NoError(t, err)
+	_, err = testStore.GetWorkScheduleAssignment(context.Background(), later.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestListDailyWorkedSeconds(t *testing.T) {
+	user := createRandomUser(t, nil, nil)
+	require.Equal(t, "Europe/Zagreb", user.Timezone)
+
+	// 23:30 UTC on March 4th is already March 5th in Zagreb
+	createClosedEntry(t, user.ID, time.Date(2024, time.March, 4, 23, 30, 0, 0, time.UTC))
+	createClosedEntry(t, user.ID, time.Date(2024, time.March, 5, 8, 0, 0, 0, time.UTC))
+	createClosedEntry(t, user.ID, time.Date(2024, time.March, 6, 8, 0, 0, 0, time.UTC))
+	createClosedEntry(t, user.ID, time.Date(2024, time.March, 8, 8, 0, 0, 0, time.UTC))
+
+	rows, err := testStore.ListDailyWorkedSeconds(context.Background(), ListDailyWorkedSecondsParams{
+		UserID: user.ID,
+		From:   date(2024, time.March, 5),
+		To:     date(2024, time.March, 8),
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, date(2024, time.March, 5), rows[0].Day)
+	require.Equal(t, int64(2*3600), rows[0].WorkedSeconds)
+	require.Equal(t, date(2024, time.March, 6), rows[1].Day)
+	require.Equal(t, int64(3600), rows[1].WorkedSeconds)
+}
+
+func TestListUserPaidAbsences(t *testing.T) {
+	user := createRandomUser(t, nil, nil)
+	manager := createRandomUser(t, nil, nil)
+
+	createAbsence := func(start time.Time, end *time.Time, paid bool, status string) Absence {
+		absence, err := testStore.CreateAbsence(context.Background(), CreateAbsenceParams{
+			UserID:    user.ID,
+			StartTime: start,
+			EndTime:   end,
+			Reason:    util.RandomString(10),
+			Paid:      paid,
+			Kind:      types.AbsenceHours,
+		})
+		require.NoError(t, err)
+		if status == types.AbsencePending {
+			return absence
+		}
+		absence, err = testStore.DecideAbsence(context.Background(), DecideAbsenceParams{
+			ID:            absence.ID,
+			Status:        status,
+			ApprovedByID:  &manager.ID,
+			CurrentStatus: types.AbsencePending,
+		})
+		require.NoError(t, err)
+		return absence
+	}
+
+	// active absences of a user must not overlap, rejected ones may
+	end := date(2024, time.March, 6)
+	createAbsence(date(2024, time.March, 4), &end, true, types.AbsenceRejected)
+	approved := createAbsence(date(2024, time.March, 4), &end, true, types.AbsenceApproved)
+	open := createAbsence(date(2024, time.March, 20), nil, true, types.AbsenceApproved)
+	unpaidEnd := date(2024, time.March, 8)
+	createAbsence(date(2024, time.March, 7), &unpaidEnd, false, types.AbsenceApproved)
+	pendingEnd := date(2024, time.March, 12)
+	createAbsence(date(2024, time.March, 11), &pendingEnd, true, types.AbsencePending)
+	endedBefore := date(2024, time.March, 1)
+	createAbsence(date(2024, time.February, 26), &endedBefore, true, types.AbsenceApproved)
+
+	absences, err := testStore.ListUserPaidAbsences(context.Background(), ListUserPaidAbsencesParams{
+		UserID: user.ID,
+		From:   date(2024, time.March, 1),
+		To:     date(2024, time.March, 31),
+	})
+	require.NoError(t, err)
+	require.Len(t, absences, 2)
+	ids := []int64{absences[0].ID, absences[1].ID}
+	require.ElementsMatch(t, []int64{approved.ID, open.ID}, ids)
+}
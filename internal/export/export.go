@@ -0,0 +1,160 @@
+// Package export writes spreadsheets as CSV or XLSX files one row at a time, so that exports of any size
+// can be streamed to the client while their rows are read from the database.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/mateoradman/tempus/internal/types"
+)
+
+// ErrUnknownColumn is returned when selecting a column an export does not have
+var ErrUnknownColumn = errors.New("unknown column")
+
+// Writer writes a spreadsheet one row at a time. Cells are strings, int64 or float64 numbers, or nil for empty cells.
+type Writer interface {
+	Write(cells []any) error
+	// Close completes the spreadsheet. It does not close the underlying writer.
+	Close() error
+}
+
+// NewWriter returns a writer of a spreadsheet in format, CSV unless it is XLSX
+func NewWriter(w io.Writer, format string) Writer {
+	if format == types.ExportXLSX {
+		return newXLSXWriter(w)
+	}
+	return newCSVWriter(w)
+}
+
+// ContentType returns the media type of spreadsheets in format
+func ContentType(format string) string {
+	if format == types.ExportXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Column is a column of a spreadsheet of rows of type T
+type Column[T any] struct {
+	// Name identifies the column when selecting the columns of an export
+	Name string
+	// Header is the first cell of the column
+	Header string
+	// Value returns the cell of the column in a row
+	Value func(row T) any
+	// Total sums the numeric cells of the column in a row after the last row
+	Total bool
+}
+
+// Select returns the columns of all with the given names in the order of names, or all columns without names
+func Select[T any](all []Column[T], names []string) ([]Column[T], error) {
+	if len(names) == 0 {
+		return all, nil
+	}
+
+	columns := make([]Column[T], 0, len(names))
+	for _, name := range names {
+		i := indexOf(all, name)
+		if i < 0 {
+			return nil, fmt.Errorf("%w %q", ErrUnknownColumn, name)
+		}
+		columns = append(columns, all[i])
+	}
+	return columns, nil
+}
+
+func indexOf[T any](columns []Column[T], name string) int {
+	for i, column := range columns {
+		if column.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Table writes rows of type T as a header row, a row per row and, if any column is summed, a row of totals
+type Table[T any] struct {
+	writer  Writer
+	columns []Column[T]
+	totals  []float64
+	started bool
+}
+
+// NewTable returns a table writing the columns of rows to writer
+func NewTable[T any](writer Writer, columns []Column[T]) *Table[T] {
+	return &Table[T]{
+		writer:  writer,
+		columns: columns,
+		totals:  make([]float64, len(columns)),
+	}
+}
+
+// Write writes a row, preceded by the header row on the first call
+func (t *Table[T]) Write(row T) error {
+	if err := t.start(); err != nil {
+		return err
+	}
+
+	cells := make([]any, len(t.columns))
+	for i, column := range t.columns {
+		cells[i] = column.Value(row)
+		if column.Total {
+			t.totals[i] += number(cells[i])
+		}
+	}
+	return t.writer.Write(cells)
+}
+
+// Close writes the header row if no row was written and the totals, then completes the spreadsheet
+func (t *Table[T]) Close() error {
+	if err := t.start(); err != nil {
+		return err
+	}
+
+	var summed bool
+	cells := make([]any, len(t.columns))
+	for i, column := range t.columns {
+		if column.Total {
+			summed = true
+			// sums of decimals are rounded to the cent, like the decimals of the rows
+			cells[i] = math.Round(t.totals[i]*100) / 100
+		}
+	}
+	if summed {
+		if cells[0] == nil {
+			cells[0] = "Total"
+		}
+		if err := t.writer.Write(cells); err != nil {
+			return err
+		}
+	}
+	return t.writer.Close()
+}
+
+// start writes the header row once
+func (t *Table[T]) start() error {
+	if t.started {
+		return nil
+	}
+	t.started = true
+
+	header := make([]any, len(t.columns))
+	for i, column := range t.columns {
+		header[i] = column.Header
+	}
+	return t.writer.Write(header)
+}
+
+// number returns the value of a numeric cell and zero for any other cell
+func number(cell any) float64 {
+	switch v := cell.(type) {
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
@@ -0,0 +1,59 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	name  string
+	hours float64
+	count int64
+}
+
+var itemColumns = []Column[item]{
+	{Name: "name", Header: "Name", Value: func(row item) any { return row.name }},
+	{Name: "hours", Header: "Hours", Value: func(row item) any { return row.hours }, Total: true},
+	{Name: "count", Header: "Count", Value: func(row item) any { return row.count }, Total: true},
+}
+
+func TestSelect(t *testing.T) {
+	columns, err := Select(itemColumns, nil)
+	require.NoError(t, err)
+	require.Len(t, columns, 3)
+
+	columns, err = Select(itemColumns, []string{"count", "name"})
+	require.NoError(t, err)
+	require.Equal(t, "count", columns[0].Name)
+	require.Equal(t, "name", columns[1].Name)
+
+	_, err = Select(itemColumns, []string{"name", "price"})
+	require.ErrorIs(t, err, ErrUnknownColumn)
+}
+
+func TestTableCSV(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewTable(NewWriter(&buf, types.ExportCSV), itemColumns)
+	require.NoError(t, table.Write(item{name: "Design, review", hours: 1.1, count: 2}))
+	require.NoError(t, table.Write(item{name: "=HYPERLINK()", hours: 2.2, count: 3}))
+	require.NoError(t, table.Close())
+
+	require.Equal(t, "Name,Hours,Count\n"+
+		"\"Design, review\",1.1,2\n"+
+		"'=HYPERLINK(),2.2,3\n"+
+		"Total,3.3,5\n", buf.String())
+}
+
+func TestTableWithoutRows(t *testing.T) {
+	var buf bytes.Buffer
+	columns, err := Select(itemColumns, []string{"name"})
+	require.NoError(t, err)
+	table := NewTable(NewWriter(&buf, types.ExportCSV), columns)
+	require.NoError(t, table.Close())
+
+	// nothing is summed, so there is no totals row
+	require.Equal(t, "Name\n", buf.String())
+}
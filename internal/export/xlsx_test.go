@@ -0,0 +1,73 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/stretchr/testify/require"
+)
+
+func readPart(t *testing.T, archive *zip.Reader, name string) string {
+	for _, file := range archive.File {
+		if file.Name == name {
+			r, err := file.Open()
+			require.NoError(t, err)
+			defer r.Close()
+			data, err := io.ReadAll(r)
+			require.NoError(t, err)
+			return string(data)
+		}
+	}
+	t.Fatalf("missing part %s", name)
+	return ""
+}
+
+func TestTableXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewTable(NewWriter(&buf, types.ExportXLSX), itemColumns)
+	require.NoError(t, table.Write(item{name: "R&D <internal>", hours: 1.5, count: 2}))
+	require.NoError(t, table.Close())
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	for _, part := range xlsxParts {
+		readPart(t, archive, part.name)
+	}
+
+	sheet := readPart(t, archive, "xl/worksheets/sheet1.xml")
+	var worksheet struct {
+		Rows []struct {
+			Ref   string `xml:"r,attr"`
+			Cells []struct {
+				Ref    string `xml:"r,attr"`
+				Type   string `xml:"t,attr"`
+				Value  string `xml:"v"`
+				Inline string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	require.NoError(t, xml.NewDecoder(strings.NewReader(sheet)).Decode(&worksheet))
+	require.Len(t, worksheet.Rows, 3)
+
+	row := worksheet.Rows[1]
+	require.Equal(t, "2", row.Ref)
+	require.Equal(t, "A2", row.Cells[0].Ref)
+	require.Equal(t, "inlineStr", row.Cells[0].Type)
+	require.Equal(t, "R&D <internal>", row.Cells[0].Inline)
+	require.Equal(t, "1.5", row.Cells[1].Value)
+	require.Equal(t, "2", row.Cells[2].Value)
+	require.Equal(t, "Total", worksheet.Rows[2].Cells[0].Inline)
+}
+
+func TestColumnName(t *testing.T) {
+	require.Equal(t, "A", columnName(0))
+	require.Equal(t, "Z", columnName(25))
+	require.Equal(t, "AA", columnName(26))
+	require.Equal(t, "AZ", columnName(51))
+	require.Equal(t, "BA", columnName(52))
+}
@@ -0,0 +1,157 @@
+// Package holiday generates public holidays from a bundled dataset, reads them from iCalendar files
+// and resolves the days off a company observes.
+package holiday
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrUnknownCountry is returned for countries the bundled dataset has no holidays of
+var ErrUnknownCountry = errors.New("no bundled holidays for country")
+
+// Holiday is a named day off. Dates are calendar dates at midnight UTC.
+type Holiday struct {
+	Date time.Time `json:"date"`
+	Name string    `json:"name"`
+}
+
+// Override replaces the public holidays on its date. It is a holiday only if DayOff is set.
+type Override struct {
+	Holiday
+	DayOff bool
+}
+
+// rule describes a holiday which recurs every year, either on a fixed date or relative to Easter Sunday
+type rule struct {
+	Name string `json:"name"`
+	// Date is the month and day of fixed holidays, formatted as MM-DD
+	Date string `json:"date,omitempty"`
+	// Easter is the number of days after Easter Sunday of movable holidays
+	Easter *int `json:"easter,omitempty"`
+	// Subdivisions observe the holiday, the whole country if empty
+	Subdivisions []string `json:"subdivisions,omitempty"`
+	// Since and Until bound the years the holiday is observed in, both inclusive
+	Since int `json:"since,omitempty"`
+	Until int `json:"until,omitempty"`
+}
+
+//go:embed holidays.json
+var dataset []byte
+
+// rules are the holidays of the bundled dataset by ISO 3166-1 alpha-2 country code
+var rules map[string][]rule
+
+func init() {
+	if err := json.Unmarshal(dataset, &rules); err != nil {
+		panic(fmt.Sprintf("invalid bundled holidays: %v", err))
+	}
+}
+
+// Countries returns the countries of the bundled dataset
+func Countries() []string {
+	countries := make([]string, 0, len(rules))
+	for country := range rules {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	return countries
+}
+
+// Generate returns the holidays of the bundled dataset in year, ordered by date. Without a subdivision they are the
+// holidays of the whole country, otherwise only those observed in the subdivision in addition.
+func Generate(country, subdivision string, year int) ([]Holiday, error) {
+	countryRules, ok := rules[strings.ToUpper(country)]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnknownCountry, country)
+	}
+
+	subdivision = strings.ToUpper(subdivision)
+	holidays := []Holiday{}
+	for _, r := range countryRules {
+		if (r.Since != 0 && year < r.Since) || (r.Until != 0 && year > r.Until) {
+			continue
+		}
+		if subdivision == "" && len(r.Subdivisions) > 0 {
+			continue
+		}
+		if subdivision != "" && !slices.Contains(r.Subdivisions, subdivision) {
+			continue
+		}
+
+		date, err := r.date(year)
+		if err != nil {
+			return nil, err
+		}
+		holidays = append(holidays, Holiday{Date: date, Name: r.Name})
+	}
+	sortByDate(holidays)
+	return holidays, nil
+}
+
+// date returns the date of the holiday in year
+func (r rule) date(year int) (time.Time, error) {
+	if r.Easter != nil {
+		return Easter(year).AddDate(0, 0, *r.Easter), nil
+	}
+	monthDay, err := time.Parse("01-02", r.Date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date of holiday %s: %w", r.Name, err)
+	}
+	return time.Date(year, monthDay.Month(), monthDay.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// Easter returns Easter Sunday of the Gregorian calendar in year
+func Easter(year int) time.Time {
+	// anonymous Gregorian algorithm
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// Observed returns the days off of the users of a company ordered by date: the public holidays, except on the
+// dates the company overrides, and the overrides which are days off. Only the first holiday of a date is kept.
+func Observed(public []Holiday, overrides []Override) []Holiday {
+	seen := make(map[time.Time]bool, len(overrides))
+	holidays := make([]Holiday, 0, len(public)+len(overrides))
+	for _, override := range overrides {
+		seen[override.Date] = true
+		if override.DayOff {
+			holidays = append(holidays, override.Holiday)
+		}
+	}
+	for _, holiday := range public {
+		if !seen[holiday.Date] {
+			seen[holiday.Date] = true
+			holidays = append(holidays, holiday)
+		}
+	}
+
+	sortByDate(holidays)
+	return holidays
+}
+
+// sortByDate orders holidays by date, keeping the order of holidays on the same date
+func sortByDate(holidays []Holiday) {
+	sort.SliceStable(holidays, func(i, j int) bool {
+		return holidays[i].Date.Before(holidays[j].Date)
+	})
+}
@@ -0,0 +1,82 @@
+package holiday
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestEaster(t *testing.T) {
+	require.Equal(t, date(2000, time.April, 23), Easter(2000))
+	require.Equal(t, date(2019, time.April, 21), Easter(2019))
+	require.Equal(t, date(2024, time.March, 31), Easter(2024))
+	require.Equal(t, date(2025, time.April, 20), Easter(2025))
+}
+
+func TestGenerate(t *testing.T) {
+	holidays, err := Generate("hr", "", 2024)
+	require.NoError(t, err)
+	require.Len(t, holidays, 14)
+	require.Equal(t, Holiday{Date: date(2024, time.January, 1), Name: "New Year's Day"}, holidays[0])
+	require.Equal(t, Holiday{Date: date(2024, time.April, 1), Name: "Easter Monday"}, holidays[3])
+	require.Equal(t, Holiday{Date: date(2024, time.December, 26), Name: "St. Stephen's Day"}, holidays[13])
+	for i := 1; i < len(holidays); i++ {
+		require.False(t, holidays[i].Date.Before(holidays[i-1].Date))
+	}
+
+	// Statehood Day moved from June 25 to May 30 in 2020
+	holidays, err = Generate("HR", "", 2019)
+	require.NoError(t, err)
+	require.Contains(t, holidays, Holiday{Date: date(2019, time.June, 25), Name: "Statehood Day"})
+	require.NotContains(t, holidays, Holiday{Date: date(2019, time.May, 30), Name: "Statehood Day"})
+}
+
+func TestGenerateSubdivision(t *testing.T) {
+	holidays, err := Generate("DE", "", 2024)
+	require.NoError(t, err)
+	require.Len(t, holidays, 9)
+	require.NotContains(t, holidays, Holiday{Date: date(2024, time.January, 6), Name: "Epiphany"})
+
+	holidays, err = Generate("DE", "by", 2024)
+	require.NoError(t, err)
+	require.Equal(t, []Holiday{
+		{Date: date(2024, time.January, 6), Name: "Epiphany"},
+		{Date: date(2024, time.May, 30), Name: "Corpus Christi"},
+		{Date: date(2024, time.November, 1), Name: "All Saints' Day"},
+	}, holidays)
+
+	holidays, err = Generate("DE", "XX", 2024)
+	require.NoError(t, err)
+	require.Empty(t, holidays)
+}
+
+func TestGenerateUnknownCountry(t *testing.T) {
+	_, err := Generate("ZZ", "", 2024)
+	require.ErrorIs(t, err, ErrUnknownCountry)
+	require.NotContains(t, Countries(), "ZZ")
+	require.Contains(t, Countries(), "HR")
+}
+
+func TestObserved(t *testing.T) {
+	public := []Holiday{
+		{Date: date(2024, time.May, 1), Name: "Labour Day"},
+		{Date: date(2024, time.May, 30), Name: "Statehood Day"},
+		{Date: date(2024, time.May, 30), Name: "Corpus Christi"},
+		{Date: date(2024, time.June, 22), Name: "Anti-Fascist Struggle Day"},
+	}
+	overrides := []Override{
+		{Holiday: Holiday{Date: date(2024, time.June, 22), Name: "Working day"}},
+		{Holiday: Holiday{Date: date(2024, time.May, 2), Name: "Bridge day"}, DayOff: true},
+	}
+
+	require.Equal(t, []Holiday{
+		{Date: date(2024, time.May, 1), Name: "Labour Day"},
+		{Date: date(2024, time.May, 2), Name: "Bridge day"},
+		{Date: date(2024, time.May, 30), Name: "Statehood Day"},
+	}, Observed(public, overrides))
+}
@@ -0,0 +1,146 @@
+package holiday
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ErrInvalidICal is returned for files which are not iCalendar files of holidays
+var ErrInvalidICal = errors.New("invalid iCalendar file")
+
+// maxEventDays limits the number of holidays a single event spans
+const maxEventDays = 31
+
+// event is a VEVENT component of an iCalendar file
+type event struct {
+	start, end string
+	summary    string
+}
+
+// ParseICal reads the holidays of the events of an iCalendar file (RFC 5545), ordered by date. An event is a holiday
+// on every date from its start until its end, exclusive, and lasts a single day without an end. Only the date of
+// events with a start time is used, and recurrence rules are not expanded.
+func ParseICal(r io.Reader) ([]Holiday, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, fmt.Errorf("%w: missing VCALENDAR", ErrInvalidICal)
+	}
+
+	holidays := []Holiday{}
+	var current *event
+	for _, line := range lines {
+		name, value := property(line)
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			current = &event{}
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if current == nil {
+				return nil, fmt.Errorf("%w: unexpected END:VEVENT", ErrInvalidICal)
+			}
+			days, err := current.holidays()
+			if err != nil {
+				return nil, err
+			}
+			holidays = append(holidays, days...)
+			current = nil
+		case current == nil:
+		case name == "DTSTART":
+			current.start = value
+		case name == "DTEND":
+			current.end = value
+		case name == "SUMMARY":
+			current.summary = unescape(value)
+		}
+	}
+
+	sortByDate(holidays)
+	return holidays, nil
+}
+
+// holidays returns a holiday for every date of the event
+func (e event) holidays() ([]Holiday, error) {
+	if e.summary == "" {
+		return nil, fmt.Errorf("%w: event without a summary", ErrInvalidICal)
+	}
+	start, err := parseDate(e.start)
+	if err != nil {
+		return nil, err
+	}
+	end := start.AddDate(0, 0, 1)
+	if e.end != "" {
+		end, err = parseDate(e.end)
+		if err != nil {
+			return nil, err
+		}
+		// an event ending at a time of its last date covers it
+		if len(e.end) > len("20060102") {
+			end = end.AddDate(0, 0, 1)
+		}
+	}
+	if !end.After(start) || end.After(start.AddDate(0, 0, maxEventDays)) {
+		return nil, fmt.Errorf("%w: event %s ends before it starts or lasts longer than %d days", ErrInvalidICal, e.summary, maxEventDays)
+	}
+
+	var holidays []Holiday
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		holidays = append(holidays, Holiday{Date: day, Name: e.summary})
+	}
+	return holidays, nil
+}
+
+// unfold reads the content lines of an iCalendar file, joining the lines folded onto several
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidICal, err)
+	}
+	return lines, nil
+}
+
+// property splits a content line into its upper case name, without parameters, and its value
+func property(line string) (string, string) {
+	var quoted bool
+	for i, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ':' && !quoted:
+			name, _, _ := strings.Cut(line[:i], ";")
+			return strings.ToUpper(name), line[i+1:]
+		}
+	}
+	return strings.ToUpper(line), ""
+}
+
+// parseDate parses the date of a DATE or DATE-TIME value
+func parseDate(value string) (time.Time, error) {
+	if len(value) < len("20060102") {
+		return time.Time{}, fmt.Errorf("%w: invalid date %q", ErrInvalidICal, value)
+	}
+	date, err := time.Parse("20060102", value[:len("20060102")])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid date %q", ErrInvalidICal, value)
+	}
+	return date, nil
+}
+
+// unescape decodes the escaped characters of a TEXT value
+var unescape = strings.NewReplacer(`\\`, `\`, `\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ").Replace
@@ -0,0 +1,78 @@
+package holiday
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const calendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//Example//Holidays//EN\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:christmas-2024@example.com\r\n" +
+	"DTSTART;VALUE=DATE:20241225\r\n" +
+	"DTEND;VALUE=DATE:20241227\r\n" +
+	"SUMMARY:Christmas\\, St. Stephen's\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:new-year-2024@example.com\r\n" +
+	"DTSTART;VALUE=DATE:20240101\r\n" +
+	"SUMMARY:New Year's\r\n" +
+	"  Day\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;TZID=\"Europe/Zagreb\":20240530T000000\r\n" +
+	"DTEND;TZID=\"Europe/Zagreb\":20240530T235900\r\n" +
+	"SUMMARY:Statehood Day\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICal(t *testing.T) {
+	holidays, err := ParseICal(strings.NewReader(calendar))
+	require.NoError(t, err)
+	require.Equal(t, []Holiday{
+		{Date: date(2024, time.January, 1), Name: "New Year's Day"},
+		{Date: date(2024, time.May, 30), Name: "Statehood Day"},
+		{Date: date(2024, time.December, 25), Name: "Christmas, St. Stephen's"},
+		{Date: date(2024, time.December, 26), Name: "Christmas, St. Stephen's"},
+	}, holidays)
+}
+
+func TestParseICalInvalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "NotCalendar",
+			body: "Date,Name\n2024-01-01,New Year's Day\n",
+		},
+		{
+			name: "InvalidDate",
+			body: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:2024-01-01\nSUMMARY:New Year's Day\nEND:VEVENT\nEND:VCALENDAR\n",
+		},
+		{
+			name: "MissingSummary",
+			body: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20240101\nEND:VEVENT\nEND:VCALENDAR\n",
+		},
+		{
+			name: "EndsBeforeStart",
+			body: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20240102\nDTEND:20240101\nSUMMARY:Holiday\nEND:VEVENT\nEND:VCALENDAR\n",
+		},
+		{
+			name: "TooLong",
+			body: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20240101\nDTEND:20250101\nSUMMARY:Holiday\nEND:VEVENT\nEND:VCALENDAR\n",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseICal(strings.NewReader(tc.body))
+			require.ErrorIs(t, err, ErrInvalidICal)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Package ical writes calendars as iCalendar files (RFC 5545) to be subscribed to by calendar applications.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Event statuses
+const (
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+)
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405Z"
+	// maxLineOctets is the length content lines are folded at, excluding the line break
+	maxLineOctets = 75
+)
+
+// Calendar is a named list of events
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Event is a VEVENT component. All day events last from the date of Start until the date of End, exclusive, and
+// other events from Start until End.
+type Event struct {
+	// UID identifies the event across every version of the calendar, so that changes replace the earlier version
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Status      string
+	// Sequence increases whenever the event is changed
+	Sequence int64
+	// Modified is the time the event was last changed
+	Modified time.Time
+	// Transparent events do not block the time of the subscriber
+	Transparent bool
+}
+
+// Write writes cal as an iCalendar file
+func Write(w io.Writer, cal Calendar) error {
+	out := writer{w: bufio.NewWriter(w)}
+	out.line("BEGIN", "VCALENDAR")
+	out.line("VERSION", "2.0")
+	out.line("PRODID", "-//tempus//tempus//EN")
+	out.line("CALSCALE", "GREGORIAN")
+	out.line("METHOD", "PUBLISH")
+	if cal.Name != "" {
+		out.line("X-WR-CALNAME", escape(cal.Name))
+	}
+	for _, event := range cal.Events {
+		out.event(event)
+	}
+	out.line("END", "VCALENDAR")
+	if out.err != nil {
+		return out.err
+	}
+	return out.w.Flush()
+}
+
+// writer writes content lines and keeps the first error
+type writer struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (out *writer) event(e Event) {
+	out.line("BEGIN", "VEVENT")
+	out.line("UID", e.UID)
+	out.line("DTSTAMP", e.Modified.UTC().Format(dateTimeLayout))
+	out.line("LAST-MODIFIED", e.Modified.UTC().Format(dateTimeLayout))
+	if e.AllDay {
+		out.line("DTSTART;VALUE=DATE", e.Start.Format(dateLayout))
+		out.line("DTEND;VALUE=DATE", e.End.Format(dateLayout))
+	} else {
+		out.line("DTSTART", e.Start.UTC().Format(dateTimeLayout))
+		out.line("DTEND", e.End.UTC().Format(dateTimeLayout))
+	}
+	out.line("SUMMARY", escape(e.Summary))
+	if e.Description != "" {
+		out.line("DESCRIPTION", escape(e.Description))
+	}
+	if e.Status != "" {
+		out.line("STATUS", e.Status)
+	}
+	out.line("SEQUENCE", fmt.Sprint(e.Sequence))
+	if e.Transparent {
+		out.line("TRANSP", "TRANSPARENT")
+	} else {
+		out.line("TRANSP", "OPAQUE")
+	}
+	out.line("END", "VEVENT")
+}
+
+// line writes a content line, folded into lines of at most 75 octets
+func (out *writer) line(name, value string) {
+	if out.err != nil {
+		return
+	}
+	_, out.err = out.w.WriteString(fold(name + ":" + value))
+}
+
+// fold breaks a content line into lines of at most 75 octets, continued by a space, without splitting characters
+func fold(line string) string {
+	var b strings.Builder
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// the leading space of a continuation line counts towards its length
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+// escape encodes the characters with a special meaning in TEXT values
+var escape = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace
@@ -0,0 +1,83 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mateoradman/tempus/internal/holiday"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	modified := time.Date(2024, time.March, 1, 12, 30, 0, 0, time.UTC)
+	zagreb, err := time.LoadLocation("Europe/Zagreb")
+	require.NoError(t, err)
+
+	cal := Calendar{
+		Name: "Team, Zagreb",
+		Events: []Event{
+			{
+				UID:         "absence-1@tempus",
+				Summary:     "Vacation; Ana",
+				Description: "first line\nsecond line",
+				Start:       time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC),
+				End:         time.Date(2024, time.March, 6, 0, 0, 0, 0, time.UTC),
+				AllDay:      true,
+				Status:      StatusConfirmed,
+				Sequence:    3,
+				Modified:    modified,
+			},
+			{
+				UID:         "absence-2@tempus",
+				Summary:     "Doctor",
+				Start:       time.Date(2024, time.March, 7, 9, 0, 0, 0, zagreb),
+				End:         time.Date(2024, time.March, 7, 11, 0, 0, 0, zagreb),
+				Status:      StatusCancelled,
+				Modified:    modified,
+				Transparent: true,
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, cal))
+	data := buf.String()
+
+	require.True(t, strings.HasPrefix(data, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	require.True(t, strings.HasSuffix(data, "END:VCALENDAR\r\n"))
+	require.Contains(t, data, "X-WR-CALNAME:Team\\, Zagreb\r\n")
+	require.Contains(t, data, "UID:absence-1@tempus\r\nDTSTAMP:20240301T123000Z\r\n")
+	require.Contains(t, data, "DTSTART;VALUE=DATE:20240304\r\nDTEND;VALUE=DATE:20240306\r\n")
+	require.Contains(t, data, "SUMMARY:Vacation\\; Ana\r\nDESCRIPTION:first line\\nsecond line\r\n")
+	require.Contains(t, data, "STATUS:CONFIRMED\r\nSEQUENCE:3\r\nTRANSP:OPAQUE\r\n")
+	// times are written in UTC
+	require.Contains(t, data, "DTSTART:20240307T080000Z\r\nDTEND:20240307T100000Z\r\n")
+	require.Contains(t, data, "STATUS:CANCELLED\r\nSEQUENCE:0\r\nTRANSP:TRANSPARENT\r\n")
+	require.Equal(t, 2, strings.Count(data, "BEGIN:VEVENT\r\n"))
+
+	// the calendar reads back as holidays
+	holidays, err := holiday.ParseICal(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, holidays, 3)
+	require.Equal(t, "Vacation; Ana", holidays[0].Name)
+}
+
+func TestFold(t *testing.T) {
+	require.Equal(t, "SUMMARY:short\r\n", fold("SUMMARY:short"))
+
+	line := "SUMMARY:" + strings.Repeat("ž", 100)
+	folded := fold(line)
+	require.True(t, strings.HasSuffix(folded, "\r\n"))
+	lines := strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n")
+	require.Greater(t, len(lines), 1)
+	for i, l := range lines {
+		require.LessOrEqual(t, len(l), maxLineOctets)
+		if i > 0 {
+			require.True(t, strings.HasPrefix(l, " "))
+		}
+		require.True(t, strings.ToValidUTF8(l, "?") == l, "line %d splits a character", i)
+	}
+	require.Equal(t, line, strings.ReplaceAll(strings.TrimSuffix(folded, "\r\n"), "\r\n ", ""))
+}
@@ -0,0 +1,61 @@
+package invoicing
+
+import (
+	"fmt"
+
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+)
+
+// dateLayout is the layout of the dates printed on an invoice
+const dateLayout = "2006-01-02"
+
+// Document is an invoice together with everything needed to render it
+type Document struct {
+	Invoice db.Invoice
+	Lines   []db.InvoiceLine
+	Company db.Company
+	Client  db.Client
+}
+
+// minorUnitExponents lists the ISO 4217 currencies whose minor unit is not a hundredth of the major unit
+var minorUnitExponents = map[string]int{
+	"BHD": 3, "BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "IQD": 3, "ISK": 0, "JOD": 3, "JPY": 0, "KMF": 0,
+	"KRW": 0, "KWD": 3, "LYD": 3, "OMR": 3, "PYG": 0, "RWF": 0, "TND": 3, "UGX": 0, "UYI": 0, "VND": 0,
+	"VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+}
+
+// minorUnitExponent returns the number of decimals of the minor unit of currency
+func minorUnitExponent(currency string) int {
+	if exponent, ok := minorUnitExponents[currency]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// formatAmount formats an amount in minor units of currency as a decimal number of major units
+func formatAmount(amount int64, currency string) string {
+	exponent := minorUnitExponent(currency)
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	digits := fmt.Sprintf("%0*d", exponent+1, amount)
+	if exponent == 0 {
+		return sign + digits
+	}
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+// formatHours formats a duration in seconds as decimal hours with two decimals
+func formatHours(seconds int64) string {
+	return formatAmount((seconds*100+1800)/3600, "")
+}
+
+// number returns the invoice number, drafts do not have one yet
+func (doc Document) number() string {
+	if doc.Invoice.Number == nil {
+		return "DRAFT"
+	}
+	return *doc.Invoice.Number
+}
@@ -0,0 +1,80 @@
+package invoicing
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// widths of the columns of the line item table in millimeters
+var pdfColumnWidths = []float64{90, 25, 30, 35}
+
+// RenderPDF writes doc as a printable A4 PDF. Drafts are rendered as well and are marked as such.
+func RenderPDF(w io.Writer, doc Document) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	// the core fonts are encoded in cp1252
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	currency := doc.Invoice.Currency
+
+	pdf.SetTitle(fmt.Sprintf("Invoice %s", doc.number()), true)
+	pdf.SetCreator("tempus", true)
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 10, tr(fmt.Sprintf("Invoice %s", doc.number())), "", 1, "L", false, 0, "")
+	if doc.Invoice.Number == nil {
+		pdf.SetTextColor(200, 0, 0)
+		pdf.CellFormat(0, 8, "DRAFT - not a valid invoice", "", 1, "L", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	}
+	pdf.Ln(4)
+
+	pdf.SetFont("Helvetica", "", 10)
+	details := [][2]string{
+		{"From", doc.Company.Name},
+		{"Bill to", doc.Client.Name},
+		{"Period", fmt.Sprintf("%s - %s", doc.Invoice.PeriodFrom.Format(dateLayout),
+			doc.Invoice.PeriodTo.Add(-time.Nanosecond).Format(dateLayout))},
+	}
+	if doc.Invoice.IssuedAt != nil {
+		details = append(details, [2]string{"Issue date", doc.Invoice.IssuedAt.Format(dateLayout)})
+	}
+	details = append(details, [2]string{"Status", doc.Invoice.Status})
+	for _, detail := range details {
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(30, 6, tr(detail[0]), "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(0, 6, tr(detail[1]), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(6)
+
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetFillColor(230, 230, 230)
+	headers := []string{"Description", "Hours", fmt.Sprintf("Rate (%s)", currency), fmt.Sprintf("Amount (%s)", currency)}
+	for i, header := range headers {
+		align := "R"
+		if i == 0 {
+			align = "L"
+		}
+		pdf.CellFormat(pdfColumnWidths[i], 7, header, "B", 0, align, true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 10)
+	for _, line := range doc.Lines {
+		pdf.CellFormat(pdfColumnWidths[0], 7, tr(line.Description), "", 0, "L", false, 0, "")
+		pdf.CellFormat(pdfColumnWidths[1], 7, formatHours(line.QuantitySeconds), "", 0, "R", false, 0, "")
+		pdf.CellFormat(pdfColumnWidths[2], 7, formatAmount(line.RateCents, currency), "", 0, "R", false, 0, "")
+		pdf.CellFormat(pdfColumnWidths[3], 7, formatAmount(line.AmountCents, currency), "", 1, "R", false, 0, "")
+	}
+
+	pdf.SetFont("Helvetica", "B", 10)
+	labelWidth := pdfColumnWidths[0] + pdfColumnWidths[1] + pdfColumnWidths[2]
+	pdf.CellFormat(labelWidth, 8, "Total", "T", 0, "R", false, 0, "")
+	pdf.CellFormat(pdfColumnWidths[3], 8, fmt.Sprintf("%s %s", formatAmount(doc.Invoice.TotalCents, currency), currency),
+		"T", 1, "R", false, 0, "")
+
+	return pdf.Output(w)
+}
@@ -0,0 +1,27 @@
+package invoicing
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderPDF(t *testing.T) {
+	var buf bytes.Buffer
+	err := RenderPDF(&buf, issuedDocument())
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+}
+
+func TestRenderPDFDraft(t *testing.T) {
+	doc := issuedDocument()
+	doc.Invoice.Number = nil
+	doc.Invoice.IssuedAt = nil
+	doc.Invoice.Status = "draft"
+
+	var buf bytes.Buffer
+	err := RenderPDF(&buf, doc)
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+}
@@ -0,0 +1,162 @@
+package invoicing
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ErrDraft is returned when rendering an e-invoice of a draft, which has no number and issue date yet
+var ErrDraft = errors.New("draft invoices cannot be rendered as e-invoices")
+
+const (
+	ublInvoiceNamespace = "urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+	ublCACNamespace     = "urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+	ublCBCNamespace     = "urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2"
+	// EN 16931 is the European standard on the semantic data model of an electronic invoice
+	ublCustomizationID = "urn:cen.eu:en16931:2017"
+	// 380 is the UNTDID 1001 code of a commercial invoice
+	ublInvoiceTypeCode = "380"
+	// HUR is the UN/ECE recommendation 20 code of an hour
+	ublHourUnitCode = "HUR"
+	// O is the UNCL 5305 code of services outside the scope of tax
+	ublTaxCategoryID = "O"
+	ublTaxSchemeID   = "VAT"
+)
+
+type ublInvoice struct {
+	XMLName              xml.Name              `xml:"Invoice"`
+	Namespace            string                `xml:"xmlns,attr"`
+	CACNamespace         string                `xml:"xmlns:cac,attr"`
+	CBCNamespace         string                `xml:"xmlns:cbc,attr"`
+	CustomizationID      string                `xml:"cbc:CustomizationID"`
+	ID                   string                `xml:"cbc:ID"`
+	IssueDate            string                `xml:"cbc:IssueDate"`
+	InvoiceTypeCode      string                `xml:"cbc:InvoiceTypeCode"`
+	DocumentCurrencyCode string                `xml:"cbc:DocumentCurrencyCode"`
+	InvoicePeriod        ublPeriod             `xml:"cac:InvoicePeriod"`
+	Supplier             ublParty              `xml:"cac:AccountingSupplierParty>cac:Party"`
+	Customer             ublParty              `xml:"cac:AccountingCustomerParty>cac:Party"`
+	TaxTotal             ublTaxTotal           `xml:"cac:TaxTotal"`
+	LegalMonetaryTotal   ublLegalMonetaryTotal `xml:"cac:LegalMonetaryTotal"`
+	Lines                []ublInvoiceLine      `xml:"cac:InvoiceLine"`
+}
+
+type ublPeriod struct {
+	StartDate string `xml:"cbc:StartDate"`
+	EndDate   string `xml:"cbc:EndDate"`
+}
+
+type ublParty struct {
+	Name             string `xml:"cac:PartyName>cbc:Name"`
+	RegistrationName string `xml:"cac:PartyLegalEntity>cbc:RegistrationName"`
+}
+
+type ublAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"currencyID,attr"`
+}
+
+type ublQuantity struct {
+	Value    string `xml:",chardata"`
+	UnitCode string `xml:"unitCode,attr"`
+}
+
+type ublTaxCategory struct {
+	ID          string `xml:"cbc:ID"`
+	TaxSchemeID string `xml:"cac:TaxScheme>cbc:ID"`
+}
+
+type ublTaxSubtotal struct {
+	TaxableAmount ublAmount      `xml:"cbc:TaxableAmount"`
+	TaxAmount     ublAmount      `xml:"cbc:TaxAmount"`
+	TaxCategory   ublTaxCategory `xml:"cac:TaxCategory"`
+}
+
+type ublTaxTotal struct {
+	TaxAmount   ublAmount      `xml:"cbc:TaxAmount"`
+	TaxSubtotal ublTaxSubtotal `xml:"cac:TaxSubtotal"`
+}
+
+type ublLegalMonetaryTotal struct {
+	LineExtensionAmount ublAmount `xml:"cbc:LineExtensionAmount"`
+	TaxExclusiveAmount  ublAmount `xml:"cbc:TaxExclusiveAmount"`
+	TaxInclusiveAmount  ublAmount `xml:"cbc:TaxInclusiveAmount"`
+	PayableAmount       ublAmount `xml:"cbc:PayableAmount"`
+}
+
+type ublInvoiceLine struct {
+	ID                  string         `xml:"cbc:ID"`
+	InvoicedQuantity    ublQuantity    `xml:"cbc:InvoicedQuantity"`
+	LineExtensionAmount ublAmount      `xml:"cbc:LineExtensionAmount"`
+	ItemName            string         `xml:"cac:Item>cbc:Name"`
+	ItemTaxCategory     ublTaxCategory `xml:"cac:Item>cac:ClassifiedTaxCategory"`
+	PriceAmount         ublAmount      `xml:"cac:Price>cbc:PriceAmount"`
+}
+
+// RenderUBL writes doc as an OASIS UBL 2.1 invoice following the EN 16931 data model.
+// Lines are billed in hours and no tax is charged.
+func RenderUBL(w io.Writer, doc Document) error {
+	if doc.Invoice.Number == nil || doc.Invoice.IssuedAt == nil {
+		return ErrDraft
+	}
+
+	currency := doc.Invoice.Currency
+	amount := func(value int64) ublAmount {
+		return ublAmount{Value: formatAmount(value, currency), Currency: currency}
+	}
+	taxCategory := ublTaxCategory{ID: ublTaxCategoryID, TaxSchemeID: ublTaxSchemeID}
+	total := amount(doc.Invoice.TotalCents)
+
+	invoice := ublInvoice{
+		Namespace:            ublInvoiceNamespace,
+		CACNamespace:         ublCACNamespace,
+		CBCNamespace:         ublCBCNamespace,
+		CustomizationID:      ublCustomizationID,
+		ID:                   *doc.Invoice.Number,
+		IssueDate:            doc.Invoice.IssuedAt.Format(dateLayout),
+		InvoiceTypeCode:      ublInvoiceTypeCode,
+		DocumentCurrencyCode: currency,
+		InvoicePeriod: ublPeriod{
+			StartDate: doc.Invoice.PeriodFrom.Format(dateLayout),
+			// the period of an invoice ends before its end time
+			EndDate: doc.Invoice.PeriodTo.Add(-time.Nanosecond).Format(dateLayout),
+		},
+		Supplier: ublParty{Name: doc.Company.Name, RegistrationName: doc.Company.Name},
+		Customer: ublParty{Name: doc.Client.Name, RegistrationName: doc.Client.Name},
+		TaxTotal: ublTaxTotal{
+			TaxAmount: amount(0),
+			TaxSubtotal: ublTaxSubtotal{
+				TaxableAmount: total,
+				TaxAmount:     amount(0),
+				TaxCategory:   taxCategory,
+			},
+		},
+		LegalMonetaryTotal: ublLegalMonetaryTotal{
+			LineExtensionAmount: total,
+			TaxExclusiveAmount:  total,
+			TaxInclusiveAmount:  total,
+			PayableAmount:       total,
+		},
+		Lines: make([]ublInvoiceLine, len(doc.Lines)),
+	}
+	for i, line := range doc.Lines {
+		invoice.Lines[i] = ublInvoiceLine{
+			ID:                  fmt.Sprint(i + 1),
+			InvoicedQuantity:    ublQuantity{Value: formatHours(line.QuantitySeconds), UnitCode: ublHourUnitCode},
+			LineExtensionAmount: amount(line.AmountCents),
+			ItemName:            line.Description,
+			ItemTaxCategory:     taxCategory,
+			PriceAmount:         amount(line.RateCents),
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return encoder.Encode(invoice)
+}
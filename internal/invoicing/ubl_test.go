@@ -0,0 +1,95 @@
+package invoicing
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func issuedDocument() Document {
+	issuedAt := time.Date(2024, time.April, 2, 9, 30, 0, 0, time.UTC)
+	return Document{
+		Invoice: db.Invoice{
+			ID:         1,
+			CompanyID:  1,
+			ClientID:   2,
+			Number:     util.Pointer("INV-00042"),
+			Status:     "issued",
+			Currency:   "EUR",
+			PeriodFrom: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			PeriodTo:   time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+			TotalCents: 18750,
+			IssuedAt:   &issuedAt,
+		},
+		Lines: []db.InvoiceLine{
+			{ID: 1, InvoiceID: 1, Description: "Website: Design", QuantitySeconds: 5400, RateCents: 10000, AmountCents: 15000},
+			{ID: 2, InvoiceID: 1, Description: "Website", QuantitySeconds: 2700, RateCents: 5000, AmountCents: 3750},
+		},
+		Company: db.Company{ID: 1, Name: "Tempus & Co"},
+		Client:  db.Client{ID: 2, CompanyID: 1, Name: "Acme"},
+	}
+}
+
+func TestRenderUBL(t *testing.T) {
+	var buf bytes.Buffer
+	err := RenderUBL(&buf, issuedDocument())
+	require.NoError(t, err)
+
+	out := buf.String()
+	require.Contains(t, out, `<Invoice xmlns="urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"`)
+	require.Contains(t, out, `<cbc:ID>INV-00042</cbc:ID>`)
+	require.Contains(t, out, `<cbc:IssueDate>2024-04-02</cbc:IssueDate>`)
+	require.Contains(t, out, `<cbc:EndDate>2024-03-31</cbc:EndDate>`)
+	require.Contains(t, out, `<cbc:RegistrationName>Tempus &amp; Co</cbc:RegistrationName>`)
+	require.Contains(t, out, `<cbc:PayableAmount currencyID="EUR">187.50</cbc:PayableAmount>`)
+	require.Contains(t, out, `<cbc:InvoicedQuantity unitCode="HUR">1.50</cbc:InvoicedQuantity>`)
+	require.Contains(t, out, `<cbc:InvoicedQuantity unitCode="HUR">0.75</cbc:InvoicedQuantity>`)
+	require.Contains(t, out, `<cbc:PriceAmount currencyID="EUR">100.00</cbc:PriceAmount>`)
+
+	// the output is well-formed
+	decoder := xml.NewDecoder(&buf)
+	for {
+		_, err := decoder.Token()
+		if err != nil {
+			require.ErrorIs(t, err, io.EOF)
+			break
+		}
+	}
+}
+
+func TestRenderUBLDraft(t *testing.T) {
+	doc := issuedDocument()
+	doc.Invoice.Number = nil
+	doc.Invoice.IssuedAt = nil
+	doc.Invoice.Status = "draft"
+
+	var buf bytes.Buffer
+	err := RenderUBL(&buf, doc)
+	require.ErrorIs(t, err, ErrDraft)
+	require.Zero(t, buf.Len())
+}
+
+func TestFormatAmount(t *testing.T) {
+	testCases := []struct {
+		amount   int64
+		currency string
+		want     string
+	}{
+		{amount: 0, currency: "EUR", want: "0.00"},
+		{amount: 5, currency: "EUR", want: "0.05"},
+		{amount: 123456, currency: "USD", want: "1234.56"},
+		{amount: -150, currency: "EUR", want: "-1.50"},
+		{amount: 1500, currency: "JPY", want: "1500"},
+		{amount: 1500, currency: "KWD", want: "1.500"},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.want, formatAmount(tc.amount, tc.currency))
+	}
+}
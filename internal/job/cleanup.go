@@ -0,0 +1,31 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+const (
+	// CleanupKind is the kind of the daily job deleting old succeeded jobs
+	CleanupKind = "jobs.cleanup"
+	// retention is the time succeeded jobs are kept for, dead jobs are kept until they are retried
+	retention = 7 * 24 * time.Hour
+)
+
+// RegisterCleanup registers and schedules the daily deletion of the jobs which succeeded over a week ago
+func RegisterCleanup(r *Runner) {
+	Register(r, CleanupKind, 0, func(ctx context.Context, _ struct{}) error {
+		n, err := r.store.DeleteSucceededJobs(ctx, r.now().UTC().Add(-retention))
+		if err == nil && n > 0 {
+			log.Printf("deleted %d succeeded jobs", n)
+		}
+		return err
+	})
+	r.schedules = append(r.schedules, &schedule{
+		kind:     CleanupKind,
+		schedule: MustParseCron("30 3 * * *"),
+		payload:  []byte("{}"),
+		opts:     Options{MaxAttempts: 3},
+	})
+}
@@ -0,0 +1,289 @@
+// Package job runs background jobs queued in Postgres. Workers claim jobs with SELECT ... FOR UPDATE SKIP LOCKED,
+// so that the workers of any number of processes share the queue without running a job twice at once.
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/util"
+)
+
+const (
+	// DefaultMaxAttempts is the number of attempts after which a job is dead-lettered, unless set when enqueued
+	DefaultMaxAttempts = 10
+	// DefaultTimeout is the time a job may run for, unless set when its handler is registered
+	DefaultTimeout = 5 * time.Minute
+	firstBackoff   = 10 * time.Second
+	maxBackoff     = time.Hour
+	pollInterval   = time.Second
+)
+
+// Options of an enqueued job
+type Options struct {
+	// RunAt is the earliest time the job is run at, now if zero
+	RunAt time.Time
+	// MaxAttempts is the number of attempts after which the job is dead-lettered, DefaultMaxAttempts if zero
+	MaxAttempts int32
+}
+
+func (opts Options) runAt() time.Time {
+	if opts.RunAt.IsZero() {
+		return time.Now().UTC()
+	}
+	return opts.RunAt.UTC()
+}
+
+func (opts Options) maxAttempts() int32 {
+	if opts.MaxAttempts == 0 {
+		return DefaultMaxAttempts
+	}
+	return opts.MaxAttempts
+}
+
+// Enqueue adds a job of kind with payload encoded as JSON to the queue. Enqueued with the queries of a transaction,
+// the job is only run if the transaction commits.
+func Enqueue(ctx context.Context, q db.Querier, kind string, payload any, opts Options) (db.Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return db.Job{}, err
+	}
+	return q.CreateJob(ctx, db.CreateJobParams{
+		Kind:        kind,
+		Payload:     data,
+		RunAt:       opts.runAt(),
+		MaxAttempts: opts.maxAttempts(),
+	})
+}
+
+// Backoff returns the delay before the next attempt of a job which failed attempts times
+func Backoff(attempts int32) time.Duration {
+	delay := firstBackoff
+	for i := int32(1); i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		return maxBackoff
+	}
+	return delay
+}
+
+type handler struct {
+	handle  func(ctx context.Context, payload []byte) error
+	timeout time.Duration
+}
+
+type schedule struct {
+	kind     string
+	schedule Schedule
+	payload  []byte
+	opts     Options
+	next     time.Time
+}
+
+// Runner is a pool of workers running the jobs of the registered kinds, and enqueues the runs of the schedules
+type Runner struct {
+	store     db.Store
+	workers   int
+	handlers  map[string]handler
+	schedules []*schedule
+	now       func() time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewRunner creates a runner of workers workers
+func NewRunner(store db.Store, workers int) *Runner {
+	return &Runner{
+		store:    store,
+		workers:  workers,
+		handlers: make(map[string]handler),
+		now:      time.Now,
+	}
+}
+
+// Register sets the handler of the jobs of kind, whose payloads are decoded into T. Handlers are cancelled after
+// timeout, or DefaultTimeout if zero. A job is retried with exponential backoff whenever its handler returns an error
+// or panics, until it is dead-lettered after its last attempt. As a job whose worker stops is run again, handlers
+// should be idempotent.
+func Register[T any](r *Runner, kind string, timeout time.Duration, handle func(ctx context.Context, payload T) error) {
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	r.handlers[kind] = handler{
+		handle: func(ctx context.Context, data []byte) error {
+			var payload T
+			if err := json.Unmarshal(data, &payload); err != nil {
+				return fmt.Errorf("cannot decode payload: %w", err)
+			}
+			return handle(ctx, payload)
+		},
+		timeout: timeout,
+	}
+}
+
+// Schedule enqueues a job of kind with payload at every time of s. Every run is enqueued with a unique key of the kind
+// and the time, so that it runs once whatever the number of runners. Runs missed while no runner was started are
+// skipped.
+func (r *Runner) Schedule(kind string, s Schedule, payload any, opts Options) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	r.schedules = append(r.schedules, &schedule{kind: kind, schedule: s, payload: data, opts: opts})
+	return nil
+}
+
+// Start starts the workers and the scheduler. They stop claiming jobs when ctx is done or Stop is called.
+func (r *Runner) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+
+	now := r.now()
+	for _, s := range r.schedules {
+		s.next = s.schedule.Next(now)
+	}
+
+	r.wg.Add(r.workers + 1)
+	for i := 0; i < r.workers; i++ {
+		go func() {
+			defer r.wg.Done()
+			r.work(ctx)
+		}()
+	}
+	go func() {
+		defer r.wg.Done()
+		r.poll(ctx, r.EnqueueDue)
+	}()
+}
+
+// Stop stops claiming jobs and waits for the running jobs to finish
+func (r *Runner) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+}
+
+// work runs jobs until ctx is done
+func (r *Runner) work(ctx context.Context) {
+	r.poll(ctx, func(ctx context.Context) error {
+		// run jobs back to back while there are due ones
+		for ctx.Err() == nil {
+			ran, err := r.RunNext(ctx)
+			if err != nil || !ran {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// poll calls fn every poll interval until ctx is done
+func (r *Runner) poll(ctx context.Context, fn func(ctx context.Context) error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := fn(ctx); err != nil && ctx.Err() == nil {
+			log.Println("job runner:", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// EnqueueDue enqueues the runs of the schedules whose time has come
+func (r *Runner) EnqueueDue(ctx context.Context) error {
+	now := r.now()
+	for _, s := range r.schedules {
+		for !s.next.After(now) {
+			_, err := r.store.CreateUniqueJob(ctx, db.CreateUniqueJobParams{
+				Kind:        s.kind,
+				Payload:     s.payload,
+				RunAt:       s.next.UTC(),
+				MaxAttempts: s.opts.maxAttempts(),
+				UniqueKey:   util.Pointer(fmt.Sprintf("%s@%s", s.kind, s.next.UTC().Format(time.RFC3339))),
+			})
+			if err != nil {
+				return err
+			}
+			// only the latest missed run is enqueued
+			s.next = s.schedule.Next(now)
+		}
+	}
+	return nil
+}
+
+// RunNext claims a due job of a registered kind and runs it. It reports whether a job was run.
+func (r *Runner) RunNext(ctx context.Context) (bool, error) {
+	kinds := make([]string, 0, len(r.handlers))
+	var lease time.Duration
+	for kind, h := range r.handlers {
+		kinds = append(kinds, kind)
+		if h.timeout > lease {
+			lease = h.timeout
+		}
+	}
+	if len(kinds) == 0 {
+		return false, nil
+	}
+	sort.Strings(kinds)
+
+	// jobs whose worker stopped are claimed again once their lease is over
+	lockedUntil := r.now().UTC().Add(lease + time.Minute)
+	jobs, err := r.store.ClaimJobs(ctx, db.ClaimJobsParams{
+		Kinds:       kinds,
+		LockedUntil: &lockedUntil,
+		Limit:       1,
+	})
+	if err != nil || len(jobs) == 0 {
+		return false, err
+	}
+
+	// running jobs finish when the runner is stopped
+	_, err = r.run(context.WithoutCancel(ctx), jobs[0])
+	return true, err
+}
+
+// run runs a claimed job and records its outcome
+func (r *Runner) run(ctx context.Context, job db.Job) (db.Job, error) {
+	h := r.handlers[job.Kind]
+	handleCtx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	err := safely(func() error { return h.handle(handleCtx, job.Payload) })
+	if err == nil {
+		return r.store.CompleteJob(ctx, job.ID)
+	}
+
+	lastError := err.Error()
+	if job.Attempts >= job.MaxAttempts {
+		log.Printf("job %d of kind %s is dead after %d attempts: %v", job.ID, job.Kind, job.Attempts, err)
+		return r.store.DeadLetterJob(ctx, db.DeadLetterJobParams{ID: job.ID, LastError: &lastError})
+	}
+	return r.store.RetryJob(ctx, db.RetryJobParams{
+		ID:        job.ID,
+		RunAt:     r.now().UTC().Add(Backoff(job.Attempts)),
+		LastError: &lastError,
+	})
+}
+
+// safely calls fn and returns the panic of fn as an error
+func safely(fn func() error) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return fn()
+}
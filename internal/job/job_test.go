@@ -0,0 +1,182 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+type testPayload struct {
+	UserID int64 `json:"user_id"`
+}
+
+func TestBackoff(t *testing.T) {
+	require.Equal(t, 10*time.Second, Backoff(1))
+	require.Equal(t, 20*time.Second, Backoff(2))
+	require.Equal(t, 40*time.Second, Backoff(3))
+	require.Equal(t, maxBackoff, Backoff(DefaultMaxAttempts))
+}
+
+func TestRunNext(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		attempts   int32
+		handle     func(ctx context.Context, payload testPayload) error
+		buildStubs func(store *mockdb.MockStore)
+	}{
+		{
+			name: "Succeeded",
+			handle: func(ctx context.Context, payload testPayload) error {
+				require.Equal(t, int64(42), payload.UserID)
+				return nil
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CompleteJob(gomock.Any(), gomock.Eq(int64(7))).
+					Times(1).
+					Return(db.Job{ID: 7, Status: types.JobSucceeded}, nil)
+			},
+		},
+		{
+			name:     "Retried",
+			attempts: 3,
+			handle: func(ctx context.Context, payload testPayload) error {
+				return errors.New("failure")
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RetryJob(gomock.Any(), gomock.Eq(db.RetryJobParams{
+						ID:        7,
+						RunAt:     now.Add(40 * time.Second),
+						LastError: util.Pointer("failure"),
+					})).
+					Times(1).
+					Return(db.Job{ID: 7, Status: types.JobPending}, nil)
+			},
+		},
+		{
+			name:     "Panicked",
+			attempts: 1,
+			handle: func(ctx context.Context, payload testPayload) error {
+				panic("nil map")
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RetryJob(gomock.Any(), gomock.Eq(db.RetryJobParams{
+						ID:        7,
+						RunAt:     now.Add(10 * time.Second),
+						LastError: util.Pointer("panic: nil map"),
+					})).
+					Times(1).
+					Return(db.Job{ID: 7, Status: types.JobPending}, nil)
+			},
+		},
+		{
+			name:     "DeadLettered",
+			attempts: DefaultMaxAttempts,
+			handle: func(ctx context.Context, payload testPayload) error {
+				return errors.New("failure")
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeadLetterJob(gomock.Any(), gomock.Eq(db.DeadLetterJobParams{
+						ID:        7,
+						LastError: util.Pointer("failure"),
+					})).
+					Times(1).
+					Return(db.Job{ID: 7, Status: types.JobDead}, nil)
+				store.EXPECT().
+					RetryJob(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			lockedUntil := now.Add(time.Minute + time.Minute)
+			store.EXPECT().
+				ClaimJobs(gomock.Any(), gomock.Eq(db.ClaimJobsParams{
+					Kinds:       []string{"test"},
+					LockedUntil: &lockedUntil,
+					Limit:       1,
+				})).
+				Times(1).
+				Return([]db.Job{{
+					ID:          7,
+					Kind:        "test",
+					Payload:     []byte(`{"user_id":42}`),
+					Status:      types.JobRunning,
+					Attempts:    tc.attempts,
+					MaxAttempts: DefaultMaxAttempts,
+				}}, nil)
+			tc.buildStubs(store)
+
+			runner := NewRunner(store, 1)
+			runner.now = func() time.Time { return now }
+			Register(runner, "test", time.Minute, tc.handle)
+
+			ran, err := runner.RunNext(context.Background())
+			require.NoError(t, err)
+			require.True(t, ran)
+		})
+	}
+}
+
+func TestRunNextWithoutHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ClaimJobs(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	ran, err := NewRunner(store, 1).RunNext(context.Background())
+	require.NoError(t, err)
+	require.False(t, ran)
+}
+
+func TestEnqueueDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		CreateUniqueJob(gomock.Any(), gomock.Eq(db.CreateUniqueJobParams{
+			Kind:        "test",
+			Payload:     []byte(`{"user_id":42}`),
+			RunAt:       now.Add(10 * time.Second),
+			MaxAttempts: 1,
+			UniqueKey:   util.Pointer("test@2024-03-01T12:00:10Z"),
+		})).
+		Times(1).
+		Return(int64(1), nil)
+
+	runner := NewRunner(store, 1)
+	runner.now = func() time.Time { return now }
+	require.NoError(t, runner.Schedule("test", Every(10*time.Second), testPayload{UserID: 42}, Options{MaxAttempts: 1}))
+	runner.schedules[0].next = runner.schedules[0].schedule.Next(now)
+
+	// runs are enqueued once their time has come
+	require.NoError(t, runner.EnqueueDue(context.Background()))
+	runner.now = func() time.Time { return now.Add(15 * time.Second) }
+	require.NoError(t, runner.EnqueueDue(context.Background()))
+	require.Equal(t, now.Add(20*time.Second), runner.schedules[0].next)
+}
@@ -0,0 +1,158 @@
+package job
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule returns the times a recurring job is run at. Schedules only depend on the time they are asked about, so
+// that every runner enqueues the same runs.
+type Schedule interface {
+	// Next returns the first time of the schedule after t
+	Next(t time.Time) time.Time
+}
+
+// every runs at the multiples of an interval since the Unix epoch
+type every time.Duration
+
+// Every returns a schedule running at every multiple of d since the Unix epoch
+func Every(d time.Duration) Schedule {
+	return every(d)
+}
+
+func (e every) Next(t time.Time) time.Time {
+	d := time.Duration(e)
+	return t.Truncate(d).Add(d)
+}
+
+// cron holds the allowed minutes, hours, days of the month, months and days of the week of a cron expression
+type cron struct {
+	minute, hour, dom, month, dow []bool
+	// anyDom and anyDow are set if the day of the month or the day of the week is `*`
+	anyDom, anyDow bool
+}
+
+// ParseCron parses a cron expression of five fields: minute, hour, day of the month, month and day of the week,
+// evaluated in UTC. Fields are `*` or lists of numbers and ranges, each optionally followed by a step. Days of the
+// week range from 0 (Sunday) to 6. As in cron, a day matches either field if both are restricted.
+func ParseCron(spec string) (Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields", spec)
+	}
+
+	var c cron
+	var err error
+	for _, f := range []struct {
+		field    string
+		set      *[]bool
+		min, max int
+	}{
+		{fields[0], &c.minute, 0, 59},
+		{fields[1], &c.hour, 0, 23},
+		{fields[2], &c.dom, 1, 31},
+		{fields[3], &c.month, 1, 12},
+		{fields[4], &c.dow, 0, 6},
+	} {
+		if *f.set, err = parseCronField(f.field, f.min, f.max); err != nil {
+			return nil, fmt.Errorf("cron expression %q: %w", spec, err)
+		}
+	}
+	c.anyDom = fields[2] == "*"
+	c.anyDow = fields[4] == "*"
+	if c.Next(time.Unix(0, 0)).IsZero() {
+		return nil, fmt.Errorf("cron expression %q never runs", spec)
+	}
+	return c, nil
+}
+
+// MustParseCron is like ParseCron but panics if the expression is invalid
+func MustParseCron(spec string) Schedule {
+	schedule, err := ParseCron(spec)
+	if err != nil {
+		panic(err)
+	}
+	return schedule
+}
+
+// parseCronField returns the values of [0, max] a field allows
+func parseCronField(field string, min, max int) ([]bool, error) {
+	allowed := make([]bool, max+1)
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rng = part[:i]
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid step in %q", part)
+			}
+		}
+
+		from, to := min, max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if from, err = strconv.Atoi(bounds[0]); err != nil {
+				return nil, fmt.Errorf("invalid value in %q", part)
+			}
+			to = from
+			if len(bounds) == 2 {
+				if to, err = strconv.Atoi(bounds[1]); err != nil {
+					return nil, fmt.Errorf("invalid range in %q", part)
+				}
+			} else if step > 1 {
+				// `n/step` runs from n until the maximum
+				to = max
+			}
+		}
+		if from < min || to > max || from > to {
+			return nil, fmt.Errorf("%q is out of range [%d, %d]", part, min, max)
+		}
+
+		for v := from; v <= to; v += step {
+			allowed[v] = true
+		}
+	}
+	return allowed, nil
+}
+
+func (c cron) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	// a schedule which runs at all runs within 4 years, such as on February 29 only
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if !c.month[t.Month()] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.hour[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.minute[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (c cron) dayMatches(t time.Time) bool {
+	dom, dow := c.dom[t.Day()], c.dow[t.Weekday()]
+	switch {
+	case c.anyDom && c.anyDow:
+		return true
+	case c.anyDom:
+		return dow
+	case c.anyDow:
+		return dom
+	}
+	return dom || dow
+}
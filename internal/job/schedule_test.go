@@ -0,0 +1,60 @@
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvery(t *testing.T) {
+	t0 := time.Date(2024, time.March, 1, 12, 0, 7, 0, time.UTC)
+	require.Equal(t, time.Date(2024, time.March, 1, 12, 0, 10, 0, time.UTC), Every(10*time.Second).Next(t0))
+	require.Equal(t, time.Date(2024, time.March, 1, 12, 0, 20, 0, time.UTC), Every(10*time.Second).Next(t0.Add(3*time.Second)))
+	require.Equal(t, time.Date(2024, time.March, 1, 13, 0, 0, 0, time.UTC), Every(time.Hour).Next(t0))
+}
+
+func TestParseCron(t *testing.T) {
+	t0 := time.Date(2024, time.March, 1, 12, 0, 7, 0, time.UTC) // a Friday
+
+	testCases := []struct {
+		spec string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2024, time.March, 1, 12, 1, 0, 0, time.UTC)},
+		{"30 3 * * *", time.Date(2024, time.March, 2, 3, 30, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, time.March, 1, 12, 15, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2024, time.March, 1, 13, 0, 0, 0, time.UTC)},
+		{"0 8 * * 1,3", time.Date(2024, time.March, 4, 8, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		// either the day of the month or the day of the week matches
+		{"0 0 15 * 0", time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.spec, func(t *testing.T) {
+			schedule, err := ParseCron(tc.spec)
+			require.NoError(t, err)
+			require.Equal(t, tc.next, schedule.Next(t0))
+		})
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 7",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"0 0 31 2 *",
+	} {
+		_, err := ParseCron(spec)
+		require.Error(t, err, spec)
+	}
+}
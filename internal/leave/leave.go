@@ -0,0 +1,193 @@
+// Package leave computes the leave balances of users from their annual entitlements.
+package leave
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/worktime"
+)
+
+// ErrInsufficientBalance is returned when a user does not have enough leave left for an absence
+var ErrInsufficientBalance = errors.New("insufficient leave balance")
+
+// Usage is the share of a working day taken as leave by an approved or pending absence
+type Usage struct {
+	worktime.Day
+	Pending bool
+}
+
+// Balance is the leave of a type a user has in a year as of a day. Days are working days.
+type Balance struct {
+	AbsenceTypeID int64  `json:"absence_type_id"`
+	Name          string `json:"name"`
+	Year          int    `json:"year"`
+	// EntitlementDays is the annual entitlement, pro-rated for users who joined during the year
+	EntitlementDays float64 `json:"entitlement_days"`
+	// AccruedDays is the part of the entitlement accrued as of the day
+	AccruedDays     float64 `json:"accrued_days"`
+	CarriedOverDays float64 `json:"carried_over_days"`
+	// CarryOverExpiresAt is the first day the days carried over can no longer be taken on, if they expire
+	CarryOverExpiresAt *time.Time `json:"carry_over_expires_at"`
+	// ExpiredDays are the days carried over which were not taken before they expired
+	ExpiredDays   float64 `json:"expired_days"`
+	UsedDays      float64 `json:"used_days"`
+	PendingDays   float64 `json:"pending_days"`
+	RemainingDays float64 `json:"remaining_days"`
+}
+
+// Policy computes the leave balances of a user for an absence type
+type Policy struct {
+	AbsenceType db.AbsenceType
+	// Joined is the day the user joined, entitlements are pro-rated by the months left in the year they joined
+	Joined time.Time
+	// Entitlements of the user, ordered by the year they apply from
+	Entitlements []db.LeaveEntitlement
+}
+
+// annualDays returns the entitlement which applies to year
+func (p Policy) annualDays(year int) float64 {
+	var days int32
+	for _, entitlement := range p.Entitlements {
+		if entitlement.AbsenceTypeID == p.AbsenceType.ID && int(entitlement.FromYear) <= year {
+			days = entitlement.Days
+		}
+	}
+	return float64(days)
+}
+
+// firstMonth returns the first month of year the user accrues leave in, or 13 if they joined later
+func (p Policy) firstMonth(year int) int {
+	switch {
+	case year < p.Joined.Year():
+		return 13
+	case year == p.Joined.Year():
+		return int(p.Joined.Month())
+	}
+	return 1
+}
+
+// accrued returns the entitlement of year accrued as of day and the pro-rated entitlement of the whole year.
+// A yearly entitlement is available from the first day of the year or the day the user joined,
+// a monthly entitlement accrues a twelfth at the beginning of every month.
+func (p Policy) accrued(year int, day time.Time) (float64, float64) {
+	annual := p.annualDays(year)
+	first := p.firstMonth(year)
+	entitlement := annual * float64(13-first) / 12
+
+	if day.Year() > year {
+		return entitlement, entitlement
+	}
+	if day.Year() < year || day.Before(p.Joined) {
+		return 0, entitlement
+	}
+	if p.AbsenceType.Accrual == types.AccrualMonthly {
+		return annual * float64(max(int(day.Month())-first+1, 0)) / 12, entitlement
+	}
+	return entitlement, entitlement
+}
+
+// Balance returns the balance of the year of day as of day. Leave which is not taken by the end of a year
+// is carried over into the next, up to the carry-over maximum of the absence type. Leave is taken from the days
+// carried over first, which expire after the carry-over expiry months of the absence type, if any.
+func (p Policy) Balance(day time.Time, usage []Usage) Balance {
+	first := day.Year()
+	for _, entitlement := range p.Entitlements {
+		if entitlement.AbsenceTypeID == p.AbsenceType.ID {
+			first = min(first, max(int(entitlement.FromYear), p.Joined.Year()))
+		}
+	}
+
+	var balance Balance
+	var remaining float64
+	for year := first; year <= day.Year(); year++ {
+		asOf := day
+		if year < day.Year() {
+			asOf = time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+		}
+		balance = p.yearBalance(year, asOf, min(max(remaining, 0), float64(p.AbsenceType.CarryOverMaxDays)), usage)
+		remaining = balance.RemainingDays
+	}
+	return balance.rounded()
+}
+
+func (p Policy) yearBalance(year int, day time.Time, carried float64, usage []Usage) Balance {
+	balance := Balance{
+		AbsenceTypeID:   p.AbsenceType.ID,
+		Name:            p.AbsenceType.Name,
+		Year:            year,
+		CarriedOverDays: carried,
+	}
+	balance.AccruedDays, balance.EntitlementDays = p.accrued(year, day)
+
+	var expiresAt time.Time
+	if p.AbsenceType.CarryOverExpiryMonths != nil {
+		expiresAt = time.Date(year, time.Month(1+*p.AbsenceType.CarryOverExpiryMonths), 1, 0, 0, 0, 0, time.UTC)
+		balance.CarryOverExpiresAt = &expiresAt
+	}
+
+	var takenBeforeExpiry float64
+	for _, u := range usage {
+		if u.Date.Year() != year {
+			continue
+		}
+		if u.Pending {
+			balance.PendingDays += u.Days
+		} else {
+			balance.UsedDays += u.Days
+		}
+		if u.Date.Before(expiresAt) {
+			takenBeforeExpiry += u.Days
+		}
+	}
+	if balance.CarryOverExpiresAt != nil && !day.Before(expiresAt) {
+		balance.ExpiredDays = max(carried-takenBeforeExpiry, 0)
+	}
+
+	balance.RemainingDays = balance.CarriedOverDays + balance.AccruedDays - balance.ExpiredDays -
+		balance.UsedDays - balance.PendingDays
+	return balance
+}
+
+// Check returns ErrInsufficientBalance if the balance of any year the requested leave falls in does not cover it.
+// The balance of a year is taken as of the last day of the requested leave within it.
+func (p Policy) Check(requested []worktime.Day, usage []Usage) error {
+	byYear := make(map[int]float64)
+	last := make(map[int]time.Time)
+	for _, day := range requested {
+		year := day.Date.Year()
+		byYear[year] += day.Days
+		if day.Date.After(last[year]) {
+			last[year] = day.Date
+		}
+	}
+
+	for year, days := range byYear {
+		balance := p.Balance(last[year], usage)
+		if round(days) > balance.RemainingDays {
+			return fmt.Errorf("%w: %.2f days requested in %d, %.2f remaining",
+				ErrInsufficientBalance, round(days), year, balance.RemainingDays)
+		}
+	}
+	return nil
+}
+
+func (b Balance) rounded() Balance {
+	b.EntitlementDays = round(b.EntitlementDays)
+	b.AccruedDays = round(b.AccruedDays)
+	b.CarriedOverDays = round(b.CarriedOverDays)
+	b.ExpiredDays = round(b.ExpiredDays)
+	b.UsedDays = round(b.UsedDays)
+	b.PendingDays = round(b.PendingDays)
+	b.RemainingDays = round(b.RemainingDays)
+	return b
+}
+
+// round rounds days to hundredths
+func round(days float64) float64 {
+	return math.Round(days*100) / 100
+}
@@ -0,0 +1,127 @@
+package leave
+
+import (
+	"testing"
+	"time"
+
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/worktime"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func vacation(accrual string) db.AbsenceType {
+	return db.AbsenceType{
+		ID:               1,
+		Name:             "Vacation",
+		Paid:             true,
+		TracksBalance:    true,
+		Accrual:          accrual,
+		CarryOverMaxDays: 5,
+	}
+}
+
+func entitlement(fromYear, days int32) db.LeaveEntitlement {
+	return db.LeaveEntitlement{AbsenceTypeID: 1, FromYear: fromYear, Days: days}
+}
+
+func used(day time.Time, days float64) Usage {
+	return Usage{Day: worktime.Day{Date: day, Days: days}}
+}
+
+func TestYearlyAccrual(t *testing.T) {
+	policy := Policy{
+		AbsenceType:  vacation(types.AccrualYearly),
+		Joined:       date(2023, time.January, 1),
+		Entitlements: []db.LeaveEntitlement{entitlement(2023, 24)},
+	}
+
+	balance := policy.Balance(date(2024, time.February, 1), []Usage{
+		used(date(2024, time.January, 15), 1),
+		{Day: worktime.Day{Date: date(2024, time.July, 1), Days: 0.5}, Pending: true},
+	})
+	require.Equal(t, 2024, balance.Year)
+	require.Equal(t, 24.0, balance.EntitlementDays)
+	require.Equal(t, 24.0, balance.AccruedDays)
+	require.Equal(t, 5.0, balance.CarriedOverDays)
+	require.Equal(t, 1.0, balance.UsedDays)
+	require.Equal(t, 0.5, balance.PendingDays)
+	require.Equal(t, 27.5, balance.RemainingDays)
+}
+
+func TestMonthlyAccrualProRated(t *testing.T) {
+	policy := Policy{
+		AbsenceType:  vacation(types.AccrualMonthly),
+		Joined:       date(2024, time.April, 10),
+		Entitlements: []db.LeaveEntitlement{entitlement(2020, 24)},
+	}
+
+	// nothing accrues before the user joined
+	balance := policy.Balance(date(2024, time.March, 1), nil)
+	require.Zero(t, balance.AccruedDays)
+	require.Equal(t, 18.0, balance.EntitlementDays)
+
+	balance = policy.Balance(date(2024, time.June, 30), nil)
+	require.Equal(t, 18.0, balance.EntitlementDays)
+	require.Equal(t, 6.0, balance.AccruedDays)
+	require.Zero(t, balance.CarriedOverDays)
+	require.Equal(t, 6.0, balance.RemainingDays)
+}
+
+func TestCarryOverCapAndExpiry(t *testing.T) {
+	absenceType := vacation(types.AccrualYearly)
+	months := int32(3)
+	absenceType.CarryOverExpiryMonths = &months
+	policy := Policy{
+		AbsenceType: absenceType,
+		Joined:      date(2022, time.January, 1),
+		Entitlements: []db.LeaveEntitlement{
+			entitlement(2022, 20),
+			entitlement(2024, 25),
+		},
+	}
+	usage := []Usage{
+		used(date(2023, time.March, 1), 2),
+		used(date(2024, time.February, 1), 3),
+	}
+
+	// 20 days are left of 2022 and 2 expire in 2023
+	balance := policy.Balance(date(2023, time.December, 31), usage)
+	require.Equal(t, 5.0, balance.CarriedOverDays)
+	require.Equal(t, 3.0, balance.ExpiredDays)
+	require.Equal(t, 20.0, balance.RemainingDays)
+
+	before := policy.Balance(date(2024, time.March, 31), usage)
+	require.Equal(t, 25.0, before.EntitlementDays)
+	require.Equal(t, date(2024, time.April, 1), *before.CarryOverExpiresAt)
+	require.Zero(t, before.ExpiredDays)
+	require.Equal(t, 27.0, before.RemainingDays)
+
+	after := policy.Balance(date(2024, time.April, 1), usage)
+	require.Equal(t, 2.0, after.ExpiredDays)
+	require.Equal(t, 25.0, after.RemainingDays)
+}
+
+func TestCheck(t *testing.T) {
+	policy := Policy{
+		AbsenceType:  vacation(types.AccrualMonthly),
+		Joined:       date(2020, time.January, 1),
+		Entitlements: []db.LeaveEntitlement{entitlement(2024, 12)},
+	}
+	usage := []Usage{used(date(2024, time.January, 8), 1)}
+
+	// two days have accrued by the end of February, one of which is taken
+	requested := []worktime.Day{{Date: date(2024, time.February, 26), Days: 1}}
+	require.NoError(t, policy.Check(requested, usage))
+
+	requested = append(requested, worktime.Day{Date: date(2024, time.February, 27), Days: 0.5})
+	require.ErrorIs(t, policy.Check(requested, usage), ErrInsufficientBalance)
+
+	// the entitlement of 2024 does not cover 2023
+	requested = []worktime.Day{{Date: date(2023, time.December, 29), Days: 1}}
+	require.ErrorIs(t, policy.Check(requested, nil), ErrInsufficientBalance)
+}
@@ -0,0 +1,99 @@
+// Package timesheetpdf renders timesheets as printable PDF documents to be signed by the employee and their manager.
+package timesheetpdf
+
+import (
+	"fmt"
+	"time"
+
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/holiday"
+	"github.com/mateoradman/tempus/internal/worktime"
+)
+
+// Document is a timesheet together with everything needed to render it
+type Document struct {
+	Timesheet db.Timesheet
+	User      db.User
+	// Approver approved the timesheet, nil unless it is approved
+	Approver *db.User
+	// Location is the time zone of the user, whose days begin at its midnight
+	Location *time.Location
+	// Entries started within the period, ordered by their start
+	Entries []db.ListTimesheetEntriesRow
+	// Absences are the approved absences overlapping the period, ordered by their start
+	Absences []db.ListTimesheetAbsencesRow
+	Holidays []holiday.Holiday
+	// Balance is the work balance of the period by day
+	Balance worktime.Balance
+}
+
+// Day is a calendar date of the timesheet together with everything which happened on it
+type Day struct {
+	worktime.Period
+	Date     time.Time
+	Holiday  *holiday.Holiday
+	Entries  []db.ListTimesheetEntriesRow
+	Absences []db.ListTimesheetAbsencesRow
+	// BreakSeconds is the time between the entries of the day
+	BreakSeconds int64
+}
+
+// Days returns every date of the period, at midnight UTC, with the entries which started on it, the absences
+// covering it and its balance
+func (doc Document) Days() []Day {
+	var days []Day
+	index := make(map[time.Time]int)
+	for day := worktime.Date(doc.Timesheet.PeriodStart); day.Before(doc.Timesheet.PeriodEnd); day = day.AddDate(0, 0, 1) {
+		index[day] = len(days)
+		days = append(days, Day{Date: day})
+	}
+
+	for _, period := range doc.Balance.Periods {
+		if i, ok := index[period.Start]; ok {
+			days[i].Period = period
+		}
+	}
+	for i := range doc.Holidays {
+		if d, ok := index[doc.Holidays[i].Date]; ok && days[d].Holiday == nil {
+			days[d].Holiday = &doc.Holidays[i]
+		}
+	}
+	for _, entry := range doc.Entries {
+		d, ok := index[worktime.Date(entry.StartTime.In(doc.Location))]
+		if !ok {
+			continue
+		}
+		if n := len(days[d].Entries); n > 0 {
+			if previous := days[d].Entries[n-1]; previous.EndTime != nil && entry.StartTime.After(*previous.EndTime) {
+				days[d].BreakSeconds += int64(entry.StartTime.Sub(*previous.EndTime) / time.Second)
+			}
+		}
+		days[d].Entries = append(days[d].Entries, entry)
+	}
+	for i := range days {
+		from, to := doc.localDay(days[i].Date)
+		for _, absence := range doc.Absences {
+			if absence.StartTime.Before(to) && (absence.EndTime == nil || absence.EndTime.After(from)) {
+				days[i].Absences = append(days[i].Absences, absence)
+			}
+		}
+	}
+	return days
+}
+
+// localDay returns the start of day and of the following day in the time zone of the user
+func (doc Document) localDay(day time.Time) (time.Time, time.Time) {
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, doc.Location)
+	return from, from.AddDate(0, 0, 1)
+}
+
+// formatDuration formats a duration in seconds as hours and minutes, rounded to the minute
+func formatDuration(seconds int64) string {
+	sign := ""
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	minutes := (seconds + 30) / 60
+	return fmt.Sprintf("%s%d:%02d", sign, minutes/60, minutes%60)
+}
@@ -0,0 +1,100 @@
+package timesheetpdf
+
+import (
+	"testing"
+	"time"
+
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/holiday"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/mateoradman/tempus/internal/worktime"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// marchDocument returns the approved March timesheet of a user in Europe/Zagreb with two entries and a break on
+// the 4th, a holiday on the 1st and a half day absence on the 5th
+func marchDocument(t *testing.T) Document {
+	location, err := time.LoadLocation("Europe/Zagreb")
+	require.NoError(t, err)
+
+	decidedAt := date(2024, time.April, 2).Add(9 * time.Hour)
+	project := "Website"
+	vacation := "Vacation"
+	entry := func(id int64, start, end time.Time) db.ListTimesheetEntriesRow {
+		return db.ListTimesheetEntriesRow{ID: id, StartTime: start.UTC(), EndTime: util.Pointer(end.UTC()), ProjectName: &project}
+	}
+	local := func(day int, hour, minute int) time.Time {
+		return time.Date(2024, time.March, day, hour, minute, 0, 0, location)
+	}
+
+	doc := Document{
+		Timesheet: db.Timesheet{
+			ID:          1,
+			UserID:      2,
+			Period:      types.TimesheetMonth,
+			PeriodStart: date(2024, time.March, 1),
+			PeriodEnd:   date(2024, time.April, 1),
+			Status:      types.TimesheetApproved,
+			DecidedByID: util.Pointer(int64(3)),
+			DecidedAt:   &decidedAt,
+		},
+		User:     db.User{ID: 2, Name: "Ivana", Surname: "Đurić", Language: types.Croatian, Timezone: "Europe/Zagreb"},
+		Approver: &db.User{ID: 3, Name: "Marko", Surname: "Čović"},
+		Location: location,
+		Entries: []db.ListTimesheetEntriesRow{
+			entry(1, local(4, 8, 0), local(4, 12, 0)),
+			entry(2, local(4, 12, 30), local(4, 16, 30)),
+			// started before midnight UTC, but on the 5th in Zagreb
+			entry(3, local(5, 0, 30), local(5, 4, 0)),
+		},
+		Absences: []db.ListTimesheetAbsencesRow{{
+			ID:              4,
+			AbsenceTypeName: &vacation,
+			Kind:            types.AbsenceAfternoon,
+			StartTime:       local(5, 12, 0).UTC(),
+			EndTime:         util.Pointer(local(6, 0, 0).UTC()),
+			Paid:            true,
+		}},
+		Holidays: []holiday.Holiday{{Date: date(2024, time.March, 1), Name: "Test holiday"}},
+	}
+	calendar := worktime.Calendar{Location: location, Start: doc.Timesheet.PeriodStart}
+	doc.Balance = calendar.Compute(doc.Timesheet.PeriodStart, doc.Timesheet.PeriodEnd, types.BalanceDay, nil, nil)
+	return doc
+}
+
+func TestDays(t *testing.T) {
+	doc := marchDocument(t)
+	days := doc.Days()
+	require.Len(t, days, 31)
+	for i, day := range days {
+		require.Equal(t, date(2024, time.March, i+1), day.Date)
+		require.Equal(t, day.Date, day.Start)
+	}
+
+	require.NotNil(t, days[0].Holiday)
+	require.Equal(t, "Test holiday", days[0].Holiday.Name)
+	require.Nil(t, days[1].Holiday)
+
+	require.Len(t, days[3].Entries, 2)
+	require.Equal(t, int64(30*60), days[3].BreakSeconds)
+
+	require.Len(t, days[4].Entries, 1)
+	require.Equal(t, int64(3), days[4].Entries[0].ID)
+	require.Zero(t, days[4].BreakSeconds)
+	require.Len(t, days[4].Absences, 1)
+	// the absence ends at midnight of the 6th
+	require.Empty(t, days[5].Absences)
+}
+
+func TestFormatDuration(t *testing.T) {
+	require.Equal(t, "0:00", formatDuration(0))
+	require.Equal(t, "7:30", formatDuration(7*3600+30*60))
+	require.Equal(t, "0:01", formatDuration(59))
+	require.Equal(t, "-1:15", formatDuration(-(3600 + 15*60)))
+	require.Equal(t, "100:00", formatDuration(100*3600))
+}
@@ -0,0 +1,170 @@
+package timesheetpdf
+
+import (
+	"strings"
+
+	"github.com/mateoradman/tempus/internal/types"
+)
+
+// labels are the texts of a document in one language
+type labels struct {
+	title          string
+	employee       string
+	manager        string
+	period         string
+	status         string
+	date           string
+	time           string
+	description    string
+	breaks         string
+	target         string
+	worked         string
+	absence        string
+	balance        string
+	total          string
+	carriedForward string
+	closing        string
+	unpaid         string
+	running        string
+	signature      string
+	// approvedBy is formatted with the name of the approver and the date of the approval
+	approvedBy string
+	preview    string
+	page       string
+	// dateLayout is the layout of dates
+	dateLayout string
+	// weekdays are abbreviated, starting with Sunday
+	weekdays [7]string
+	kinds    map[string]string
+	statuses map[string]string
+}
+
+// translations holds the labels of every supported language
+var translations = map[string]labels{
+	types.English: {
+		title:          "Timesheet",
+		employee:       "Employee",
+		manager:        "Manager",
+		period:         "Period",
+		status:         "Status",
+		date:           "Date",
+		time:           "Time",
+		description:    "Description",
+		breaks:         "Break",
+		target:         "Target",
+		worked:         "Worked",
+		absence:        "Absence",
+		balance:        "Balance",
+		total:          "Total",
+		carriedForward: "Overtime carried forward",
+		closing:        "Overtime balance at the end of the period",
+		unpaid:         "unpaid",
+		running:        "running",
+		signature:      "Signature and date",
+		approvedBy:     "Approved by %s on %s",
+		preview:        "PREVIEW - not approved",
+		page:           "Page %d of %s",
+		dateLayout:     "2006-01-02",
+		weekdays:       [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		kinds: map[string]string{
+			types.AbsenceFullDay:   "Full day",
+			types.AbsenceMorning:   "Morning",
+			types.AbsenceAfternoon: "Afternoon",
+		},
+		statuses: map[string]string{
+			types.TimesheetOpen:      "Open",
+			types.TimesheetSubmitted: "Submitted",
+			types.TimesheetApproved:  "Approved",
+			types.TimesheetRejected:  "Rejected",
+		},
+	},
+	types.German: {
+		title:          "Stundenzettel",
+		employee:       "Mitarbeiter",
+		manager:        "Vorgesetzter",
+		period:         "Zeitraum",
+		status:         "Status",
+		date:           "Datum",
+		time:           "Zeit",
+		description:    "Beschreibung",
+		breaks:         "Pause",
+		target:         "Soll",
+		worked:         "Ist",
+		absence:        "Abwesenheit",
+		balance:        "Saldo",
+		total:          "Summe",
+		carriedForward: "Übertrag Überstunden",
+		closing:        "Überstundensaldo am Ende des Zeitraums",
+		unpaid:         "unbezahlt",
+		running:        "läuft",
+		signature:      "Unterschrift und Datum",
+		approvedBy:     "Genehmigt von %s am %s",
+		preview:        "VORSCHAU - nicht genehmigt",
+		page:           "Seite %d von %s",
+		dateLayout:     "02.01.2006",
+		weekdays:       [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		kinds: map[string]string{
+			types.AbsenceFullDay:   "Ganztägig",
+			types.AbsenceMorning:   "Vormittag",
+			types.AbsenceAfternoon: "Nachmittag",
+		},
+		statuses: map[string]string{
+			types.TimesheetOpen:      "Offen",
+			types.TimesheetSubmitted: "Eingereicht",
+			types.TimesheetApproved:  "Genehmigt",
+			types.TimesheetRejected:  "Abgelehnt",
+		},
+	},
+	types.Croatian: {
+		title:          "Evidencija radnog vremena",
+		employee:       "Zaposlenik",
+		manager:        "Voditelj",
+		period:         "Razdoblje",
+		status:         "Status",
+		date:           "Datum",
+		time:           "Vrijeme",
+		description:    "Opis",
+		breaks:         "Stanka",
+		target:         "Fond",
+		worked:         "Rad",
+		absence:        "Odsutnost",
+		balance:        "Saldo",
+		total:          "Ukupno",
+		carriedForward: "Preneseni prekovremeni sati",
+		closing:        "Stanje prekovremenih sati na kraju razdoblja",
+		unpaid:         "neplaćeno",
+		running:        "u tijeku",
+		signature:      "Potpis i datum",
+		approvedBy:     "Odobrio/la %s dana %s",
+		preview:        "PREGLED - nije odobreno",
+		page:           "Stranica %d od %s",
+		dateLayout:     "02.01.2006.",
+		weekdays:       [7]string{"Ned", "Pon", "Uto", "Sri", "Čet", "Pet", "Sub"},
+		kinds: map[string]string{
+			types.AbsenceFullDay:   "Cijeli dan",
+			types.AbsenceMorning:   "Prijepodne",
+			types.AbsenceAfternoon: "Poslijepodne",
+		},
+		statuses: map[string]string{
+			types.TimesheetOpen:      "Otvoreno",
+			types.TimesheetSubmitted: "Predano",
+			types.TimesheetApproved:  "Odobreno",
+			types.TimesheetRejected:  "Odbijeno",
+		},
+	},
+}
+
+// translation returns the labels of language, English if it is not supported
+func translation(language string) labels {
+	if l, ok := translations[language]; ok {
+		return l
+	}
+	return translations[types.English]
+}
+
+// transliterator replaces the letters the core PDF fonts cannot encode in cp1252 with their closest equivalent
+var transliterator = strings.NewReplacer(
+	"Č", "C", "č", "c",
+	"Ć", "C", "ć", "c",
+	"Đ", "Dj", "đ", "dj",
+)
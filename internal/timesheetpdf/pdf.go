@@ -0,0 +1,322 @@
+package timesheetpdf
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
+)
+
+// widths of the columns of the day table in millimeters: date, time, description, break, target, worked, absence
+// and balance
+var pdfColumnWidths = []float64{26, 22, 58, 16, 17, 17, 17, 17}
+
+const (
+	// pdfRowHeight is the height of a row of the day table in millimeters
+	pdfRowHeight = 5
+	// pdfSignatureHeight is the height of the signature block in millimeters
+	pdfSignatureHeight = 40
+	// clockLayout is the layout of the times of day printed on a timesheet
+	clockLayout = "15:04"
+)
+
+// renderer writes a document with the labels of its language
+type renderer struct {
+	pdf *fpdf.Fpdf
+	doc Document
+	l   labels
+	// tr encodes text in cp1252, the encoding of the core fonts
+	tr func(string) string
+}
+
+// RenderPDF writes doc as a printable A4 PDF in the language of the user, listing the entries, breaks, absences and
+// holidays of every day of the period, the daily totals and the overtime balance, followed by signature lines for
+// the employee and their manager. Timesheets which are not approved are marked as previews.
+func RenderPDF(w io.Writer, doc Document) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	cp1252 := pdf.UnicodeTranslatorFromDescriptor("")
+	r := renderer{
+		pdf: pdf,
+		doc: doc,
+		l:   translation(doc.User.Language),
+		tr: func(s string) string {
+			return cp1252(transliterator.Replace(s))
+		},
+	}
+
+	title := fmt.Sprintf("%s %s", r.l.title, fullName(doc.User))
+	pdf.SetTitle(title, true)
+	pdf.SetCreator("tempus", true)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.CellFormat(0, 6, r.tr(fmt.Sprintf(r.l.page, pdf.PageNo(), "{nb}")), "", 0, "R", false, 0, "")
+	})
+	pdf.AddPage()
+
+	r.header()
+	breaks := r.days()
+	r.summary(breaks)
+	r.signatures()
+	return pdf.Output(w)
+}
+
+// header writes the title and the details of the timesheet
+func (r renderer) header() {
+	pdf := r.pdf
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 10, r.tr(r.l.title), "", 1, "L", false, 0, "")
+	if r.doc.Timesheet.Status != types.TimesheetApproved {
+		pdf.SetTextColor(200, 0, 0)
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(0, 6, r.tr(r.l.preview), "", 1, "L", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	}
+	pdf.Ln(3)
+
+	last := r.doc.Timesheet.PeriodEnd.AddDate(0, 0, -1)
+	details := [][2]string{
+		{r.l.employee, fullName(r.doc.User)},
+		{r.l.period, fmt.Sprintf("%s - %s", r.date(r.doc.Timesheet.PeriodStart), r.date(last))},
+		{r.l.status, r.l.statuses[r.doc.Timesheet.Status]},
+	}
+	for _, detail := range details {
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(30, 6, r.tr(detail[0]), "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(0, 6, r.tr(detail[1]), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+}
+
+// tableHeader writes the header of the day table
+func (r renderer) tableHeader() {
+	pdf := r.pdf
+	pdf.SetFont("Helvetica", "B", 8)
+	pdf.SetFillColor(230, 230, 230)
+	headers := []string{r.l.date, r.l.time, r.l.description, r.l.breaks, r.l.target, r.l.worked, r.l.absence, r.l.balance}
+	for i, header := range headers {
+		pdf.CellFormat(pdfColumnWidths[i], 6, r.tr(header), "B", 0, alignment(i), true, 0, "")
+	}
+	pdf.Ln(-1)
+	pdf.SetFont("Helvetica", "", 8)
+}
+
+// alignment aligns the text columns of the day table left and the durations right
+func alignment(column int) string {
+	if column < 3 {
+		return "L"
+	}
+	return "R"
+}
+
+// row writes a row of the day table, starting a new page with a table header if the page is full
+func (r renderer) row(cells []string, border string) {
+	pdf := r.pdf
+	_, pageHeight := pdf.GetPageSize()
+	_, _, _, bottom := pdf.GetMargins()
+	if pdf.GetY()+pdfRowHeight > pageHeight-bottom-10 {
+		pdf.AddPage()
+		r.tableHeader()
+	}
+	for i, cell := range cells {
+		pdf.CellFormat(pdfColumnWidths[i], pdfRowHeight, r.fit(cell, pdfColumnWidths[i]), border, 0, alignment(i), false, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+// days writes the day table and returns the total time of the breaks. The first row of a day holds its totals and
+// every holiday, absence and entry of the day takes a row.
+func (r renderer) days() int64 {
+	var total int64
+	r.tableHeader()
+	for _, day := range r.doc.Days() {
+		total += day.BreakSeconds
+		var lines [][2]string
+		if day.Holiday != nil {
+			lines = append(lines, [2]string{"", day.Holiday.Name})
+		}
+		for _, absence := range day.Absences {
+			lines = append(lines, [2]string{r.absenceTime(day, absence), r.absenceDescription(absence)})
+		}
+		for _, entry := range day.Entries {
+			lines = append(lines, [2]string{r.entryTime(entry), entryDescription(entry)})
+		}
+		if len(lines) == 0 {
+			lines = append(lines, [2]string{})
+		}
+
+		breaks := ""
+		if day.BreakSeconds > 0 {
+			breaks = formatDuration(day.BreakSeconds)
+		}
+		for i, line := range lines {
+			cells := make([]string, len(pdfColumnWidths))
+			cells[1], cells[2] = line[0], line[1]
+			if i == 0 {
+				cells[0] = fmt.Sprintf("%s %s", r.l.weekdays[day.Date.Weekday()], r.date(day.Date))
+				cells[3] = breaks
+				cells[4] = formatDuration(day.TargetSeconds)
+				cells[5] = formatDuration(day.WorkedSeconds)
+				cells[6] = formatDuration(day.AbsenceSeconds)
+				cells[7] = formatDuration(day.BalanceSeconds)
+			}
+			border := ""
+			if i == len(lines)-1 {
+				border = "B"
+			}
+			r.row(cells, border)
+		}
+	}
+	return total
+}
+
+// summary writes the totals of the period, including the total time of the breaks, and the overtime balance
+func (r renderer) summary(breaks int64) {
+	pdf := r.pdf
+	balance := r.doc.Balance
+
+	pdf.SetFont("Helvetica", "B", 8)
+	r.row([]string{
+		r.l.total, "", "",
+		formatDuration(breaks),
+		formatDuration(balance.TargetSeconds),
+		formatDuration(balance.WorkedSeconds),
+		formatDuration(balance.AbsenceSeconds),
+		formatDuration(balance.BalanceSeconds),
+	}, "T")
+	pdf.Ln(4)
+
+	totals := [][2]string{
+		{r.l.carriedForward, formatDuration(balance.CarriedForwardSeconds)},
+		{r.l.closing, formatDuration(balance.ClosingSeconds)},
+	}
+	for _, total := range totals {
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(90, 6, r.tr(total[0]), "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(25, 6, total[1], "", 1, "R", false, 0, "")
+	}
+}
+
+// signatures writes the signature lines of the employee and their manager. The approver of an approved timesheet is
+// printed below the line of the manager.
+func (r renderer) signatures() {
+	pdf := r.pdf
+	_, pageHeight := pdf.GetPageSize()
+	_, _, _, bottom := pdf.GetMargins()
+	if pdf.GetY()+pdfSignatureHeight > pageHeight-bottom-10 {
+		pdf.AddPage()
+	}
+	pdf.Ln(12)
+
+	left, _, _, _ := pdf.GetMargins()
+	const width, gap = 85.0, 20.0
+	y := pdf.GetY()
+
+	var approver, approval string
+	if r.doc.Approver != nil && r.doc.Timesheet.Status == types.TimesheetApproved {
+		approver = fullName(*r.doc.Approver)
+		if r.doc.Timesheet.DecidedAt != nil {
+			approval = fmt.Sprintf(r.l.approvedBy, approver, r.date(r.doc.Timesheet.DecidedAt.In(r.doc.Location)))
+		}
+	}
+	blocks := [][3]string{
+		{r.l.employee, fullName(r.doc.User), ""},
+		{r.l.manager, approver, approval},
+	}
+	for i, block := range blocks {
+		x := left + float64(i)*(width+gap)
+		pdf.SetXY(x, y)
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(width, 6, r.tr(block[0]), "", 2, "L", false, 0, "")
+		pdf.Ln(12)
+		pdf.SetX(x)
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.CellFormat(width, 5, r.tr(r.l.signature), "T", 2, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(width, 6, r.tr(block[1]), "", 2, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.CellFormat(width, 5, r.tr(block[2]), "", 2, "L", false, 0, "")
+	}
+}
+
+// fit encodes text and truncates it to the width of a cell
+func (r renderer) fit(text string, width float64) string {
+	encoded := r.tr(text)
+	// leave room for the cell margins
+	width -= 2 * r.pdf.GetCellMargin()
+	if r.pdf.GetStringWidth(encoded) <= width {
+		return encoded
+	}
+	for len(encoded) > 0 && r.pdf.GetStringWidth(encoded+"...") > width {
+		encoded = encoded[:len(encoded)-1]
+	}
+	return encoded + "..."
+}
+
+// date formats a calendar date
+func (r renderer) date(day time.Time) string {
+	return day.Format(r.l.dateLayout)
+}
+
+// fullName returns the name and surname of a user
+func fullName(user db.User) string {
+	return strings.TrimSpace(user.Name + " " + user.Surname)
+}
+
+// entryTime returns the local time span of an entry
+func (r renderer) entryTime(entry db.ListTimesheetEntriesRow) string {
+	start := entry.StartTime.In(r.doc.Location).Format(clockLayout)
+	if entry.EndTime == nil {
+		return fmt.Sprintf("%s - %s", start, r.l.running)
+	}
+	return fmt.Sprintf("%s - %s", start, entry.EndTime.In(r.doc.Location).Format(clockLayout))
+}
+
+// entryDescription joins the project, task and description of an entry
+func entryDescription(entry db.ListTimesheetEntriesRow) string {
+	var parts []string
+	for _, part := range []*string{entry.ProjectName, entry.TaskName, entry.Description} {
+		if part != nil && *part != "" {
+			parts = append(parts, *part)
+		}
+	}
+	return strings.Join(parts, " / ")
+}
+
+// absenceTime returns the kind of a full or half day absence, or the local time span an hourly absence covers on day
+func (r renderer) absenceTime(day Day, absence db.ListTimesheetAbsencesRow) string {
+	if kind, ok := r.l.kinds[absence.Kind]; ok {
+		return kind
+	}
+	from, to := r.doc.localDay(day.Date)
+	start, end := absence.StartTime.In(r.doc.Location), to
+	if absence.EndTime != nil && absence.EndTime.Before(to) {
+		end = absence.EndTime.In(r.doc.Location)
+	}
+	if start.Before(from) {
+		start = from
+	}
+	if end.Equal(to) {
+		return fmt.Sprintf("%s - 24:00", start.Format(clockLayout))
+	}
+	return fmt.Sprintf("%s - %s", start.Format(clockLayout), end.Format(clockLayout))
+}
+
+// absenceDescription returns the type of an absence, marking unpaid absences
+func (r renderer) absenceDescription(absence db.ListTimesheetAbsencesRow) string {
+	description := r.l.absence
+	if absence.AbsenceTypeName != nil {
+		description = *absence.AbsenceTypeName
+	}
+	if !absence.Paid {
+		description = fmt.Sprintf("%s (%s)", description, r.l.unpaid)
+	}
+	return description
+}
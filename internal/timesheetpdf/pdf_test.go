@@ -0,0 +1,69 @@
+package timesheetpdf
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderPDF(t *testing.T) {
+	for _, language := range []string{types.English, types.German, types.Croatian, "xx"} {
+		t.Run(language, func(t *testing.T) {
+			doc := marchDocument(t)
+			doc.User.Language = language
+
+			var buf bytes.Buffer
+			err := RenderPDF(&buf, doc)
+			require.NoError(t, err)
+			require.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+		})
+	}
+}
+
+func TestRenderPDFPreview(t *testing.T) {
+	doc := marchDocument(t)
+	doc.Timesheet.Status = types.TimesheetSubmitted
+	doc.Timesheet.DecidedByID = nil
+	doc.Timesheet.DecidedAt = nil
+	doc.Approver = nil
+	// a running entry
+	doc.Entries = append(doc.Entries, db.ListTimesheetEntriesRow{
+		ID:        5,
+		StartTime: date(2024, time.March, 29).Add(8 * time.Hour),
+	})
+
+	var buf bytes.Buffer
+	err := RenderPDF(&buf, doc)
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+}
+
+func TestRenderPDFPages(t *testing.T) {
+	doc := marchDocument(t)
+	description := util.RandomString(200)
+	doc.Entries = nil
+	for day := 1; day <= 31; day++ {
+		for hour := 6; hour < 18; hour += 2 {
+			start := date(2024, time.March, day).Add(time.Duration(hour) * time.Hour)
+			doc.Entries = append(doc.Entries, db.ListTimesheetEntriesRow{
+				ID:          int64(len(doc.Entries) + 1),
+				StartTime:   start,
+				EndTime:     util.Pointer(start.Add(time.Hour)),
+				Description: &description,
+			})
+		}
+	}
+
+	pages := func(doc Document) int {
+		var buf bytes.Buffer
+		require.NoError(t, RenderPDF(&buf, doc))
+		return bytes.Count(buf.Bytes(), []byte("/Type /Page\n"))
+	}
+	// every entry takes a row, so the table continues on further pages
+	require.Greater(t, pages(doc), pages(marchDocument(t))+1)
+}
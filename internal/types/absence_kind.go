@@ -0,0 +1,18 @@
+package types
+
+// Constants for all absence kinds
+const (
+	AbsenceFullDay   = "full_day"
+	AbsenceMorning   = "morning"
+	AbsenceAfternoon = "afternoon"
+	AbsenceHours     = "hours"
+)
+
+// IsValidAbsenceKind returns true if the provided absence kind is supported
+func IsValidAbsenceKind(kind string) bool {
+	switch kind {
+	case AbsenceFullDay, AbsenceMorning, AbsenceAfternoon, AbsenceHours:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package types
+
+// Constants for all absence statuses
+const (
+	AbsencePending   = "pending"
+	AbsenceApproved  = "approved"
+	AbsenceRejected  = "rejected"
+	AbsenceCancelled = "cancelled"
+)
+
+// IsValidAbsenceStatus returns true if the provided absence status is supported
+func IsValidAbsenceStatus(status string) bool {
+	switch status {
+	case AbsencePending, AbsenceApproved, AbsenceRejected, AbsenceCancelled:
+		return true
+	}
+	return false
+}
+
+// CanTransitionAbsence returns true if an absence may move from one status to another.
+// Pending absences can be approved, rejected or cancelled, approved absences can only be cancelled.
+func CanTransitionAbsence(from, to string) bool {
+	switch from {
+	case AbsencePending:
+		return to == AbsenceApproved || to == AbsenceRejected || to == AbsenceCancelled
+	case AbsenceApproved:
+		return to == AbsenceCancelled
+	}
+	return false
+}
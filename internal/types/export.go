@@ -0,0 +1,16 @@
+package types
+
+// Constants for all spreadsheet formats data can be exported as
+const (
+	ExportCSV  = "csv"
+	ExportXLSX = "xlsx"
+)
+
+// IsValidExportFormat returns true if the provided export format is supported
+func IsValidExportFormat(format string) bool {
+	switch format {
+	case ExportCSV, ExportXLSX:
+		return true
+	}
+	return false
+}
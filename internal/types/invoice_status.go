@@ -0,0 +1,30 @@
+package types
+
+// Constants for all invoice statuses
+const (
+	InvoiceDraft  = "draft"
+	InvoiceIssued = "issued"
+	InvoicePaid   = "paid"
+	InvoiceVoid   = "void"
+)
+
+// IsValidInvoiceStatus returns true if the provided invoice status is supported
+func IsValidInvoiceStatus(status string) bool {
+	switch status {
+	case InvoiceDraft, InvoiceIssued, InvoicePaid, InvoiceVoid:
+		return true
+	}
+	return false
+}
+
+// CanTransitionInvoice returns true if an invoice may move from one status to another.
+// Drafts can be issued, issued invoices can be paid or voided. Drafts are deleted instead of voided.
+func CanTransitionInvoice(from, to string) bool {
+	switch from {
+	case InvoiceDraft:
+		return to == InvoiceIssued
+	case InvoiceIssued:
+		return to == InvoicePaid || to == InvoiceVoid
+	}
+	return false
+}
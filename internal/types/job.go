@@ -0,0 +1,19 @@
+package types
+
+// Constants for all job statuses
+const (
+	JobPending   = "pending"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	// JobDead is the status of jobs which failed every attempt, they are kept until they are retried
+	JobDead = "dead"
+)
+
+// IsValidJobStatus returns true if the provided job status is supported
+func IsValidJobStatus(status string) bool {
+	switch status {
+	case JobPending, JobRunning, JobSucceeded, JobDead:
+		return true
+	}
+	return false
+}
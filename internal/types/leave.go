@@ -0,0 +1,16 @@
+package types
+
+// Constants for all ways a leave entitlement accrues
+const (
+	AccrualYearly  = "yearly"
+	AccrualMonthly = "monthly"
+)
+
+// IsValidAccrual returns true if the provided leave accrual is supported
+func IsValidAccrual(accrual string) bool {
+	switch accrual {
+	case AccrualYearly, AccrualMonthly:
+		return true
+	}
+	return false
+}
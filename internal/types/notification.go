@@ -0,0 +1,7 @@
+package types
+
+// Constants for all notification kinds
+const (
+	NotificationEntryAutoClosed = "entry.auto_closed"
+	NotificationTimerReminder   = "timer.reminder"
+)
@@ -1,15 +1,17 @@
 package types
 
 const (
-	AdminRole    = "admin"
-	ManagerRole  = "manager"
-	EmployeeRole = "employee"
+	// SuperuserRole is the role of the platform superuser, the only user working across companies
+	SuperuserRole = "superuser"
+	AdminRole     = "admin"
+	ManagerRole   = "manager"
+	EmployeeRole  = "employee"
 )
 
 // IsValidRole returns true if the provided role is supported
 func IsValidRole(role string) bool {
 	switch role {
-	case AdminRole, ManagerRole, EmployeeRole:
+	case SuperuserRole, AdminRole, ManagerRole, EmployeeRole:
 		return true
 	}
 	return false
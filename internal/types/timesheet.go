@@ -0,0 +1,48 @@
+package types
+
+// Constants for all timesheet statuses
+const (
+	TimesheetOpen      = "open"
+	TimesheetSubmitted = "submitted"
+	TimesheetApproved  = "approved"
+	TimesheetRejected  = "rejected"
+)
+
+// Constants for all timesheet periods
+const (
+	TimesheetWeek  = "week"
+	TimesheetMonth = "month"
+)
+
+// IsValidTimesheetStatus returns true if the provided timesheet status is supported
+func IsValidTimesheetStatus(status string) bool {
+	switch status {
+	case TimesheetOpen, TimesheetSubmitted, TimesheetApproved, TimesheetRejected:
+		return true
+	}
+	return false
+}
+
+// IsValidTimesheetPeriod returns true if the provided timesheet period is supported
+func IsValidTimesheetPeriod(period string) bool {
+	switch period {
+	case TimesheetWeek, TimesheetMonth:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTimesheet returns true if a timesheet may move from one status to another.
+// Open and rejected timesheets can be submitted, submitted timesheets can be approved or rejected
+// and approved timesheets can only be reopened.
+func CanTransitionTimesheet(from, to string) bool {
+	switch from {
+	case TimesheetOpen, TimesheetRejected:
+		return to == TimesheetSubmitted
+	case TimesheetSubmitted:
+		return to == TimesheetApproved || to == TimesheetRejected
+	case TimesheetApproved:
+		return to == TimesheetOpen
+	}
+	return false
+}
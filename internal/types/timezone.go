@@ -0,0 +1,16 @@
+package types
+
+import "time"
+
+// DefaultTimezone is the time zone of users created without one
+const DefaultTimezone = "UTC"
+
+// IsValidTimezone returns true if the provided name is an IANA time zone known to Go and Postgres
+func IsValidTimezone(name string) bool {
+	// time.LoadLocation reads "" as UTC and "Local" as the zone of the server, neither of which Postgres accepts
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
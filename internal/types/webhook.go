@@ -0,0 +1,44 @@
+package types
+
+// Constants for all webhook event types
+const (
+	EventEntryCreated     = "entry.created"
+	EventEntryUpdated     = "entry.updated"
+	EventEntryDeleted     = "entry.deleted"
+	EventEntryAutoClosed  = "entry.auto_closed"
+	EventAbsenceApproved  = "absence.approved"
+	EventAbsenceRejected  = "absence.rejected"
+	EventAbsenceCancelled = "absence.cancelled"
+	EventUserDeleted      = "user.deleted"
+)
+
+// Constants for all webhook delivery statuses
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// IsValidWebhookEvent returns true if the provided webhook event type is supported
+func IsValidWebhookEvent(event string) bool {
+	switch event {
+	case EventEntryCreated, EventEntryUpdated, EventEntryDeleted, EventEntryAutoClosed,
+		EventAbsenceApproved, EventAbsenceRejected, EventAbsenceCancelled,
+		EventUserDeleted:
+		return true
+	}
+	return false
+}
+
+// AbsenceEvent returns the webhook event type of an absence moving to status
+func AbsenceEvent(status string) string {
+	switch status {
+	case AbsenceApproved:
+		return EventAbsenceApproved
+	case AbsenceRejected:
+		return EventAbsenceRejected
+	case AbsenceCancelled:
+		return EventAbsenceCancelled
+	}
+	return ""
+}
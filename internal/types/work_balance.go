@@ -0,0 +1,17 @@
+package types
+
+// Constants for all periods a work balance can be grouped by
+const (
+	BalanceDay   = "day"
+	BalanceWeek  = "week"
+	BalanceMonth = "month"
+)
+
+// IsValidBalancePeriod returns true if the provided work balance period is supported
+func IsValidBalancePeriod(period string) bool {
+	switch period {
+	case BalanceDay, BalanceWeek, BalanceMonth:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+// ErrPrivateTarget is returned when a delivery would connect to an address which is not public
+var ErrPrivateTarget = errors.New("webhook target is not a public address")
+
+// IsPublicURL reports whether the host of rawURL may be public: neither localhost nor a loopback, private, link-local
+// or unspecified IP address. Host names resolving to such addresses are refused when the deliveries are sent.
+func IsPublicURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return isPublicIP(ip)
+	}
+	return true
+}
+
+// isPublicIP reports whether ip is reachable from the internet, so that subscriptions cannot reach the services next
+// to the server
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified()
+}
+
+// publicClient returns a client which refuses to connect to addresses which are not public, whatever the host name
+// of the URL or of a redirect resolves to
+func publicClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: attemptTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return ErrPrivateTarget
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would connect to the target on behalf of the dispatcher
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: attemptTimeout, Transport: transport}
+}
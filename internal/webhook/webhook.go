@@ -0,0 +1,203 @@
+// Package webhook delivers the events written to the outbox to the webhook subscriptions of companies.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/mateoradman/tempus/internal/util"
+)
+
+// Headers of a delivery
+const (
+	// SignatureHeader holds the time of the attempt and the HMAC-SHA256 signature of the body, see Sign
+	SignatureHeader = "X-Tempus-Signature"
+	EventHeader     = "X-Tempus-Event"
+	DeliveryHeader  = "X-Tempus-Delivery"
+)
+
+const (
+	// MaxAttempts is the number of attempts after which a delivery fails
+	MaxAttempts = 10
+	// firstBackoff is the delay after the first failed attempt, which doubles after every further attempt
+	firstBackoff = 30 * time.Second
+	maxBackoff   = 12 * time.Hour
+	// responseBodyLimit is the number of bytes of the response body kept in the delivery log
+	responseBodyLimit = 1024
+	// attemptTimeout is the time a subscriber has to respond
+	attemptTimeout = 10 * time.Second
+	batchSize      = 20
+	// lease is the time the deliveries of a batch are locked for, long enough to attempt all of them one after another
+	lease = batchSize*attemptTimeout + time.Minute
+)
+
+const (
+	// DispatchKind is the kind of the recurring job dispatching the due deliveries
+	DispatchKind = "webhooks.dispatch"
+	// DispatchTimeout is the time a dispatch job may run for, long enough to attempt at least one batch
+	DispatchTimeout = 2 * lease
+)
+
+// Event is the body of a delivery
+type Event struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Sign returns the signature header of a body sent at timestamp: `t=<unix seconds>,v1=<hex HMAC-SHA256>`, where the
+// HMAC is computed with the secret of the subscription over the unix seconds, a dot and the body. Subscribers should
+// reject old timestamps to prevent replays.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return fmt.Sprintf("t=%s,v1=%s", t, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// Backoff returns the delay before the next attempt of a delivery which failed attempts times
+func Backoff(attempts int32) time.Duration {
+	delay := firstBackoff
+	for i := int32(1); i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		return maxBackoff
+	}
+	return delay
+}
+
+// Dispatcher attempts the pending deliveries whose time has come. Deliveries are claimed with FOR UPDATE SKIP LOCKED,
+// so that any number of dispatchers can run at once.
+type Dispatcher struct {
+	store  db.Store
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewDispatcher creates a dispatcher sending requests with client, or if client is nil with a client timing out after
+// 10 seconds which only connects to public addresses
+func NewDispatcher(store db.Store, client *http.Client) *Dispatcher {
+	if client == nil {
+		client = publicClient()
+	}
+	return &Dispatcher{store: store, client: client, now: time.Now}
+}
+
+// Dispatch attempts the due deliveries in batches until no full batch is due. No further batch is claimed once the
+// deadline of ctx is closer than the lease of a batch, so that the attempts of a batch are not cut short.
+func (d *Dispatcher) Dispatch(ctx context.Context) error {
+	for {
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < lease {
+			return nil
+		}
+		n, err := d.DispatchDue(ctx)
+		if err != nil || n < batchSize {
+			return err
+		}
+	}
+}
+
+// DispatchDue attempts a batch of due deliveries and returns the number of deliveries attempted
+func (d *Dispatcher) DispatchDue(ctx context.Context) (int, error) {
+	deliveries, err := d.store.ClaimWebhookDeliveries(ctx, db.ClaimWebhookDeliveriesParams{
+		LockedUntil: d.now().UTC().Add(lease),
+		Limit:       batchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		if _, err := d.Attempt(ctx, delivery); err != nil {
+			return 0, err
+		}
+	}
+	return len(deliveries), nil
+}
+
+// Attempt sends a delivery and records the outcome in the delivery log. Deliveries succeed on a 2xx response, and
+// are retried with exponential backoff until they fail after MaxAttempts attempts.
+func (d *Dispatcher) Attempt(ctx context.Context, delivery db.WebhookDelivery) (db.WebhookDelivery, error) {
+	req, err := d.store.GetWebhookDeliveryRequest(ctx, delivery.ID)
+	if err != nil {
+		return db.WebhookDelivery{}, err
+	}
+
+	body, err := json.Marshal(Event{
+		ID:        req.EventID,
+		Type:      req.Type,
+		CreatedAt: req.CreatedAt,
+		Data:      req.Payload,
+	})
+	if err != nil {
+		return db.WebhookDelivery{}, err
+	}
+
+	now := d.now().UTC()
+	arg := db.RecordWebhookDeliveryAttemptParams{
+		ID:            delivery.ID,
+		Status:        types.DeliverySucceeded,
+		NextAttemptAt: now,
+	}
+	status, response, err := d.send(ctx, req, delivery.ID, now, body)
+	if err != nil {
+		arg.Error = util.Pointer(err.Error())
+	} else {
+		arg.ResponseStatus = &status
+		arg.ResponseBody = &response
+	}
+	if err != nil || status < 200 || status > 299 {
+		arg.Status = types.DeliveryPending
+		arg.NextAttemptAt = now.Add(Backoff(delivery.Attempts + 1))
+		if delivery.Attempts+1 >= MaxAttempts {
+			arg.Status = types.DeliveryFailed
+		}
+	}
+
+	// a request which was sent is recorded even if ctx was cancelled meanwhile, so that it is not sent again
+	return d.store.RecordWebhookDeliveryAttempt(context.WithoutCancel(ctx), arg)
+}
+
+// send posts a signed body to the URL of a subscription and returns the status and the beginning of the body of the
+// response
+func (d *Dispatcher) send(ctx context.Context, req db.GetWebhookDeliveryRequestRow, deliveryID int64, now time.Time, body []byte) (int32, string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, req.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "tempus-webhooks")
+	request.Header.Set(EventHeader, req.Type)
+	request.Header.Set(DeliveryHeader, strconv.FormatInt(deliveryID, 10))
+	request.Header.Set(SignatureHeader, Sign(req.Secret, now, body))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return 0, "", err
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, responseBodyLimit))
+	if err != nil {
+		return 0, "", err
+	}
+	// text columns hold neither invalid UTF-8 nor NUL characters
+	text := strings.ReplaceAll(strings.ToValidUTF8(string(data), ""), "\x00", "")
+	return int32(response.StatusCode), text, nil
+}
@@ -0,0 +1,274 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/mateoradman/tempus/internal/db/mock"
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestSign(t *testing.T) {
+	timestamp := time.Unix(1700000000, 0)
+	body := []byte(`{"id":1}`)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(`1700000000.{"id":1}`))
+	require.Equal(t, "t=1700000000,v1="+hex.EncodeToString(mac.Sum(nil)), Sign("secret", timestamp, body))
+
+	require.NotEqual(t, Sign("secret", timestamp, body), Sign("other", timestamp, body))
+	require.NotEqual(t, Sign("secret", timestamp, body), Sign("secret", timestamp.Add(time.Second), body))
+}
+
+func TestBackoff(t *testing.T) {
+	require.Equal(t, 30*time.Second, Backoff(1))
+	require.Equal(t, time.Minute, Backoff(2))
+	require.Equal(t, 2*time.Minute, Backoff(3))
+	require.Equal(t, 256*time.Minute, Backoff(MaxAttempts))
+	require.Equal(t, maxBackoff, Backoff(1000))
+}
+
+func TestAttempt(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	request := db.GetWebhookDeliveryRequestRow{
+		ID:        7,
+		Secret:    "secret",
+		EventID:   3,
+		Type:      types.EventEntryCreated,
+		Payload:   []byte(`{"id":42}`),
+		CreatedAt: now.Add(-time.Minute),
+	}
+
+	testCases := []struct {
+		name      string
+		attempts  int32
+		status    int
+		closed    bool
+		checkArgs func(t *testing.T, arg db.RecordWebhookDeliveryAttemptParams)
+	}{
+		{
+			name:   "Succeeded",
+			status: http.StatusNoContent,
+			checkArgs: func(t *testing.T, arg db.RecordWebhookDeliveryAttemptParams) {
+				require.Equal(t, types.DeliverySucceeded, arg.Status)
+				require.Equal(t, int32(http.StatusNoContent), *arg.ResponseStatus)
+				require.Nil(t, arg.Error)
+			},
+		},
+		{
+			name:     "Retried",
+			attempts: 2,
+			status:   http.StatusInternalServerError,
+			checkArgs: func(t *testing.T, arg db.RecordWebhookDeliveryAttemptParams) {
+				require.Equal(t, types.DeliveryPending, arg.Status)
+				require.Equal(t, now.Add(2*time.Minute), arg.NextAttemptAt)
+				require.Equal(t, int32(http.StatusInternalServerError), *arg.ResponseStatus)
+				require.Equal(t, "failure", *arg.ResponseBody)
+			},
+		},
+		{
+			name:     "Failed",
+			attempts: MaxAttempts - 1,
+			status:   http.StatusGone,
+			checkArgs: func(t *testing.T, arg db.RecordWebhookDeliveryAttemptParams) {
+				require.Equal(t, types.DeliveryFailed, arg.Status)
+			},
+		},
+		{
+			name:   "Unreachable",
+			closed: true,
+			checkArgs: func(t *testing.T, arg db.RecordWebhookDeliveryAttemptParams) {
+				require.Equal(t, types.DeliveryPending, arg.Status)
+				require.Equal(t, now.Add(30*time.Second), arg.NextAttemptAt)
+				require.Nil(t, arg.ResponseStatus)
+				require.NotNil(t, arg.Error)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.Equal(t, Sign("secret", now, body), r.Header.Get(SignatureHeader))
+				require.Equal(t, types.EventEntryCreated, r.Header.Get(EventHeader))
+				require.Equal(t, "7", r.Header.Get(DeliveryHeader))
+
+				var event Event
+				require.NoError(t, json.Unmarshal(body, &event))
+				require.Equal(t, int64(3), event.ID)
+				require.JSONEq(t, `{"id":42}`, string(event.Data))
+
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte("failure"))
+			}))
+			defer subscriber.Close()
+			if tc.closed {
+				subscriber.Close()
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			req := request
+			req.Url = subscriber.URL
+			store.EXPECT().
+				GetWebhookDeliveryRequest(gomock.Any(), gomock.Eq(int64(7))).
+				Times(1).
+				Return(req, nil)
+			store.EXPECT().
+				RecordWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ any, arg db.RecordWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+					require.Equal(t, int64(7), arg.ID)
+					tc.checkArgs(t, arg)
+					return db.WebhookDelivery{ID: arg.ID, Status: arg.Status}, nil
+				})
+
+			dispatcher := NewDispatcher(store, subscriber.Client())
+			dispatcher.now = func() time.Time { return now }
+			_, err := dispatcher.Attempt(context.Background(), db.WebhookDelivery{ID: 7, Attempts: tc.attempts})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDispatchDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ClaimWebhookDeliveries(gomock.Any(), gomock.Eq(db.ClaimWebhookDeliveriesParams{
+			LockedUntil: now.Add(lease),
+			Limit:       batchSize,
+		})).
+		Times(1).
+		Return([]db.WebhookDelivery{}, nil)
+
+	dispatcher := NewDispatcher(store, nil)
+	dispatcher.now = func() time.Time { return now }
+	n, err := dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
+
+func TestDispatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ClaimWebhookDeliveries(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.WebhookDelivery{}, nil)
+
+	// dispatching stops once a batch is not full
+	require.NoError(t, NewDispatcher(store, nil).Dispatch(context.Background()))
+}
+
+func TestDispatchDeadline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ClaimWebhookDeliveries(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	// a batch claimed now could not be attempted before the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), lease/2)
+	defer cancel()
+	require.NoError(t, NewDispatcher(store, nil).Dispatch(ctx))
+}
+
+func TestAttemptCancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the job is cancelled while the subscriber handles the request
+		cancel()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer subscriber.Close()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetWebhookDeliveryRequest(gomock.Any(), gomock.Eq(int64(7))).
+		Times(1).
+		Return(db.GetWebhookDeliveryRequestRow{ID: 7, Url: subscriber.URL, Secret: "secret", Payload: []byte(`{}`)}, nil)
+	store.EXPECT().
+		RecordWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, arg db.RecordWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+			require.NoError(t, ctx.Err())
+			return db.WebhookDelivery{ID: arg.ID, Status: arg.Status}, nil
+		})
+
+	_, err := NewDispatcher(store, subscriber.Client()).Attempt(ctx, db.WebhookDelivery{ID: 7})
+	require.NoError(t, err)
+}
+
+func TestIsPublicURL(t *testing.T) {
+	for url, public := range map[string]bool{
+		"https://example.com/hooks":      true,
+		"http://93.184.216.34:8080/hook": true,
+		"http://localhost:8080/hook":     false,
+		"http://api.localhost/hook":      false,
+		"http://127.0.0.1/hook":          false,
+		"http://10.0.0.4/hook":           false,
+		"http://192.168.1.1/hook":        false,
+		"http://169.254.169.254/latest":  false,
+		"http://[::1]:8080/hook":         false,
+		"http://[fd00::1]/hook":          false,
+		"http://0.0.0.0/hook":            false,
+	} {
+		require.Equal(t, public, IsPublicURL(url), url)
+	}
+}
+
+func TestAttemptPrivateTarget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the subscriber on a loopback address was reached")
+	}))
+	defer subscriber.Close()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetWebhookDeliveryRequest(gomock.Any(), gomock.Eq(int64(7))).
+		Times(1).
+		Return(db.GetWebhookDeliveryRequestRow{ID: 7, Url: subscriber.URL, Secret: "secret", Payload: []byte(`{}`)}, nil)
+	store.EXPECT().
+		RecordWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.RecordWebhookDeliveryAttemptParams) (db.WebhookDelivery, error) {
+			require.Equal(t, types.DeliveryPending, arg.Status)
+			require.NotNil(t, arg.Error)
+			require.Contains(t, *arg.Error, ErrPrivateTarget.Error())
+			return db.WebhookDelivery{ID: arg.ID, Status: arg.Status}, nil
+		})
+
+	_, err := NewDispatcher(store, nil).Attempt(context.Background(), db.WebhookDelivery{ID: 7})
+	require.NoError(t, err)
+}
@@ -0,0 +1,112 @@
+package worktime
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/holiday"
+)
+
+// LoadCalendar loads the work schedules of the user and their holidays until to. The balance of a user accrues from
+// the day they were created, or from their first schedule assignment if it is effective earlier.
+func LoadCalendar(ctx context.Context, q db.Querier, user db.User, location *time.Location, to time.Time) (Calendar, error) {
+	calendar := Calendar{
+		Location: location,
+		Start:    Date(user.CreatedAt.In(location)),
+	}
+
+	if user.CompanyID != nil {
+		company, err := q.GetCompany(ctx, *user.CompanyID)
+		if err != nil {
+			return calendar, err
+		}
+		if company.DefaultWorkScheduleID != nil {
+			schedule, err := q.GetWorkSchedule(ctx, *company.DefaultWorkScheduleID)
+			if err != nil {
+				return calendar, err
+			}
+			calendar.Default = &schedule
+		}
+	}
+
+	assignments, err := q.ListUserWorkScheduleAssignments(ctx, user.ID)
+	if err != nil {
+		return calendar, err
+	}
+	schedules := make(map[int64]db.WorkSchedule)
+	for _, assignment := range assignments {
+		schedule, ok := schedules[assignment.WorkScheduleID]
+		if !ok {
+			schedule, err = q.GetWorkSchedule(ctx, assignment.WorkScheduleID)
+			if err != nil {
+				return calendar, err
+			}
+			schedules[schedule.ID] = schedule
+		}
+		effectiveFrom := Date(assignment.EffectiveFrom)
+		calendar.Assignments = append(calendar.Assignments, Assignment{
+			EffectiveFrom: effectiveFrom,
+			Schedule:      schedule,
+		})
+		if effectiveFrom.Before(calendar.Start) {
+			calendar.Start = effectiveFrom
+		}
+	}
+
+	holidays, err := LoadHolidays(ctx, q, user, calendar.Start, to)
+	if err != nil {
+		return calendar, err
+	}
+	calendar.Holidays = make(map[time.Time]bool, len(holidays))
+	for _, holiday := range holidays {
+		calendar.Holidays[holiday.Date] = true
+	}
+	return calendar, nil
+}
+
+// LoadHolidays returns the days off of the user within [from, to): the public holidays of their country and
+// subdivision, as overridden by their company.
+func LoadHolidays(ctx context.Context, q db.Querier, user db.User, from, to time.Time) ([]holiday.Holiday, error) {
+	var public []holiday.Holiday
+	if user.Country != nil {
+		var subdivision *string
+		if user.Subdivision != nil {
+			subdivision = new(string)
+			*subdivision = strings.ToUpper(*user.Subdivision)
+		}
+		rows, err := q.ListPublicHolidays(ctx, db.ListPublicHolidaysParams{
+			Country:     strings.ToUpper(*user.Country),
+			Subdivision: subdivision,
+			From:        from,
+			To:          to,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			public = append(public, holiday.Holiday{Date: Date(row.Date), Name: row.Name})
+		}
+	}
+
+	var overrides []holiday.Override
+	if user.CompanyID != nil {
+		rows, err := q.ListCompanyHolidays(ctx, db.ListCompanyHolidaysParams{
+			CompanyID: *user.CompanyID,
+			From:      from,
+			To:        to,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			overrides = append(overrides, holiday.Override{
+				Holiday: holiday.Holiday{Date: Date(row.Date), Name: row.Name},
+				DayOff:  row.DayOff,
+			})
+		}
+	}
+
+	return holiday.Observed(public, overrides), nil
+}
@@ -0,0 +1,259 @@
+// Package worktime balances the time users worked against the target working time of their work schedules.
+package worktime
+
+import (
+	"time"
+
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
+)
+
+// Assignment is a work schedule a user follows from a day on
+type Assignment struct {
+	EffectiveFrom time.Time
+	Schedule      db.WorkSchedule
+}
+
+// Calendar describes the working time of a user. Days are calendar dates at midnight UTC.
+type Calendar struct {
+	// Location is the time zone of the user, whose days begin at its midnight
+	Location *time.Location
+	// Start is the first day the balance accrues on
+	Start time.Time
+	// Default is the schedule followed on days no assignment is effective on, if any
+	Default *db.WorkSchedule
+	// Assignments are ordered by the day they are effective from
+	Assignments []Assignment
+	// Holidays are the days off of the user, which have no target whatever their schedule
+	Holidays map[time.Time]bool
+}
+
+// Period is the time a user had to work and worked within [Start, End)
+type Period struct {
+	Start          time.Time `json:"start"`
+	End            time.Time `json:"end"`
+	TargetSeconds  int64     `json:"target_seconds"`
+	WorkedSeconds  int64     `json:"worked_seconds"`
+	AbsenceSeconds int64     `json:"absence_seconds"`
+	// BalanceSeconds is the time worked or spent on paid absences less the target, overtime when positive
+	BalanceSeconds int64 `json:"balance_seconds"`
+	// CumulativeSeconds is the balance carried forward into the range plus the balance of this and all earlier periods
+	CumulativeSeconds int64 `json:"cumulative_seconds"`
+}
+
+// Balance is the time a user had to work and worked within [From, To), grouped by period
+type Balance struct {
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+	Period string    `json:"period"`
+	// CarriedForwardSeconds is the balance of all days before From
+	CarriedForwardSeconds int64    `json:"carried_forward_seconds"`
+	Periods               []Period `json:"periods"`
+	TargetSeconds         int64    `json:"target_seconds"`
+	WorkedSeconds         int64    `json:"worked_seconds"`
+	AbsenceSeconds        int64    `json:"absence_seconds"`
+	BalanceSeconds        int64    `json:"balance_seconds"`
+	// ClosingSeconds is the balance carried forward beyond To
+	ClosingSeconds int64 `json:"closing_seconds"`
+}
+
+// Date returns the calendar date of t as midnight UTC
+func Date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Bounds returns the first day of the period containing day and the first day after it. Weeks start on Monday.
+func Bounds(period string, day time.Time) (time.Time, time.Time) {
+	switch period {
+	case types.BalanceWeek:
+		start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 7)
+	case types.BalanceMonth:
+		start := day.AddDate(0, 0, 1-day.Day())
+		return start, start.AddDate(0, 1, 0)
+	}
+	return day, day.AddDate(0, 0, 1)
+}
+
+// TargetSeconds returns the working time of schedule on weekday
+func TargetSeconds(schedule db.WorkSchedule, weekday time.Weekday) int64 {
+	minutes := [...]int32{
+		schedule.SundayMinutes,
+		schedule.MondayMinutes,
+		schedule.TuesdayMinutes,
+		schedule.WednesdayMinutes,
+		schedule.ThursdayMinutes,
+		schedule.FridayMinutes,
+		schedule.SaturdayMinutes,
+	}[weekday]
+	return int64(minutes) * 60 * int64(schedule.PartTimePercent) / 100
+}
+
+// schedule returns the schedule followed on day or nil if the user does not have to work
+func (c Calendar) schedule(day time.Time) *db.WorkSchedule {
+	for i := len(c.Assignments) - 1; i >= 0; i-- {
+		if !c.Assignments[i].EffectiveFrom.After(day) {
+			return &c.Assignments[i].Schedule
+		}
+	}
+	return c.Default
+}
+
+// absenceSeconds returns the time of day covered by paid absences, at most the target of the day
+func (c Calendar) absenceSeconds(day time.Time, target int64, absences []db.Absence) int64 {
+	if target == 0 {
+		return 0
+	}
+
+	var seconds int64
+	for _, absence := range absences {
+		seconds += c.takenSeconds(day, target, absence.Kind, absence.StartTime, absence.EndTime)
+	}
+	return min(seconds, target)
+}
+
+// takenSeconds returns the working time of day an absence of kind within [start, end) takes, at most the target
+// of the day. Full days take the whole target of every day they cover, half days half of it and hourly absences
+// the time they cover.
+func (c Calendar) takenSeconds(day time.Time, target int64, kind string, start time.Time, end *time.Time) int64 {
+	covered := c.coveredSeconds(day, start, end)
+	if covered == 0 {
+		return 0
+	}
+	switch kind {
+	case types.AbsenceFullDay:
+		return target
+	case types.AbsenceMorning, types.AbsenceAfternoon:
+		return target / 2
+	}
+	return min(covered, target)
+}
+
+// coveredSeconds returns the time of day within [start, end). A nil end has not ended yet.
+func (c Calendar) coveredSeconds(day, start time.Time, end *time.Time) int64 {
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, c.Location)
+	to := from.AddDate(0, 0, 1)
+	if start.After(from) {
+		from = start
+	}
+	if end != nil && end.Before(to) {
+		to = *end
+	}
+	if !to.After(from) {
+		return 0
+	}
+	return int64(to.Sub(from) / time.Second)
+}
+
+// Day is a share of the working time of a calendar date
+type Day struct {
+	Date time.Time `json:"date"`
+	Days float64   `json:"days"`
+	// Seconds is the working time the share amounts to
+	Seconds int64 `json:"seconds"`
+}
+
+// defaultTargetSeconds is the working time of weekdays when no schedule applies
+const defaultTargetSeconds = 8 * 3600
+
+// WorkingDays returns the share of working time an absence of kind within [start, end) takes of every working day
+// it covers, at most a whole day. Without a schedule, weekdays are working days of eight hours. Holidays are no
+// working days.
+func (c Calendar) WorkingDays(kind string, start, end time.Time) []Day {
+	var days []Day
+	last := Date(end.In(c.Location))
+	for day := Date(start.In(c.Location)); !day.After(last); day = day.AddDate(0, 0, 1) {
+		if c.Holidays[day] {
+			continue
+		}
+		var target int64
+		if schedule := c.schedule(day); schedule != nil {
+			target = TargetSeconds(*schedule, day.Weekday())
+		} else if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			target = defaultTargetSeconds
+		}
+		if target == 0 {
+			continue
+		}
+
+		taken := c.takenSeconds(day, target, kind, start, &end)
+		if taken > 0 {
+			days = append(days, Day{Date: day, Days: float64(taken) / float64(target), Seconds: taken})
+		}
+	}
+	return days
+}
+
+// Compute balances the time worked from the start of the calendar until to and groups the days from from on by period.
+// worked holds the seconds worked per day and absences the approved paid absences of the user.
+func (c Calendar) Compute(from, to time.Time, period string, worked []db.ListDailyWorkedSecondsRow, absences []db.Absence) Balance {
+	balance := Balance{
+		From:    from,
+		To:      to,
+		Period:  period,
+		Periods: []Period{},
+	}
+
+	workedSeconds := make(map[int64]int64, len(worked))
+	for _, row := range worked {
+		workedSeconds[Date(row.Day).Unix()] += row.WorkedSeconds
+	}
+
+	first := c.Start
+	if first.IsZero() || from.Before(first) {
+		first = from
+	}
+	for day := first; day.Before(to); day = day.AddDate(0, 0, 1) {
+		var target, absence, dayWorked int64
+		if !day.Before(c.Start) {
+			if schedule := c.schedule(day); schedule != nil && !c.Holidays[day] {
+				target = TargetSeconds(*schedule, day.Weekday())
+			}
+			absence = c.absenceSeconds(day, target, absences)
+			dayWorked = workedSeconds[day.Unix()]
+		}
+
+		if day.Before(from) {
+			balance.CarriedForwardSeconds += dayWorked + absence - target
+			continue
+		}
+
+		if n := len(balance.Periods); n == 0 || !day.Before(balance.Periods[n-1].End) {
+			start, end := Bounds(period, day)
+			balance.Periods = append(balance.Periods, Period{Start: maxTime(start, from), End: minTime(end, to)})
+		}
+		current := &balance.Periods[len(balance.Periods)-1]
+		current.TargetSeconds += target
+		current.WorkedSeconds += dayWorked
+		current.AbsenceSeconds += absence
+	}
+
+	cumulative := balance.CarriedForwardSeconds
+	for i := range balance.Periods {
+		p := &balance.Periods[i]
+		p.BalanceSeconds = p.WorkedSeconds + p.AbsenceSeconds - p.TargetSeconds
+		cumulative += p.BalanceSeconds
+		p.CumulativeSeconds = cumulative
+
+		balance.TargetSeconds += p.TargetSeconds
+		balance.WorkedSeconds += p.WorkedSeconds
+		balance.AbsenceSeconds += p.AbsenceSeconds
+		balance.BalanceSeconds += p.BalanceSeconds
+	}
+	balance.ClosingSeconds = cumulative
+	return balance
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,260 @@
+package worktime
+
+import (
+	"testing"
+	"time"
+
+	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/types"
+	"github.com/stretchr/testify/require"
+)
+
+const hour = int64(3600)
+
+func fullTime() db.WorkSchedule {
+	return db.WorkSchedule{
+		ID:               1,
+		Name:             "Full time",
+		MondayMinutes:    480,
+		TuesdayMinutes:   480,
+		WednesdayMinutes: 480,
+		ThursdayMinutes:  480,
+		FridayMinutes:    480,
+		PartTimePercent:  100,
+	}
+}
+
+func day(month time.Month, d int) time.Time {
+	return time.Date(2024, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func workedRow(date time.Time, hours int64) db.ListDailyWorkedSecondsRow {
+	return db.ListDailyWorkedSecondsRow{Day: date, WorkedSeconds: hours * hour}
+}
+
+func TestTargetSeconds(t *testing.T) {
+	schedule := fullTime()
+	require.Equal(t, 8*hour, TargetSeconds(schedule, time.Monday))
+	require.Zero(t, TargetSeconds(schedule, time.Sunday))
+
+	schedule.PartTimePercent = 50
+	schedule.SaturdayMinutes = 90
+	require.Equal(t, 4*hour, TargetSeconds(schedule, time.Friday))
+	require.Equal(t, 45*int64(60), TargetSeconds(schedule, time.Saturday))
+}
+
+func TestBounds(t *testing.T) {
+	start, end := Bounds(types.BalanceDay, day(time.March, 6))
+	require.Equal(t, day(time.March, 6), start)
+	require.Equal(t, day(time.March, 7), end)
+
+	start, end = Bounds(types.BalanceWeek, day(time.March, 10))
+	require.Equal(t, day(time.March, 4), start)
+	require.Equal(t, day(time.March, 11), end)
+
+	start, end = Bounds(types.BalanceMonth, day(time.February, 29))
+	require.Equal(t, day(time.February, 1), start)
+	require.Equal(t, day(time.March, 1), end)
+}
+
+func TestComputeWeek(t *testing.T) {
+	zagreb, err := time.LoadLocation("Europe/Zagreb")
+	require.NoError(t, err)
+	schedule := fullTime()
+	calendar := Calendar{
+		Location: zagreb,
+		Start:    day(time.March, 1),
+		Default:  &schedule,
+	}
+
+	worked := []db.ListDailyWorkedSecondsRow{
+		workedRow(day(time.March, 1), 9),
+		workedRow(day(time.March, 4), 8),
+		workedRow(day(time.March, 5), 10),
+		workedRow(day(time.March, 7), 8),
+		workedRow(day(time.March, 8), 6),
+	}
+	// Wednesday in Zagreb, which is an hour ahead of UTC
+	absenceStart := time.Date(2024, time.March, 5, 23, 0, 0, 0, time.UTC)
+	absenceEnd := time.Date(2024, time.March, 6, 23, 0, 0, 0, time.UTC)
+	absences := []db.Absence{{StartTime: absenceStart, EndTime: &absenceEnd, Paid: true}}
+
+	balance := calendar.Compute(day(time.March, 4), day(time.March, 11), types.BalanceWeek, worked, absences)
+	require.Equal(t, hour, balance.CarriedForwardSeconds)
+	require.Len(t, balance.Periods, 1)
+
+	week := balance.Periods[0]
+	require.Equal(t, day(time.March, 4), week.Start)
+	require.Equal(t, day(time.March, 11), week.End)
+	require.Equal(t, 40*hour, week.TargetSeconds)
+	require.Equal(t, 32*hour, week.WorkedSeconds)
+	require.Equal(t, 8*hour, week.AbsenceSeconds)
+	require.Zero(t, week.BalanceSeconds)
+	require.Equal(t, hour, week.CumulativeSeconds)
+	require.Equal(t, hour, balance.ClosingSeconds)
+
+	daily := calendar.Compute(day(time.March, 4), day(time.March, 11), types.BalanceDay, worked, absences)
+	require.Len(t, daily.Periods, 7)
+	require.Equal(t, 2*hour, daily.Periods[1].BalanceSeconds)
+	require.Equal(t, 3*hour, daily.Periods[1].CumulativeSeconds)
+	require.Equal(t, 8*hour, daily.Periods[2].AbsenceSeconds)
+	require.Equal(t, -2*hour, daily.Periods[4].BalanceSeconds)
+	require.Zero(t, daily.Periods[6].TargetSeconds)
+	require.Equal(t, week.BalanceSeconds, daily.BalanceSeconds)
+}
+
+func TestComputeAssignments(t *testing.T) {
+	fullTimeSchedule := fullTime()
+	partTime := fullTime()
+	partTime.PartTimePercent = 50
+	calendar := Calendar{
+		Location: time.UTC,
+		Start:    day(time.January, 1),
+		Default:  &fullTimeSchedule,
+		Assignments: []Assignment{
+			{EffectiveFrom: day(time.March, 15), Schedule: partTime},
+		},
+	}
+
+	// March 2024 has 10 working days before and 11 from the 15th on
+	balance := calendar.Compute(day(time.March, 1), day(time.April, 1), types.BalanceMonth, nil, nil)
+	require.Len(t, balance.Periods, 1)
+	require.Equal(t, (10*8+11*4)*hour, balance.Periods[0].TargetSeconds)
+	require.Equal(t, -balance.Periods[0].TargetSeconds, balance.BalanceSeconds)
+	// 23 working days in January and 21 in February
+	require.Equal(t, -(23+21)*8*hour, balance.CarriedForwardSeconds)
+}
+
+func TestComputeClipsPeriods(t *testing.T) {
+	schedule := fullTime()
+	calendar := Calendar{
+		Location: time.UTC,
+		Start:    day(time.March, 6),
+		Default:  &schedule,
+	}
+	worked := []db.ListDailyWorkedSecondsRow{
+		workedRow(day(time.March, 4), 8),
+		workedRow(day(time.March, 6), 8),
+	}
+
+	balance := calendar.Compute(day(time.March, 1), day(time.March, 13), types.BalanceWeek, worked, nil)
+	require.Zero(t, balance.CarriedForwardSeconds)
+	require.Len(t, balance.Periods, 3)
+	require.Equal(t, day(time.March, 1), balance.Periods[0].Start)
+	require.Equal(t, day(time.March, 4), balance.Periods[0].End)
+	require.Equal(t, day(time.March, 11), balance.Periods[2].Start)
+	require.Equal(t, day(time.March, 13), balance.Periods[2].End)
+	// days before the start of the calendar are not balanced
+	require.Equal(t, 3*8*hour, balance.Periods[1].TargetSeconds)
+	require.Equal(t, 8*hour, balance.Periods[1].WorkedSeconds)
+	require.Equal(t, 2*8*hour, balance.Periods[2].TargetSeconds)
+}
+
+func TestComputePartialAbsence(t *testing.T) {
+	schedule := fullTime()
+	calendar := Calendar{
+		Location: time.UTC,
+		Start:    day(time.March, 1),
+		Default:  &schedule,
+	}
+	start := day(time.March, 4).Add(13 * time.Hour)
+	end := start.Add(2 * time.Hour)
+	openStart := day(time.March, 7).Add(12 * time.Hour)
+	absences := []db.Absence{
+		{StartTime: start, EndTime: &end, Paid: true},
+		{StartTime: openStart, Paid: true},
+	}
+	worked := []db.ListDailyWorkedSecondsRow{workedRow(day(time.March, 4), 6)}
+
+	balance := calendar.Compute(day(time.March, 4), day(time.March, 9), types.BalanceDay, worked, absences)
+	require.Equal(t, 2*hour, balance.Periods[0].AbsenceSeconds)
+	require.Zero(t, balance.Periods[1].AbsenceSeconds)
+	// an absence without an end has not ended yet
+	require.Equal(t, 8*hour, balance.Periods[3].AbsenceSeconds)
+	require.Equal(t, 8*hour, balance.Periods[4].AbsenceSeconds)
+}
+
+func TestWorkingDays(t *testing.T) {
+	zagreb, err := time.LoadLocation("Europe/Zagreb")
+	require.NoError(t, err)
+	schedule := fullTime()
+	schedule.FridayMinutes = 240
+	calendar := Calendar{Location: zagreb, Default: &schedule}
+
+	// from Thursday noon until Monday midnight in Zagreb
+	start := time.Date(2024, time.March, 7, 11, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.March, 10, 23, 0, 0, 0, time.UTC)
+	days := calendar.WorkingDays(types.AbsenceHours, start, end)
+	require.Equal(t, []Day{
+		{Date: day(time.March, 7), Days: 1, Seconds: 8 * hour},
+		{Date: day(time.March, 8), Days: 1, Seconds: 4 * hour},
+	}, days)
+
+	// a half day
+	days = calendar.WorkingDays(types.AbsenceHours, start, start.Add(4*time.Hour))
+	require.Equal(t, []Day{{Date: day(time.March, 7), Days: 0.5, Seconds: 4 * hour}}, days)
+
+	// without a schedule weekdays are working days
+	calendar.Default = nil
+	days = calendar.WorkingDays(types.AbsenceHours, start, end)
+	require.Len(t, days, 2)
+}
+
+func TestHolidays(t *testing.T) {
+	schedule := fullTime()
+	calendar := Calendar{
+		Location: time.UTC,
+		Start:    day(time.April, 29),
+		Default:  &schedule,
+		Holidays: map[time.Time]bool{day(time.May, 1): true},
+	}
+
+	// work on a holiday is overtime
+	worked := []db.ListDailyWorkedSecondsRow{workedRow(day(time.May, 1), 2)}
+	balance := calendar.Compute(day(time.April, 29), day(time.May, 6), types.BalanceWeek, worked, nil)
+	require.Equal(t, 32*hour, balance.TargetSeconds)
+	require.Equal(t, -30*hour, balance.BalanceSeconds)
+
+	// an absence over a holiday does not take it
+	days := calendar.WorkingDays(types.AbsenceFullDay, day(time.April, 30), day(time.May, 3))
+	require.Equal(t, []Day{
+		{Date: day(time.April, 30), Days: 1, Seconds: 8 * hour},
+		{Date: day(time.May, 2), Days: 1, Seconds: 8 * hour},
+	}, days)
+}
+
+func TestAbsenceKinds(t *testing.T) {
+	schedule := fullTime()
+	schedule.FridayMinutes = 360
+	calendar := Calendar{
+		Location: time.UTC,
+		Start:    day(time.March, 1),
+		Default:  &schedule,
+	}
+	thursday := day(time.March, 7)
+	friday := day(time.March, 8)
+
+	// full days take the whole target of every day, whatever time of the day they cover
+	days := calendar.WorkingDays(types.AbsenceFullDay, thursday.Add(9*time.Hour), friday.Add(10*time.Hour))
+	require.Equal(t, []Day{
+		{Date: thursday, Days: 1, Seconds: 8 * hour},
+		{Date: friday, Days: 1, Seconds: 6 * hour},
+	}, days)
+
+	// half days take half of the target
+	days = calendar.WorkingDays(types.AbsenceAfternoon, friday.Add(12*time.Hour), friday.AddDate(0, 0, 1))
+	require.Equal(t, []Day{{Date: friday, Days: 0.5, Seconds: 3 * hour}}, days)
+
+	// hourly absences take the time they cover, at most the target
+	days = calendar.WorkingDays(types.AbsenceHours, friday.Add(8*time.Hour), friday.Add(18*time.Hour))
+	require.Equal(t, []Day{{Date: friday, Days: 1, Seconds: 6 * hour}}, days)
+
+	morningEnd := thursday.Add(12 * time.Hour)
+	absences := []db.Absence{
+		{Kind: types.AbsenceMorning, StartTime: thursday, EndTime: &morningEnd, Paid: true},
+		{Kind: types.AbsenceFullDay, StartTime: friday, Paid: true},
+	}
+	balance := calendar.Compute(thursday, friday.AddDate(0, 0, 1), types.BalanceDay, nil, absences)
+	require.Equal(t, 4*hour, balance.Periods[0].AbsenceSeconds)
+	require.Equal(t, 6*hour, balance.Periods[1].AbsenceSeconds)
+}
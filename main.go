@@ -3,17 +3,29 @@ package main
 import (
 	"context"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/mateoradman/tempus/internal/api"
+	"github.com/mateoradman/tempus/internal/clock"
 	"github.com/mateoradman/tempus/internal/config"
 	db "github.com/mateoradman/tempus/internal/db/sqlc"
+	"github.com/mateoradman/tempus/internal/job"
+	"github.com/mateoradman/tempus/internal/webhook"
 )
 
+// main runs the API server, and the background job workers inside it if WORKERS is set. `tempus worker` runs the
+// workers only.
 func main() {
+	// Stop gracefully on interrupt
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Load environment variables
 	config, err := config.LoadConfig(".")
 	if err != nil {
@@ -21,31 +33,91 @@ func main() {
 	}
 
 	// Connect to the database
-	conn, err := pgxpool.New(context.Background(), config.DBSource)
+	poolConfig, err := pgxpool.ParseConfig(config.DBSource)
+	if err != nil {
+		log.Fatal("cannot parse database url:", err)
+	}
+	if config.RowLevelSecurity {
+		db.EnableRowLevelSecurity(poolConfig)
+	}
+	conn, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		log.Fatal("cannot connect to database:", err)
 	}
+	defer conn.Close()
 
 	// Run migrations
 	runDatabaseMigration(config.MigrationURL, config.DBSource)
 
 	store := db.NewStore(conn)
+
+	if len(os.Args) > 1 && os.Args[1] == "worker" {
+		runner := newJobRunner(config, store, max(config.Workers, 1))
+		runner.Start(ctx)
+		log.Println("job workers started")
+		<-ctx.Done()
+		runner.Stop()
+		return
+	}
+
 	// Seed the database with pre-defined values
-	err = store.SeedDatabase(context.Background(), config)
+	err = store.SeedDatabase(ctx, config)
 	if err != nil {
 		log.Fatalf("cannot seed database %v", err)
 	}
 
+	if config.Workers > 0 {
+		runner := newJobRunner(config, store, config.Workers)
+		runner.Start(ctx)
+		defer runner.Stop()
+	}
+
 	server, err := api.NewServer(config, store)
 	if err != nil {
 		log.Fatal("cannot create server:", err)
 	}
-	err = server.Start(config.ServerAddress)
+	err = server.Start(ctx, config.ServerAddress)
 	if err != nil {
 		log.Fatalf("cannot start server at address %s due to err: %v", config.ServerAddress, err)
 	}
 }
 
+// newJobRunner registers the handlers and the schedules of the background jobs
+func newJobRunner(config config.Config, store db.Store, workers int) *job.Runner {
+	runner := job.NewRunner(store, workers)
+	job.RegisterCleanup(runner)
+
+	// Stop forgotten timers and remind users to track their time
+	enforcer := clock.NewEnforcer(store)
+	job.Register(runner, clock.AutoCloseKind, 0, func(ctx context.Context, _ struct{}) error {
+		return enforcer.AutoClose(ctx)
+	})
+	job.Register(runner, clock.RemindKind, 0, func(ctx context.Context, _ struct{}) error {
+		return enforcer.Remind(ctx)
+	})
+	for _, kind := range []string{clock.AutoCloseKind, clock.RemindKind} {
+		// a failed run is made up for by the next one
+		err := runner.Schedule(kind, job.Every(clock.Interval), struct{}{}, job.Options{MaxAttempts: 1})
+		if err != nil {
+			log.Fatal("cannot schedule clock policies:", err)
+		}
+	}
+
+	// Send the webhook deliveries written to the outbox
+	if config.WebhookDispatchInterval > 0 {
+		dispatcher := webhook.NewDispatcher(store, nil)
+		job.Register(runner, webhook.DispatchKind, webhook.DispatchTimeout, func(ctx context.Context, _ struct{}) error {
+			return dispatcher.Dispatch(ctx)
+		})
+		// failed deliveries are retried by the next run
+		err := runner.Schedule(webhook.DispatchKind, job.Every(config.WebhookDispatchInterval), struct{}{}, job.Options{MaxAttempts: 1})
+		if err != nil {
+			log.Fatal("cannot schedule webhook dispatch:", err)
+		}
+	}
+	return runner
+}
+
 func runDatabaseMigration(migrationURL string, dbSource string) {
 	m, err := migrate.New(migrationURL, dbSource)
 	if err != nil {